@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -12,15 +13,16 @@ import (
 	_ "autonomous-task-management/docs" // Swagger docs
 	"autonomous-task-management/internal/httpserver"
 	"autonomous-task-management/internal/task/repository"
+	localRepo "autonomous-task-management/internal/task/repository/local"
 	memosRepo "autonomous-task-management/internal/task/repository/memos"
 	qdrantRepo "autonomous-task-management/internal/task/repository/qdrant"
 	"autonomous-task-management/pkg/datemath"
+	"autonomous-task-management/pkg/embedding"
 	"autonomous-task-management/pkg/gcalendar"
 	"autonomous-task-management/pkg/llmprovider"
 	"autonomous-task-management/pkg/log"
 	pkgQdrant "autonomous-task-management/pkg/qdrant"
 	"autonomous-task-management/pkg/telegram"
-	"autonomous-task-management/pkg/voyage"
 )
 
 // @title       Autonomous Task Management API
@@ -109,9 +111,20 @@ func main() {
 		telegramBot = telegram.NewBot(cfg.Telegram.BotToken)
 	}
 
-	// Memos repository
-	memosClient := memosRepo.NewClient(cfg.Memos.URL, cfg.Memos.AccessToken)
-	taskRepo := memosRepo.New(memosClient, cfg.Memos.ExternalURL, logger)
+	// Task repository: Memos, or Markdown files on disk
+	var taskRepo repository.MemosRepository
+	switch cfg.Storage.Backend {
+	case config.StorageBackendLocal:
+		taskRepo, err = localRepo.New(cfg.Storage.LocalDir, "", logger)
+		if err != nil {
+			logger.Error(ctx, "Failed to initialize local task storage: ", err)
+			return
+		}
+		logger.Infof(ctx, "Storing tasks as Markdown files in %s", cfg.Storage.LocalDir)
+	default:
+		memosClient := memosRepo.NewClient(cfg.Memos.URL, cfg.Memos.AccessToken)
+		taskRepo = memosRepo.New(memosClient, cfg.Memos.ExternalURL, logger)
+	}
 
 	// Google Calendar client (optional)
 	var calendarClient gcalendar.IGCalendar
@@ -125,10 +138,38 @@ func main() {
 	// Qdrant Vector repository (optional)
 	var vectorRepo repository.VectorRepository
 	if cfg.Qdrant.URL != "" {
-		qdrantClient := pkgQdrant.NewClient(cfg.Qdrant.URL)
-		embeddingClient, voyageErr := voyage.New(cfg.Voyage.APIKey)
-		if voyageErr == nil && embeddingClient != nil {
-			vectorRepo = qdrantRepo.New(qdrantClient, embeddingClient, cfg.Qdrant.CollectionName, logger)
+		embedder, embedErr := embedding.New(cfg.Embedding)
+		if embedErr != nil {
+			logger.Warnf(ctx, "Semantic search disabled: %s embeddings not available: %v", cfg.Embedding.Provider, embedErr)
+		} else {
+			// The fallback gets its own collection so local vectors never mix with the provider's
+			collection := cfg.Qdrant.CollectionName
+			if cfg.Embedding.Provider != config.EmbeddingProviderLocal && embedder.Model() == embedding.LocalModel {
+				collection = embedding.FallbackCollection(collection)
+				logger.Warnf(ctx, "No API key for %s embeddings: falling back to the local embedder in collection %s (embedding.fallback_local)", cfg.Embedding.Provider, collection)
+			}
+			qdrantClient := pkgQdrant.NewClient(cfg.Qdrant.URL)
+
+			// Create the collection and indexes, and apply pending schema migrations.
+			// The collection must have the embedder's dimension; another model needs scripts/reindex.
+			schemaErr := qdrantRepo.Bootstrap(ctx, qdrantClient, collection, embedder.Dimension(), logger)
+			if errors.Is(schemaErr, pkgQdrant.ErrVectorSizeMismatch) {
+				logger.Errorf(ctx, "Semantic search disabled: %s embeddings do not fit the collection, rebuild it with scripts/reindex: %v", embedder.Model(), schemaErr)
+			} else {
+				if schemaErr != nil {
+					logger.Warnf(ctx, "Qdrant schema bootstrap failed, search may be degraded: %v", schemaErr)
+				}
+				vectorRepo = qdrantRepo.New(qdrantClient, embedder, collection, cfg.LLM.Timezone, logger)
+				logger.Infof(ctx, "Semantic search using %s embeddings (%s, %d dimensions)", cfg.Embedding.Provider, embedder.Model(), embedder.Dimension())
+
+				// Vectors from another model or enrichment version do not compare well with new queries
+				stale, staleErr := qdrantRepo.StaleEmbeddings(ctx, qdrantClient, collection, embedder.Model())
+				if staleErr != nil {
+					logger.Warnf(ctx, "Failed to check embedding versions: %v", staleErr)
+				} else if stale > 0 {
+					logger.Warnf(ctx, "%d task vectors were built with another embedding model or enrichment version; rebuild them with scripts/reindex", stale)
+				}
+			}
 		}
 	}
 
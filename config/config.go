@@ -3,7 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -18,17 +20,49 @@ type Config struct {
 	Logger     LoggerConfig
 
 	// Autonomous Task Management specifics
+	Storage        StorageConfig
 	Memos          MemosConfig
 	Qdrant         QdrantConfig
 	Telegram       TelegramConfig
 	GoogleCalendar GoogleCalendarConfig
 	Voyage         VoyageConfig
+	Embedding      EmbeddingConfig
 
 	// LLM Provider Abstraction
 	LLM LLMConfig
 
 	// Webhooks
 	Webhook WebhookConfig
+
+	// Scheduled reports
+	Digest DigestConfig
+
+	// Archiving of completed tasks
+	Archive ArchiveConfig
+
+	// Change log behind /history and /undo
+	History HistoryConfig
+
+	// Timers behind /track
+	TimeTrack TimeTrackConfig
+
+	// Repairing drift between Memos and Qdrant
+	Reconcile ReconcileConfig
+
+	// Re-embedding tasks whose "tomorrow" / "this week" wording went stale
+	EnrichmentRefresh EnrichmentRefreshConfig
+
+	// Booking work blocks into free calendar time
+	Schedule ScheduleConfig
+
+	// "What should I do next" recommendations
+	Ranking RankingConfig
+
+	// Understanding search queries
+	Search SearchConfig
+
+	// HTTP API
+	API APIConfig
 }
 
 type EnvironmentConfig struct {
@@ -47,6 +81,18 @@ type LoggerConfig struct {
 	ColorEnabled bool
 }
 
+// Task storage backends.
+const (
+	StorageBackendMemos = "memos"
+	StorageBackendLocal = "local"
+)
+
+// StorageConfig selects where tasks are stored.
+type StorageConfig struct {
+	Backend  string // "memos" (default) or "local"
+	LocalDir string // Directory of Markdown task files for the local backend
+}
+
 type MemosConfig struct {
 	URL         string
 	APIVersion  string
@@ -61,8 +107,9 @@ type QdrantConfig struct {
 }
 
 type TelegramConfig struct {
-	BotToken   string
-	WebhookURL string
+	BotToken      string
+	WebhookURL    string
+	NotifyChatIDs []int64 // Chats receiving background notifications (e.g. unblocked tasks)
 }
 
 type GoogleCalendarConfig struct {
@@ -74,6 +121,27 @@ type VoyageConfig struct {
 	APIKey string
 }
 
+// Embedding providers selectable with embedding.provider.
+const (
+	EmbeddingProviderVoyage = "voyage"
+	EmbeddingProviderOpenAI = "openai" // Any OpenAI-compatible /embeddings endpoint
+	EmbeddingProviderLocal  = "local"  // Deterministic hashing embedder, no network
+)
+
+// EmbeddingConfig selects the model that turns tasks and queries into vectors.
+type EmbeddingConfig struct {
+	Provider  string
+	Model     string // "" = the provider's default
+	BaseURL   string // "" = the provider's default
+	APIKey    string // Falls back to voyage.api_key for the voyage provider
+	Dimension int    // Vector size; defaults to qdrant.vector_size and must match the collection
+
+	// FallbackLocal switches to the local embedder when the hosted provider has no API key.
+	// It is decided once at startup: a provider that fails later is not replaced, because
+	// vectors of two models in one collection do not compare.
+	FallbackLocal bool
+}
+
 // LLMConfig holds configuration for the LLM provider abstraction layer
 type LLMConfig struct {
 	Providers       []ProviderConfig `yaml:"providers"`
@@ -102,6 +170,89 @@ type WebhookConfig struct {
 	RateLimitPerMin int
 }
 
+// DigestConfig configures the scheduled daily briefing and weekly review.
+// Timezone comes from LLMConfig.Timezone.
+type DigestConfig struct {
+	Enabled    bool
+	DailyTime  string  // "HH:MM"
+	WeeklyDay  string  // e.g. "friday"
+	WeeklyTime string  // "HH:MM"
+	LLMSummary bool    // Prepend an LLM-written summary
+	ChatIDs    []int64 // Telegram chats subscribed at startup
+	StorePath  string  // JSON file /digest on|off subscriptions are kept in across restarts
+}
+
+// HistoryConfig configures the task change log.
+type HistoryConfig struct {
+	StorePath string // JSON file the log is kept in across restarts; empty keeps it in memory only
+}
+
+// TimeTrackConfig configures the per-task timers.
+type TimeTrackConfig struct {
+	StorePath string // JSON file running timers are kept in across restarts; empty keeps them in memory only
+}
+
+// ArchiveConfig configures the scheduled archiving of completed tasks.
+type ArchiveConfig struct {
+	Enabled     bool
+	GracePeriod time.Duration // How long a task stays done before it is archived
+	Interval    time.Duration // How often the archiver runs
+}
+
+// ReconcileConfig configures the periodic comparison of Memos with the Qdrant index,
+// which re-embeds changed memos and deletes orphaned vectors that missed webhooks left behind.
+type ReconcileConfig struct {
+	Enabled        bool
+	Interval       time.Duration // How often the reconciler runs
+	MaxOrphanRatio float64       // Share of points a run may delete as orphans before it skips the repair
+}
+
+// EnrichmentRefreshConfig configures the periodic re-embedding of tasks whose humanized due date
+// ("ngay mai", "tuan nay"...) moved to another bucket since they were embedded.
+type EnrichmentRefreshConfig struct {
+	Enabled  bool
+	Interval time.Duration // How often the buckets are checked
+}
+
+// ScheduleConfig configures booking work blocks into free calendar time.
+// Timezone comes from LLMConfig.Timezone.
+type ScheduleConfig struct {
+	Enabled            bool          // Book tasks that only have a due date into free slots instead of at 23:59
+	WorkStart          string        // "HH:MM"
+	WorkEnd            string        // "HH:MM"
+	WorkDays           []string      // e.g. mon, tue, wed, thu, fri
+	Horizon            time.Duration // How far ahead to look for free time
+	Buffer             time.Duration // Gap kept free around existing events
+	AutoReschedule     bool          // Rebook blocks of tasks that were not finished in time
+	RescheduleInterval time.Duration // How often missed blocks are checked
+}
+
+// RankingConfig weights the factors of a task's score for /next. Only the ratios between
+// the weights matter; 0 switches a factor off and negative weights are rejected.
+type RankingConfig struct {
+	Weights RankingWeights
+}
+
+// RankingWeights are the weights of the ranking factors.
+type RankingWeights struct {
+	Priority     float64 // #priority/p0..p3
+	Urgency      float64 // How close the due date is
+	Effort       float64 // Favours tasks with little work left
+	Dependency   float64 // Favours tasks that block other tasks
+	Availability float64 // Favours tasks that fit before the next calendar event
+}
+
+// SearchConfig configures how search queries are read. Dates, priorities, statuses and tags
+// are always taken out of the query; LLMExpansion also asks the LLM for synonyms of the rest.
+type SearchConfig struct {
+	LLMExpansion bool // Adds an LLM call to every search
+}
+
+// APIConfig protects the /api routes; they are disabled when Token is empty.
+type APIConfig struct {
+	Token string // Bearer token expected in the Authorization header
+}
+
 // Load loads configuration using Viper.
 // Config file name: config.yaml — searched in ./config, ., /etc/app/
 func Load() (*Config, error) {
@@ -134,6 +285,12 @@ func Load() (*Config, error) {
 	cfg.Logger.ColorEnabled = viper.GetBool("logger.color_enabled")
 
 	// Autonomous Task Management specifics
+	cfg.Storage.Backend = strings.ToLower(strings.TrimSpace(viper.GetString("storage.backend")))
+	cfg.Storage.LocalDir = viper.GetString("storage.local_dir")
+	if cfg.Storage.Backend != StorageBackendMemos && cfg.Storage.Backend != StorageBackendLocal {
+		return nil, fmt.Errorf("invalid storage.backend %q: must be %q or %q", cfg.Storage.Backend, StorageBackendMemos, StorageBackendLocal)
+	}
+
 	cfg.Memos.URL = viper.GetString("memos.url")
 	cfg.Memos.APIVersion = viper.GetString("memos.api_version")
 	cfg.Memos.AccessToken = viper.GetString("memos.access_token")
@@ -158,6 +315,11 @@ func Load() (*Config, error) {
 
 	cfg.Telegram.BotToken = viper.GetString("telegram.bot_token")
 	cfg.Telegram.WebhookURL = viper.GetString("telegram.webhook_url")
+	notifyChatIDs, err := parseChatIDs("telegram.notify_chat_ids")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Telegram.NotifyChatIDs = notifyChatIDs
 	if tgToken := viper.GetString("telegram_bot_token"); tgToken != "" {
 		cfg.Telegram.BotToken = tgToken
 	}
@@ -174,6 +336,26 @@ func Load() (*Config, error) {
 		cfg.Voyage.APIKey = voyageKey
 	}
 
+	// Embeddings
+	cfg.Embedding.Provider = strings.ToLower(viper.GetString("embedding.provider"))
+	cfg.Embedding.Model = viper.GetString("embedding.model")
+	cfg.Embedding.BaseURL = viper.GetString("embedding.base_url")
+	cfg.Embedding.APIKey = viper.GetString("embedding.api_key")
+	cfg.Embedding.Dimension = viper.GetInt("embedding.dimension")
+	cfg.Embedding.FallbackLocal = viper.GetBool("embedding.fallback_local")
+	if cfg.Embedding.Dimension == 0 {
+		cfg.Embedding.Dimension = cfg.Qdrant.VectorSize
+	}
+	if cfg.Embedding.APIKey == "" && cfg.Embedding.Provider == EmbeddingProviderVoyage {
+		cfg.Embedding.APIKey = cfg.Voyage.APIKey
+	}
+	switch cfg.Embedding.Provider {
+	case EmbeddingProviderVoyage, EmbeddingProviderOpenAI, EmbeddingProviderLocal:
+	default:
+		return nil, fmt.Errorf("invalid embedding.provider %q: must be %q, %q or %q", cfg.Embedding.Provider,
+			EmbeddingProviderVoyage, EmbeddingProviderOpenAI, EmbeddingProviderLocal)
+	}
+
 	// LLM Provider Abstraction
 	cfg.LLM.FallbackEnabled = viper.GetBool("llm.fallback_enabled")
 	cfg.LLM.RetryAttempts = viper.GetInt("llm.retry_attempts")
@@ -227,9 +409,111 @@ func Load() (*Config, error) {
 	}
 	cfg.Webhook.AllowedIPs = ips
 
+	// Digest
+	cfg.Digest.Enabled = viper.GetBool("digest.enabled")
+	cfg.Digest.DailyTime = viper.GetString("digest.daily_time")
+	cfg.Digest.WeeklyDay = viper.GetString("digest.weekly_day")
+	cfg.Digest.WeeklyTime = viper.GetString("digest.weekly_time")
+	cfg.Digest.LLMSummary = viper.GetBool("digest.llm_summary")
+	cfg.Digest.StorePath = viper.GetString("digest.store_path")
+
+	digestChatIDs, err := parseChatIDs("digest.chat_ids")
+	if err != nil {
+		return nil, err
+	}
+	cfg.Digest.ChatIDs = digestChatIDs
+
+	// History
+	cfg.History.StorePath = viper.GetString("history.store_path")
+
+	// TimeTrack
+	cfg.TimeTrack.StorePath = viper.GetString("timetrack.store_path")
+
+	// Archive
+	cfg.Archive.Enabled = viper.GetBool("archive.enabled")
+	cfg.Archive.GracePeriod = viper.GetDuration("archive.grace_period")
+	cfg.Archive.Interval = viper.GetDuration("archive.interval")
+
+	// Reconcile
+	cfg.Reconcile.Enabled = viper.GetBool("reconcile.enabled")
+	cfg.Reconcile.Interval = viper.GetDuration("reconcile.interval")
+	cfg.Reconcile.MaxOrphanRatio = viper.GetFloat64("reconcile.max_orphan_ratio")
+
+	// Enrichment refresh
+	cfg.EnrichmentRefresh.Enabled = viper.GetBool("enrichment_refresh.enabled")
+	cfg.EnrichmentRefresh.Interval = viper.GetDuration("enrichment_refresh.interval")
+
+	// Schedule
+	cfg.Schedule.Enabled = viper.GetBool("schedule.enabled")
+	cfg.Schedule.WorkStart = viper.GetString("schedule.work_start")
+	cfg.Schedule.WorkEnd = viper.GetString("schedule.work_end")
+	for _, day := range strings.Split(viper.GetString("schedule.work_days"), ",") {
+		if day = strings.TrimSpace(day); day != "" {
+			cfg.Schedule.WorkDays = append(cfg.Schedule.WorkDays, day)
+		}
+	}
+	cfg.Schedule.Horizon = viper.GetDuration("schedule.horizon")
+	cfg.Schedule.Buffer = viper.GetDuration("schedule.buffer")
+	cfg.Schedule.AutoReschedule = viper.GetBool("schedule.auto_reschedule")
+	cfg.Schedule.RescheduleInterval = viper.GetDuration("schedule.reschedule_interval")
+
+	// Ranking
+	cfg.Ranking.Weights.Priority = viper.GetFloat64("ranking.weights.priority")
+	cfg.Ranking.Weights.Urgency = viper.GetFloat64("ranking.weights.urgency")
+	cfg.Ranking.Weights.Effort = viper.GetFloat64("ranking.weights.effort")
+	cfg.Ranking.Weights.Dependency = viper.GetFloat64("ranking.weights.dependency")
+	cfg.Ranking.Weights.Availability = viper.GetFloat64("ranking.weights.availability")
+	if err := validateRankingWeights(cfg.Ranking.Weights); err != nil {
+		return nil, err
+	}
+
+	// Search
+	cfg.Search.LLMExpansion = viper.GetBool("search.llm_expansion")
+
+	// HTTP API
+	cfg.API.Token = viper.GetString("api.token")
+
 	return cfg, nil
 }
 
+// parseChatIDs reads a comma separated list of Telegram chat IDs.
+// Same as allowed IPs: a plain string so it can be set from env.
+func parseChatIDs(key string) ([]int64, error) {
+	var chatIDs []int64
+	raw := viper.GetString(key)
+	if raw == "" {
+		return nil, nil
+	}
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s entry %q: %w", key, part, err)
+		}
+		chatIDs = append(chatIDs, id)
+	}
+	return chatIDs, nil
+}
+
+// validateRankingWeights rejects negative weights; 0 is the way to switch a factor off.
+func validateRankingWeights(w RankingWeights) error {
+	for name, v := range map[string]float64{
+		"priority":     w.Priority,
+		"urgency":      w.Urgency,
+		"effort":       w.Effort,
+		"dependency":   w.Dependency,
+		"availability": w.Availability,
+	} {
+		if v < 0 {
+			return fmt.Errorf("invalid ranking.weights.%s %v: must not be negative", name, v)
+		}
+	}
+	return nil
+}
+
 func setDefaults() {
 	viper.SetDefault("environment.name", "development")
 	viper.SetDefault("http_server.port", 8080)
@@ -238,10 +522,42 @@ func setDefaults() {
 	viper.SetDefault("logger.mode", "debug")
 	viper.SetDefault("logger.encoding", "console")
 	viper.SetDefault("logger.color_enabled", true)
+	viper.SetDefault("storage.backend", StorageBackendMemos)
+	viper.SetDefault("storage.local_dir", "./data/tasks")
 	viper.SetDefault("qdrant.collection_name", "tasks")
 	viper.SetDefault("qdrant.vector_size", 1024)
+	viper.SetDefault("embedding.provider", EmbeddingProviderVoyage)
 	viper.SetDefault("webhook.rate_limit_per_min", 60)
 	viper.SetDefault("webhook.enabled", true)
+	viper.SetDefault("digest.enabled", false)
+	viper.SetDefault("digest.daily_time", "08:00")
+	viper.SetDefault("digest.weekly_day", "friday")
+	viper.SetDefault("digest.weekly_time", "17:00")
+	viper.SetDefault("digest.store_path", "./data/digest_subscriptions.json")
+	viper.SetDefault("history.store_path", "./data/history.json")
+	viper.SetDefault("timetrack.store_path", "./data/timers.json")
+	viper.SetDefault("archive.enabled", false)
+	viper.SetDefault("archive.grace_period", "168h")
+	viper.SetDefault("archive.interval", "24h")
+	viper.SetDefault("reconcile.enabled", true)
+	viper.SetDefault("reconcile.interval", "6h")
+	viper.SetDefault("reconcile.max_orphan_ratio", 0.5)
+	viper.SetDefault("enrichment_refresh.enabled", true)
+	viper.SetDefault("enrichment_refresh.interval", "1h")
+	viper.SetDefault("schedule.enabled", false)
+	viper.SetDefault("schedule.work_start", "09:00")
+	viper.SetDefault("schedule.work_end", "18:00")
+	viper.SetDefault("schedule.work_days", "mon,tue,wed,thu,fri")
+	viper.SetDefault("schedule.horizon", "336h")
+	viper.SetDefault("schedule.buffer", "10m")
+	viper.SetDefault("schedule.auto_reschedule", false)
+	viper.SetDefault("schedule.reschedule_interval", "1h")
+	viper.SetDefault("ranking.weights.priority", 3)
+	viper.SetDefault("ranking.weights.urgency", 3)
+	viper.SetDefault("ranking.weights.effort", 1)
+	viper.SetDefault("ranking.weights.dependency", 2)
+	viper.SetDefault("ranking.weights.availability", 1)
+	viper.SetDefault("search.llm_expansion", false)
 
 	// LLM defaults
 	viper.SetDefault("llm.fallback_enabled", true)
@@ -12,6 +12,7 @@ type UseCase interface {
 	// CompleteTask manually marks a task as complete
 	CompleteTask(ctx context.Context, sc model.Scope, taskID string) error
 
-	// ArchiveCompletedTasks archives all fully completed tasks
-	ArchiveCompletedTasks(ctx context.Context, sc model.Scope) (int, error)
+	// ArchiveCompletedTasks archives tasks that have been completed for longer than the grace period
+	// and removes them from the vector index. With input.DryRun it only lists them.
+	ArchiveCompletedTasks(ctx context.Context, sc model.Scope, input ArchiveInput) (ArchiveOutput, error)
 }
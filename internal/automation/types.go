@@ -1,9 +1,16 @@
 package automation
 
 import (
+	"time"
+
 	"autonomous-task-management/internal/model"
 )
 
+// Config holds the automation settings.
+type Config struct {
+	ArchiveGracePeriod time.Duration // How long a task must stay done before it is archived
+}
+
 // ProcessWebhookInput is input for webhook processing
 type ProcessWebhookInput struct {
 	Event model.WebhookEvent
@@ -15,3 +22,22 @@ type ProcessWebhookOutput struct {
 	TaskIDs      []string // IDs of updated tasks
 	Message      string   // Summary message
 }
+
+// ArchiveInput controls an archive run.
+type ArchiveInput struct {
+	DryRun bool // Only list the tasks that would be archived
+}
+
+// ArchiveCandidate is a completed task past the grace period.
+type ArchiveCandidate struct {
+	TaskID  string
+	Title   string
+	MemoURL string
+	DoneAt  time.Time // Last update of the task, taken as its completion time
+}
+
+// ArchiveOutput is the result of an archive run.
+type ArchiveOutput struct {
+	Candidates []ArchiveCandidate // Tasks eligible for archiving
+	Archived   int                // Tasks actually archived (0 on a dry run)
+}
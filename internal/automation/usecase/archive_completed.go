@@ -2,12 +2,79 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"autonomous-task-management/internal/automation"
 	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/taskmeta"
 )
 
-// ArchiveCompletedTasks archives all fully completed tasks
-func (uc *implUseCase) ArchiveCompletedTasks(ctx context.Context, sc model.Scope) (int, error) {
-	uc.l.Infof(ctx, "Archive completed tasks not yet implemented")
-	return 0, nil
+// archivePageSize is the page size used while scanning Memos for completed tasks.
+const archivePageSize = 200
+
+// ArchiveCompletedTasks archives tasks that have been completed for longer than the grace period.
+// Candidates are collected first and archived afterwards, so archiving does not shift the pages being read.
+func (uc *implUseCase) ArchiveCompletedTasks(ctx context.Context, sc model.Scope, input automation.ArchiveInput) (automation.ArchiveOutput, error) {
+	cutoff := time.Now().Add(-uc.cfg.ArchiveGracePeriod)
+
+	var out automation.ArchiveOutput
+	for t, err := range uc.memosRepo.IterateTasks(ctx, repository.ListTasksOptions{Limit: archivePageSize}) {
+		if err != nil {
+			return automation.ArchiveOutput{}, fmt.Errorf("failed to list tasks: %w", err)
+		}
+		if !uc.isDone(t) {
+			continue
+		}
+		doneAt := uc.doneAt(t)
+		if doneAt.IsZero() || doneAt.After(cutoff) {
+			continue
+		}
+		out.Candidates = append(out.Candidates, automation.ArchiveCandidate{
+			TaskID:  t.ID,
+			Title:   taskmeta.ExtractTitle(t.Content),
+			MemoURL: t.MemoURL,
+			DoneAt:  doneAt,
+		})
+	}
+
+	if input.DryRun {
+		uc.l.Infof(ctx, "automation.ArchiveCompletedTasks: user=%s dry run, %d candidate(s)", sc.UserID, len(out.Candidates))
+		return out, nil
+	}
+
+	var errs []error
+	for _, c := range out.Candidates {
+		if err := uc.memosRepo.ArchiveTask(ctx, c.TaskID); err != nil {
+			errs = append(errs, fmt.Errorf("archive %s: %w", c.TaskID, err))
+			continue
+		}
+		out.Archived++
+
+		// Archived tasks must not show up in search
+		if uc.vectorRepo != nil {
+			if err := uc.vectorRepo.DeleteTask(ctx, c.TaskID); err != nil {
+				uc.l.Warnf(ctx, "automation.ArchiveCompletedTasks: failed to remove %s from Qdrant: %v", c.TaskID, err)
+			}
+		}
+	}
+
+	uc.l.Infof(ctx, "automation.ArchiveCompletedTasks: user=%s archived %d/%d task(s)", sc.UserID, out.Archived, len(out.Candidates))
+	return out, errors.Join(errs...)
+}
+
+// doneAt returns when a task was completed: its "- **Done:**" time, or for tasks completed before
+// that line was written, its last update.
+func (uc *implUseCase) doneAt(t model.Task) time.Time {
+	if at, ok := taskmeta.DoneAt(t.Content); ok {
+		return at
+	}
+	return taskmeta.ParseTime(t.UpdateTime)
+}
+
+// isDone reports whether every checkbox is ticked or the task is tagged #status/done.
+func (uc *implUseCase) isDone(t model.Task) bool {
+	return taskmeta.IsDone(t.Content)
 }
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"autonomous-task-management/internal/automation"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/taskmeta"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newArchiveFixture() (*mockMemosRepo, *mockVectorRepo, *implUseCase) {
+	old := time.Now().Add(-10 * 24 * time.Hour).Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).Format(time.RFC3339)
+
+	memos := newMockMemosRepo()
+	memos.tasks["memos/done-old"] = model.Task{ID: "memos/done-old", Content: "## Ship v1\n\n#status/done", UpdateTime: old}
+	memos.tasks["memos/done-recent"] = model.Task{ID: "memos/done-recent", Content: "## Ship v2\n\n#status/done", UpdateTime: recent}
+	memos.tasks["memos/open-old"] = model.Task{ID: "memos/open-old", Content: "## Ship v3\n\n- [ ] todo", UpdateTime: old}
+
+	vector := &mockVectorRepo{}
+	uc := New(memos, vector, &mockChecklistSvc{}, &mockLogger{}, automation.Config{ArchiveGracePeriod: 7 * 24 * time.Hour})
+	return memos, vector, uc.(*implUseCase)
+}
+
+func TestArchiveCompletedTasks_DryRunOnlyLists(t *testing.T) {
+	memos, vector, uc := newArchiveFixture()
+
+	out, err := uc.ArchiveCompletedTasks(context.Background(), model.Scope{UserID: "u1"}, automation.ArchiveInput{DryRun: true})
+	require.NoError(t, err)
+
+	require.Len(t, out.Candidates, 1)
+	assert.Equal(t, "memos/done-old", out.Candidates[0].TaskID)
+	assert.Equal(t, "Ship v1", out.Candidates[0].Title)
+	assert.Equal(t, 0, out.Archived)
+	assert.Empty(t, memos.archived)
+	assert.Empty(t, vector.deleted)
+}
+
+func TestArchiveCompletedTasks_ArchivesPastGracePeriod(t *testing.T) {
+	memos, vector, uc := newArchiveFixture()
+
+	out, err := uc.ArchiveCompletedTasks(context.Background(), model.Scope{UserID: "u1"}, automation.ArchiveInput{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, out.Archived)
+	assert.Equal(t, []string{"memos/done-old"}, memos.archived)
+	assert.Equal(t, []string{"memos/done-old"}, vector.deleted)
+}
+
+func TestArchiveCompletedTasks_UsesCompletionTime(t *testing.T) {
+	now := time.Now()
+	stamp := func(d time.Duration) string { return now.Add(-d).Format(taskmeta.TimeLogLayout) }
+
+	memos := newMockMemosRepo()
+	// Done long ago but edited since: the edit does not restart the grace period
+	memos.tasks["memos/edited"] = model.Task{ID: "memos/edited",
+		Content:    "## Ship v1\n\n- **Done:** " + stamp(10*24*time.Hour) + "\n\n#status/done",
+		UpdateTime: now.Add(-time.Hour).Format(time.RFC3339)}
+	// Done recently, whatever its update time says
+	memos.tasks["memos/fresh"] = model.Task{ID: "memos/fresh",
+		Content:    "## Ship v2\n\n- **Done:** " + stamp(time.Hour) + "\n\n#status/done",
+		UpdateTime: now.Add(-10 * 24 * time.Hour).Format(time.RFC3339)}
+
+	uc := New(memos, &mockVectorRepo{}, &mockChecklistSvc{}, &mockLogger{}, automation.Config{ArchiveGracePeriod: 7 * 24 * time.Hour})
+	out, err := uc.ArchiveCompletedTasks(context.Background(), model.Scope{UserID: "u1"}, automation.ArchiveInput{DryRun: true})
+	require.NoError(t, err)
+
+	require.Len(t, out.Candidates, 1)
+	assert.Equal(t, "memos/edited", out.Candidates[0].TaskID)
+}
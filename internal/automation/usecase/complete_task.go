@@ -2,34 +2,24 @@ package usecase
 
 import (
 	"context"
-	"fmt"
 
+	"autonomous-task-management/internal/history"
 	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
 )
 
 // CompleteTask manually marks a task as complete
 func (uc *implUseCase) CompleteTask(ctx context.Context, sc model.Scope, taskID string) error {
-	// Fetch task
-	task, err := uc.memosRepo.GetTask(ctx, taskID)
+	// Update all checkboxes on the latest content; unchanged content skips the update
+	ctx = history.WithAction(ctx, history.ActionComplete)
+	task, err := repository.ModifyTask(ctx, uc.memosRepo, taskID, func(current model.Task) (string, error) {
+		return uc.checklistSvc.UpdateAllCheckboxes(current.Content, true), nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to fetch task: %w", err)
-	}
-
-	// Update all checkboxes
-	updatedContent := uc.checklistSvc.UpdateAllCheckboxes(task.Content, true)
-
-	// OPTIMIZATION: Skip update if nothing changed
-	if updatedContent == task.Content {
-		uc.l.Infof(ctx, "Task %s already completed or has no checkboxes, skipping update", taskID)
-		return nil
-	}
-
-	// Update Memos
-	if err := uc.memosRepo.UpdateTask(ctx, taskID, updatedContent); err != nil {
-		return fmt.Errorf("failed to update task: %w", err)
+		return err
 	}
 
 	// Phase 3 webhook handles re-embedding
-	uc.l.Infof(ctx, "Manually completed task %s", taskID)
+	uc.l.Infof(ctx, "Manually completed task %s (updated %s)", taskID, task.UpdateTime)
 	return nil
 }
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 
+	"autonomous-task-management/internal/checklist"
+	"autonomous-task-management/internal/history"
 	"autonomous-task-management/internal/model"
 	"autonomous-task-management/internal/task/repository"
 	pkgLog "autonomous-task-management/pkg/log"
@@ -16,29 +18,29 @@ type taskMatcher struct {
 	l          pkgLog.Logger
 }
 
-// updateTaskChecklist updates all checkboxes in a task to checked
-func (uc *implUseCase) updateTaskChecklist(ctx context.Context, taskID string, content string) error {
-	// Check if task has checkboxes
-	stats := uc.checklistSvc.GetStats(content)
-	if stats.Total == 0 {
-		uc.l.Infof(ctx, "Task %s has no checkboxes, skipping", taskID)
-		return nil
+// updateTaskChecklist updates all checkboxes in a task to checked.
+// The change is applied to the latest content so concurrent edits are not overwritten.
+func (uc *implUseCase) updateTaskChecklist(ctx context.Context, taskID string) error {
+	ctx = history.WithAction(ctx, history.ActionAutomation)
+	var stats checklist.ChecklistStats
+	_, err := repository.ModifyTask(ctx, uc.memosRepo, taskID, func(current model.Task) (string, error) {
+		// Check if task has checkboxes
+		stats = uc.checklistSvc.GetStats(current.Content)
+		if stats.Total == 0 {
+			return current.Content, nil
+		}
+		// Unchanged content (already completed) skips the update
+		return uc.checklistSvc.UpdateAllCheckboxes(current.Content, true), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update Memos: %w", err)
 	}
 
-	// Update all checkboxes to checked
-	updatedContent := uc.checklistSvc.UpdateAllCheckboxes(content, true)
-
-	// OPTIMIZATION: Check if the content actually changed before submitting
-	if updatedContent == content {
-		uc.l.Infof(ctx, "Task %s already completed or has no changes, skipping update", taskID)
+	if stats.Total == 0 {
+		uc.l.Infof(ctx, "Task %s has no checkboxes, skipping", taskID)
 		return nil
 	}
 
-	// Update Memos
-	if err := uc.memosRepo.UpdateTask(ctx, taskID, updatedContent); err != nil {
-		return fmt.Errorf("failed to update Memos: %w", err)
-	}
-
 	uc.l.Infof(ctx, "Updated task %s (%d/%d checkboxes), Phase 3 webhook will re-embed",
 		taskID, stats.Total, stats.Total)
 	return nil
@@ -112,23 +114,28 @@ func (m *taskMatcher) searchByTags(ctx context.Context, criteria MatchCriteria)
 
 	m.l.Infof(ctx, "Searching by exact tags: %v", criteria.Tags)
 
-	results, err := m.vectorRepo.SearchTasksWithFilter(ctx, repository.SearchTasksOptions{
-		Filter: repository.PayloadFilter{
-			Should: []repository.Condition{
-				{
-					Key:   "tags",
-					Match: repository.MatchAny{Values: criteria.Tags},
-				},
+	filter := repository.PayloadFilter{
+		Should: []repository.Condition{
+			{
+				Key:   repository.PayloadTags,
+				Match: repository.MatchAny{Values: criteria.Tags},
 			},
 		},
-		Limit: 10,
-	})
+	}
+
+	// Exact tags must find every tagged task, so list them all instead of taking a top-K
+	total, err := m.vectorRepo.CountTasks(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
+	m.l.Infof(ctx, "%d tasks carry tags %v", total, criteria.Tags)
+
+	matches := make([]TaskMatch, 0, total)
+	for result, err := range m.vectorRepo.IterateFilteredTasks(ctx, repository.FilterTasksOptions{Filter: filter}) {
+		if err != nil {
+			return matches, err
+		}
 
-	matches := make([]TaskMatch, 0, len(results))
-	for _, result := range results {
 		task, err := m.memosRepo.GetTask(ctx, result.MemoID)
 		if err != nil {
 			m.l.Warnf(ctx, "Failed to fetch task %s: %v", result.MemoID, err)
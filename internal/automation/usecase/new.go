@@ -13,6 +13,7 @@ type implUseCase struct {
 	checklistSvc checklist.UseCase
 	matcher      *taskMatcher
 	l            pkgLog.Logger
+	cfg          automation.Config
 }
 
 func New(
@@ -20,6 +21,7 @@ func New(
 	vectorRepo repository.VectorRepository,
 	checklistSvc checklist.UseCase,
 	l pkgLog.Logger,
+	cfg automation.Config,
 ) automation.UseCase {
 	matcher := &taskMatcher{
 		memosRepo:  memosRepo,
@@ -33,5 +35,6 @@ func New(
 		checklistSvc: checklistSvc,
 		matcher:      matcher,
 		l:            l,
+		cfg:          cfg,
 	}
 }
@@ -45,7 +45,7 @@ func (uc *implUseCase) ProcessWebhook(ctx context.Context, sc model.Scope, input
 	// Update each matched task
 	updatedIDs := make([]string, 0)
 	for _, match := range matches {
-		if err := uc.updateTaskChecklist(ctx, match.TaskID, match.Content); err != nil {
+		if err := uc.updateTaskChecklist(ctx, match.TaskID); err != nil {
 			uc.l.Errorf(ctx, "Failed to update task %s: %v", match.TaskID, err)
 			continue
 		}
@@ -3,6 +3,10 @@ package usecase
 import (
 	"context"
 	"errors"
+	"iter"
+	"maps"
+	"slices"
+	"strconv"
 	"testing"
 
 	"autonomous-task-management/internal/agent"
@@ -10,6 +14,7 @@ import (
 	"autonomous-task-management/internal/checklist"
 	"autonomous-task-management/internal/model"
 	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/taskmeta"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -38,6 +43,7 @@ func (m *mockLogger) Fatalf(_ context.Context, _ string, _ ...any)  {}
 type mockMemosRepo struct {
 	tasks     map[string]model.Task
 	updates   map[string]string
+	archived  []string
 	getErr    error
 	updateErr error
 }
@@ -84,24 +90,117 @@ func (m *mockMemosRepo) UpdateTask(_ context.Context, id string, content string)
 	return nil
 }
 
+func (m *mockMemosRepo) UpdateTaskIfUnchanged(ctx context.Context, id, content, _ string) (model.Task, error) {
+	if err := m.UpdateTask(ctx, id, content); err != nil {
+		return model.Task{}, err
+	}
+	return model.Task{ID: id, Content: content}, nil
+}
+
+func (m *mockMemosRepo) DeleteTask(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockMemosRepo) ListTaskPage(_ context.Context, _ repository.ListTasksOptions) (repository.TaskPage, error) {
+	return repository.TaskPage{}, nil
+}
+
+func (m *mockMemosRepo) IterateTasks(_ context.Context, _ repository.ListTasksOptions) iter.Seq2[model.Task, error] {
+	return func(yield func(model.Task, error) bool) {
+		for _, id := range slices.Sorted(maps.Keys(m.tasks)) {
+			if !yield(m.tasks[id], nil) {
+				return
+			}
+		}
+	}
+}
+
+func (m *mockMemosRepo) ArchiveTask(_ context.Context, id string) error {
+	m.archived = append(m.archived, id)
+	return nil
+}
+
+func (m *mockMemosRepo) RestoreTask(_ context.Context, _ string) error {
+	return nil
+}
+
+func (m *mockMemosRepo) PinTask(_ context.Context, _ string, _ bool) error {
+	return nil
+}
+
+func (m *mockMemosRepo) ListRelations(_ context.Context, _ string) ([]repository.TaskRelation, error) {
+	return nil, nil
+}
+
+func (m *mockMemosRepo) SetRelations(_ context.Context, _ string, _ []repository.TaskRelation) error {
+	return nil
+}
+
 type mockVectorRepo struct {
 	searchResults []repository.SearchResult
 	filterResults []repository.SearchResult
 	searchErr     error
 	filterErr     error
+	deleted       []string
 }
 
 func (m *mockVectorRepo) EmbedTask(_ context.Context, _ model.Task) error { return nil }
 
+func (m *mockVectorRepo) IndexTasks(_ context.Context, tasks []model.Task, _ repository.IndexOptions) (repository.IndexResult, error) {
+	return repository.IndexResult{Total: len(tasks), Indexed: len(tasks)}, nil
+}
+
 func (m *mockVectorRepo) SearchTasks(_ context.Context, _ repository.SearchTasksOptions) ([]repository.SearchResult, error) {
 	return m.searchResults, m.searchErr
 }
 
-func (m *mockVectorRepo) SearchTasksWithFilter(_ context.Context, _ repository.SearchTasksOptions) ([]repository.SearchResult, error) {
-	return m.filterResults, m.filterErr
+func (m *mockVectorRepo) FindSimilarTasks(_ context.Context, queries []string, _ int) ([][]repository.SearchResult, error) {
+	return make([][]repository.SearchResult, len(queries)), nil
 }
 
-func (m *mockVectorRepo) DeleteTask(_ context.Context, _ string) error { return nil }
+// FilterTaskPage serves filterResults two at a time, so callers must follow NextOffset.
+func (m *mockVectorRepo) FilterTaskPage(_ context.Context, opt repository.FilterTasksOptions) (repository.SearchPage, error) {
+	if m.filterErr != nil {
+		return repository.SearchPage{}, m.filterErr
+	}
+	start, _ := strconv.Atoi(opt.Offset)
+	end := min(start+2, len(m.filterResults))
+	page := repository.SearchPage{Results: m.filterResults[start:end]}
+	if end < len(m.filterResults) {
+		page.NextOffset = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+func (m *mockVectorRepo) IterateFilteredTasks(ctx context.Context, opt repository.FilterTasksOptions) iter.Seq2[repository.SearchResult, error] {
+	return func(yield func(repository.SearchResult, error) bool) {
+		for {
+			page, err := m.FilterTaskPage(ctx, opt)
+			if err != nil {
+				yield(repository.SearchResult{}, err)
+				return
+			}
+			for _, r := range page.Results {
+				if !yield(r, nil) {
+					return
+				}
+			}
+			if page.NextOffset == "" {
+				return
+			}
+			opt.Offset = page.NextOffset
+		}
+	}
+}
+
+func (m *mockVectorRepo) CountTasks(_ context.Context, _ repository.PayloadFilter) (int, error) {
+	return len(m.filterResults), m.filterErr
+}
+
+func (m *mockVectorRepo) DeleteTask(_ context.Context, id string) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
 
 type mockChecklistSvc struct {
 	stats     checklist.ChecklistStats
@@ -126,7 +225,7 @@ func (m *mockChecklistSvc) IsFullyCompleted(_ string) bool           { return fa
 func (m *mockChecklistSvc) RegisterAgentTools(_ *agent.ToolRegistry) {}
 
 func newTestAutomationUC(memos *mockMemosRepo, vector *mockVectorRepo, cl *mockChecklistSvc) *implUseCase {
-	uc := New(memos, vector, cl, &mockLogger{})
+	uc := New(memos, vector, cl, &mockLogger{}, automation.Config{})
 	return uc.(*implUseCase)
 }
 
@@ -345,6 +444,34 @@ func TestProcessWebhook_UpdateTaskFails_ContinuesOthers(t *testing.T) {
 	assert.Equal(t, 0, output.TasksUpdated)
 }
 
+func TestProcessWebhook_MatchesEveryTaggedTask(t *testing.T) {
+	memos := newMockMemosRepo()
+	vector := &mockVectorRepo{}
+	for i := range 12 {
+		id := "task-" + strconv.Itoa(i)
+		memos.tasks[id] = model.Task{ID: id, Content: "- [ ] item", Tags: []string{"#pr/7"}}
+		vector.filterResults = append(vector.filterResults, repository.SearchResult{MemoID: id})
+	}
+
+	cl := &mockChecklistSvc{
+		stats: checklist.ChecklistStats{Total: 1, Completed: 0, Pending: 1},
+	}
+
+	uc := newTestAutomationUC(memos, vector, cl)
+
+	output, err := uc.ProcessWebhook(context.Background(), model.Scope{UserID: "test"}, automation.ProcessWebhookInput{
+		Event: model.WebhookEvent{
+			EventType:  "pull_request",
+			Action:     "merged",
+			Repository: "org/repo",
+			PRNumber:   7,
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 12, output.TasksUpdated)
+}
+
 // ---------------------------------------------------------------------------
 // CompleteTask tests
 // ---------------------------------------------------------------------------
@@ -366,6 +493,8 @@ func TestCompleteTask_Success(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.Contains(t, memos.updates["task-1"], "[x]")
+	_, stamped := taskmeta.DoneAt(memos.updates["task-1"])
+	assert.True(t, stamped, "completing a task records when it was done")
 }
 
 func TestCompleteTask_TaskNotFound(t *testing.T) {
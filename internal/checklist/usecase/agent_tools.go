@@ -7,6 +7,8 @@ import (
 
 	"autonomous-task-management/internal/agent"
 	"autonomous-task-management/internal/checklist"
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
 	"autonomous-task-management/internal/task/repository"
 	pkgLog "autonomous-task-management/pkg/log"
 )
@@ -162,18 +164,31 @@ func (t *updateChecklistItemTool) Execute(ctx context.Context, input map[string]
 
 	t.l.Infof(ctx, "update_checklist_item: task_id=%s item=%q checked=%v", params.TaskID, params.ItemText, params.Checked)
 
-	task, err := t.memosRepo.GetTask(ctx, params.TaskID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch task: %w", err)
-	}
-
-	output, err := t.checklistUC.UpdateCheckbox(ctx, checklist.UpdateCheckboxInput{
-		Content:      task.Content,
-		CheckboxText: params.ItemText,
-		Checked:      params.Checked,
+	ctx = history.WithActor(ctx, history.ActorAgent)
+	ctx = history.WithAction(ctx, history.ActionCheck)
+	if !params.Checked {
+		ctx = history.WithAction(ctx, history.ActionUncheck)
+	}
+
+	// Re-applied to the latest content if the task is edited concurrently
+	var output checklist.UpdateCheckboxOutput
+	_, err = repository.ModifyTask(ctx, t.memosRepo, params.TaskID, func(current model.Task) (string, error) {
+		out, err := t.checklistUC.UpdateCheckbox(ctx, checklist.UpdateCheckboxInput{
+			Content:      current.Content,
+			CheckboxText: params.ItemText,
+			Checked:      params.Checked,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to update checkbox: %w", err)
+		}
+		output = out
+		if !out.Updated {
+			return current.Content, nil
+		}
+		return out.Content, nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update checkbox: %w", err)
+		return nil, err
 	}
 
 	if !output.Updated {
@@ -185,10 +200,6 @@ func (t *updateChecklistItemTool) Execute(ctx context.Context, input map[string]
 		}, nil
 	}
 
-	if err := t.memosRepo.UpdateTask(ctx, params.TaskID, output.Content); err != nil {
-		return nil, fmt.Errorf("failed to update Memos: %w", err)
-	}
-
 	t.l.Infof(ctx, "Updated checklist for task %s", params.TaskID)
 
 	action := "unchecked"
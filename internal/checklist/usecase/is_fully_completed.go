@@ -1,16 +1,9 @@
 package usecase
 
-// IsFullyCompleted checks if all checkboxes are checked
-func (s *implUseCase) IsFullyCompleted(content string) bool {
-	checkboxes := s.ParseCheckboxes(content)
-	if len(checkboxes) == 0 {
-		return false // No checkboxes = not a checklist task
-	}
+import "autonomous-task-management/pkg/taskmeta"
 
-	for _, cb := range checkboxes {
-		if !cb.Checked {
-			return false
-		}
-	}
-	return true
+// IsFullyCompleted checks if all checkboxes are checked.
+// No checkboxes = not a checklist task.
+func (s *implUseCase) IsFullyCompleted(content string) bool {
+	return taskmeta.ChecklistDone(content)
 }
@@ -0,0 +1,10 @@
+package dependency
+
+import "errors"
+
+var (
+	ErrSelfLink        = errors.New("dependency: a task cannot be linked to itself")
+	ErrCycle           = errors.New("dependency: link would create a cycle")
+	ErrInvalidRelation = errors.New("dependency: invalid relation")
+	ErrNotLinked       = errors.New("dependency: tasks are not linked")
+)
@@ -0,0 +1,36 @@
+package dependency
+
+import (
+	"context"
+
+	"autonomous-task-management/internal/agent"
+	"autonomous-task-management/internal/model"
+)
+
+// Notifier delivers unblocking notifications to a chat (satisfied by telegram.IBot).
+type Notifier interface {
+	SendMessageWithMode(chatID int64, text string, parseMode string) error
+}
+
+// UseCase defines the business logic interface for the dependency domain.
+// Relations are stored as "- **Blocked by:**" and "- **Parent:**" lines in the task content.
+type UseCase interface {
+	// Link adds a blocked-by or parent relation, rejecting cycles.
+	Link(ctx context.Context, sc model.Scope, input LinkInput) error
+
+	// Unlink removes a blocked-by or parent relation.
+	Unlink(ctx context.Context, sc model.Scope, input LinkInput) error
+
+	// GetBlockers returns what blocks a task and what the task blocks.
+	GetBlockers(ctx context.Context, sc model.Scope, taskID string) (BlockersOutput, error)
+
+	// GetRollup aggregates progress over a task's subtasks (recursively).
+	GetRollup(ctx context.Context, sc model.Scope, taskID string) (RollupOutput, error)
+
+	// OnTaskCompleted finds tasks unblocked by a completed task and notifies about them.
+	// It is a no-op if the task is not actually completed.
+	OnTaskCompleted(ctx context.Context, sc model.Scope, input CompletedInput) (CompletedOutput, error)
+
+	// RegisterAgentTools registers this domain's agent tools into the registry.
+	RegisterAgentTools(registry *agent.ToolRegistry)
+}
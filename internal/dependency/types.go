@@ -0,0 +1,60 @@
+package dependency
+
+// Relation is the kind of link between two tasks.
+type Relation string
+
+const (
+	RelationBlockedBy Relation = "blocked_by" // TaskID cannot start until TargetID is done
+	RelationParent    Relation = "parent"     // TaskID is a subtask of TargetID
+)
+
+// Config holds the dependency domain settings.
+type Config struct {
+	ChatIDs []int64 // Chats notified about unblocked tasks when no chat triggered the completion
+}
+
+// LinkInput links TaskID to TargetID.
+type LinkInput struct {
+	TaskID   string
+	TargetID string
+	Relation Relation
+}
+
+// TaskRef is a lightweight reference to a task.
+type TaskRef struct {
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	MemoURL  string  `json:"memo_url,omitempty"`
+	Done     bool    `json:"done"`
+	Progress float64 `json:"progress"` // 0-100
+}
+
+// BlockersOutput describes a task's blocked-by relations in both directions.
+type BlockersOutput struct {
+	Task         TaskRef   `json:"task"`
+	Blockers     []TaskRef `json:"blockers"`      // Tasks this task is blocked by
+	OpenBlockers int       `json:"open_blockers"` // Blockers not yet done
+	Blocking     []TaskRef `json:"blocking"`      // Tasks blocked by this task
+}
+
+// RollupOutput is the progress of a parent task computed from its subtasks.
+type RollupOutput struct {
+	Task      TaskRef   `json:"task"`
+	Children  []TaskRef `json:"children"`
+	Completed int       `json:"completed"`
+	Total     int       `json:"total"`
+	Progress  float64   `json:"progress"` // Mean progress of the children, 0-100
+}
+
+// CompletedInput reports a completed task.
+type CompletedInput struct {
+	TaskID string
+	ChatID int64 // Chat to notify; 0 = Config.ChatIDs
+}
+
+// CompletedOutput lists what the completion unlocked.
+type CompletedOutput struct {
+	Unblocked     []TaskRef // Tasks whose blockers are now all done
+	ParentsReady  []TaskRef // Parents whose subtasks are now all done
+	Notifications int       // Messages sent
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/agent"
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/model"
+	pkgLog "autonomous-task-management/pkg/log"
+)
+
+// RegisterAgentTools registers the dependency domain's agent tools into the registry.
+func (uc *implUseCase) RegisterAgentTools(registry *agent.ToolRegistry) {
+	registry.Register(&getTaskBlockersTool{uc: uc, l: uc.l})
+	registry.Register(&getSubtaskProgressTool{uc: uc, l: uc.l})
+}
+
+type taskIDInput struct {
+	TaskID string `json:"task_id"`
+}
+
+func parseTaskIDInput(input map[string]interface{}) (taskIDInput, error) {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return taskIDInput{}, fmt.Errorf("failed to marshal input: %w", err)
+	}
+	var params taskIDInput
+	if err := json.Unmarshal(inputBytes, &params); err != nil {
+		return taskIDInput{}, fmt.Errorf("failed to parse input: %w", err)
+	}
+	if params.TaskID == "" {
+		return taskIDInput{}, fmt.Errorf("task_id is required")
+	}
+	return params, nil
+}
+
+func taskIDParameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"task_id": map[string]interface{}{
+				"type":        "string",
+				"description": "Memos task ID (UID)",
+			},
+		},
+		"required": []string{"task_id"},
+	}
+}
+
+// getTaskBlockersTool answers "what is blocking X".
+type getTaskBlockersTool struct {
+	uc *implUseCase
+	l  pkgLog.Logger
+}
+
+type getTaskBlockersOutput struct {
+	dependency.BlockersOutput
+	Summary string `json:"summary"`
+}
+
+func (t *getTaskBlockersTool) Name() string { return "get_task_blockers" }
+
+func (t *getTaskBlockersTool) Description() string {
+	return "Find what is blocking a task (its blocked-by dependencies and whether they are done) and which tasks it blocks."
+}
+
+func (t *getTaskBlockersTool) Parameters() map[string]interface{} { return taskIDParameters() }
+
+func (t *getTaskBlockersTool) Execute(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	params, err := parseTaskIDInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	t.l.Infof(ctx, "get_task_blockers: task_id=%s", params.TaskID)
+
+	out, err := t.uc.GetBlockers(ctx, model.Scope{UserID: "agent"}, params.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := "Task này không bị chặn bởi task nào"
+	if out.OpenBlockers > 0 {
+		var open []string
+		for _, b := range out.Blockers {
+			if !b.Done {
+				open = append(open, b.Title)
+			}
+		}
+		summary = fmt.Sprintf("⛔ Bị chặn bởi %d task chưa xong: %s", out.OpenBlockers, strings.Join(open, ", "))
+	} else if len(out.Blockers) > 0 {
+		summary = "✅ Tất cả task chặn đã hoàn thành, có thể bắt đầu"
+	}
+
+	return getTaskBlockersOutput{BlockersOutput: out, Summary: summary}, nil
+}
+
+var _ agent.Tool = (*getTaskBlockersTool)(nil)
+
+// getSubtaskProgressTool reports rollup progress over a task's subtasks.
+type getSubtaskProgressTool struct {
+	uc *implUseCase
+	l  pkgLog.Logger
+}
+
+type getSubtaskProgressOutput struct {
+	dependency.RollupOutput
+	Summary string `json:"summary"`
+}
+
+func (t *getSubtaskProgressTool) Name() string { return "get_subtask_progress" }
+
+func (t *getSubtaskProgressTool) Description() string {
+	return "Get the overall progress of a parent task computed from its subtasks."
+}
+
+func (t *getSubtaskProgressTool) Parameters() map[string]interface{} { return taskIDParameters() }
+
+func (t *getSubtaskProgressTool) Execute(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	params, err := parseTaskIDInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	t.l.Infof(ctx, "get_subtask_progress: task_id=%s", params.TaskID)
+
+	out, err := t.uc.GetRollup(ctx, model.Scope{UserID: "agent"}, params.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := "Task này không có subtask"
+	if out.Total > 0 {
+		summary = fmt.Sprintf("📊 %d/%d subtask hoàn thành (%.0f%%)", out.Completed, out.Total, out.Progress)
+	}
+
+	return getSubtaskProgressOutput{RollupOutput: out, Summary: summary}, nil
+}
+
+var _ agent.Tool = (*getSubtaskProgressTool)(nil)
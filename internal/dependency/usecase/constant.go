@@ -0,0 +1,4 @@
+package usecase
+
+// graphPageSize is the page size used when reading every memo to build the dependency graph.
+const graphPageSize = 200
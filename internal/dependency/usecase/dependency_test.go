@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	checklistUC "autonomous-task-management/internal/checklist/usecase"
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/internal/task/repository/local"
+	"autonomous-task-management/pkg/taskmeta"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Mock implementations
+// ---------------------------------------------------------------------------
+
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Debugf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Info(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Infof(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Warn(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Warnf(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Error(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Errorf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) DPanic(_ context.Context, _ ...any)            {}
+func (m *mockLogger) DPanicf(_ context.Context, _ string, _ ...any) {}
+func (m *mockLogger) Panic(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Panicf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Fatal(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Fatalf(_ context.Context, _ string, _ ...any)  {}
+
+// newTestRepo stores one task per content in a local Markdown repository and returns their IDs.
+// "{0}", "{1}", ... in a content stand for the IDs of the tasks at those positions.
+func newTestRepo(t *testing.T, contents ...string) (repository.MemosRepository, []string) {
+	t.Helper()
+	repo, err := local.New(t.TempDir(), "", &mockLogger{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ids := make([]string, len(contents))
+	placeholders := make([]string, 0, 2*len(contents))
+	for i := range contents {
+		task, err := repo.CreateTask(ctx, repository.CreateTaskOptions{Content: "## Task"})
+		require.NoError(t, err)
+		ids[i] = task.ID
+		placeholders = append(placeholders, fmt.Sprintf("{%d}", i), task.ID)
+	}
+	r := strings.NewReplacer(placeholders...)
+	for i, content := range contents {
+		require.NoError(t, repo.UpdateTask(ctx, ids[i], r.Replace(content)))
+	}
+	return repo, ids
+}
+
+// contentOf returns the stored content of task id.
+func contentOf(t *testing.T, repo repository.MemosRepository, id string) string {
+	t.Helper()
+	task, err := repo.GetTask(context.Background(), id)
+	require.NoError(t, err)
+	return task.Content
+}
+
+type recordingNotifier struct {
+	chats []int64
+}
+
+func (n *recordingNotifier) SendMessageWithMode(chatID int64, _ string, _ string) error {
+	n.chats = append(n.chats, chatID)
+	return nil
+}
+
+func newTestUseCase(repo repository.MemosRepository, notifier dependency.Notifier) dependency.UseCase {
+	l := &mockLogger{}
+	return New(l, repo, checklistUC.New(repo, nil, l), notifier, dependency.Config{ChatIDs: []int64{7}})
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestLink_WritesMarkerAndRejectsCycle(t *testing.T) {
+	repo, ids := newTestRepo(t, "## A", "## B", "## C")
+	a, b, c := ids[0], ids[1], ids[2]
+	uc := newTestUseCase(repo, nil)
+	ctx := context.Background()
+
+	require.NoError(t, uc.Link(ctx, model.Scope{}, dependency.LinkInput{TaskID: a, TargetID: b, Relation: dependency.RelationBlockedBy}))
+	require.NoError(t, uc.Link(ctx, model.Scope{}, dependency.LinkInput{TaskID: b, TargetID: c, Relation: dependency.RelationBlockedBy}))
+	assert.Contains(t, contentOf(t, repo, a), "- **Blocked by:** "+b)
+
+	err := uc.Link(ctx, model.Scope{}, dependency.LinkInput{TaskID: c, TargetID: a, Relation: dependency.RelationBlockedBy})
+	assert.ErrorIs(t, err, dependency.ErrCycle)
+
+	err = uc.Link(ctx, model.Scope{}, dependency.LinkInput{TaskID: a, TargetID: a, Relation: dependency.RelationParent})
+	assert.ErrorIs(t, err, dependency.ErrSelfLink)
+
+	require.NoError(t, uc.Link(ctx, model.Scope{}, dependency.LinkInput{TaskID: b, TargetID: a, Relation: dependency.RelationParent}))
+	err = uc.Link(ctx, model.Scope{}, dependency.LinkInput{TaskID: a, TargetID: b, Relation: dependency.RelationParent})
+	assert.ErrorIs(t, err, dependency.ErrCycle)
+}
+
+func TestUnlink_RemovesOnlyTarget(t *testing.T) {
+	repo, ids := newTestRepo(t, "## A\n\n- **Blocked by:** b, c")
+	uc := newTestUseCase(repo, nil)
+	ctx := context.Background()
+
+	require.NoError(t, uc.Unlink(ctx, model.Scope{}, dependency.LinkInput{TaskID: ids[0], TargetID: "b", Relation: dependency.RelationBlockedBy}))
+	assert.Contains(t, contentOf(t, repo, ids[0]), "- **Blocked by:** c")
+
+	err := uc.Unlink(ctx, model.Scope{}, dependency.LinkInput{TaskID: ids[0], TargetID: "b", Relation: dependency.RelationBlockedBy})
+	assert.ErrorIs(t, err, dependency.ErrNotLinked)
+}
+
+func TestUnlink_Parent(t *testing.T) {
+	repo, ids := newTestRepo(t, "## A\n\n- **Parent:** memos/b")
+	uc := newTestUseCase(repo, nil)
+	ctx := context.Background()
+
+	err := uc.Unlink(ctx, model.Scope{}, dependency.LinkInput{TaskID: ids[0], TargetID: "c", Relation: dependency.RelationParent})
+	assert.ErrorIs(t, err, dependency.ErrNotLinked)
+
+	require.NoError(t, uc.Unlink(ctx, model.Scope{}, dependency.LinkInput{TaskID: ids[0], TargetID: "b", Relation: dependency.RelationParent}))
+	assert.Empty(t, taskmeta.Parse(contentOf(t, repo, ids[0])).Parent)
+}
+
+func TestGetBlockers(t *testing.T) {
+	repo, ids := newTestRepo(t,
+		"## A\n\n- **Blocked by:** {1}, {2}",
+		"## B\n\n- [x] done",
+		"## C\n\n- [ ] todo",
+	)
+	uc := newTestUseCase(repo, nil)
+
+	out, err := uc.GetBlockers(context.Background(), model.Scope{}, ids[0])
+	require.NoError(t, err)
+	assert.Len(t, out.Blockers, 2)
+	assert.Equal(t, 1, out.OpenBlockers)
+
+	out, err = uc.GetBlockers(context.Background(), model.Scope{}, ids[2])
+	require.NoError(t, err)
+	require.Len(t, out.Blocking, 1)
+	assert.Equal(t, ids[0], out.Blocking[0].ID)
+}
+
+func TestGetRollup_Recursive(t *testing.T) {
+	repo, ids := newTestRepo(t,
+		"## Parent",
+		"## C1\n\n- **Parent:** {0}\n- [x] one",
+		"## C2\n\n- **Parent:** {0}",
+		"## G1\n\n- **Parent:** {2}\n- [x] a\n- [ ] b",
+	)
+	uc := newTestUseCase(repo, nil)
+
+	out, err := uc.GetRollup(context.Background(), model.Scope{}, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, 2, out.Total)
+	assert.Equal(t, 1, out.Completed)
+	assert.InDelta(t, 75.0, out.Progress, 0.01) // (100 + 50) / 2
+}
+
+func TestOnTaskCompleted_NotifiesUnblocked(t *testing.T) {
+	repo, ids := newTestRepo(t,
+		"## A\n\n- **Blocked by:** {2}",
+		"## D\n\n- **Blocked by:** {2}, {3}",
+		"## B\n\n- [x] done",
+		"## C\n\n- [ ] todo",
+	)
+	notifier := &recordingNotifier{}
+	uc := newTestUseCase(repo, notifier)
+
+	out, err := uc.OnTaskCompleted(context.Background(), model.Scope{}, dependency.CompletedInput{TaskID: ids[2]})
+	require.NoError(t, err)
+	require.Len(t, out.Unblocked, 1)
+	assert.Equal(t, ids[0], out.Unblocked[0].ID)
+	assert.Equal(t, []int64{7}, notifier.chats)
+
+	// Not completed: no-op
+	out, err = uc.OnTaskCompleted(context.Background(), model.Scope{}, dependency.CompletedInput{TaskID: ids[3], ChatID: 1})
+	require.NoError(t, err)
+	assert.Empty(t, out.Unblocked)
+	assert.Equal(t, []int64{7}, notifier.chats)
+}
+
+func TestRenderCompleted_EscapesTitles(t *testing.T) {
+	text := renderCompleted(dependency.TaskRef{Title: "Fix db_migrate"}, dependency.CompletedOutput{
+		Unblocked: []dependency.TaskRef{{ID: "a", Title: "Deploy *prod*"}, {Title: "Review [draft]", MemoURL: "https://memos/b"}},
+	})
+
+	assert.Contains(t, text, "*Fix db_migrate*")
+	assert.Contains(t, text, "• Deploy \\*prod\\* (`a`)")
+	assert.Contains(t, text, "• [Review (draft)](https://memos/b)")
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// GetBlockers returns the tasks blocking taskID and the tasks taskID blocks.
+func (uc *implUseCase) GetBlockers(ctx context.Context, sc model.Scope, taskID string) (dependency.BlockersOutput, error) {
+	task, err := uc.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return dependency.BlockersOutput{}, fmt.Errorf("failed to fetch task: %w", err)
+	}
+
+	g, err := uc.loadGraph(ctx, task)
+	if err != nil {
+		return dependency.BlockersOutput{}, err
+	}
+	k := taskmeta.NormalizeID(task.ID)
+
+	out := dependency.BlockersOutput{Task: uc.ref(g.nodes[k])}
+	for _, b := range g.blockedBy(k) {
+		n, ok := g.nodes[taskmeta.NormalizeID(b)]
+		if !ok {
+			uc.l.Warnf(ctx, "dependency: task %s references missing blocker %s", task.ID, b)
+			out.Blockers = append(out.Blockers, missingRef(b))
+			continue
+		}
+		r := uc.ref(n)
+		if !r.Done {
+			out.OpenBlockers++
+		}
+		out.Blockers = append(out.Blockers, r)
+	}
+	for _, n := range g.dependents(k) {
+		out.Blocking = append(out.Blocking, uc.ref(n))
+	}
+
+	uc.l.Infof(ctx, "dependency.GetBlockers: user=%s task=%s blockers=%d open=%d",
+		sc.UserID, task.ID, len(out.Blockers), out.OpenBlockers)
+	return out, nil
+}
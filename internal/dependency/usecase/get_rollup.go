@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// GetRollup computes a parent task's progress from its subtasks.
+// Subtasks that have their own children contribute their rolled-up progress.
+func (uc *implUseCase) GetRollup(ctx context.Context, sc model.Scope, taskID string) (dependency.RollupOutput, error) {
+	task, err := uc.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return dependency.RollupOutput{}, fmt.Errorf("failed to fetch task: %w", err)
+	}
+
+	g, err := uc.loadGraph(ctx, task)
+	if err != nil {
+		return dependency.RollupOutput{}, err
+	}
+	k := taskmeta.NormalizeID(task.ID)
+
+	out := dependency.RollupOutput{Task: uc.ref(g.nodes[k])}
+	visited := map[string]bool{k: true}
+	var sum float64
+	for _, child := range g.children(k) {
+		r := uc.ref(child)
+		r.Progress = uc.progress(g, child, visited)
+		if r.Done {
+			out.Completed++
+		}
+		sum += r.Progress
+		out.Children = append(out.Children, r)
+	}
+	out.Total = len(out.Children)
+	if out.Total > 0 {
+		out.Progress = sum / float64(out.Total)
+	}
+
+	uc.l.Infof(ctx, "dependency.GetRollup: user=%s task=%s children=%d progress=%.0f%%",
+		sc.UserID, task.ID, out.Total, out.Progress)
+	return out, nil
+}
+
+// progress returns 100 for done tasks, the mean of the children for parents,
+// and the checklist progress otherwise. visited guards against corrupt cycles.
+func (uc *implUseCase) progress(g *graph, n *node, visited map[string]bool) float64 {
+	k := taskmeta.NormalizeID(n.task.ID)
+	if visited[k] {
+		return 0
+	}
+	visited[k] = true
+
+	if uc.isDone(n) {
+		return 100
+	}
+	children := g.children(k)
+	if len(children) == 0 {
+		return uc.checklist.GetStats(n.task.Content).Progress
+	}
+	var sum float64
+	for _, c := range children {
+		sum += uc.progress(g, c, visited)
+	}
+	return sum / float64(len(children))
+}
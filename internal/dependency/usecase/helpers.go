@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// node is a task in the dependency graph.
+type node struct {
+	task model.Task
+	meta taskmeta.Meta
+}
+
+// graph indexes tasks by normalized ID.
+type graph struct {
+	nodes map[string]*node
+	order []string // Keeps listing order for stable output
+}
+
+// loadGraph reads tasks from Memos. Fresh copies of already-fetched tasks override the listing.
+func (uc *implUseCase) loadGraph(ctx context.Context, fresh ...model.Task) (*graph, error) {
+	g := &graph{nodes: make(map[string]*node)}
+	for t, err := range uc.repo.IterateTasks(ctx, repository.ListTasksOptions{Limit: graphPageSize}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tasks: %w", err)
+		}
+		g.put(t)
+	}
+	for _, t := range fresh {
+		g.put(t)
+	}
+	return g, nil
+}
+
+// put adds t to the graph, replacing any older copy of the same task.
+func (g *graph) put(t model.Task) {
+	k := taskmeta.NormalizeID(t.ID)
+	if _, ok := g.nodes[k]; !ok {
+		g.order = append(g.order, k)
+	}
+	g.nodes[k] = &node{task: t, meta: taskmeta.Parse(t.Content)}
+}
+
+// blockedBy returns the IDs n is blocked by.
+func (g *graph) blockedBy(k string) []string {
+	n, ok := g.nodes[k]
+	if !ok {
+		return nil
+	}
+	return n.meta.BlockedBy
+}
+
+// dependents returns the nodes blocked by k.
+func (g *graph) dependents(k string) []*node {
+	var out []*node
+	for _, id := range g.order {
+		n := g.nodes[id]
+		for _, b := range n.meta.BlockedBy {
+			if taskmeta.NormalizeID(b) == k {
+				out = append(out, n)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// children returns the subtasks of k.
+func (g *graph) children(k string) []*node {
+	var out []*node
+	for _, id := range g.order {
+		if n := g.nodes[id]; n.meta.Parent != "" && taskmeta.NormalizeID(n.meta.Parent) == k {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// reachesViaBlockers reports whether from is (transitively) blocked by to.
+func (g *graph) reachesViaBlockers(from, to string) bool {
+	visited := make(map[string]bool)
+	stack := []string{from}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if cur == to {
+			return true
+		}
+		if visited[cur] {
+			continue
+		}
+		visited[cur] = true
+		for _, b := range g.blockedBy(cur) {
+			stack = append(stack, taskmeta.NormalizeID(b))
+		}
+	}
+	return false
+}
+
+// reachesViaParents reports whether to is an ancestor of from (or from itself).
+func (g *graph) reachesViaParents(from, to string) bool {
+	visited := make(map[string]bool)
+	for cur := from; cur != "" && !visited[cur]; {
+		if cur == to {
+			return true
+		}
+		visited[cur] = true
+		n, ok := g.nodes[cur]
+		if !ok {
+			return false
+		}
+		cur = taskmeta.NormalizeID(n.meta.Parent)
+	}
+	return false
+}
+
+// isDone reports whether a task is completed: fully checked checklist or #status/done.
+func (uc *implUseCase) isDone(n *node) bool {
+	return n.meta.Done()
+}
+
+// ref converts a node to a TaskRef.
+func (uc *implUseCase) ref(n *node) dependency.TaskRef {
+	title := n.meta.Title
+	if title == "" {
+		title = n.task.ID
+	}
+	done := uc.isDone(n)
+	progress := uc.checklist.GetStats(n.task.Content).Progress
+	if done {
+		progress = 100
+	}
+	return dependency.TaskRef{
+		ID:       n.task.ID,
+		Title:    title,
+		MemoURL:  n.task.MemoURL,
+		Done:     done,
+		Progress: progress,
+	}
+}
+
+// missingRef is used for relations pointing at tasks that no longer exist.
+func missingRef(id string) dependency.TaskRef {
+	return dependency.TaskRef{ID: id, Title: id}
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// Link adds a blocked-by or parent relation to input.TaskID.
+// The write goes through ModifyTask and the cycle check runs again on every attempt, so a
+// concurrent edit of the task is neither lost nor allowed to sneak a cycle in.
+func (uc *implUseCase) Link(ctx context.Context, sc model.Scope, input dependency.LinkInput) error {
+	if err := validateLink(input); err != nil {
+		return err
+	}
+
+	task, err := uc.repo.GetTask(ctx, input.TaskID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch task: %w", err)
+	}
+	target, err := uc.repo.GetTask(ctx, input.TargetID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch target task: %w", err)
+	}
+
+	g, err := uc.loadGraph(ctx, task, target)
+	if err != nil {
+		return err
+	}
+	from, to := taskmeta.NormalizeID(task.ID), taskmeta.NormalizeID(target.ID)
+
+	_, err = repository.ModifyTask(history.WithAction(ctx, history.ActionLink), uc.repo, task.ID, func(current model.Task) (string, error) {
+		g.put(current)
+		meta := g.nodes[from].meta
+
+		switch input.Relation {
+		case dependency.RelationBlockedBy:
+			for _, b := range meta.BlockedBy {
+				if taskmeta.NormalizeID(b) == to {
+					return current.Content, nil // already linked
+				}
+			}
+			// task blocked by target closes a cycle if target already waits on task
+			if g.reachesViaBlockers(to, from) {
+				return "", dependency.ErrCycle
+			}
+			return taskmeta.SetField(current.Content, taskmeta.FieldBlockedBy,
+				strings.Join(append(meta.BlockedBy, target.ID), ", ")), nil
+
+		default: // dependency.RelationParent
+			if meta.Parent != "" && taskmeta.NormalizeID(meta.Parent) == to {
+				return current.Content, nil
+			}
+			// target cannot become the parent of one of its own ancestors
+			if g.reachesViaParents(to, from) {
+				return "", dependency.ErrCycle
+			}
+			return taskmeta.SetField(current.Content, taskmeta.FieldParent, target.ID), nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.l.Infof(ctx, "dependency.Link: user=%s %s %s %s", sc.UserID, task.ID, input.Relation, target.ID)
+	return nil
+}
+
+// Unlink removes a blocked-by or parent relation from input.TaskID.
+func (uc *implUseCase) Unlink(ctx context.Context, sc model.Scope, input dependency.LinkInput) error {
+	if err := validateLink(input); err != nil {
+		return err
+	}
+	to := taskmeta.NormalizeID(input.TargetID)
+
+	task, err := repository.ModifyTask(history.WithAction(ctx, history.ActionLink), uc.repo, input.TaskID, func(current model.Task) (string, error) {
+		meta := taskmeta.Parse(current.Content)
+
+		switch input.Relation {
+		case dependency.RelationBlockedBy:
+			remaining := make([]string, 0, len(meta.BlockedBy))
+			for _, b := range meta.BlockedBy {
+				if taskmeta.NormalizeID(b) != to {
+					remaining = append(remaining, b)
+				}
+			}
+			if len(remaining) == len(meta.BlockedBy) {
+				return "", dependency.ErrNotLinked
+			}
+			return taskmeta.SetField(current.Content, taskmeta.FieldBlockedBy, strings.Join(remaining, ", ")), nil
+
+		default: // dependency.RelationParent
+			if meta.Parent == "" || taskmeta.NormalizeID(meta.Parent) != to {
+				return "", dependency.ErrNotLinked
+			}
+			return taskmeta.SetField(current.Content, taskmeta.FieldParent, ""), nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	uc.l.Infof(ctx, "dependency.Unlink: user=%s %s %s %s", sc.UserID, task.ID, input.Relation, input.TargetID)
+	return nil
+}
+
+func validateLink(input dependency.LinkInput) error {
+	if input.Relation != dependency.RelationBlockedBy && input.Relation != dependency.RelationParent {
+		return dependency.ErrInvalidRelation
+	}
+	if taskmeta.NormalizeID(input.TaskID) == taskmeta.NormalizeID(input.TargetID) {
+		return dependency.ErrSelfLink
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"autonomous-task-management/internal/checklist"
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/task/repository"
+	pkgLog "autonomous-task-management/pkg/log"
+)
+
+type implUseCase struct {
+	l         pkgLog.Logger
+	repo      repository.MemosRepository
+	checklist checklist.UseCase
+	notifier  dependency.Notifier // optional; nil = no notifications
+	cfg       dependency.Config
+}
+
+// New creates a new dependency UseCase instance.
+func New(
+	l pkgLog.Logger,
+	repo repository.MemosRepository,
+	checklistUC checklist.UseCase,
+	notifier dependency.Notifier,
+	cfg dependency.Config,
+) dependency.UseCase {
+	return &implUseCase{
+		l:         l,
+		repo:      repo,
+		checklist: checklistUC,
+		notifier:  notifier,
+		cfg:       cfg,
+	}
+}
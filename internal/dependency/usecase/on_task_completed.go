@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/taskmeta"
+	"autonomous-task-management/pkg/telegram"
+)
+
+// OnTaskCompleted reports which tasks become actionable now that input.TaskID is done:
+// dependents whose blockers are all done, and parents whose subtasks are all done.
+func (uc *implUseCase) OnTaskCompleted(ctx context.Context, sc model.Scope, input dependency.CompletedInput) (dependency.CompletedOutput, error) {
+	task, err := uc.repo.GetTask(ctx, input.TaskID)
+	if err != nil {
+		return dependency.CompletedOutput{}, fmt.Errorf("failed to fetch task: %w", err)
+	}
+
+	g, err := uc.loadGraph(ctx, task)
+	if err != nil {
+		return dependency.CompletedOutput{}, err
+	}
+	k := taskmeta.NormalizeID(task.ID)
+	completed := g.nodes[k]
+	if !uc.isDone(completed) {
+		return dependency.CompletedOutput{}, nil
+	}
+
+	var out dependency.CompletedOutput
+	for _, dep := range g.dependents(k) {
+		if uc.isDone(dep) || !uc.allBlockersDone(g, dep) {
+			continue
+		}
+		out.Unblocked = append(out.Unblocked, uc.ref(dep))
+	}
+
+	if parent, ok := g.nodes[taskmeta.NormalizeID(completed.meta.Parent)]; ok && !uc.isDone(parent) {
+		allDone := true
+		for _, c := range g.children(taskmeta.NormalizeID(parent.task.ID)) {
+			if !uc.isDone(c) {
+				allDone = false
+				break
+			}
+		}
+		if allDone {
+			out.ParentsReady = append(out.ParentsReady, uc.ref(parent))
+		}
+	}
+
+	if len(out.Unblocked) == 0 && len(out.ParentsReady) == 0 {
+		return out, nil
+	}
+
+	uc.l.Infof(ctx, "dependency.OnTaskCompleted: user=%s task=%s unblocked=%d parents_ready=%d",
+		sc.UserID, task.ID, len(out.Unblocked), len(out.ParentsReady))
+
+	sent, err := uc.notify(uc.ref(completed), out, input.ChatID)
+	out.Notifications = sent
+	return out, err
+}
+
+func (uc *implUseCase) allBlockersDone(g *graph, n *node) bool {
+	for _, b := range n.meta.BlockedBy {
+		blocker, ok := g.nodes[taskmeta.NormalizeID(b)]
+		if ok && !uc.isDone(blocker) {
+			return false
+		}
+	}
+	return true
+}
+
+// notify sends the unblocking message to chatID, or to every configured chat when chatID is 0.
+func (uc *implUseCase) notify(completed dependency.TaskRef, out dependency.CompletedOutput, chatID int64) (int, error) {
+	if uc.notifier == nil {
+		return 0, nil
+	}
+	chatIDs := uc.cfg.ChatIDs
+	if chatID != 0 {
+		chatIDs = []int64{chatID}
+	}
+
+	text := renderCompleted(completed, out)
+	var (
+		sent int
+		errs []error
+	)
+	for _, id := range chatIDs {
+		if err := uc.notifier.SendMessageWithMode(id, text, "Markdown"); err != nil {
+			errs = append(errs, fmt.Errorf("notify chat %d: %w", id, err))
+			continue
+		}
+		sent++
+	}
+	return sent, errors.Join(errs...)
+}
+
+func renderCompleted(completed dependency.TaskRef, out dependency.CompletedOutput) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("✅ %s đã hoàn thành.\n", telegram.MarkdownBold(completed.Title)))
+
+	if len(out.Unblocked) > 0 {
+		sb.WriteString("\n🔓 *Có thể bắt đầu:*\n")
+		for _, r := range out.Unblocked {
+			writeRef(&sb, r)
+		}
+	}
+	if len(out.ParentsReady) > 0 {
+		sb.WriteString("\n🏁 *Đã xong toàn bộ subtask:*\n")
+		for _, r := range out.ParentsReady {
+			writeRef(&sb, r)
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func writeRef(sb *strings.Builder, r dependency.TaskRef) {
+	if r.MemoURL != "" {
+		sb.WriteString(fmt.Sprintf("• %s\n", telegram.MarkdownLink(r.Title, r.MemoURL)))
+		return
+	}
+	sb.WriteString(fmt.Sprintf("• %s (`%s`)\n", telegram.EscapeMarkdown(r.Title), r.ID))
+}
@@ -0,0 +1,9 @@
+package digest
+
+import "errors"
+
+var (
+	ErrInvalidTime     = errors.New("digest: invalid time, expected HH:MM")
+	ErrInvalidTimezone = errors.New("digest: invalid timezone")
+	ErrNotSubscribed   = errors.New("digest: chat is not subscribed")
+)
@@ -0,0 +1,37 @@
+package digest
+
+import (
+	"context"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/gcalendar"
+)
+
+// CalendarClient is the subset of the Google Calendar API the digest needs.
+type CalendarClient interface {
+	ListEvents(ctx context.Context, req gcalendar.ListEventsRequest) ([]gcalendar.Event, error)
+}
+
+// Notifier delivers a rendered digest to a chat (satisfied by telegram.IBot).
+type Notifier interface {
+	SendMessageWithMode(chatID int64, text string, parseMode string) error
+}
+
+// UseCase defines the business logic interface for the digest domain.
+type UseCase interface {
+	// Daily builds the morning briefing: tasks due today, meetings and overdue tasks.
+	Daily(ctx context.Context, sc model.Scope, input DailyInput) (DailyOutput, error)
+
+	// Weekly builds the weekly review: created vs completed, slipped deadlines, per-project breakdown.
+	Weekly(ctx context.Context, sc model.Scope, input WeeklyInput) (WeeklyOutput, error)
+
+	// Subscribe schedules daily (and optionally weekly) digests for a chat.
+	Subscribe(ctx context.Context, sc model.Scope, input SubscribeInput) (Subscription, error)
+
+	// Unsubscribe stops scheduled digests for a chat.
+	Unsubscribe(ctx context.Context, sc model.Scope, chatID int64) error
+
+	// RunDue sends every digest whose scheduled time has passed; returns how many were sent.
+	RunDue(ctx context.Context, now time.Time) (int, error)
+}
@@ -0,0 +1,105 @@
+package digest
+
+import "time"
+
+// Config holds the service-wide digest defaults.
+type Config struct {
+	Timezone   string  // IANA timezone, e.g. "Asia/Ho_Chi_Minh"
+	DailyTime  string  // "HH:MM" local time of the morning briefing
+	WeeklyDay  string  // Weekday name of the weekly review, e.g. "friday"
+	WeeklyTime string  // "HH:MM" local time of the weekly review
+	LLMSummary bool    // Prepend an LLM-written summary to every digest
+	ChatIDs    []int64 // Chats subscribed at startup
+	StorePath  string  // JSON file subscriptions are saved to; empty keeps them in memory only
+}
+
+// DailyInput is the input for building a daily briefing.
+type DailyInput struct {
+	Date      time.Time // Day to report on (default: now)
+	Timezone  string    // Overrides Config.Timezone (optional)
+	Summarize bool      // Ask the LLM for a short summary
+}
+
+// WeeklyInput is the input for building a weekly review.
+type WeeklyInput struct {
+	WeekOf    time.Time // Any moment inside the week to report on (default: now)
+	Timezone  string    // Overrides Config.Timezone (optional)
+	Summarize bool      // Ask the LLM for a short summary
+}
+
+// TaskItem is a task line in a digest.
+type TaskItem struct {
+	MemoID   string
+	MemoURL  string
+	Title    string
+	Priority string
+	DueDate  time.Time // Zero if the task has no due date
+	Progress float64   // Checklist completion percentage (0-100)
+	HasList  bool      // true if the task has a checklist
+}
+
+// EventItem is a calendar event in a digest.
+type EventItem struct {
+	Title string
+	Start time.Time
+	End   time.Time
+}
+
+// ProjectStats is the weekly breakdown for one #project/* tag.
+type ProjectStats struct {
+	Project   string
+	Created   int
+	Completed int
+	Slipped   int
+	Open      int
+
+	TrackedMinutes   int // Time logged on the project's tasks during the week
+	EstimatedMinutes int // Estimates of tasks completed this week that have logged time
+	SpentMinutes     int // All time logged on those same tasks, to compare with EstimatedMinutes
+}
+
+// DailyOutput is the morning briefing.
+type DailyOutput struct {
+	Date       time.Time
+	DueToday   []TaskItem
+	Overdue    []TaskItem
+	Meetings   []EventItem
+	InProgress int    // Open tasks with a partially checked checklist
+	Summary    string // LLM summary (empty if disabled or failed)
+	Text       string // Rendered Markdown message
+}
+
+// WeeklyOutput is the weekly review.
+type WeeklyOutput struct {
+	Start     time.Time // Monday 00:00 local
+	End       time.Time // Next Monday 00:00 local
+	Created   int
+	Completed int
+	Slipped   []TaskItem
+	Projects  []ProjectStats
+	Tracked   int // Minutes logged on all tasks during the week
+	Summary   string
+	Text      string
+}
+
+// SubscribeInput schedules digests for a chat.
+type SubscribeInput struct {
+	ChatID    int64
+	DailyTime string // "HH:MM" (default: Config.DailyTime)
+	Timezone  string // IANA timezone (default: Config.Timezone)
+	Weekly    bool   // Also send the weekly review
+}
+
+// Subscription is a chat's digest schedule.
+type Subscription struct {
+	ChatID     int64
+	UserID     string
+	Timezone   string
+	DailyTime  string
+	Weekly     bool
+	WeeklyDay  time.Weekday
+	WeeklyTime string
+
+	LastDaily  string // Local date ("2006-01-02") of the last daily digest sent
+	LastWeekly string // Local date of the last weekly review sent
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import "time"
+
+const (
+	defaultTimezone   = "Asia/Ho_Chi_Minh"
+	defaultDailyTime  = "08:00"
+	defaultWeeklyTime = "17:00"
+	defaultWeeklyDay  = time.Friday
+
+	// listPageSize is how many memos a digest reads from Memos per request.
+	listPageSize = 200
+	// maxItemsPerSection keeps Telegram messages readable.
+	maxItemsPerSection = 10
+	// sendWindow is how late a scheduled digest may still be sent (e.g. after a restart).
+	sendWindow = 2 * time.Hour
+
+	dateKeyLayout = "2006-01-02"
+	clockLayout   = "15:04"
+)
+
+var weekdays = map[string]time.Weekday{
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+	"sunday":    time.Sunday,
+}
+
+// weekdayNames are Vietnamese weekday labels used in rendered digests.
+var weekdayNames = map[time.Weekday]string{
+	time.Monday:    "Thứ Hai",
+	time.Tuesday:   "Thứ Ba",
+	time.Wednesday: "Thứ Tư",
+	time.Thursday:  "Thứ Năm",
+	time.Friday:    "Thứ Sáu",
+	time.Saturday:  "Thứ Bảy",
+	time.Sunday:    "Chủ Nhật",
+}
+
+// noProjectLabel groups tasks without a #project/* tag in the weekly breakdown.
+const noProjectLabel = "khác"
+
+const summaryPrompt = `Bạn là trợ lý quản lý công việc. Dưới đây là báo cáo công việc của người dùng.
+Hãy viết 2-3 câu tóm tắt bằng tiếng Việt: điểm quan trọng nhất cần chú ý và một gợi ý hành động cụ thể.
+Không lặp lại toàn bộ danh sách, không bịa thông tin.
+
+BÁO CÁO:
+%s`
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/digest"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/gcalendar"
+	"autonomous-task-management/pkg/telegram"
+)
+
+// Daily builds the morning briefing: tasks due today, today's meetings and overdue tasks.
+func (uc *implUseCase) Daily(ctx context.Context, sc model.Scope, input digest.DailyInput) (digest.DailyOutput, error) {
+	loc, err := uc.location(input.Timezone)
+	if err != nil {
+		return digest.DailyOutput{}, err
+	}
+
+	date := input.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	today := startOfDay(date, loc)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	uc.l.Infof(ctx, "digest.Daily: user=%s date=%s", sc.UserID, today.Format(dateKeyLayout))
+
+	views, err := uc.loadTasks(ctx)
+	if err != nil {
+		return digest.DailyOutput{}, err
+	}
+
+	out := digest.DailyOutput{Date: today}
+	for _, v := range views {
+		if v.done {
+			continue
+		}
+		if v.stats.Completed > 0 {
+			out.InProgress++
+		}
+		if !v.meta.HasDue {
+			continue
+		}
+		due := v.meta.DueIn(loc)
+		switch {
+		case due.Equal(today):
+			out.DueToday = append(out.DueToday, toItem(v, loc))
+		case due.Before(today):
+			out.Overdue = append(out.Overdue, toItem(v, loc))
+		}
+	}
+	sortItems(out.DueToday)
+	sortItems(out.Overdue)
+
+	out.Meetings = uc.listMeetings(ctx, today, tomorrow, loc)
+
+	out.Text = renderDaily(out)
+	if input.Summarize || uc.cfg.LLMSummary {
+		if out.Summary = uc.summarize(ctx, out.Text); out.Summary != "" {
+			out.Text = fmt.Sprintf("💡 %s\n\n%s", out.Summary, out.Text)
+		}
+	}
+
+	return out, nil
+}
+
+// listMeetings fetches calendar events in [from, to). Calendar errors degrade to no meetings.
+func (uc *implUseCase) listMeetings(ctx context.Context, from, to time.Time, loc *time.Location) []digest.EventItem {
+	if uc.calendar == nil {
+		return nil
+	}
+
+	events, err := uc.calendar.ListEvents(ctx, gcalendar.ListEventsRequest{
+		TimeMin: from,
+		TimeMax: to,
+	})
+	if err != nil {
+		uc.l.Warnf(ctx, "digest: failed to list calendar events (non-fatal): %v", err)
+		return nil
+	}
+
+	items := make([]digest.EventItem, 0, len(events))
+	for _, e := range events {
+		items = append(items, digest.EventItem{
+			Title: e.Summary,
+			Start: e.StartTime.In(loc),
+			End:   e.EndTime.In(loc),
+		})
+	}
+	return items
+}
+
+// renderDaily renders the morning briefing as Telegram Markdown.
+func renderDaily(out digest.DailyOutput) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("☀️ *Tổng quan hôm nay — %s %s*\n",
+		weekdayNames[out.Date.Weekday()], out.Date.Format("02/01")))
+	sb.WriteString(fmt.Sprintf("Hôm nay: %d task, %d cuộc họp, %d quá hạn\n\n",
+		len(out.DueToday), len(out.Meetings), len(out.Overdue)))
+
+	if len(out.DueToday) > 0 {
+		sb.WriteString("📌 *Đến hạn hôm nay*\n")
+		writeItems(&sb, out.DueToday, false)
+		sb.WriteString("\n")
+	}
+
+	if len(out.Meetings) > 0 {
+		sb.WriteString("📅 *Lịch họp*\n")
+		for _, m := range out.Meetings {
+			sb.WriteString(fmt.Sprintf("• %s–%s %s\n", m.Start.Format("15:04"), m.End.Format("15:04"), telegram.EscapeMarkdown(m.Title)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(out.Overdue) > 0 {
+		sb.WriteString("⚠️ *Quá hạn*\n")
+		writeItems(&sb, out.Overdue, true)
+		sb.WriteString("\n")
+	}
+
+	if out.InProgress > 0 {
+		sb.WriteString(fmt.Sprintf("🔄 %d task đang làm dở checklist\n", out.InProgress))
+	}
+
+	if len(out.DueToday) == 0 && len(out.Meetings) == 0 && len(out.Overdue) == 0 {
+		sb.WriteString("🎉 Không có gì gấp hôm nay!\n")
+	}
+
+	return strings.TrimSpace(sb.String())
+}
@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	checklistUC "autonomous-task-management/internal/checklist/usecase"
+	"autonomous-task-management/internal/digest"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/gcalendar"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Mock implementations
+// ---------------------------------------------------------------------------
+
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Debugf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Info(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Infof(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Warn(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Warnf(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Error(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Errorf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) DPanic(_ context.Context, _ ...any)            {}
+func (m *mockLogger) DPanicf(_ context.Context, _ string, _ ...any) {}
+func (m *mockLogger) Panic(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Panicf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Fatal(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Fatalf(_ context.Context, _ string, _ ...any)  {}
+
+type staticMemosRepo struct {
+	tasks []model.Task
+}
+
+func (r *staticMemosRepo) CreateTask(_ context.Context, _ repository.CreateTaskOptions) (model.Task, error) {
+	return model.Task{}, nil
+}
+
+func (r *staticMemosRepo) CreateTasksBatch(_ context.Context, _ []repository.CreateTaskOptions) ([]model.Task, error) {
+	return nil, nil
+}
+
+func (r *staticMemosRepo) GetTask(_ context.Context, _ string) (model.Task, error) {
+	return model.Task{}, nil
+}
+
+func (r *staticMemosRepo) ListTasks(_ context.Context, _ repository.ListTasksOptions) ([]model.Task, error) {
+	return r.tasks, nil
+}
+
+func (r *staticMemosRepo) UpdateTask(_ context.Context, _ string, _ string) error {
+	return nil
+}
+
+func (r *staticMemosRepo) UpdateTaskIfUnchanged(_ context.Context, _, _, _ string) (model.Task, error) {
+	return model.Task{}, nil
+}
+
+func (r *staticMemosRepo) DeleteTask(_ context.Context, _ string) error {
+	return nil
+}
+
+func (r *staticMemosRepo) ListTaskPage(_ context.Context, _ repository.ListTasksOptions) (repository.TaskPage, error) {
+	return repository.TaskPage{Tasks: r.tasks}, nil
+}
+
+func (r *staticMemosRepo) IterateTasks(_ context.Context, _ repository.ListTasksOptions) iter.Seq2[model.Task, error] {
+	return func(yield func(model.Task, error) bool) {
+		for _, t := range r.tasks {
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (r *staticMemosRepo) ArchiveTask(_ context.Context, _ string) error {
+	return nil
+}
+
+func (r *staticMemosRepo) RestoreTask(_ context.Context, _ string) error {
+	return nil
+}
+
+func (r *staticMemosRepo) PinTask(_ context.Context, _ string, _ bool) error {
+	return nil
+}
+
+func (r *staticMemosRepo) ListRelations(_ context.Context, _ string) ([]repository.TaskRelation, error) {
+	return nil, nil
+}
+
+func (r *staticMemosRepo) SetRelations(_ context.Context, _ string, _ []repository.TaskRelation) error {
+	return nil
+}
+
+type staticCalendar struct {
+	events []gcalendar.Event
+	err    error
+}
+
+func (c *staticCalendar) ListEvents(_ context.Context, _ gcalendar.ListEventsRequest) ([]gcalendar.Event, error) {
+	return c.events, c.err
+}
+
+type recordingNotifier struct {
+	sent []string
+}
+
+func (n *recordingNotifier) SendMessageWithMode(_ int64, text string, _ string) error {
+	n.sent = append(n.sent, text)
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+const testTZ = "Asia/Ho_Chi_Minh"
+
+func newTestUseCase(tasks []model.Task, cal digest.CalendarClient, notifier digest.Notifier) *implUseCase {
+	l := &mockLogger{}
+	repo := &staticMemosRepo{tasks: tasks}
+	uc := New(l, repo, checklistUC.New(repo, nil, l), cal, nil, notifier, digest.Config{
+		Timezone:  testTZ,
+		WeeklyDay: "friday",
+	})
+	return uc.(*implUseCase)
+}
+
+func task(id, content, created, updated string) model.Task {
+	return model.Task{ID: id, Content: content, CreateTime: created, UpdateTime: updated}
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestDaily_GroupsDueTodayOverdueAndMeetings(t *testing.T) {
+	loc, _ := time.LoadLocation(testTZ)
+	day := time.Date(2026, 3, 10, 9, 0, 0, 0, loc)
+
+	tasks := []model.Task{
+		task("1", "## Ship release\n\n- **Due:** 2026-03-10\n- **Priority:** #priority/p0", "", ""),
+		task("2", "## Write report\n\n- **Due:** 2026-03-08\n- [x] draft\n- [ ] review", "", ""),
+		task("3", "## Old done\n\n- **Due:** 2026-03-01\n- [x] all", "", ""),
+		task("4", "## Later\n\n- **Due:** 2026-03-20", "", ""),
+	}
+	cal := &staticCalendar{events: []gcalendar.Event{
+		{Summary: "Standup", StartTime: day, EndTime: day.Add(15 * time.Minute)},
+	}}
+	uc := newTestUseCase(tasks, cal, nil)
+
+	out, err := uc.Daily(context.Background(), model.Scope{UserID: "u1"}, digest.DailyInput{Date: day})
+	require.NoError(t, err)
+
+	require.Len(t, out.DueToday, 1)
+	assert.Equal(t, "Ship release", out.DueToday[0].Title)
+	require.Len(t, out.Overdue, 1)
+	assert.Equal(t, "Write report", out.Overdue[0].Title)
+	assert.Len(t, out.Meetings, 1)
+	assert.Equal(t, 1, out.InProgress)
+	assert.Contains(t, out.Text, "Hôm nay: 1 task, 1 cuộc họp, 1 quá hạn")
+}
+
+func TestDaily_CalendarErrorIsNonFatal(t *testing.T) {
+	uc := newTestUseCase(nil, &staticCalendar{err: errors.New("boom")}, nil)
+
+	out, err := uc.Daily(context.Background(), model.Scope{}, digest.DailyInput{})
+	require.NoError(t, err)
+	assert.Empty(t, out.Meetings)
+}
+
+func TestWeekly_CountsCreatedCompletedAndProjects(t *testing.T) {
+	loc, _ := time.LoadLocation(testTZ)
+	friday := time.Date(2026, 3, 13, 18, 0, 0, 0, loc) // week of Mon 09/03
+
+	tasks := []model.Task{
+		task("1", "## A #project/alpha\n\n- [x] done", "2026-03-09T03:00:00Z", "2026-03-11T03:00:00Z"),
+		task("2", "## B #project/alpha\n\n- **Due:** 2026-03-10", "2026-03-09T03:00:00Z", "2026-03-09T03:00:00Z"),
+		task("3", "## C\n\n- **Due:** 2026-03-11", "2026-02-01T03:00:00Z", "2026-02-01T03:00:00Z"),
+		task("4", "## D #project/beta", "2026-01-01T03:00:00Z", "2026-01-01T03:00:00Z"),
+	}
+	uc := newTestUseCase(tasks, nil, nil)
+
+	out, err := uc.Weekly(context.Background(), model.Scope{}, digest.WeeklyInput{WeekOf: friday})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, out.Created)
+	assert.Equal(t, 1, out.Completed)
+	assert.Len(t, out.Slipped, 2)
+
+	require.Len(t, out.Projects, 2)
+	assert.Equal(t, digest.ProjectStats{Project: "alpha", Created: 2, Completed: 1, Slipped: 1, Open: 1}, out.Projects[0])
+	assert.Equal(t, noProjectLabel, out.Projects[1].Project)
+}
+
+func TestSubscribe_RejectsInvalidInput(t *testing.T) {
+	uc := newTestUseCase(nil, nil, nil)
+	ctx := context.Background()
+
+	_, err := uc.Subscribe(ctx, model.Scope{}, digest.SubscribeInput{ChatID: 1, DailyTime: "25:99"})
+	assert.ErrorIs(t, err, digest.ErrInvalidTime)
+
+	_, err = uc.Subscribe(ctx, model.Scope{}, digest.SubscribeInput{ChatID: 1, Timezone: "Mars/Base"})
+	assert.ErrorIs(t, err, digest.ErrInvalidTimezone)
+
+	err = uc.Unsubscribe(ctx, model.Scope{}, 1)
+	assert.ErrorIs(t, err, digest.ErrNotSubscribed)
+}
+
+func TestRunDue_SendsOncePerDayInsideWindow(t *testing.T) {
+	loc, _ := time.LoadLocation(testTZ)
+	notifier := &recordingNotifier{}
+	uc := newTestUseCase(nil, nil, notifier)
+	ctx := context.Background()
+
+	_, err := uc.Subscribe(ctx, model.Scope{UserID: "u1"}, digest.SubscribeInput{ChatID: 42, DailyTime: "07:30", Weekly: true})
+	require.NoError(t, err)
+
+	// Before the scheduled time: nothing.
+	sent, err := uc.RunDue(ctx, time.Date(2026, 3, 13, 7, 0, 0, 0, loc))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	// Inside the window: daily digest only (weekly review is at 17:00).
+	sent, err = uc.RunDue(ctx, time.Date(2026, 3, 13, 7, 31, 0, 0, loc))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	// Same day again: not repeated.
+	sent, err = uc.RunDue(ctx, time.Date(2026, 3, 13, 7, 45, 0, 0, loc))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+
+	// Friday 17:00: weekly review.
+	sent, err = uc.RunDue(ctx, time.Date(2026, 3, 13, 17, 5, 0, 0, loc))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	require.Len(t, notifier.sent, 2)
+	assert.True(t, strings.Contains(notifier.sent[1], "Tổng kết tuần"))
+}
+
+func TestWeekly_ReportsTrackedTimeAgainstEstimates(t *testing.T) {
+	loc, _ := time.LoadLocation(testTZ)
+	friday := time.Date(2026, 3, 13, 18, 0, 0, 0, loc)
+
+	tasks := []model.Task{
+		task("1", "## A #project/alpha\n\n- **Estimated:** 60 min\n- **Spent:** 90 min\n- [x] done\n\n"+
+			"- ⏱ 2026-03-02 10:00 +07:00 · 30 min\n- ⏱ 2026-03-10 09:00 +07:00 · 60 min",
+			"2026-03-01T03:00:00Z", "2026-03-11T03:00:00Z"),
+		task("2", "## B #project/beta\n\n- **Spent:** 45 min\n\n- ⏱ 2026-03-12 14:00 +07:00 · 45 min",
+			"2026-01-01T03:00:00Z", "2026-01-01T03:00:00Z"),
+		task("3", "## C #project/gamma\n\n- ⏱ 2026-03-02 10:00 +07:00 · 30 min",
+			"2026-01-01T03:00:00Z", "2026-01-01T03:00:00Z"),
+	}
+	uc := newTestUseCase(tasks, nil, nil)
+
+	out, err := uc.Weekly(context.Background(), model.Scope{}, digest.WeeklyInput{WeekOf: friday})
+	require.NoError(t, err)
+
+	assert.Equal(t, 105, out.Tracked)
+	require.Len(t, out.Projects, 2)
+	assert.Equal(t, digest.ProjectStats{Project: "alpha", Completed: 1, TrackedMinutes: 60, EstimatedMinutes: 60, SpentMinutes: 90}, out.Projects[0])
+	assert.Equal(t, digest.ProjectStats{Project: "beta", Open: 1, TrackedMinutes: 45}, out.Projects[1])
+	assert.Contains(t, out.Text, "Thời gian làm việc: 1h45")
+	assert.Contains(t, out.Text, "alpha: 1h · task xong: thực tế 1h30 / ước tính 1h (+50%)")
+}
+
+func TestSubscribe_SurvivesRestart(t *testing.T) {
+	loc, _ := time.LoadLocation(testTZ)
+	cfg := digest.Config{Timezone: testTZ, WeeklyDay: "friday", StorePath: filepath.Join(t.TempDir(), "subs.json")}
+	repo := &staticMemosRepo{}
+	l := &mockLogger{}
+	ctx := context.Background()
+
+	notifier := &recordingNotifier{}
+	uc := New(l, repo, checklistUC.New(repo, nil, l), nil, nil, notifier, cfg)
+	_, err := uc.Subscribe(ctx, model.Scope{UserID: "u1"}, digest.SubscribeInput{ChatID: 42, DailyTime: "07:30"})
+	require.NoError(t, err)
+	sent, err := uc.RunDue(ctx, time.Date(2026, 3, 13, 7, 31, 0, 0, loc))
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	// After a restart the chat is still subscribed and today's digest is not sent twice
+	restarted := New(l, repo, checklistUC.New(repo, nil, l), nil, nil, notifier, cfg)
+	sent, err = restarted.RunDue(ctx, time.Date(2026, 3, 13, 7, 45, 0, 0, loc))
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	sent, err = restarted.RunDue(ctx, time.Date(2026, 3, 14, 7, 31, 0, 0, loc))
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	require.NoError(t, restarted.Unsubscribe(ctx, model.Scope{UserID: "u1"}, 42))
+	again := New(l, repo, checklistUC.New(repo, nil, l), nil, nil, notifier, cfg)
+	assert.ErrorIs(t, again.Unsubscribe(ctx, model.Scope{}, 42), digest.ErrNotSubscribed)
+}
+
+func TestRenderDaily_EscapesTitles(t *testing.T) {
+	text := renderDaily(digest.DailyOutput{
+		DueToday: []digest.TaskItem{
+			{Title: "Fix *prod* db_migrate"},
+			{Title: "Review [draft] notes", MemoURL: "https://memos/1"},
+		},
+	})
+
+	assert.Contains(t, text, `1. Fix \*prod\* db\_migrate`)
+	assert.Contains(t, text, "2. [Review (draft) notes](https://memos/1)")
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/checklist"
+	"autonomous-task-management/internal/digest"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/llmprovider"
+	"autonomous-task-management/pkg/taskmeta"
+	"autonomous-task-management/pkg/telegram"
+)
+
+// taskView is a task with its parsed metadata and checklist state.
+type taskView struct {
+	task    model.Task
+	meta    taskmeta.Meta
+	stats   checklist.ChecklistStats
+	done    bool
+	created time.Time
+	updated time.Time
+}
+
+// loadTasks reads every task from Memos, page by page, and parses their metadata.
+func (uc *implUseCase) loadTasks(ctx context.Context) ([]taskView, error) {
+	var views []taskView
+	for t, err := range uc.repo.IterateTasks(ctx, repository.ListTasksOptions{Limit: listPageSize}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tasks: %w", err)
+		}
+		meta := taskmeta.Parse(t.Content)
+		views = append(views, taskView{
+			task:    t,
+			meta:    meta,
+			stats:   uc.checklist.GetStats(t.Content),
+			done:    meta.Done(),
+			created: taskmeta.ParseTime(t.CreateTime),
+			updated: taskmeta.ParseTime(t.UpdateTime),
+		})
+	}
+	return views, nil
+}
+
+// location resolves the timezone override, falling back to the configured default.
+func (uc *implUseCase) location(override string) (*time.Location, error) {
+	tz := override
+	if tz == "" {
+		tz = uc.cfg.Timezone
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", digest.ErrInvalidTimezone, tz)
+	}
+	return loc, nil
+}
+
+// parseClock parses "HH:MM" into minutes since midnight.
+func parseClock(value string) (int, error) {
+	t, err := time.Parse(clockLayout, strings.TrimSpace(value))
+	if err != nil {
+		return 0, digest.ErrInvalidTime
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// startOfDay returns local midnight of t.
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// startOfWeek returns Monday 00:00 local of the week containing t.
+func startOfWeek(t time.Time, loc *time.Location) time.Time {
+	day := startOfDay(t, loc)
+	offset := (int(day.Weekday()) + 6) % 7 // Monday = 0
+	return day.AddDate(0, 0, -offset)
+}
+
+// toItem converts a taskView into a digest line.
+func toItem(v taskView, loc *time.Location) digest.TaskItem {
+	title := v.meta.Title
+	if title == "" {
+		title = v.task.ID
+	}
+	return digest.TaskItem{
+		MemoID:   v.task.ID,
+		MemoURL:  v.task.MemoURL,
+		Title:    title,
+		Priority: v.meta.Priority,
+		DueDate:  v.meta.DueIn(loc),
+		Progress: v.stats.Progress,
+		HasList:  v.stats.Total > 0,
+	}
+}
+
+// sortItems orders items by due date, then priority (p0 first).
+func sortItems(items []digest.TaskItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].DueDate.Equal(items[j].DueDate) {
+			return items[i].DueDate.Before(items[j].DueDate)
+		}
+		return priorityRank(items[i].Priority) < priorityRank(items[j].Priority)
+	})
+}
+
+// priorityRank maps "p0".."p3" to 0..3; unknown priorities sort last.
+func priorityRank(p string) int {
+	switch p {
+	case "p0":
+		return 0
+	case "p1":
+		return 1
+	case "p2":
+		return 2
+	case "p3":
+		return 3
+	}
+	return 4
+}
+
+// writeItem renders one task line.
+func writeItem(sb *strings.Builder, idx int, item digest.TaskItem, withDue bool) {
+	if item.MemoURL != "" {
+		sb.WriteString(fmt.Sprintf("%d. %s", idx, telegram.MarkdownLink(item.Title, item.MemoURL)))
+	} else {
+		sb.WriteString(fmt.Sprintf("%d. %s", idx, telegram.EscapeMarkdown(item.Title)))
+	}
+	if item.Priority != "" {
+		sb.WriteString(" — " + item.Priority)
+	}
+	if withDue && !item.DueDate.IsZero() {
+		sb.WriteString(fmt.Sprintf(" (hạn %s)", item.DueDate.Format("02/01")))
+	}
+	if item.HasList {
+		sb.WriteString(fmt.Sprintf(" · %.0f%%", item.Progress))
+	}
+	sb.WriteString("\n")
+}
+
+// writeItems renders a capped list of task lines.
+func writeItems(sb *strings.Builder, items []digest.TaskItem, withDue bool) {
+	for i, item := range items {
+		if i == maxItemsPerSection {
+			sb.WriteString(fmt.Sprintf("… và %d task khác\n", len(items)-maxItemsPerSection))
+			break
+		}
+		writeItem(sb, i+1, item, withDue)
+	}
+}
+
+// summarize asks the LLM for a short summary of a rendered report.
+// Failures are logged and yield an empty summary — the digest is still sent.
+func (uc *implUseCase) summarize(ctx context.Context, report string) string {
+	if uc.llm == nil {
+		return ""
+	}
+
+	resp, err := uc.llm.GenerateContent(ctx, &llmprovider.Request{
+		Messages: []llmprovider.Message{
+			{
+				Role:  "user",
+				Parts: []llmprovider.Part{{Text: fmt.Sprintf(summaryPrompt, report)}},
+			},
+		},
+		Temperature: 0.4,
+		MaxTokens:   300,
+	})
+	if err != nil {
+		uc.l.Warnf(ctx, "digest: LLM summary failed (non-fatal): %v", err)
+		return ""
+	}
+	if len(resp.Content.Parts) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(resp.Content.Parts[0].Text)
+}
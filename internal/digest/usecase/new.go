@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"autonomous-task-management/internal/checklist"
+	"autonomous-task-management/internal/digest"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/llmprovider"
+	pkgLog "autonomous-task-management/pkg/log"
+)
+
+type implUseCase struct {
+	l         pkgLog.Logger
+	repo      repository.MemosRepository
+	checklist checklist.UseCase
+	calendar  digest.CalendarClient // optional; nil = no meetings section
+	llm       llmprovider.IManager  // optional; nil = no LLM summary
+	notifier  digest.Notifier       // optional; nil = RunDue is a no-op
+	cfg       digest.Config
+	weeklyDay time.Weekday
+
+	mu   sync.Mutex
+	subs map[int64]*digest.Subscription
+}
+
+// New creates a new digest UseCase instance.
+// calendar, llm and notifier are optional — pass nil to disable the related feature.
+func New(
+	l pkgLog.Logger,
+	repo repository.MemosRepository,
+	checklistUC checklist.UseCase,
+	calendar digest.CalendarClient,
+	llm llmprovider.IManager,
+	notifier digest.Notifier,
+	cfg digest.Config,
+) digest.UseCase {
+	if cfg.Timezone == "" {
+		cfg.Timezone = defaultTimezone
+	}
+	if _, err := parseClock(cfg.DailyTime); err != nil {
+		cfg.DailyTime = defaultDailyTime
+	}
+	if _, err := parseClock(cfg.WeeklyTime); err != nil {
+		cfg.WeeklyTime = defaultWeeklyTime
+	}
+	weeklyDay, ok := weekdays[strings.ToLower(strings.TrimSpace(cfg.WeeklyDay))]
+	if !ok {
+		weeklyDay = defaultWeeklyDay
+	}
+
+	uc := &implUseCase{
+		l:         l,
+		repo:      repo,
+		checklist: checklistUC,
+		calendar:  calendar,
+		llm:       llm,
+		notifier:  notifier,
+		cfg:       cfg,
+		weeklyDay: weeklyDay,
+		subs:      make(map[int64]*digest.Subscription),
+	}
+
+	uc.loadSubscriptions()
+	for _, chatID := range cfg.ChatIDs {
+		if _, ok := uc.subs[chatID]; !ok {
+			uc.subs[chatID] = uc.newSubscription(chatID, "", cfg.DailyTime, cfg.Timezone, true)
+		}
+	}
+
+	return uc
+}
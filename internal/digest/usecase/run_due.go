@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"autonomous-task-management/internal/digest"
+	"autonomous-task-management/internal/model"
+)
+
+// RunDue sends every daily briefing and weekly review whose scheduled local time has passed
+// (within sendWindow) and that has not yet been sent today. It is meant to be called every minute.
+func (uc *implUseCase) RunDue(ctx context.Context, now time.Time) (int, error) {
+	if uc.notifier == nil {
+		return 0, nil
+	}
+
+	var (
+		sent int
+		errs []error
+	)
+
+	for _, sub := range uc.snapshot() {
+		loc, err := uc.location(sub.Timezone)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		local := now.In(loc)
+		dateKey := local.Format(dateKeyLayout)
+		sc := model.Scope{UserID: sub.UserID}
+
+		if sub.LastDaily != dateKey && isDue(local, sub.DailyTime) {
+			out, err := uc.Daily(ctx, sc, digest.DailyInput{Date: local, Timezone: sub.Timezone})
+			if err == nil {
+				err = uc.notifier.SendMessageWithMode(sub.ChatID, out.Text, "Markdown")
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("daily digest for chat %d: %w", sub.ChatID, err))
+			} else {
+				uc.markSent(ctx, sub.ChatID, false, dateKey)
+				sent++
+			}
+		}
+
+		if sub.Weekly && local.Weekday() == sub.WeeklyDay &&
+			sub.LastWeekly != dateKey && isDue(local, sub.WeeklyTime) {
+			out, err := uc.Weekly(ctx, sc, digest.WeeklyInput{WeekOf: local, Timezone: sub.Timezone})
+			if err == nil {
+				err = uc.notifier.SendMessageWithMode(sub.ChatID, out.Text, "Markdown")
+			}
+			if err != nil {
+				errs = append(errs, fmt.Errorf("weekly review for chat %d: %w", sub.ChatID, err))
+			} else {
+				uc.markSent(ctx, sub.ChatID, true, dateKey)
+				sent++
+			}
+		}
+	}
+
+	if sent > 0 {
+		uc.l.Infof(ctx, "digest.RunDue: sent %d digest(s)", sent)
+	}
+
+	return sent, errors.Join(errs...)
+}
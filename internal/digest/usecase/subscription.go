@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"autonomous-task-management/internal/digest"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/jsonfile"
+)
+
+// Subscribe schedules daily (and optionally weekly) digests for a chat.
+// Re-subscribing updates the schedule but keeps the last-sent markers so a digest is not repeated.
+func (uc *implUseCase) Subscribe(ctx context.Context, sc model.Scope, input digest.SubscribeInput) (digest.Subscription, error) {
+	dailyTime := input.DailyTime
+	if dailyTime == "" {
+		dailyTime = uc.cfg.DailyTime
+	}
+	if _, err := parseClock(dailyTime); err != nil {
+		return digest.Subscription{}, err
+	}
+
+	timezone := input.Timezone
+	if timezone == "" {
+		timezone = uc.cfg.Timezone
+	}
+	if _, err := uc.location(timezone); err != nil {
+		return digest.Subscription{}, err
+	}
+
+	sub := uc.newSubscription(input.ChatID, sc.UserID, dailyTime, timezone, input.Weekly)
+
+	uc.mu.Lock()
+	if prev, ok := uc.subs[input.ChatID]; ok {
+		sub.LastDaily = prev.LastDaily
+		sub.LastWeekly = prev.LastWeekly
+	}
+	uc.subs[input.ChatID] = sub
+	uc.saveSubscriptions(ctx)
+	uc.mu.Unlock()
+
+	uc.l.Infof(ctx, "digest.Subscribe: chat=%d user=%s daily=%s tz=%s weekly=%v",
+		input.ChatID, sc.UserID, dailyTime, timezone, input.Weekly)
+
+	return *sub, nil
+}
+
+// Unsubscribe stops scheduled digests for a chat.
+func (uc *implUseCase) Unsubscribe(ctx context.Context, sc model.Scope, chatID int64) error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if _, ok := uc.subs[chatID]; !ok {
+		return fmt.Errorf("%w: %d", digest.ErrNotSubscribed, chatID)
+	}
+	delete(uc.subs, chatID)
+	uc.saveSubscriptions(ctx)
+
+	uc.l.Infof(ctx, "digest.Unsubscribe: chat=%d user=%s", chatID, sc.UserID)
+	return nil
+}
+
+// newSubscription builds a subscription using the configured weekly schedule.
+func (uc *implUseCase) newSubscription(chatID int64, userID, dailyTime, timezone string, weekly bool) *digest.Subscription {
+	return &digest.Subscription{
+		ChatID:     chatID,
+		UserID:     userID,
+		Timezone:   timezone,
+		DailyTime:  dailyTime,
+		Weekly:     weekly,
+		WeeklyDay:  uc.weeklyDay,
+		WeeklyTime: uc.cfg.WeeklyTime,
+	}
+}
+
+// snapshot copies the current subscriptions so digests can be built without holding the lock.
+func (uc *implUseCase) snapshot() []digest.Subscription {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	subs := make([]digest.Subscription, 0, len(uc.subs))
+	for _, s := range uc.subs {
+		subs = append(subs, *s)
+	}
+	return subs
+}
+
+// markSent records that a digest was delivered for the given local date.
+// The marker is saved so a restart inside sendWindow does not send it again.
+func (uc *implUseCase) markSent(ctx context.Context, chatID int64, weekly bool, dateKey string) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	s, ok := uc.subs[chatID]
+	if !ok {
+		return
+	}
+	if weekly {
+		s.LastWeekly = dateKey
+	} else {
+		s.LastDaily = dateKey
+	}
+	uc.saveSubscriptions(ctx)
+}
+
+// loadSubscriptions restores the subscriptions saved in cfg.StorePath. The weekly schedule
+// always comes from the current config. A broken file is logged and ignored.
+func (uc *implUseCase) loadSubscriptions() {
+	if uc.cfg.StorePath == "" {
+		return
+	}
+	var stored []digest.Subscription
+	if err := jsonfile.Load(uc.cfg.StorePath, &stored); err != nil {
+		uc.l.Errorf(context.Background(), "digest: failed to load subscriptions: %v", err)
+		return
+	}
+	for _, s := range stored {
+		s.WeeklyDay = uc.weeklyDay
+		s.WeeklyTime = uc.cfg.WeeklyTime
+		uc.subs[s.ChatID] = &s
+	}
+}
+
+// saveSubscriptions writes the subscriptions to cfg.StorePath; the caller holds uc.mu.
+// A failed write is only logged: the subscription works until the next restart.
+func (uc *implUseCase) saveSubscriptions(ctx context.Context) {
+	if uc.cfg.StorePath == "" {
+		return
+	}
+	subs := make([]digest.Subscription, 0, len(uc.subs))
+	for _, s := range uc.subs {
+		subs = append(subs, *s)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].ChatID < subs[j].ChatID })
+	if err := jsonfile.Save(uc.cfg.StorePath, subs); err != nil {
+		uc.l.Warnf(ctx, "digest: failed to save subscriptions: %v", err)
+	}
+}
+
+// isDue reports whether now falls inside [clock, clock+sendWindow) on the local day of now.
+func isDue(now time.Time, clock string) bool {
+	minutes, err := parseClock(clock)
+	if err != nil {
+		return false
+	}
+	at := startOfDay(now, now.Location()).Add(time.Duration(minutes) * time.Minute)
+	return !now.Before(at) && now.Before(at.Add(sendWindow))
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/digest"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/timetrack"
+	"autonomous-task-management/pkg/taskmeta"
+	"autonomous-task-management/pkg/telegram"
+)
+
+// Weekly builds the weekly review for the Monday-Sunday week containing input.WeekOf.
+// A task counts as completed this week when it is done and was last updated inside the week
+// (Memos does not record a completion timestamp). Time is attributed to the week a session started in.
+func (uc *implUseCase) Weekly(ctx context.Context, sc model.Scope, input digest.WeeklyInput) (digest.WeeklyOutput, error) {
+	loc, err := uc.location(input.Timezone)
+	if err != nil {
+		return digest.WeeklyOutput{}, err
+	}
+
+	now := time.Now().In(loc)
+	weekOf := input.WeekOf
+	if weekOf.IsZero() {
+		weekOf = now
+	}
+	start := startOfWeek(weekOf, loc)
+	end := start.AddDate(0, 0, 7)
+
+	// Deadlines can only have slipped up to today.
+	slipCutoff := end
+	if today := startOfDay(now, loc); today.Before(slipCutoff) {
+		slipCutoff = today
+	}
+
+	uc.l.Infof(ctx, "digest.Weekly: user=%s week=%s", sc.UserID, start.Format(dateKeyLayout))
+
+	views, err := uc.loadTasks(ctx)
+	if err != nil {
+		return digest.WeeklyOutput{}, err
+	}
+
+	inWeek := func(t time.Time) bool {
+		return !t.IsZero() && !t.Before(start) && t.Before(end)
+	}
+
+	out := digest.WeeklyOutput{Start: start, End: end}
+	projects := make(map[string]*digest.ProjectStats)
+	projectOf := func(v taskView) []*digest.ProjectStats {
+		names := v.meta.Projects
+		if len(names) == 0 {
+			names = []string{noProjectLabel}
+		}
+		stats := make([]*digest.ProjectStats, 0, len(names))
+		for _, name := range names {
+			ps, ok := projects[name]
+			if !ok {
+				ps = &digest.ProjectStats{Project: name}
+				projects[name] = ps
+			}
+			stats = append(stats, ps)
+		}
+		return stats
+	}
+
+	for _, v := range views {
+		created := inWeek(v.created)
+		completed := v.done && inWeek(v.updated)
+		due := v.meta.DueIn(loc)
+		slipped := !v.done && v.meta.HasDue && !due.Before(start) && due.Before(slipCutoff)
+		tracked := 0
+		for _, e := range taskmeta.ParseTimeLog(v.task.Content) {
+			if inWeek(e.Start) {
+				tracked += e.Minutes
+			}
+		}
+		out.Tracked += tracked
+
+		if created {
+			out.Created++
+		}
+		if completed {
+			out.Completed++
+		}
+		if slipped {
+			out.Slipped = append(out.Slipped, toItem(v, loc))
+		}
+
+		if !created && !completed && !slipped && tracked == 0 {
+			continue
+		}
+		for _, ps := range projectOf(v) {
+			if created {
+				ps.Created++
+			}
+			if completed {
+				ps.Completed++
+			}
+			if slipped {
+				ps.Slipped++
+			}
+			if !v.done {
+				ps.Open++
+			}
+			ps.TrackedMinutes += tracked
+			if completed && v.meta.SpentMinutes > 0 {
+				ps.EstimatedMinutes += v.meta.EstimatedMinutes
+				ps.SpentMinutes += v.meta.SpentMinutes
+			}
+		}
+	}
+	sortItems(out.Slipped)
+
+	out.Projects = make([]digest.ProjectStats, 0, len(projects))
+	for _, ps := range projects {
+		out.Projects = append(out.Projects, *ps)
+	}
+	sort.Slice(out.Projects, func(i, j int) bool {
+		// Unlabelled tasks always go last
+		if (out.Projects[i].Project == noProjectLabel) != (out.Projects[j].Project == noProjectLabel) {
+			return out.Projects[j].Project == noProjectLabel
+		}
+		return out.Projects[i].Project < out.Projects[j].Project
+	})
+
+	out.Text = renderWeekly(out)
+	if input.Summarize || uc.cfg.LLMSummary {
+		if out.Summary = uc.summarize(ctx, out.Text); out.Summary != "" {
+			out.Text = fmt.Sprintf("💡 %s\n\n%s", out.Summary, out.Text)
+		}
+	}
+
+	return out, nil
+}
+
+// renderWeekly renders the weekly review as Telegram Markdown.
+func renderWeekly(out digest.WeeklyOutput) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("📊 *Tổng kết tuần %s – %s*\n",
+		out.Start.Format("02/01"), out.End.AddDate(0, 0, -1).Format("02/01")))
+	sb.WriteString(fmt.Sprintf("✅ Hoàn thành: %d · 🆕 Tạo mới: %d · ⏰ Trễ hạn: %d\n\n",
+		out.Completed, out.Created, len(out.Slipped)))
+
+	if len(out.Slipped) > 0 {
+		sb.WriteString("⏰ *Deadline bị trễ*\n")
+		writeItems(&sb, out.Slipped, true)
+		sb.WriteString("\n")
+	}
+
+	if len(out.Projects) > 0 {
+		sb.WriteString("📁 *Theo dự án*\n")
+		for _, ps := range out.Projects {
+			sb.WriteString(fmt.Sprintf("• %s: %d xong / %d mới, %d trễ, %d còn mở\n",
+				telegram.EscapeMarkdown(ps.Project), ps.Completed, ps.Created, ps.Slipped, ps.Open))
+		}
+	}
+
+	if out.Tracked > 0 {
+		sb.WriteString(fmt.Sprintf("\n⏱ *Thời gian làm việc: %s*\n", timetrack.FormatMinutes(out.Tracked)))
+		for _, ps := range out.Projects {
+			if ps.TrackedMinutes == 0 && ps.SpentMinutes == 0 {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("• %s: %s", telegram.EscapeMarkdown(ps.Project), timetrack.FormatMinutes(ps.TrackedMinutes)))
+			if ps.EstimatedMinutes > 0 {
+				sb.WriteString(fmt.Sprintf(" · task xong: thực tế %s / ước tính %s (%+.0f%%)",
+					timetrack.FormatMinutes(ps.SpentMinutes), timetrack.FormatMinutes(ps.EstimatedMinutes),
+					float64(ps.SpentMinutes-ps.EstimatedMinutes)*100/float64(ps.EstimatedMinutes)))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return strings.TrimSpace(sb.String())
+}
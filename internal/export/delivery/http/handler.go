@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"autonomous-task-management/internal/export"
+	"autonomous-task-management/internal/model"
+	pkgLog "autonomous-task-management/pkg/log"
+	pkgResponse "autonomous-task-management/pkg/response"
+)
+
+type handler struct {
+	uc export.UseCase
+	l  pkgLog.Logger
+}
+
+func NewHandler(uc export.UseCase, l pkgLog.Logger) export.Handler {
+	return &handler{
+		uc: uc,
+		l:  l,
+	}
+}
+
+// HandleExport serves GET /api/export?format=csv&tag=#project/smap&status=open&from=2026-03-01&to=2026-03-31&archived=true&events=true.
+// tag may be repeated; from and to are inclusive due days.
+func (h *handler) HandleExport(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	input, err := parseQuery(c)
+	if err != nil {
+		pkgResponse.Error(c, err, nil)
+		return
+	}
+
+	output, err := h.uc.Export(ctx, model.Scope{UserID: "api"}, input)
+	if err != nil {
+		if errors.Is(err, export.ErrInvalidFormat) || errors.Is(err, export.ErrInvalidStatus) || errors.Is(err, export.ErrInvalidDateRange) {
+			pkgResponse.Error(c, err, nil)
+			return
+		}
+		h.l.Errorf(ctx, "export: failed to export tasks: %v", err)
+		pkgResponse.InternalError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", output.Filename))
+	c.Header("X-Export-Count", strconv.Itoa(output.Count))
+	c.Data(http.StatusOK, output.ContentType, output.Data)
+}
+
+func parseQuery(c *gin.Context) (export.Input, error) {
+	input := export.Input{
+		Format: c.DefaultQuery("format", "json"),
+		Tags:   c.QueryArray("tag"),
+		Status: export.Status(c.Query("status")),
+	}
+
+	var err error
+	if input.DueFrom, err = parseDay(c.Query("from")); err != nil {
+		return export.Input{}, fmt.Errorf("invalid from: %w", err)
+	}
+	if input.DueTo, err = parseDay(c.Query("to")); err != nil {
+		return export.Input{}, fmt.Errorf("invalid to: %w", err)
+	}
+	if v := c.Query("archived"); v != "" {
+		if input.IncludeArchived, err = strconv.ParseBool(v); err != nil {
+			return export.Input{}, fmt.Errorf("invalid archived: %w", err)
+		}
+	}
+	if v := c.Query("events"); v != "" {
+		if input.ICSEvents, err = strconv.ParseBool(v); err != nil {
+			return export.Input{}, fmt.Errorf("invalid events: %w", err)
+		}
+	}
+	return input, nil
+}
+
+func parseDay(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
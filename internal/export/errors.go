@@ -0,0 +1,9 @@
+package export
+
+import "errors"
+
+var (
+	ErrInvalidFormat    = errors.New("export: unknown format, expected markdown, csv, json or ics")
+	ErrInvalidStatus    = errors.New("export: invalid status, expected open or done")
+	ErrInvalidDateRange = errors.New("export: due date range ends before it starts")
+)
@@ -0,0 +1,21 @@
+package export
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"autonomous-task-management/internal/model"
+)
+
+// UseCase defines the business logic interface for the export domain.
+type UseCase interface {
+	// Export renders the tasks matching the input filters as a Markdown, CSV, JSON or iCalendar file.
+	Export(ctx context.Context, sc model.Scope, input Input) (Output, error)
+}
+
+// Handler defines the interface for the export HTTP handler.
+type Handler interface {
+	// HandleExport streams an export file; filters come from the query string.
+	HandleExport(c *gin.Context)
+}
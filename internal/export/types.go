@@ -0,0 +1,36 @@
+package export
+
+import "time"
+
+// Config configures the export domain.
+type Config struct {
+	Timezone string // Used for due-date filters and the file name date
+}
+
+// Status filters tasks by completion.
+type Status string
+
+const (
+	StatusAll  Status = ""
+	StatusOpen Status = "open"
+	StatusDone Status = "done" // Fully checked checklist or #status/done
+)
+
+// Input selects the tasks to export and the file format.
+type Input struct {
+	Format          string    // "markdown", "csv", "json" or "ics"
+	Tags            []string  // Tasks must carry every tag; a tag ending in "/" matches the whole category
+	Status          Status    // Empty exports open and done tasks
+	DueFrom         time.Time // Inclusive first due day; zero = no lower bound
+	DueTo           time.Time // Inclusive last due day; zero = no upper bound
+	IncludeArchived bool      // Also export archived tasks
+	ICSEvents       bool      // iCalendar only: write all-day events instead of to-dos
+}
+
+// Output is the generated file.
+type Output struct {
+	Filename    string // e.g. "tasks-20260315.csv"
+	ContentType string
+	Data        []byte
+	Count       int // Tasks matching the filters; iCalendar files only contain those with a due date
+}
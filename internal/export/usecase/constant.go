@@ -0,0 +1,11 @@
+package usecase
+
+const (
+	// exportPageSize is the page size used to walk every task.
+	exportPageSize = 200
+
+	defaultTimezone = "Asia/Ho_Chi_Minh"
+	calendarName    = "Tasks"
+
+	fileDateLayout = "20060102"
+)
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"autonomous-task-management/internal/export"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/taskexport"
+)
+
+// Export walks every task (and archived ones on request), keeps those matching the filters and
+// encodes them in the requested format.
+func (uc *implUseCase) Export(ctx context.Context, sc model.Scope, input export.Input) (export.Output, error) {
+	format, ok := taskexport.ParseFormat(input.Format)
+	if !ok {
+		return export.Output{}, export.ErrInvalidFormat
+	}
+	if input.Status != export.StatusAll && input.Status != export.StatusOpen && input.Status != export.StatusDone {
+		return export.Output{}, export.ErrInvalidStatus
+	}
+	if !input.DueFrom.IsZero() && !input.DueTo.IsZero() && input.DueTo.Before(input.DueFrom) {
+		return export.Output{}, export.ErrInvalidDateRange
+	}
+
+	uc.l.Infof(ctx, "export.Export: user=%s format=%s tags=%v status=%q", sc.UserID, format, input.Tags, input.Status)
+
+	states := []bool{false}
+	if input.IncludeArchived {
+		states = append(states, true)
+	}
+
+	var tasks []taskexport.Task
+	for _, archived := range states {
+		for t, err := range uc.repo.IterateTasks(ctx, repository.ListTasksOptions{Limit: exportPageSize, Archived: archived}) {
+			if err != nil {
+				return export.Output{}, fmt.Errorf("failed to list tasks: %w", err)
+			}
+			if et, ok := uc.match(t, input); ok {
+				tasks = append(tasks, et)
+			}
+		}
+	}
+
+	data, err := taskexport.Encode(format, tasks, taskexport.Options{
+		ICSEvents:    input.ICSEvents,
+		CalendarName: calendarName,
+		Now:          uc.now(),
+	})
+	if err != nil {
+		return export.Output{}, err
+	}
+
+	uc.l.Infof(ctx, "export.Export: user=%s exported %d task(s) as %s (%d bytes)", sc.UserID, len(tasks), format, len(data))
+	return export.Output{
+		Filename:    fmt.Sprintf("tasks-%s.%s", uc.now().In(uc.loc).Format(fileDateLayout), format.Extension()),
+		ContentType: format.ContentType(),
+		Data:        data,
+		Count:       len(tasks),
+	}, nil
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	checklistUC "autonomous-task-management/internal/checklist/usecase"
+	"autonomous-task-management/internal/export"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+)
+
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Debugf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Info(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Infof(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Warn(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Warnf(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Error(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Errorf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) DPanic(_ context.Context, _ ...any)            {}
+func (m *mockLogger) DPanicf(_ context.Context, _ string, _ ...any) {}
+func (m *mockLogger) Panic(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Panicf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Fatal(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Fatalf(_ context.Context, _ string, _ ...any)  {}
+
+// staticRepo serves fixed active and archived tasks.
+type staticRepo struct {
+	active   []model.Task
+	archived []model.Task
+}
+
+func (r *staticRepo) CreateTask(context.Context, repository.CreateTaskOptions) (model.Task, error) {
+	return model.Task{}, nil
+}
+func (r *staticRepo) CreateTasksBatch(context.Context, []repository.CreateTaskOptions) ([]model.Task, error) {
+	return nil, nil
+}
+func (r *staticRepo) GetTask(context.Context, string) (model.Task, error) { return model.Task{}, nil }
+func (r *staticRepo) ListTasks(context.Context, repository.ListTasksOptions) ([]model.Task, error) {
+	return r.active, nil
+}
+func (r *staticRepo) UpdateTask(context.Context, string, string) error { return nil }
+func (r *staticRepo) UpdateTaskIfUnchanged(context.Context, string, string, string) (model.Task, error) {
+	return model.Task{}, nil
+}
+func (r *staticRepo) DeleteTask(context.Context, string) error { return nil }
+func (r *staticRepo) ListTaskPage(context.Context, repository.ListTasksOptions) (repository.TaskPage, error) {
+	return repository.TaskPage{Tasks: r.active}, nil
+}
+func (r *staticRepo) IterateTasks(_ context.Context, opt repository.ListTasksOptions) iter.Seq2[model.Task, error] {
+	tasks := r.active
+	if opt.Archived {
+		tasks = r.archived
+	}
+	return func(yield func(model.Task, error) bool) {
+		for _, t := range tasks {
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
+func (r *staticRepo) ArchiveTask(context.Context, string) error   { return nil }
+func (r *staticRepo) RestoreTask(context.Context, string) error   { return nil }
+func (r *staticRepo) PinTask(context.Context, string, bool) error { return nil }
+func (r *staticRepo) SetRelations(context.Context, string, []repository.TaskRelation) error {
+	return nil
+}
+func (r *staticRepo) ListRelations(context.Context, string) ([]repository.TaskRelation, error) {
+	return nil, nil
+}
+
+func newTestUseCase(repo *staticRepo) *implUseCase {
+	l := &mockLogger{}
+	uc := New(l, repo, checklistUC.New(repo, nil, l), export.Config{Timezone: "UTC"}).(*implUseCase)
+	uc.now = func() time.Time { return time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC) }
+	return uc
+}
+
+var exportRepo = &staticRepo{
+	active: []model.Task{
+		{ID: "memos/1", Content: "## Report\n\nQ1 numbers\n\n- **Due:** 2026-03-12\n- **Priority:** #priority/p1\n- [x] draft\n- [ ] review\n\n#priority/p1 #project/smap"},
+		{ID: "memos/2", Content: "## Call client\n\n- **Due:** 2026-04-01\n- **Priority:** #priority/p2\n\n#priority/p2 #project/ahamove #status/done"},
+		{ID: "memos/3", Content: "## Someday\n\n- **Priority:** #priority/p3\n\n#priority/p3 #project/smap"},
+	},
+	archived: []model.Task{
+		{ID: "memos/4", Archived: true, Content: "## Old\n\n- **Due:** 2026-03-11\n- [x] done\n\n#project/smap"},
+	},
+}
+
+func exportJSON(t *testing.T, input export.Input) []map[string]any {
+	t.Helper()
+	input.Format = "json"
+	out, err := newTestUseCase(exportRepo).Export(context.Background(), model.Scope{UserID: "u1"}, input)
+	require.NoError(t, err)
+	assert.Equal(t, "tasks-20260310.json", out.Filename)
+
+	var tasks []map[string]any
+	require.NoError(t, json.Unmarshal(out.Data, &tasks))
+	assert.Len(t, tasks, out.Count)
+	return tasks
+}
+
+func ids(tasks []map[string]any) []string {
+	out := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		out = append(out, t["id"].(string))
+	}
+	return out
+}
+
+func TestExport_Filters(t *testing.T) {
+	all := exportJSON(t, export.Input{})
+	assert.Equal(t, []string{"memos/1", "memos/2", "memos/3"}, ids(all))
+	assert.Equal(t, "Q1 numbers", all[0]["description"])
+	assert.Equal(t, "2026-03-12", all[0]["due"])
+	assert.Equal(t, 50.0, all[0]["progress"])
+
+	assert.Equal(t, []string{"memos/1", "memos/3"}, ids(exportJSON(t, export.Input{Tags: []string{"project/smap"}})))
+	assert.Equal(t, []string{"memos/1", "memos/2", "memos/3"}, ids(exportJSON(t, export.Input{Tags: []string{"#project/"}})))
+	assert.Equal(t, []string{"memos/2"}, ids(exportJSON(t, export.Input{Status: export.StatusDone})))
+	assert.Equal(t, []string{"memos/1", "memos/3"}, ids(exportJSON(t, export.Input{Status: export.StatusOpen})))
+
+	march := export.Input{
+		DueFrom: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		DueTo:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, []string{"memos/1"}, ids(exportJSON(t, march)))
+
+	march.IncludeArchived = true
+	assert.Equal(t, []string{"memos/1", "memos/4"}, ids(exportJSON(t, march)))
+}
+
+func TestExport_ICSOnlyWritesTasksWithDueDate(t *testing.T) {
+	out, err := newTestUseCase(exportRepo).Export(context.Background(), model.Scope{}, export.Input{Format: "ics"})
+	require.NoError(t, err)
+	assert.Equal(t, "tasks-20260310.ics", out.Filename)
+	assert.Equal(t, 2, strings.Count(string(out.Data), "BEGIN:VTODO"))
+	assert.Contains(t, string(out.Data), "STATUS:COMPLETED")
+}
+
+func TestExport_InvalidInput(t *testing.T) {
+	uc := newTestUseCase(exportRepo)
+	ctx := context.Background()
+
+	_, err := uc.Export(ctx, model.Scope{}, export.Input{Format: "xlsx"})
+	assert.ErrorIs(t, err, export.ErrInvalidFormat)
+
+	_, err = uc.Export(ctx, model.Scope{}, export.Input{Format: "csv", Status: "later"})
+	assert.ErrorIs(t, err, export.ErrInvalidStatus)
+
+	_, err = uc.Export(ctx, model.Scope{}, export.Input{
+		Format:  "csv",
+		DueFrom: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		DueTo:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.ErrorIs(t, err, export.ErrInvalidDateRange)
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/export"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/taskexport"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+var (
+	// metadataLineRegex matches the "- **Label:** value" lines written by buildMarkdownContent.
+	metadataLineRegex = regexp.MustCompile(`^\s*[-*]\s+\*\*[^*]+:\*\*`)
+	// checkboxLineRegex matches checklist lines, exported separately from the description.
+	checkboxLineRegex = regexp.MustCompile(`^\s*[-*]\s+\[[ xX]\]\s`)
+	// tagLineRegex matches a line made only of hashtags.
+	tagLineRegex = regexp.MustCompile(`^(#[a-zA-Z0-9_/]+\s*)+$`)
+)
+
+// match converts t when it passes the input filters.
+func (uc *implUseCase) match(t model.Task, input export.Input) (taskexport.Task, bool) {
+	meta := taskmeta.Parse(t.Content)
+
+	for _, want := range input.Tags {
+		if !hasTag(meta.Tags, want) {
+			return taskexport.Task{}, false
+		}
+	}
+
+	done := meta.Done()
+	if (input.Status == export.StatusOpen && done) || (input.Status == export.StatusDone && !done) {
+		return taskexport.Task{}, false
+	}
+
+	if !input.DueFrom.IsZero() || !input.DueTo.IsZero() {
+		if !meta.HasDue {
+			return taskexport.Task{}, false
+		}
+		due := meta.DueIn(uc.loc)
+		if !input.DueFrom.IsZero() && due.Before(uc.day(input.DueFrom)) {
+			return taskexport.Task{}, false
+		}
+		if !input.DueTo.IsZero() && due.After(uc.day(input.DueTo)) {
+			return taskexport.Task{}, false
+		}
+	}
+
+	title := meta.Title
+	if title == "" {
+		title = t.ID
+	}
+	et := taskexport.Task{
+		ID:          t.ID,
+		Title:       title,
+		Description: description(t.Content),
+		Priority:    meta.Priority,
+		Tags:        meta.Tags,
+		Done:        done,
+		Progress:    uc.checklist.GetStats(t.Content).Progress,
+		Archived:    t.Archived,
+		URL:         t.MemoURL,
+		Created:     taskmeta.ParseTime(t.CreateTime),
+		Updated:     taskmeta.ParseTime(t.UpdateTime),
+	}
+	if meta.HasDue {
+		et.Due = meta.DueDate
+	}
+	if done {
+		et.Progress = 100
+	}
+	for _, cb := range uc.checklist.ParseCheckboxes(t.Content) {
+		et.Checklist = append(et.Checklist, taskexport.ChecklistItem{Text: cb.Text, Done: cb.Checked})
+	}
+	return et, true
+}
+
+// day is the calendar day of t in the configured timezone.
+func (uc *implUseCase) day(t time.Time) time.Time {
+	t = t.In(uc.loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, uc.loc)
+}
+
+// hasTag matches a tag with or without its leading '#'; a tag ending in '/' matches its category.
+func hasTag(tags []string, want string) bool {
+	want = "#" + strings.TrimPrefix(strings.TrimSpace(want), "#")
+	if strings.HasSuffix(want, "/") {
+		for _, tag := range tags {
+			if strings.HasPrefix(strings.ToLower(tag), strings.ToLower(want)) {
+				return true
+			}
+		}
+		return false
+	}
+	return taskmeta.HasTag(tags, want)
+}
+
+// description is the free text of a memo: everything but the title, metadata lines, checklist
+// items and tag-only lines.
+func description(content string) string {
+	title := taskmeta.ExtractTitle(content)
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case title != "" && trimmed != "" && taskmeta.ExtractTitle(trimmed) == title:
+			title = "" // Only the first occurrence is the title
+			continue
+		case metadataLineRegex.MatchString(line), checkboxLineRegex.MatchString(line), tagLineRegex.MatchString(trimmed):
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
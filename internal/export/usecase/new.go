@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"time"
+
+	"autonomous-task-management/internal/checklist"
+	"autonomous-task-management/internal/export"
+	"autonomous-task-management/internal/task/repository"
+	pkgLog "autonomous-task-management/pkg/log"
+)
+
+type implUseCase struct {
+	l         pkgLog.Logger
+	repo      repository.MemosRepository
+	checklist checklist.UseCase
+	loc       *time.Location
+	now       func() time.Time
+}
+
+// New creates a new export UseCase instance.
+func New(l pkgLog.Logger, repo repository.MemosRepository, checklistUC checklist.UseCase, cfg export.Config) export.UseCase {
+	if cfg.Timezone == "" {
+		cfg.Timezone = defaultTimezone
+	}
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	return &implUseCase{
+		l:         l,
+		repo:      repo,
+		checklist: checklistUC,
+		loc:       loc,
+		now:       time.Now,
+	}
+}
@@ -0,0 +1,44 @@
+package history
+
+import "context"
+
+type actorKey struct{}
+type actionKey struct{}
+
+type actor struct {
+	userID string
+	name   string
+}
+
+// WithActor marks changes made with ctx as made by name. The first actor set on a context is
+// also the user the changes are made for, so an agent acting inside a Telegram request still
+// records changes on behalf of that Telegram user.
+func WithActor(ctx context.Context, name string) context.Context {
+	a := actor{userID: name, name: name}
+	if parent, ok := ctx.Value(actorKey{}).(actor); ok {
+		a.userID = parent.userID
+	}
+	return context.WithValue(ctx, actorKey{}, a)
+}
+
+// ActorFrom returns who makes changes with ctx and on whose behalf (ActorSystem when unset).
+func ActorFrom(ctx context.Context) (name, userID string) {
+	a, ok := ctx.Value(actorKey{}).(actor)
+	if !ok {
+		return ActorSystem, ActorSystem
+	}
+	return a.name, a.userID
+}
+
+// WithAction names the change about to be made with ctx.
+func WithAction(ctx context.Context, action Action) context.Context {
+	return context.WithValue(ctx, actionKey{}, action)
+}
+
+// ActionFrom returns the action named on ctx, or ActionUpdate.
+func ActionFrom(ctx context.Context) Action {
+	if action, ok := ctx.Value(actionKey{}).(Action); ok {
+		return action
+	}
+	return ActionUpdate
+}
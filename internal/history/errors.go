@@ -0,0 +1,8 @@
+package history
+
+import "errors"
+
+var (
+	ErrNothingToUndo = errors.New("history: nothing to undo")
+	ErrUndoConflict  = errors.New("history: task changed after the change being undone")
+)
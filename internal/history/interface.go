@@ -0,0 +1,22 @@
+package history
+
+import (
+	"context"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+)
+
+// UseCase defines the business logic interface for the task history domain.
+type UseCase interface {
+	// Repository returns the Memos repository wrapped so that every content update, archive and
+	// restore made through it is recorded, with the actor and action taken from the context.
+	Repository() repository.MemosRepository
+
+	// List returns the recorded changes of a task, newest first.
+	List(ctx context.Context, sc model.Scope, input ListInput) ([]ChangeEvent, error)
+
+	// Undo reverts the most recent change made on behalf of sc.UserID that is not yet undone.
+	// It returns ErrUndoConflict, with the change in the output, if the task was edited since.
+	Undo(ctx context.Context, sc model.Scope) (UndoOutput, error)
+}
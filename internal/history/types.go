@@ -0,0 +1,60 @@
+package history
+
+import (
+	"time"
+
+	"autonomous-task-management/internal/model"
+)
+
+// Action is the kind of change recorded for a task.
+type Action string
+
+const (
+	ActionUpdate     Action = "update" // Default when the caller did not name the change
+	ActionCheck      Action = "check"
+	ActionUncheck    Action = "uncheck"
+	ActionComplete   Action = "complete"
+	ActionReschedule Action = "reschedule"
+	ActionMerge      Action = "merge"
+	ActionLink       Action = "link"
+	ActionAutomation Action = "automation"
+	ActionArchive    Action = "archive"
+	ActionRestore    Action = "restore"
+	ActionUndo       Action = "undo"
+	ActionTimeLog    Action = "time_log"
+)
+
+// Actors that are not Telegram users.
+const (
+	ActorWebhook   = "system_webhook"
+	ActorAgent     = "agent"
+	ActorScheduler = "scheduler"
+	ActorSystem    = "system" // Changes made without an actor in the context
+)
+
+// ChangeEvent is one recorded mutation of a task.
+type ChangeEvent struct {
+	ID      string    `json:"id"`
+	TaskID  string    `json:"task_id"`
+	Actor   string    `json:"actor"`   // Who made the change: telegram_<id>, agent, system_webhook, ...
+	UserID  string    `json:"user_id"` // Whose behalf the change was made on; Undo only reverts these
+	Action  Action    `json:"action"`
+	Before  string    `json:"before"`
+	After   string    `json:"after"`
+	Added   []string  `json:"added,omitempty"`   // Lines present only in After
+	Removed []string  `json:"removed,omitempty"` // Lines present only in Before
+	At      time.Time `json:"at"`
+	Undone  bool      `json:"undone"`
+}
+
+// ListInput selects the history of one task.
+type ListInput struct {
+	TaskID string
+	Limit  int // 0 = default
+}
+
+// UndoOutput is the change that was reverted and the task as restored.
+type UndoOutput struct {
+	Event ChangeEvent
+	Task  model.Task
+}
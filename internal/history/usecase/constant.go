@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"time"
+
+	"autonomous-task-management/internal/history"
+)
+
+const (
+	// maxEvents bounds the log; the oldest changes are dropped first.
+	maxEvents = 1000
+
+	// snapshotCacheSize and snapshotTTL bound the task contents kept between a read and the
+	// conditional update that follows it.
+	snapshotCacheSize = 256
+	snapshotTTL       = 5 * time.Minute
+
+	defaultListLimit = 10
+)
+
+// stateActions change a task without touching its content, so they are recorded with empty content.
+var stateActions = map[history.Action]bool{
+	history.ActionArchive: true,
+	history.ActionRestore: true,
+	history.ActionUndo:    true,
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import "strings"
+
+// diffLines returns the lines only in before and the lines only in after, in order.
+// Repeated lines are matched by count, which is enough to summarise checklist and metadata edits.
+func diffLines(before, after string) (removed, added []string) {
+	beforeLines, afterLines := splitLines(before), splitLines(after)
+
+	remaining := make(map[string]int, len(afterLines))
+	for _, line := range afterLines {
+		remaining[line]++
+	}
+	for _, line := range beforeLines {
+		if remaining[line] > 0 {
+			remaining[line]--
+			continue
+		}
+		removed = append(removed, line)
+	}
+
+	remaining = make(map[string]int, len(beforeLines))
+	for _, line := range beforeLines {
+		remaining[line]++
+	}
+	for _, line := range afterLines {
+		if remaining[line] > 0 {
+			remaining[line]--
+			continue
+		}
+		added = append(added, line)
+	}
+	return removed, added
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
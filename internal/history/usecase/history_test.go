@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/internal/task/repository/local"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Mock implementations
+// ---------------------------------------------------------------------------
+
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Debugf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Info(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Infof(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Warn(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Warnf(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Error(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Errorf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) DPanic(_ context.Context, _ ...any)            {}
+func (m *mockLogger) DPanicf(_ context.Context, _ string, _ ...any) {}
+func (m *mockLogger) Panic(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Panicf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Fatal(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Fatalf(_ context.Context, _ string, _ ...any)  {}
+
+// countingRepo counts the reads that reach the underlying repository.
+type countingRepo struct {
+	repository.MemosRepository
+	gets int
+}
+
+func (r *countingRepo) GetTask(ctx context.Context, id string) (model.Task, error) {
+	r.gets++
+	return r.MemosRepository.GetTask(ctx, id)
+}
+
+// newTestUseCase records changes over a local Markdown repository holding one task per content.
+func newTestUseCase(t *testing.T, contents ...string) (*implUseCase, repository.MemosRepository, []string) {
+	t.Helper()
+	repo, err := local.New(t.TempDir(), "", &mockLogger{})
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(contents))
+	for _, content := range contents {
+		task, err := repo.CreateTask(context.Background(), repository.CreateTaskOptions{Content: content})
+		require.NoError(t, err)
+		ids = append(ids, task.ID)
+	}
+	return New(&mockLogger{}, repo, "").(*implUseCase), repo, ids
+}
+
+// content reads the stored content of a task.
+func content(t *testing.T, repo repository.MemosRepository, id string) string {
+	t.Helper()
+	task, err := repo.GetTask(context.Background(), id)
+	require.NoError(t, err)
+	return task.Content
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestRepository_RecordsActorActionAndDiff(t *testing.T) {
+	uc, _, ids := newTestUseCase(t, "# Task\n- [ ] a\n- [ ] b")
+	tracked := uc.Repository()
+	t1 := ids[0]
+
+	ctx := history.WithActor(context.Background(), "telegram_1")
+	require.NoError(t, tracked.UpdateTask(history.WithAction(ctx, history.ActionCheck), t1, "# Task\n- [x] a\n- [ ] b"))
+
+	// An agent acting inside the user's request records the change on the user's behalf
+	agentCtx := history.WithActor(ctx, history.ActorAgent)
+	_, err := repository.ModifyTask(agentCtx, tracked, t1, func(current model.Task) (string, error) {
+		return current.Content + "\n- [ ] c", nil
+	})
+	require.NoError(t, err)
+
+	// Rewriting the same content is not a change
+	require.NoError(t, tracked.UpdateTask(context.Background(), t1, "# Task\n- [x] a\n- [ ] b\n- [ ] c"))
+
+	events, err := uc.List(context.Background(), model.Scope{}, history.ListInput{TaskID: t1})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, history.ActorAgent, events[0].Actor)
+	assert.Equal(t, "telegram_1", events[0].UserID)
+	assert.Equal(t, history.ActionUpdate, events[0].Action)
+	assert.Equal(t, []string{"- [ ] c"}, events[0].Added)
+
+	assert.Equal(t, "telegram_1", events[1].Actor)
+	assert.Equal(t, history.ActionCheck, events[1].Action)
+	assert.Equal(t, []string{"- [ ] a"}, events[1].Removed)
+	assert.Equal(t, []string{"- [x] a"}, events[1].Added)
+}
+
+func TestUndo_RevertsOwnChangesNewestFirst(t *testing.T) {
+	uc, repo, ids := newTestUseCase(t, "v1", "other")
+	tracked := uc.Repository()
+	t1, t2 := ids[0], ids[1]
+	user := history.WithActor(context.Background(), "telegram_1")
+	webhook := history.WithActor(context.Background(), history.ActorWebhook)
+
+	require.NoError(t, tracked.UpdateTask(user, t1, "v2"))
+	require.NoError(t, tracked.ArchiveTask(user, t2))
+	require.NoError(t, tracked.UpdateTask(webhook, t1, "v3"))
+	require.NoError(t, tracked.UpdateTask(webhook, t1, "v2"))
+
+	sc := model.Scope{UserID: "telegram_1"}
+
+	// Newest own change first; the webhook's changes are not the user's to undo
+	out, err := uc.Undo(context.Background(), sc)
+	require.NoError(t, err)
+	assert.Equal(t, history.ActionArchive, out.Event.Action)
+	archived, err := repo.GetTask(context.Background(), t2)
+	require.NoError(t, err)
+	assert.False(t, archived.Archived)
+
+	out, err = uc.Undo(context.Background(), sc)
+	require.NoError(t, err)
+	assert.Equal(t, t1, out.Event.TaskID)
+	assert.Equal(t, "v1", content(t, repo, t1))
+	assert.Equal(t, "v1", out.Task.Content)
+
+	_, err = uc.Undo(context.Background(), sc)
+	assert.ErrorIs(t, err, history.ErrNothingToUndo)
+
+	events, _ := uc.List(context.Background(), sc, history.ListInput{TaskID: t1})
+	require.NotEmpty(t, events)
+	assert.Equal(t, history.ActionUndo, events[0].Action)
+	assert.Equal(t, "telegram_1", events[0].Actor)
+}
+
+func TestUndo_RefusesToOverwriteLaterEdit(t *testing.T) {
+	uc, repo, ids := newTestUseCase(t, "v1")
+	tracked := uc.Repository()
+	t1 := ids[0]
+
+	require.NoError(t, tracked.UpdateTask(history.WithActor(context.Background(), "telegram_1"), t1, "v2"))
+	require.NoError(t, tracked.UpdateTask(history.WithActor(context.Background(), "telegram_2"), t1, "v3"))
+
+	out, err := uc.Undo(context.Background(), model.Scope{UserID: "telegram_1"})
+	assert.ErrorIs(t, err, history.ErrUndoConflict)
+	assert.Equal(t, t1, out.Event.TaskID)
+	assert.Equal(t, "v3", content(t, repo, t1))
+
+	events, _ := uc.List(context.Background(), model.Scope{}, history.ListInput{TaskID: t1})
+	for _, e := range events {
+		assert.False(t, e.Undone, "a failed undo must leave the change undoable")
+	}
+}
+
+func TestRepository_ModifyTaskReadsOnce(t *testing.T) {
+	uc, repo, ids := newTestUseCase(t, "v1")
+	counting := &countingRepo{MemosRepository: repo}
+	uc.repo = counting
+
+	_, err := repository.ModifyTask(context.Background(), uc.Repository(), ids[0], func(model.Task) (string, error) {
+		return "v2", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, counting.gets)
+
+	events, err := uc.List(context.Background(), model.Scope{}, history.ListInput{TaskID: ids[0]})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "v1", events[0].Before)
+}
+
+func TestHistory_SurvivesRestart(t *testing.T) {
+	repo, err := local.New(t.TempDir(), "", &mockLogger{})
+	require.NoError(t, err)
+	task, err := repo.CreateTask(context.Background(), repository.CreateTaskOptions{Content: "v1"})
+	require.NoError(t, err)
+	storePath := filepath.Join(t.TempDir(), "history.json")
+
+	uc := New(&mockLogger{}, repo, storePath)
+	user := history.WithActor(context.Background(), "telegram_1")
+	require.NoError(t, uc.Repository().UpdateTask(user, task.ID, "v2"))
+
+	// A new process still lists the change and can undo it
+	restarted := New(&mockLogger{}, repo, storePath)
+	events, err := restarted.List(context.Background(), model.Scope{}, history.ListInput{TaskID: task.ID})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	_, err = restarted.Undo(context.Background(), model.Scope{UserID: "telegram_1"})
+	require.NoError(t, err)
+	assert.Equal(t, "v1", content(t, repo, task.ID))
+
+	_, err = New(&mockLogger{}, repo, storePath).Undo(context.Background(), model.Scope{UserID: "telegram_1"})
+	assert.ErrorIs(t, err, history.ErrNothingToUndo)
+}
+
+func TestDiffLines(t *testing.T) {
+	removed, added := diffLines("a\nb\nb\nc", "a\nb\nc\nd\n")
+	assert.Equal(t, []string{"b"}, removed)
+	assert.Equal(t, []string{"d"}, added)
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+)
+
+func (uc *implUseCase) List(ctx context.Context, sc model.Scope, input history.ListInput) ([]history.ChangeEvent, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	var out []history.ChangeEvent
+	for i := len(uc.events) - 1; i >= 0 && len(out) < limit; i-- {
+		if uc.events[i].TaskID == input.TaskID {
+			out = append(out, uc.events[i])
+		}
+	}
+	return out, nil
+}
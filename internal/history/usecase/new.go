@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/jsonfile"
+	pkgLog "autonomous-task-management/pkg/log"
+)
+
+type implUseCase struct {
+	l         pkgLog.Logger
+	repo      repository.MemosRepository // Unwrapped, so undo writes are recorded only once
+	now       func() time.Time
+	storePath string // JSON file the log is saved to; empty keeps it in memory only
+
+	// snapshots holds the content of tasks read through Repository(), keyed by ID and update
+	// time, so a conditional update can record its "before" without reading the task again.
+	snapshots *expirable.LRU[string, string]
+
+	mu     sync.Mutex
+	events []history.ChangeEvent // Oldest first
+}
+
+// New creates a new history UseCase recording changes made through Repository().
+// The log is restored from storePath and saved there after every change; pass "" to keep it
+// in memory only.
+func New(l pkgLog.Logger, repo repository.MemosRepository, storePath string) history.UseCase {
+	uc := &implUseCase{
+		l:         l,
+		repo:      repo,
+		now:       time.Now,
+		storePath: storePath,
+		snapshots: expirable.NewLRU[string, string](snapshotCacheSize, nil, snapshotTTL),
+	}
+	if storePath != "" {
+		if err := jsonfile.Load(storePath, &uc.events); err != nil {
+			l.Errorf(context.Background(), "history: failed to load the change log: %v", err)
+		}
+	}
+	return uc
+}
+
+// saveLocked writes the log to storePath; the caller holds uc.mu.
+// A failed write is only logged: the change is still in memory and undoable until a restart.
+func (uc *implUseCase) saveLocked(ctx context.Context) {
+	if uc.storePath == "" {
+		return
+	}
+	if err := jsonfile.Save(uc.storePath, uc.events); err != nil {
+		uc.l.Warnf(ctx, "history: failed to save the change log: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+)
+
+// trackedRepo records the changes made through the embedded repository.
+type trackedRepo struct {
+	repository.MemosRepository
+	uc *implUseCase
+}
+
+func (uc *implUseCase) Repository() repository.MemosRepository {
+	return &trackedRepo{MemosRepository: uc.repo, uc: uc}
+}
+
+func (r *trackedRepo) UpdateTask(ctx context.Context, id string, content string) error {
+	before, getErr := r.MemosRepository.GetTask(ctx, id)
+	if err := r.MemosRepository.UpdateTask(ctx, id, content); err != nil {
+		return err
+	}
+	if getErr != nil {
+		r.uc.l.Warnf(ctx, "history: task %s updated without a recorded change: %v", id, getErr)
+		return nil
+	}
+	r.uc.record(ctx, id, history.ActionFrom(ctx), before.Content, content)
+	return nil
+}
+
+// GetTask remembers the content it read, so the UpdateTaskIfUnchanged of a read-modify-write
+// (see repository.ModifyTask) finds its "before" without another round trip.
+func (r *trackedRepo) GetTask(ctx context.Context, id string) (model.Task, error) {
+	task, err := r.MemosRepository.GetTask(ctx, id)
+	if err == nil {
+		r.uc.snapshots.Add(snapshotKey(task.ID, task.UpdateTime), task.Content)
+	}
+	return task, err
+}
+
+func (r *trackedRepo) UpdateTaskIfUnchanged(ctx context.Context, id, content, expectedUpdateTime string) (model.Task, error) {
+	before, ok := r.uc.snapshots.Get(snapshotKey(id, expectedUpdateTime))
+	if !ok {
+		current, err := r.MemosRepository.GetTask(ctx, id)
+		if err != nil {
+			return model.Task{}, err
+		}
+		if current.UpdateTime != expectedUpdateTime {
+			return model.Task{}, repository.ErrConflict
+		}
+		before = current.Content
+	}
+
+	updated, err := r.MemosRepository.UpdateTaskIfUnchanged(ctx, id, content, expectedUpdateTime)
+	if err != nil {
+		return model.Task{}, err
+	}
+	r.uc.snapshots.Remove(snapshotKey(id, expectedUpdateTime))
+	r.uc.record(ctx, id, history.ActionFrom(ctx), before, content)
+	return updated, nil
+}
+
+// snapshotKey identifies one version of a task.
+func snapshotKey(id, updateTime string) string {
+	return id + "@" + updateTime
+}
+
+func (r *trackedRepo) ArchiveTask(ctx context.Context, id string) error {
+	if err := r.MemosRepository.ArchiveTask(ctx, id); err != nil {
+		return err
+	}
+	r.uc.record(ctx, id, history.ActionArchive, "", "")
+	return nil
+}
+
+func (r *trackedRepo) RestoreTask(ctx context.Context, id string) error {
+	if err := r.MemosRepository.RestoreTask(ctx, id); err != nil {
+		return err
+	}
+	r.uc.record(ctx, id, history.ActionRestore, "", "")
+	return nil
+}
+
+// record appends a change made with ctx. Content updates that change nothing are not recorded.
+func (uc *implUseCase) record(ctx context.Context, taskID string, action history.Action, before, after string) {
+	if before == after && !stateActions[action] {
+		return
+	}
+
+	actor, userID := history.ActorFrom(ctx)
+	removed, added := diffLines(before, after)
+	event := history.ChangeEvent{
+		ID:      uuid.NewString()[:8],
+		TaskID:  taskID,
+		Actor:   actor,
+		UserID:  userID,
+		Action:  action,
+		Before:  before,
+		After:   after,
+		Added:   added,
+		Removed: removed,
+		At:      uc.now(),
+	}
+
+	uc.mu.Lock()
+	uc.events = append(uc.events, event)
+	if len(uc.events) > maxEvents {
+		uc.events = append(uc.events[:0:0], uc.events[len(uc.events)-maxEvents:]...)
+	}
+	uc.saveLocked(ctx)
+	uc.mu.Unlock()
+
+	uc.l.Infof(ctx, "history: task=%s action=%s actor=%s user=%s (+%d/-%d lines)",
+		taskID, action, actor, userID, len(added), len(removed))
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+)
+
+func (uc *implUseCase) Undo(ctx context.Context, sc model.Scope) (history.UndoOutput, error) {
+	event, ok := uc.claimUndoable(ctx, sc.UserID)
+	if !ok {
+		return history.UndoOutput{}, history.ErrNothingToUndo
+	}
+
+	ctx = history.WithActor(ctx, sc.UserID)
+	var (
+		restored model.Task
+		err      error
+	)
+	switch event.Action {
+	case history.ActionArchive:
+		err = uc.repo.RestoreTask(ctx, event.TaskID)
+	case history.ActionRestore:
+		err = uc.repo.ArchiveTask(ctx, event.TaskID)
+	default:
+		restored, err = repository.ModifyTask(ctx, uc.repo, event.TaskID, func(current model.Task) (string, error) {
+			// Reverting over a later edit would silently drop it
+			if current.Content != event.After {
+				return "", history.ErrUndoConflict
+			}
+			return event.Before, nil
+		})
+	}
+	if err != nil {
+		uc.setUndone(ctx, event.ID, false)
+		if errors.Is(err, history.ErrUndoConflict) {
+			return history.UndoOutput{Event: event}, err
+		}
+		return history.UndoOutput{}, fmt.Errorf("failed to undo change %s: %w", event.ID, err)
+	}
+	if restored.ID == "" {
+		if restored, err = uc.repo.GetTask(ctx, event.TaskID); err != nil {
+			uc.l.Warnf(ctx, "history.Undo: failed to reload task %s: %v", event.TaskID, err)
+		}
+	}
+
+	uc.record(ctx, event.TaskID, history.ActionUndo, event.After, event.Before)
+
+	uc.l.Infof(ctx, "history.Undo: user=%s reverted %s on task %s", sc.UserID, event.Action, event.TaskID)
+	return history.UndoOutput{Event: event, Task: restored}, nil
+}
+
+// claimUndoable marks the newest change made on behalf of userID that is not yet undone as undone,
+// so concurrent undos pick different changes. Undo events themselves are skipped, so repeated
+// undos walk further back.
+func (uc *implUseCase) claimUndoable(ctx context.Context, userID string) (history.ChangeEvent, bool) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	for i := len(uc.events) - 1; i >= 0; i-- {
+		e := uc.events[i]
+		if e.UserID == userID && !e.Undone && e.Action != history.ActionUndo {
+			uc.events[i].Undone = true
+			uc.saveLocked(ctx)
+			return e, true
+		}
+	}
+	return history.ChangeEvent{}, false
+}
+
+// setUndone updates the undone flag of an event, if it is still in the log.
+func (uc *implUseCase) setUndone(ctx context.Context, id string, undone bool) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	for i := len(uc.events) - 1; i >= 0; i-- {
+		if uc.events[i].ID == id {
+			uc.events[i].Undone = undone
+			uc.saveLocked(ctx)
+			return
+		}
+	}
+}
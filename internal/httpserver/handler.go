@@ -2,6 +2,7 @@ package httpserver
 
 import (
 	"context"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -9,14 +10,32 @@ import (
 
 	"autonomous-task-management/internal/agent"
 	agentUC "autonomous-task-management/internal/agent/usecase"
+	"autonomous-task-management/internal/automation"
 	automationUC "autonomous-task-management/internal/automation/usecase"
 	checklistUC "autonomous-task-management/internal/checklist/usecase"
+	"autonomous-task-management/internal/dependency"
+	dependencyUC "autonomous-task-management/internal/dependency/usecase"
+	"autonomous-task-management/internal/digest"
+	digestUC "autonomous-task-management/internal/digest/usecase"
+	"autonomous-task-management/internal/export"
+	exportHttp "autonomous-task-management/internal/export/delivery/http"
+	exportUC "autonomous-task-management/internal/export/usecase"
+	"autonomous-task-management/internal/history"
+	historyUC "autonomous-task-management/internal/history/usecase"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/ranking"
+	rankingUC "autonomous-task-management/internal/ranking/usecase"
 	routerUC "autonomous-task-management/internal/router/usecase"
+	"autonomous-task-management/internal/schedule"
+	scheduleUC "autonomous-task-management/internal/schedule/usecase"
+	"autonomous-task-management/internal/sync"
 	syncHttp "autonomous-task-management/internal/sync/delivery/http"
 	syncUC "autonomous-task-management/internal/sync/usecase"
+	"autonomous-task-management/internal/task"
 	tgDelivery "autonomous-task-management/internal/task/delivery/telegram"
 	taskUC "autonomous-task-management/internal/task/usecase"
 	"autonomous-task-management/internal/test"
+	timetrackUC "autonomous-task-management/internal/timetrack/usecase"
 	"autonomous-task-management/internal/webhook"
 	webhookHttp "autonomous-task-management/internal/webhook/delivery/http"
 	webhookUC "autonomous-task-management/internal/webhook/usecase"
@@ -27,11 +46,18 @@ func (srv *HTTPServer) mapHandlers() error {
 	srv.registerSystemRoutes()
 
 	// Initialize domains in order of dependency
+	srv.setupHistoryDomain()
 	srv.setupChecklistDomain()
+	srv.setupDependencyDomain()
 	srv.setupRouterDomain()
+	srv.setupScheduleDomain()
 	srv.setupTaskDomain()
 	srv.setupSyncDomain()
 	srv.setupAutomationDomain()
+	srv.setupDigestDomain()
+	srv.setupExportDomain()
+	srv.setupTimetrackDomain()
+	srv.setupRankingDomain()
 	srv.setupAgentDomain()
 	srv.setupWebhookDomain()
 	srv.setupTestDomain()
@@ -57,15 +83,68 @@ func (srv *HTTPServer) registerSystemRoutes() {
 	))
 }
 
+// setupHistoryDomain swaps in a Memos repository that records every change, so it must run
+// before any domain receives srv.memosRepo.
+func (srv *HTTPServer) setupHistoryDomain() {
+	srv.historyUC = historyUC.New(srv.l, srv.memosRepo, srv.cfg.History.StorePath)
+	srv.memosRepo = srv.historyUC.Repository()
+}
+
 func (srv *HTTPServer) setupChecklistDomain() {
 	srv.checklistUC = checklistUC.New(srv.memosRepo, srv.vectorRepo, srv.l)
 }
 
+func (srv *HTTPServer) setupDependencyDomain() {
+	var notifier dependency.Notifier
+	if srv.telegramBot != nil {
+		notifier = srv.telegramBot
+	}
+	srv.dependencyUC = dependencyUC.New(srv.l, srv.memosRepo, srv.checklistUC, notifier, dependency.Config{
+		ChatIDs: srv.cfg.Telegram.NotifyChatIDs,
+	})
+}
+
 func (srv *HTTPServer) setupRouterDomain() {
 	srv.routerUC = routerUC.New(srv.llmManager, srv.l)
 }
 
+func (srv *HTTPServer) setupScheduleDomain() {
+	var notifier schedule.Notifier
+	if srv.telegramBot != nil {
+		notifier = srv.telegramBot
+	}
+	var calendar schedule.CalendarClient
+	if srv.calendarClient != nil {
+		calendar = srv.calendarClient
+	}
+
+	srv.scheduleUC = scheduleUC.New(srv.l, srv.memosRepo, calendar, notifier, schedule.Config{
+		Timezone:  srv.cfg.LLM.Timezone,
+		WorkStart: srv.cfg.Schedule.WorkStart,
+		WorkEnd:   srv.cfg.Schedule.WorkEnd,
+		WorkDays:  srv.cfg.Schedule.WorkDays,
+		Horizon:   srv.cfg.Schedule.Horizon,
+		Buffer:    srv.cfg.Schedule.Buffer,
+		ChatIDs:   srv.cfg.Telegram.NotifyChatIDs,
+	})
+
+	if calendar != nil && srv.cfg.Schedule.Enabled && srv.cfg.Schedule.AutoReschedule && srv.cfg.Schedule.RescheduleInterval > 0 {
+		srv.scheduler.Every("reschedule", srv.cfg.Schedule.RescheduleInterval, func(ctx context.Context) error {
+			sc := model.Scope{UserID: history.ActorScheduler}
+			_, err := srv.scheduleUC.RescheduleMissed(history.WithActor(ctx, sc.UserID), sc)
+			return err
+		})
+		srv.l.Infof(context.Background(), "Reschedule scheduler enabled (every %s)", srv.cfg.Schedule.RescheduleInterval)
+	}
+}
+
 func (srv *HTTPServer) setupTaskDomain() {
+	// Book deadline-only tasks into free slots when smart scheduling is on
+	var scheduler task.Scheduler
+	if srv.calendarClient != nil && srv.cfg.Schedule.Enabled {
+		scheduler = srv.scheduleUC
+	}
+
 	srv.taskUC = taskUC.New(
 		srv.l,
 		srv.llmManager,
@@ -76,6 +155,8 @@ func (srv *HTTPServer) setupTaskDomain() {
 		nil, // reranker: optional, wired externally via srv.reranker if configured
 		srv.cfg.LLM.Timezone,
 		srv.cfg.Memos.ExternalURL,
+		scheduler,
+		srv.cfg.Search.LLMExpansion,
 	)
 
 	// Register Telegram Webhook if token exists
@@ -87,15 +168,122 @@ func (srv *HTTPServer) setupTaskDomain() {
 
 func (srv *HTTPServer) setupSyncDomain() {
 	if srv.vectorRepo != nil {
-		srv.syncUC = syncUC.New(srv.memosRepo, srv.vectorRepo, srv.l)
+		srv.syncUC = syncUC.New(srv.memosRepo, srv.vectorRepo, srv.l, srv.cfg.Reconcile.MaxOrphanRatio)
 		srv.syncHandler = syncHttp.NewHandler(srv.syncUC, srv.l)
 		srv.gin.POST("/webhook/memos", srv.syncHandler.HandleMemosWebhook)
 		srv.l.Infof(context.Background(), "Sync domain routes registered at POST /webhook/memos")
+
+		if srv.cfg.API.Token != "" {
+			srv.apiGroup().GET("/admin/reconcile", srv.syncHandler.HandleReconcileStatus)
+			srv.apiGroup().POST("/admin/reconcile", srv.syncHandler.HandleReconcile)
+			srv.l.Infof(context.Background(), "Reconcile routes registered at GET/POST /api/admin/reconcile")
+		}
+
+		if srv.cfg.Reconcile.Enabled && srv.cfg.Reconcile.Interval > 0 {
+			srv.scheduler.Every("reconcile", srv.cfg.Reconcile.Interval, func(ctx context.Context) error {
+				_, err := srv.syncUC.Reconcile(ctx, sync.ReconcileInput{})
+				return err
+			})
+			srv.l.Infof(context.Background(), "Reconcile scheduler enabled (every %s)", srv.cfg.Reconcile.Interval)
+		}
+
+		if srv.cfg.EnrichmentRefresh.Enabled && srv.cfg.EnrichmentRefresh.Interval > 0 {
+			srv.scheduler.Every("enrichment-refresh", srv.cfg.EnrichmentRefresh.Interval, func(ctx context.Context) error {
+				_, err := srv.syncUC.RefreshTemporal(ctx)
+				return err
+			})
+			srv.l.Infof(context.Background(), "Enrichment refresh scheduler enabled (every %s)", srv.cfg.EnrichmentRefresh.Interval)
+		}
 	}
 }
 
 func (srv *HTTPServer) setupAutomationDomain() {
-	srv.automationUC = automationUC.New(srv.memosRepo, srv.vectorRepo, srv.checklistUC, srv.l)
+	srv.automationUC = automationUC.New(srv.memosRepo, srv.vectorRepo, srv.checklistUC, srv.l, automation.Config{
+		ArchiveGracePeriod: srv.cfg.Archive.GracePeriod,
+	})
+
+	if srv.cfg.Archive.Enabled && srv.cfg.Archive.Interval > 0 {
+		srv.scheduler.Every("archive", srv.cfg.Archive.Interval, func(ctx context.Context) error {
+			sc := model.Scope{UserID: history.ActorScheduler}
+			_, err := srv.automationUC.ArchiveCompletedTasks(history.WithActor(ctx, sc.UserID), sc, automation.ArchiveInput{})
+			return err
+		})
+		srv.l.Infof(context.Background(), "Archive scheduler enabled (every %s, grace period %s)",
+			srv.cfg.Archive.Interval, srv.cfg.Archive.GracePeriod)
+	}
+}
+
+func (srv *HTTPServer) setupDigestDomain() {
+	var notifier digest.Notifier
+	if srv.telegramBot != nil {
+		notifier = srv.telegramBot
+	}
+	var calendar digest.CalendarClient
+	if srv.calendarClient != nil {
+		calendar = srv.calendarClient
+	}
+
+	srv.digestUC = digestUC.New(
+		srv.l,
+		srv.memosRepo,
+		srv.checklistUC,
+		calendar,
+		srv.llmManager,
+		notifier,
+		digest.Config{
+			Timezone:   srv.cfg.LLM.Timezone,
+			DailyTime:  srv.cfg.Digest.DailyTime,
+			WeeklyDay:  srv.cfg.Digest.WeeklyDay,
+			WeeklyTime: srv.cfg.Digest.WeeklyTime,
+			LLMSummary: srv.cfg.Digest.LLMSummary,
+			ChatIDs:    srv.cfg.Digest.ChatIDs,
+			StorePath:  srv.cfg.Digest.StorePath,
+		},
+	)
+
+	if srv.cfg.Digest.Enabled {
+		srv.scheduler.Every("digest", time.Minute, func(ctx context.Context) error {
+			_, err := srv.digestUC.RunDue(ctx, time.Now())
+			return err
+		})
+		srv.l.Infof(context.Background(), "Digest scheduler enabled (daily %s, weekly %s %s)",
+			srv.cfg.Digest.DailyTime, srv.cfg.Digest.WeeklyDay, srv.cfg.Digest.WeeklyTime)
+	}
+}
+
+func (srv *HTTPServer) setupExportDomain() {
+	srv.exportUC = exportUC.New(srv.l, srv.memosRepo, srv.checklistUC, export.Config{
+		Timezone: srv.cfg.LLM.Timezone,
+	})
+
+	if srv.cfg.API.Token != "" {
+		srv.exportHandler = exportHttp.NewHandler(srv.exportUC, srv.l)
+		srv.apiGroup().GET("/export", srv.exportHandler.HandleExport)
+		srv.l.Infof(context.Background(), "Export route registered at GET /api/export")
+	}
+}
+
+func (srv *HTTPServer) setupTimetrackDomain() {
+	srv.timetrackUC = timetrackUC.New(srv.l, srv.memosRepo, srv.cfg.LLM.Timezone, srv.cfg.TimeTrack.StorePath)
+}
+
+func (srv *HTTPServer) setupRankingDomain() {
+	var calendar ranking.CalendarClient
+	if srv.calendarClient != nil {
+		calendar = srv.calendarClient
+	}
+
+	w := srv.cfg.Ranking.Weights
+	srv.rankingUC = rankingUC.New(srv.l, srv.memosRepo, calendar, ranking.Config{
+		Timezone: srv.cfg.LLM.Timezone,
+		Weights: ranking.Weights{
+			Priority:     w.Priority,
+			Urgency:      w.Urgency,
+			Effort:       w.Effort,
+			Dependency:   w.Dependency,
+			Availability: w.Availability,
+		},
+	})
 }
 
 func (srv *HTTPServer) setupAgentDomain() {
@@ -103,6 +291,10 @@ func (srv *HTTPServer) setupAgentDomain() {
 	registry := agent.NewToolRegistry()
 	srv.taskUC.RegisterAgentTools(registry)
 	srv.checklistUC.RegisterAgentTools(registry)
+	srv.dependencyUC.RegisterAgentTools(registry)
+	srv.timetrackUC.RegisterAgentTools(registry)
+	srv.scheduleUC.RegisterAgentTools(registry)
+	srv.rankingUC.RegisterAgentTools(registry)
 
 	srv.agentUC = agentUC.New(srv.llmManager, registry, srv.l, srv.cfg.LLM.Timezone)
 
@@ -117,6 +309,13 @@ func (srv *HTTPServer) setupAgentDomain() {
 			srv.checklistUC,
 			srv.memosRepo,
 			srv.routerUC,
+			srv.digestUC,
+			srv.dependencyUC,
+			srv.historyUC,
+			srv.exportUC,
+			srv.timetrackUC,
+			srv.scheduleUC,
+			srv.rankingUC,
 		)
 		srv.gin.POST("/webhook/telegram", srv.telegramHandler.HandleWebhook)
 		srv.l.Infof(context.Background(), "Telegram webhook route registered at POST /webhook/telegram")
@@ -131,7 +330,7 @@ func (srv *HTTPServer) setupWebhookDomain() {
 			RateLimitPerMin: srv.cfg.Webhook.RateLimitPerMin,
 		}
 		srv.webhookUC = webhookUC.New(webhookConfig, srv.l)
-		srv.webhookHandler = webhookHttp.NewHandler(srv.webhookUC, srv.automationUC, srv.l)
+		srv.webhookHandler = webhookHttp.NewHandler(srv.webhookUC, srv.automationUC, srv.dependencyUC, srv.l)
 
 		srv.gin.POST("/webhook/github", srv.webhookHandler.HandleGitHubWebhook)
 		srv.gin.POST("/webhook/gitlab", srv.webhookHandler.HandleGitLabWebhook)
@@ -17,6 +17,9 @@ func (srv HTTPServer) Run() error {
 		return err
 	}
 
+	srv.scheduler.Start(context.Background())
+	defer srv.scheduler.Stop()
+
 	addr := fmt.Sprintf(":%d", srv.port)
 	server := &http.Server{
 		Addr:    addr,
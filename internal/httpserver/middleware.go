@@ -0,0 +1,27 @@
+package httpserver
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	pkgResponse "autonomous-task-management/pkg/response"
+)
+
+// apiGroup returns the /api route group, guarded by the configured bearer token.
+// Callers only register routes on it when cfg.API.Token is set.
+func (srv *HTTPServer) apiGroup() *gin.RouterGroup {
+	return srv.gin.Group("/api", srv.requireAPIToken)
+}
+
+// requireAPIToken rejects requests whose "Authorization: Bearer" token does not match cfg.API.Token.
+func (srv *HTTPServer) requireAPIToken(c *gin.Context) {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || srv.cfg.API.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(srv.cfg.API.Token)) != 1 {
+		pkgResponse.Unauthorized(c)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
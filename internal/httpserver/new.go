@@ -9,16 +9,24 @@ import (
 	"autonomous-task-management/internal/agent"
 	"autonomous-task-management/internal/automation"
 	"autonomous-task-management/internal/checklist"
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/digest"
+	"autonomous-task-management/internal/export"
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/ranking"
 	"autonomous-task-management/internal/router"
+	"autonomous-task-management/internal/schedule"
 	"autonomous-task-management/internal/sync"
 	"autonomous-task-management/internal/task"
 	tgDelivery "autonomous-task-management/internal/task/delivery/telegram"
 	"autonomous-task-management/internal/task/repository"
 	"autonomous-task-management/internal/test"
+	"autonomous-task-management/internal/timetrack"
 	"autonomous-task-management/internal/webhook"
 	"autonomous-task-management/pkg/datemath"
 	"autonomous-task-management/pkg/llmprovider"
 	"autonomous-task-management/pkg/log"
+	"autonomous-task-management/pkg/scheduler"
 	"autonomous-task-management/pkg/telegram"
 )
 
@@ -39,6 +47,7 @@ type HTTPServer struct {
 	calendarClient task.CalendarClient
 	telegramBot    telegram.IBot
 	dateMathParser datemath.IParser
+	scheduler      scheduler.IScheduler
 
 	// Domain UseCases
 	taskUC       task.UseCase
@@ -48,12 +57,20 @@ type HTTPServer struct {
 	routerUC     router.UseCase
 	syncUC       sync.UseCase
 	webhookUC    webhook.UseCase
+	digestUC     digest.UseCase
+	dependencyUC dependency.UseCase
+	historyUC    history.UseCase
+	exportUC     export.UseCase
+	timetrackUC  timetrack.UseCase
+	scheduleUC   schedule.UseCase
+	rankingUC    ranking.UseCase
 
 	// Domain Handlers
 	telegramHandler tgDelivery.Handler
 	syncHandler     sync.Handler
 	webhookHandler  webhook.Handler
 	testHandler     test.Handler
+	exportHandler   export.Handler
 }
 
 // Config is the dependency bag passed to New().
@@ -90,6 +107,7 @@ func New(logger log.Logger, cfg Config) (*HTTPServer, error) {
 		calendarClient: cfg.CalendarClient,
 		telegramBot:    cfg.TelegramBot,
 		dateMathParser: cfg.DateMathParser,
+		scheduler:      scheduler.New(logger),
 	}
 
 	if err := srv.validate(); err != nil {
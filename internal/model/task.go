@@ -8,6 +8,8 @@ type Task struct {
 	Tags       []string // Extracted tags
 	MemoURL    string   // Deep link to the Memos web UI
 	Visibility string   // "PRIVATE" or "PUBLIC"
+	Archived   bool     // Row status is ARCHIVED
+	Pinned     bool     // Pinned to the top in Memos
 	CreateTime string   // RFC3339 creation time string from Memos API
 	UpdateTime string   // RFC3339 last updated time string from Memos API
 }
@@ -0,0 +1,7 @@
+package ranking
+
+import "errors"
+
+var (
+	ErrNoOpenTasks = errors.New("ranking: no open task to work on")
+)
@@ -0,0 +1,27 @@
+package ranking
+
+import (
+	"context"
+
+	"autonomous-task-management/internal/agent"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/gcalendar"
+)
+
+// CalendarClient lists the upcoming events that bound the free time right now
+// (satisfied by gcalendar.IGCalendar).
+type CalendarClient interface {
+	ListEvents(ctx context.Context, req gcalendar.ListEventsRequest) ([]gcalendar.Event, error)
+}
+
+// UseCase defines the business logic interface for the ranking domain.
+// A task's score is the weighted mean of its priority, due-date urgency, remaining effort,
+// how many tasks it blocks and whether it fits in the free time before the next event.
+type UseCase interface {
+	// Next ranks the open tasks that are not blocked and returns the best ones with the
+	// reasons behind their score.
+	Next(ctx context.Context, sc model.Scope, input NextInput) (NextOutput, error)
+
+	// RegisterAgentTools registers this domain's agent tools into the registry.
+	RegisterAgentTools(registry *agent.ToolRegistry)
+}
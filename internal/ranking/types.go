@@ -0,0 +1,56 @@
+package ranking
+
+import "time"
+
+// Weights sets how much each factor counts in a task's score. Only their ratios matter;
+// a zero weight switches the factor off.
+type Weights struct {
+	Priority     float64
+	Urgency      float64
+	Effort       float64
+	Dependency   float64
+	Availability float64
+}
+
+// Config holds the ranking domain settings.
+type Config struct {
+	Timezone string
+	Weights  Weights // All zero = defaults
+}
+
+// NextInput selects the tasks to rank.
+type NextInput struct {
+	Limit   int    // Number of tasks returned; 0 = default
+	Project string // Only tasks tagged #project/<Project>; empty = all
+}
+
+// Factors are the per-factor scores of a task, each between 0 and 1.
+type Factors struct {
+	Priority     float64 `json:"priority"`
+	Urgency      float64 `json:"urgency"`
+	Effort       float64 `json:"effort"`
+	Dependency   float64 `json:"dependency"`
+	Availability float64 `json:"availability"`
+}
+
+// RankedTask is a task with its score and the reasons for it.
+type RankedTask struct {
+	TaskID           string    `json:"task_id"`
+	Title            string    `json:"title"`
+	MemoURL          string    `json:"memo_url,omitempty"`
+	Priority         string    `json:"priority,omitempty"`
+	Due              time.Time `json:"due,omitempty"` // Zero when the task has no due date
+	RemainingMinutes int       `json:"remaining_minutes,omitempty"`
+	Blocking         int       `json:"blocking,omitempty"` // Open tasks waiting on this one
+	Score            float64   `json:"score"`              // 0-100
+	Factors          Factors   `json:"factors"`
+	Reasons          []string  `json:"reasons"`
+}
+
+// NextOutput is the ranking of the open tasks.
+type NextOutput struct {
+	Tasks     []RankedTask
+	Ranked    int       // Open, unblocked tasks that were scored
+	Blocked   int       // Open tasks skipped because a blocker is not done
+	FreeUntil time.Time // Start of the next calendar event; zero without a calendar
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/agent"
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/ranking"
+	pkgLog "autonomous-task-management/pkg/log"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// RegisterAgentTools registers the ranking domain's agent tools into the registry.
+func (uc *implUseCase) RegisterAgentTools(registry *agent.ToolRegistry) {
+	registry.Register(&suggestNextTasksTool{uc: uc, l: uc.l})
+}
+
+// agentScope returns the scope of the user the agent acts for.
+func agentScope(ctx context.Context) model.Scope {
+	_, userID := history.ActorFrom(ctx)
+	return model.Scope{UserID: userID}
+}
+
+// suggestNextTasksTool recommends what to work on next.
+type suggestNextTasksTool struct {
+	uc *implUseCase
+	l  pkgLog.Logger
+}
+
+type suggestNextTasksInput struct {
+	Limit   int    `json:"limit"`
+	Project string `json:"project"`
+}
+
+type suggestedTask struct {
+	TaskID  string   `json:"task_id"`
+	Title   string   `json:"title"`
+	MemoURL string   `json:"memo_url,omitempty"`
+	Due     string   `json:"due,omitempty"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+type suggestNextTasksOutput struct {
+	Tasks   []suggestedTask `json:"tasks"`
+	Blocked int             `json:"blocked"`
+	Summary string          `json:"summary"`
+}
+
+func (t *suggestNextTasksTool) Name() string { return "suggest_next_tasks" }
+
+func (t *suggestNextTasksTool) Description() string {
+	return "Recommend which open tasks to work on next. Tasks are scored on priority, due date, remaining effort, " +
+		"how many tasks they block and whether they fit before the next calendar event; blocked tasks are left out. " +
+		"Returns the best tasks with the reasons for each."
+}
+
+func (t *suggestNextTasksTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": fmt.Sprintf("Number of tasks to return (default %d, max %d)", defaultLimit, maxLimit),
+			},
+			"project": map[string]interface{}{
+				"type":        "string",
+				"description": "Only consider tasks tagged #project/<project>",
+			},
+		},
+	}
+}
+
+func (t *suggestNextTasksTool) Execute(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input: %w", err)
+	}
+	var params suggestNextTasksInput
+	if err := json.Unmarshal(inputBytes, &params); err != nil {
+		return nil, fmt.Errorf("failed to parse input: %w", err)
+	}
+
+	t.l.Infof(ctx, "suggest_next_tasks: limit=%d project=%s", params.Limit, params.Project)
+
+	out, err := t.uc.Next(ctx, agentScope(ctx), ranking.NextInput{Limit: params.Limit, Project: params.Project})
+	if errors.Is(err, ranking.ErrNoOpenTasks) {
+		summary := "Không còn task nào đang mở"
+		if out.Blocked > 0 {
+			summary = fmt.Sprintf("Tất cả %d task đang mở đều bị chặn bởi task khác", out.Blocked)
+		}
+		return suggestNextTasksOutput{Blocked: out.Blocked, Summary: summary}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := suggestNextTasksOutput{Blocked: out.Blocked}
+	lines := make([]string, 0, len(out.Tasks))
+	for i, r := range out.Tasks {
+		s := suggestedTask{TaskID: r.TaskID, Title: r.Title, MemoURL: r.MemoURL, Score: r.Score, Reasons: r.Reasons}
+		if !r.Due.IsZero() {
+			s.Due = r.Due.Format(taskmeta.DateLayout)
+		}
+		result.Tasks = append(result.Tasks, s)
+		lines = append(lines, fmt.Sprintf("%d. %s (%.0f điểm)", i+1, r.Title, r.Score))
+	}
+	result.Summary = "🎯 Nên làm tiếp: " + strings.Join(lines, "; ")
+	return result, nil
+}
+
+var _ agent.Tool = (*suggestNextTasksTool)(nil)
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"time"
+
+	"autonomous-task-management/internal/ranking"
+)
+
+const (
+	defaultTimezone = "Asia/Ho_Chi_Minh"
+	defaultLimit    = 3
+	maxLimit        = 10
+	maxTasksScanned = 500
+	maxEventsListed = 50
+
+	// defaultEffortMinutes is assumed for tasks without an estimate.
+	defaultEffortMinutes = 60
+	// maxEffortMinutes is the remaining effort that scores zero on the effort factor.
+	maxEffortMinutes = 8 * 60
+	// quickWinMinutes is the remaining effort below which a task is called out as quick.
+	quickWinMinutes = 30
+	// blockingSaturation is the number of blocked tasks that maxes out the dependency factor.
+	blockingSaturation = 3
+	// urgencyDays is the number of days after which a due date's urgency has halved.
+	urgencyDays = 3.0
+	// soonDays is how far ahead a due date is still mentioned as a reason.
+	soonDays = 7
+	// freeLookahead bounds the free window when no event comes up soon.
+	freeLookahead = 4 * time.Hour
+)
+
+// defaultWeights favours deadlines and priority; the other factors break ties between
+// tasks that are equally urgent.
+var defaultWeights = ranking.Weights{
+	Priority:     3,
+	Urgency:      3,
+	Effort:       1,
+	Dependency:   2,
+	Availability: 1,
+}
+
+// priorityScores maps priority tags onto the priority factor. Tasks without one count as p2.
+var priorityScores = map[string]float64{
+	"p0": 1,
+	"p1": 0.75,
+	"p2": 0.5,
+	"p3": 0.25,
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/gcalendar"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// candidate is a task read for ranking.
+type candidate struct {
+	task model.Task
+	meta taskmeta.Meta
+	done bool
+}
+
+// loadTasks reads every task with its metadata.
+func (uc *implUseCase) loadTasks(ctx context.Context) ([]candidate, error) {
+	var out []candidate
+	for t, err := range uc.repo.IterateTasks(ctx, repository.ListTasksOptions{Limit: maxTasksScanned}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tasks: %w", err)
+		}
+		meta := taskmeta.Parse(t.Content)
+		out = append(out, candidate{
+			task: t,
+			meta: meta,
+			done: meta.Done(),
+		})
+	}
+	return out, nil
+}
+
+// window is the free time from now until the next calendar event.
+type window struct {
+	known bool          // false without a calendar; the availability factor is then left out
+	free  time.Duration // Zero while an event is in progress
+	until time.Time
+	event string // Title of the event that ends the window, empty if none comes up soon
+}
+
+// freeWindow looks up how long the user is free from now on.
+func (uc *implUseCase) freeWindow(ctx context.Context, now time.Time) window {
+	if uc.calendar == nil {
+		return window{}
+	}
+
+	horizon := now.Add(freeLookahead)
+	events, err := uc.calendar.ListEvents(ctx, gcalendar.ListEventsRequest{
+		TimeMin:    now,
+		TimeMax:    horizon,
+		MaxResults: maxEventsListed,
+	})
+	if err != nil {
+		uc.l.Warnf(ctx, "ranking: failed to list calendar events (non-fatal): %v", err)
+		return window{}
+	}
+
+	win := window{known: true, until: horizon}
+	for _, e := range events {
+		// All-day events do not take up working time
+		if !e.EndTime.After(e.StartTime) || e.EndTime.Sub(e.StartTime) >= 24*time.Hour || !e.EndTime.After(now) {
+			continue
+		}
+		if e.StartTime.Before(win.until) {
+			win.until = e.StartTime
+			win.event = e.Summary
+		}
+	}
+	if win.until.Before(now) {
+		win.until = now
+	}
+	win.until = win.until.In(uc.loc)
+	win.free = win.until.Sub(now)
+	return win
+}
+
+// isBlocked reports whether one of the task's blockers is still open.
+// Blockers that no longer exist do not block.
+func isBlocked(meta taskmeta.Meta, open map[string]bool) bool {
+	for _, b := range meta.BlockedBy {
+		if open[taskmeta.NormalizeID(b)] {
+			return true
+		}
+	}
+	return false
+}
+
+func hasProject(meta taskmeta.Meta, project string) bool {
+	for _, p := range meta.Projects {
+		if strings.ToLower(p) == project {
+			return true
+		}
+	}
+	return false
+}
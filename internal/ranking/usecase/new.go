@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"time"
+
+	"autonomous-task-management/internal/ranking"
+	"autonomous-task-management/internal/task/repository"
+	pkgLog "autonomous-task-management/pkg/log"
+)
+
+type implUseCase struct {
+	l        pkgLog.Logger
+	repo     repository.MemosRepository
+	calendar ranking.CalendarClient // optional; nil = the availability factor is left out
+	weights  ranking.Weights
+	loc      *time.Location
+	now      func() time.Time
+}
+
+// New creates a new ranking UseCase instance. Negative weights count as zero; if every
+// weight is zero the defaults are used.
+func New(
+	l pkgLog.Logger,
+	repo repository.MemosRepository,
+	calendar ranking.CalendarClient,
+	cfg ranking.Config,
+) ranking.UseCase {
+	if cfg.Timezone == "" {
+		cfg.Timezone = defaultTimezone
+	}
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	w := cfg.Weights
+	w.Priority = max(w.Priority, 0)
+	w.Urgency = max(w.Urgency, 0)
+	w.Effort = max(w.Effort, 0)
+	w.Dependency = max(w.Dependency, 0)
+	w.Availability = max(w.Availability, 0)
+	if w == (ranking.Weights{}) {
+		w = defaultWeights
+	}
+
+	return &implUseCase{
+		l:        l,
+		repo:     repo,
+		calendar: calendar,
+		weights:  w,
+		loc:      loc,
+		now:      time.Now,
+	}
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/ranking"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// Next ranks the open tasks that are not blocked and returns the best ones with the reasons
+// behind their score. Calendar errors only drop the availability factor.
+func (uc *implUseCase) Next(ctx context.Context, sc model.Scope, input ranking.NextInput) (ranking.NextOutput, error) {
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	limit = min(limit, maxLimit)
+	project := strings.ToLower(strings.TrimSpace(input.Project))
+
+	now := uc.now().In(uc.loc)
+	uc.l.Infof(ctx, "ranking.Next: user=%s limit=%d project=%q", sc.UserID, limit, project)
+
+	tasks, err := uc.loadTasks(ctx)
+	if err != nil {
+		return ranking.NextOutput{}, err
+	}
+
+	// Open tasks by ID, to tell which blockers are still pending
+	open := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		if !t.done {
+			open[taskmeta.NormalizeID(t.task.ID)] = true
+		}
+	}
+	blocking := make(map[string]int)
+	for _, t := range tasks {
+		if t.done {
+			continue
+		}
+		for _, b := range t.meta.BlockedBy {
+			if open[taskmeta.NormalizeID(b)] {
+				blocking[taskmeta.NormalizeID(b)]++
+			}
+		}
+	}
+
+	win := uc.freeWindow(ctx, now)
+
+	out := ranking.NextOutput{FreeUntil: win.until}
+	for _, t := range tasks {
+		if t.done || (project != "" && !hasProject(t.meta, project)) {
+			continue
+		}
+		if isBlocked(t.meta, open) {
+			out.Blocked++
+			continue
+		}
+		out.Tasks = append(out.Tasks, uc.score(t, blocking[taskmeta.NormalizeID(t.task.ID)], now, win))
+	}
+	out.Ranked = len(out.Tasks)
+
+	if out.Ranked == 0 {
+		return out, ranking.ErrNoOpenTasks
+	}
+
+	sort.SliceStable(out.Tasks, func(i, j int) bool {
+		a, b := out.Tasks[i], out.Tasks[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Due.IsZero() != b.Due.IsZero() {
+			return !a.Due.IsZero()
+		}
+		return a.Due.Before(b.Due)
+	})
+	if len(out.Tasks) > limit {
+		out.Tasks = out.Tasks[:limit]
+	}
+	return out, nil
+}
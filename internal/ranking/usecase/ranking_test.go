@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/ranking"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/internal/task/repository/local"
+	"autonomous-task-management/pkg/gcalendar"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Mock implementations
+// ---------------------------------------------------------------------------
+
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Debugf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Info(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Infof(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Warn(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Warnf(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Error(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Errorf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) DPanic(_ context.Context, _ ...any)            {}
+func (m *mockLogger) DPanicf(_ context.Context, _ string, _ ...any) {}
+func (m *mockLogger) Panic(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Panicf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Fatal(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Fatalf(_ context.Context, _ string, _ ...any)  {}
+
+// fakeCalendar returns fixed events.
+type fakeCalendar struct {
+	events []gcalendar.Event
+}
+
+func (c *fakeCalendar) ListEvents(_ context.Context, req gcalendar.ListEventsRequest) ([]gcalendar.Event, error) {
+	var out []gcalendar.Event
+	for _, e := range c.events {
+		if e.EndTime.After(req.TimeMin) && e.StartTime.Before(req.TimeMax) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+var testLoc = time.FixedZone("ICT", 7*3600)
+
+// testNow is Tuesday 10 March 2026, 09:00.
+var testNow = time.Date(2026, 3, 10, 9, 0, 0, 0, testLoc)
+
+// newTask builds a task memo. Empty fields are left out.
+func newTask(title, due, priority string, estimate int, extra ...string) string {
+	lines := []string{"## " + title, ""}
+	if due != "" {
+		lines = append(lines, "- **Due:** "+due)
+	}
+	if estimate > 0 {
+		lines = append(lines, fmt.Sprintf("- **Estimated:** %d min", estimate))
+	}
+	lines = append(lines, extra...)
+	if priority != "" {
+		lines = append(lines, "", "#priority/"+priority)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// newTestRepo stores one task per content in a local Markdown repository and returns their IDs.
+// "{0}", "{1}", ... in a content stand for the IDs of the tasks at those positions.
+func newTestRepo(t *testing.T, contents ...string) (repository.MemosRepository, []string) {
+	t.Helper()
+	repo, err := local.New(t.TempDir(), "", &mockLogger{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ids := make([]string, len(contents))
+	placeholders := make([]string, 0, 2*len(contents))
+	for i := range contents {
+		task, err := repo.CreateTask(ctx, repository.CreateTaskOptions{Content: "## Task"})
+		require.NoError(t, err)
+		ids[i] = task.ID
+		placeholders = append(placeholders, fmt.Sprintf("{%d}", i), task.ID)
+	}
+	r := strings.NewReplacer(placeholders...)
+	for i, content := range contents {
+		require.NoError(t, repo.UpdateTask(ctx, ids[i], r.Replace(content)))
+	}
+	return repo, ids
+}
+
+func newTestUC(repo repository.MemosRepository, calendar ranking.CalendarClient, weights ranking.Weights) *implUseCase {
+	l := &mockLogger{}
+	uc := New(l, repo, calendar, ranking.Config{Timezone: "UTC", Weights: weights}).(*implUseCase)
+	uc.loc = testLoc
+	uc.now = func() time.Time { return testNow }
+	return uc
+}
+
+func titles(tasks []ranking.RankedTask) []string {
+	out := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		out = append(out, t.Title)
+	}
+	return out
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestNext_RanksOpenUnblockedTasks(t *testing.T) {
+	repo, ids := newTestRepo(t,
+		newTask("Overdue report", "2026-03-08", "p2", 60),
+		newTask("Later launch", "2026-03-25", "p0", 60),
+		newTask("Someday idea", "", "p3", 0),
+		newTask("Deploy", "2026-03-10", "p1", 60, "- **Blocked by:** {4}"),
+		newTask("Fix build", "", "p2", 20),
+		newTask("Done already", "2026-03-09", "p0", 30, "#status/done"),
+	)
+	uc := newTestUC(repo, nil, ranking.Weights{})
+
+	out, err := uc.Next(context.Background(), model.Scope{UserID: "u1"}, ranking.NextInput{Limit: 10})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, out.Blocked)
+	assert.Equal(t, 4, out.Ranked)
+	assert.True(t, out.FreeUntil.IsZero())
+	assert.Equal(t, []string{"Overdue report", "Later launch", "Fix build", "Someday idea"}, titles(out.Tasks))
+
+	assert.Equal(t, []string{"quá hạn 2 ngày"}, out.Tasks[0].Reasons)
+	assert.Equal(t, []string{"ưu tiên cao (p0)"}, out.Tasks[1].Reasons)
+	assert.Equal(t, 1, out.Tasks[2].Blocking)
+	assert.Equal(t, []string{"việc nhanh (~20 phút)", "đang chặn 1 task khác"}, out.Tasks[2].Reasons)
+	assert.Empty(t, out.Tasks[3].Reasons)
+	for _, r := range out.Tasks {
+		assert.True(t, r.Score > 0 && r.Score <= 100, "score %v of %s", r.Score, r.Title)
+	}
+
+	// Once the blocker is done, the blocked task is ranked
+	blocker, err := repo.GetTask(context.Background(), ids[4])
+	require.NoError(t, err)
+	require.NoError(t, repo.UpdateTask(context.Background(), ids[4], blocker.Content+" #status/done"))
+	out, err = uc.Next(context.Background(), model.Scope{UserID: "u1"}, ranking.NextInput{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 0, out.Blocked)
+	assert.Equal(t, []string{"Deploy"}, titles(out.Tasks))
+}
+
+func TestNext_WeightsChangeTheOrder(t *testing.T) {
+	repo, _ := newTestRepo(t,
+		newTask("Overdue chore", "2026-03-01", "p3", 60),
+		newTask("Important plan", "2026-04-30", "p0", 60),
+	)
+
+	out, err := newTestUC(repo, nil, ranking.Weights{}).Next(context.Background(), model.Scope{}, ranking.NextInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "Overdue chore", out.Tasks[0].Title)
+
+	out, err = newTestUC(repo, nil, ranking.Weights{Priority: 1}).Next(context.Background(), model.Scope{}, ranking.NextInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "Important plan", out.Tasks[0].Title)
+	assert.Equal(t, 100.0, out.Tasks[0].Score)
+}
+
+func TestNext_PrefersTasksThatFitBeforeTheNextEvent(t *testing.T) {
+	repo, ids := newTestRepo(t,
+		newTask("Long review", "2026-03-12", "p1", 120),
+		newTask("Short reply", "2026-03-12", "p1", 40),
+		newTask("Booked work", "2026-03-13", "p2", 90,
+			"- **Scheduled:** 2026-03-10 08:30 +07:00 · 90 min · event blk1"),
+	)
+	calendar := &fakeCalendar{events: []gcalendar.Event{
+		{ID: "blk1", Summary: "Booked work", StartTime: testNow.Add(-30 * time.Minute), EndTime: testNow.Add(time.Hour)},
+		{ID: "allday", Summary: "Holiday", StartTime: time.Date(2026, 3, 10, 0, 0, 0, 0, testLoc), EndTime: time.Date(2026, 3, 11, 0, 0, 0, 0, testLoc)},
+	}}
+
+	// The block booked for t3 is in progress, so nothing else fits right now
+	out, err := newTestUC(repo, calendar, ranking.Weights{Availability: 1}).Next(context.Background(), model.Scope{}, ranking.NextInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "Booked work", out.Tasks[0].Title)
+	assert.Equal(t, []string{"đang trong khung giờ đã xếp cho task này"}, out.Tasks[0].Reasons)
+	assert.True(t, out.FreeUntil.Equal(testNow))
+
+	// With a meeting in 45 minutes the short task fits and the long one does not
+	calendar.events = []gcalendar.Event{
+		{ID: "m1", Summary: "Standup", StartTime: testNow.Add(45 * time.Minute), EndTime: testNow.Add(time.Hour)},
+	}
+	require.NoError(t, repo.DeleteTask(context.Background(), ids[2]))
+	out, err = newTestUC(repo, calendar, ranking.Weights{}).Next(context.Background(), model.Scope{}, ranking.NextInput{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Short reply", "Long review"}, titles(out.Tasks))
+	assert.Contains(t, out.Tasks[0].Reasons, "làm kịp trước Standup (09:45)")
+	assert.Equal(t, 1.0, out.Tasks[0].Factors.Availability)
+	assert.InDelta(t, 45.0/120, out.Tasks[1].Factors.Availability, 0.001)
+}
+
+func TestNext_NoOpenTasks(t *testing.T) {
+	repo, _ := newTestRepo(t,
+		newTask("Blocked", "", "p1", 0, "- **Blocked by:** {1}"),
+		newTask("Blocker", "", "p1", 0, "- **Blocked by:** {0}"),
+		newTask("Other project", "", "p1", 0),
+	)
+
+	out, err := newTestUC(repo, nil, ranking.Weights{}).Next(context.Background(), model.Scope{}, ranking.NextInput{Project: "smap"})
+	assert.ErrorIs(t, err, ranking.ErrNoOpenTasks)
+	assert.Equal(t, 0, out.Blocked)
+
+	out, err = newTestUC(repo, nil, ranking.Weights{}).Next(context.Background(), model.Scope{}, ranking.NextInput{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, out.Blocked)
+	assert.Equal(t, []string{"Other project"}, titles(out.Tasks))
+}
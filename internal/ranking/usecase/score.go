@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/ranking"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// reason is an explanation of a factor, weighted by how much the factor added to the score.
+type reason struct {
+	weight float64
+	text   string
+}
+
+// score computes a task's factors, its 0-100 score and the reasons for it, most important first.
+func (uc *implUseCase) score(c candidate, blocking int, now time.Time, win window) ranking.RankedTask {
+	w := uc.weights
+	var f ranking.Factors
+	var reasons []reason
+
+	// Priority
+	priority := strings.ToLower(c.meta.Priority)
+	p, ok := priorityScores[priority]
+	if !ok {
+		p = priorityScores["p2"]
+	}
+	f.Priority = p
+	if priority == "p0" || priority == "p1" {
+		reasons = append(reasons, reason{w.Priority * p, fmt.Sprintf("ưu tiên cao (%s)", priority)})
+	}
+
+	// Urgency
+	var due time.Time
+	if c.meta.HasDue {
+		due = c.meta.DueIn(uc.loc)
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, uc.loc)
+		days := int(math.Round(due.Sub(today).Hours() / 24))
+		var text string
+		switch {
+		case days < 0:
+			f.Urgency = 1
+			text = fmt.Sprintf("quá hạn %d ngày", -days)
+		default:
+			f.Urgency = 0.8 / (1 + float64(days)/urgencyDays)
+			switch {
+			case days == 0:
+				text = "đến hạn hôm nay"
+			case days == 1:
+				text = "đến hạn ngày mai"
+			case days <= soonDays:
+				text = fmt.Sprintf("đến hạn trong %d ngày", days)
+			}
+		}
+		if text != "" {
+			reasons = append(reasons, reason{w.Urgency * f.Urgency, text})
+		}
+	}
+
+	// Effort: quick tasks first. Tasks without an estimate are neutral.
+	remaining := 0
+	need := time.Duration(defaultEffortMinutes) * time.Minute
+	f.Effort = 0.5
+	if c.meta.EstimatedMinutes > 0 {
+		remaining = max(c.meta.EstimatedMinutes-c.meta.SpentMinutes, 0)
+		need = time.Duration(remaining) * time.Minute
+		f.Effort = 1 - float64(min(remaining, maxEffortMinutes))/maxEffortMinutes
+		if remaining <= quickWinMinutes {
+			reasons = append(reasons, reason{w.Effort * f.Effort, fmt.Sprintf("việc nhanh (~%d phút)", max(remaining, 1))})
+		}
+	}
+
+	// Dependency: unblocking other tasks
+	if blocking > 0 {
+		f.Dependency = float64(min(blocking, blockingSaturation)) / blockingSaturation
+		reasons = append(reasons, reason{w.Dependency * f.Dependency, fmt.Sprintf("đang chặn %d task khác", blocking)})
+	}
+
+	// Availability: fits in the free time before the next event
+	total := w.Priority + w.Urgency + w.Effort + w.Dependency
+	if win.known {
+		total += w.Availability
+		block, booked := taskmeta.ScheduledBlock(c.task.Content)
+		switch {
+		case booked && !block.Start.After(now) && block.End().After(now):
+			f.Availability = 1
+			reasons = append(reasons, reason{w.Availability, "đang trong khung giờ đã xếp cho task này"})
+		case win.free <= 0:
+			f.Availability = 0
+		case need <= win.free:
+			f.Availability = 1
+			if win.event != "" {
+				reasons = append(reasons, reason{w.Availability, fmt.Sprintf("làm kịp trước %s (%s)", win.event, win.until.Format("15:04"))})
+			}
+		default:
+			f.Availability = float64(win.free) / float64(need)
+		}
+	}
+
+	score := 0.0
+	if total > 0 {
+		sum := w.Priority*f.Priority + w.Urgency*f.Urgency + w.Effort*f.Effort +
+			w.Dependency*f.Dependency + w.Availability*f.Availability
+		score = math.Round(1000*sum/total) / 10
+	}
+
+	sort.SliceStable(reasons, func(i, j int) bool { return reasons[i].weight > reasons[j].weight })
+	texts := make([]string, 0, len(reasons))
+	for _, r := range reasons {
+		// Factors switched off in the config are not given as reasons
+		if r.weight > 0 {
+			texts = append(texts, r.text)
+		}
+	}
+
+	title := c.meta.Title
+	if title == "" {
+		title = c.task.ID
+	}
+	return ranking.RankedTask{
+		TaskID:           c.task.ID,
+		Title:            title,
+		MemoURL:          c.task.MemoURL,
+		Priority:         c.meta.Priority,
+		Due:              due,
+		RemainingMinutes: remaining,
+		Blocking:         blocking,
+		Score:            score,
+		Factors:          f,
+		Reasons:          texts,
+	}
+}
@@ -0,0 +1,13 @@
+package schedule
+
+import "errors"
+
+var (
+	ErrNoCalendar      = errors.New("schedule: calendar is not configured")
+	ErrNoFreeSlot      = errors.New("schedule: no free slot within the scheduling horizon")
+	ErrInvalidStart    = errors.New("schedule: invalid start time, expected YYYY-MM-DD HH:MM")
+	ErrSlotUnavailable = errors.New("schedule: slot is outside working hours or overlaps another event")
+	ErrTaskRequired    = errors.New("schedule: task ID is required")
+	ErrTaskDone        = errors.New("schedule: task is already done")
+	ErrInvalidDuration = errors.New("schedule: block duration must be positive and fit in a working day")
+)
@@ -0,0 +1,39 @@
+package schedule
+
+import (
+	"context"
+
+	"autonomous-task-management/internal/agent"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/gcalendar"
+)
+
+// CalendarClient is the subset of the Google Calendar API the scheduler needs.
+type CalendarClient interface {
+	CreateEvent(ctx context.Context, req gcalendar.CreateEventRequest) (*gcalendar.Event, error)
+	ListEvents(ctx context.Context, req gcalendar.ListEventsRequest) ([]gcalendar.Event, error)
+	DeleteEvent(ctx context.Context, calendarID, eventID string) error
+}
+
+// Notifier reports automatic reschedules to a chat (satisfied by telegram.IBot).
+type Notifier interface {
+	SendMessageWithMode(chatID int64, text string, parseMode string) error
+}
+
+// UseCase defines the business logic interface for the schedule domain.
+// A booked work block is a calendar event recorded on its task as a "- **Scheduled:**" line.
+type UseCase interface {
+	// FindSlots proposes free slots inside working hours that avoid calendar events
+	// and the blocks booked for other tasks, earliest first.
+	FindSlots(ctx context.Context, sc model.Scope, input FindInput) (FindOutput, error)
+
+	// Book books a work block for a task, in the given slot or the best free one,
+	// replacing the block the task already had.
+	Book(ctx context.Context, sc model.Scope, input BookInput) (BookOutput, error)
+
+	// RescheduleMissed books a new block for every unfinished task whose block is over.
+	RescheduleMissed(ctx context.Context, sc model.Scope) (RescheduleOutput, error)
+
+	// RegisterAgentTools registers this domain's agent tools into the registry.
+	RegisterAgentTools(registry *agent.ToolRegistry)
+}
@@ -0,0 +1,69 @@
+package schedule
+
+import "time"
+
+// Config holds the working hours the scheduler books into.
+type Config struct {
+	Timezone  string        // IANA timezone of the working hours
+	WorkStart string        // "HH:MM" start of the working day
+	WorkEnd   string        // "HH:MM" end of the working day
+	WorkDays  []string      // Weekday names, e.g. "monday" or "mon"
+	Horizon   time.Duration // How far ahead to look for free time
+	Buffer    time.Duration // Gap kept free around existing events
+	ChatIDs   []int64       // Chats told about automatic reschedules
+}
+
+// Slot is a free period long enough for a work block.
+type Slot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Late  bool      `json:"late"` // Ends after the task's deadline
+}
+
+// FindInput describes the block to fit. With TaskID set, Duration and Deadline default to
+// the task's estimate and due date.
+type FindInput struct {
+	TaskID   string
+	Duration time.Duration
+	Deadline time.Time
+	Limit    int // 0 = default
+}
+
+// FindOutput is the proposed slots for a block.
+type FindOutput struct {
+	Title    string
+	Duration time.Duration
+	Deadline time.Time // Zero if the block has no deadline
+	Slots    []Slot
+}
+
+// BookInput books a block for a task.
+type BookInput struct {
+	TaskID   string
+	At       string        // "YYYY-MM-DD HH:MM" in the scheduler's timezone, or RFC3339; empty = best free slot
+	Duration time.Duration // Default: the task's estimate
+}
+
+// BookOutput is the booked block.
+type BookOutput struct {
+	TaskID    string
+	Title     string
+	Slot      Slot
+	EventID   string
+	EventLink string
+	Replaced  bool // The task's previous block was removed; an already ended block is kept
+}
+
+// Moved is a block that was rebooked because its task was not finished in time.
+type Moved struct {
+	TaskID string
+	Title  string
+	From   Slot
+	To     Slot
+}
+
+// RescheduleOutput is the result of one reschedule pass.
+type RescheduleOutput struct {
+	Moved  []Moved
+	Failed []string // IDs of tasks no new slot could be booked for
+}
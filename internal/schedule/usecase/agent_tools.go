@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/agent"
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/schedule"
+	pkgLog "autonomous-task-management/pkg/log"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// RegisterAgentTools registers the schedule domain's agent tools into the registry.
+func (uc *implUseCase) RegisterAgentTools(registry *agent.ToolRegistry) {
+	if uc.calendar == nil {
+		return
+	}
+	registry.Register(&findFreeSlotsTool{uc: uc, l: uc.l})
+	registry.Register(&scheduleTaskTool{uc: uc, l: uc.l})
+}
+
+func decodeInput(input map[string]interface{}, params interface{}) error {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to marshal input: %w", err)
+	}
+	if err := json.Unmarshal(inputBytes, params); err != nil {
+		return fmt.Errorf("failed to parse input: %w", err)
+	}
+	return nil
+}
+
+// findFreeSlotsTool proposes free calendar slots for a task or a duration.
+type findFreeSlotsTool struct {
+	uc *implUseCase
+	l  pkgLog.Logger
+}
+
+type findFreeSlotsInput struct {
+	TaskID          string `json:"task_id"`
+	DurationMinutes int    `json:"duration_minutes"`
+	Deadline        string `json:"deadline"`
+}
+
+type slotOutput struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Late  bool   `json:"late"`
+}
+
+type findFreeSlotsOutput struct {
+	Title           string       `json:"title,omitempty"`
+	DurationMinutes int          `json:"duration_minutes"`
+	Slots           []slotOutput `json:"slots"`
+	Summary         string       `json:"summary"`
+}
+
+func (t *findFreeSlotsTool) Name() string { return "find_free_slots" }
+
+func (t *findFreeSlotsTool) Description() string {
+	return "Find free time slots in working hours for a task (uses its estimate and due date) or for a given duration. " +
+		"Slots avoid calendar events and time already booked for other tasks, earliest first."
+}
+
+func (t *findFreeSlotsTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"task_id": map[string]interface{}{
+				"type":        "string",
+				"description": "Memos task ID (UID). Optional if duration_minutes is given.",
+			},
+			"duration_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Length of the block in minutes (default: the task's estimate, or 60)",
+			},
+			"deadline": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional deadline date YYYY-MM-DD (default: the task's due date)",
+			},
+		},
+	}
+}
+
+func (t *findFreeSlotsTool) Execute(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var params findFreeSlotsInput
+	if err := decodeInput(input, &params); err != nil {
+		return nil, err
+	}
+
+	t.l.Infof(ctx, "find_free_slots: task_id=%s duration=%d deadline=%s", params.TaskID, params.DurationMinutes, params.Deadline)
+
+	findInput := schedule.FindInput{
+		TaskID:   params.TaskID,
+		Duration: time.Duration(params.DurationMinutes) * time.Minute,
+	}
+	if params.Deadline != "" {
+		day, err := time.ParseInLocation(taskmeta.DateLayout, params.Deadline, t.uc.loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deadline %q, expected YYYY-MM-DD", params.Deadline)
+		}
+		findInput.Deadline = day.Add(t.uc.workEnd)
+	}
+
+	out, err := t.uc.FindSlots(ctx, agentScope(ctx), findInput)
+	if errors.Is(err, schedule.ErrNoFreeSlot) {
+		return findFreeSlotsOutput{Title: out.Title, Summary: "Không còn khoảng trống nào trong giờ làm việc sắp tới"}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := findFreeSlotsOutput{Title: out.Title, DurationMinutes: int(out.Duration / time.Minute)}
+	lines := make([]string, 0, len(out.Slots))
+	for _, s := range out.Slots {
+		result.Slots = append(result.Slots, slotOutput{Start: s.Start.Format(time.RFC3339), End: s.End.Format(time.RFC3339), Late: s.Late})
+		lines = append(lines, formatSlot(s))
+	}
+	result.Summary = "🗓 Khoảng trống: " + strings.Join(lines, "; ")
+	return result, nil
+}
+
+var _ agent.Tool = (*findFreeSlotsTool)(nil)
+
+// scheduleTaskTool books a work block for a task.
+type scheduleTaskTool struct {
+	uc *implUseCase
+	l  pkgLog.Logger
+}
+
+type scheduleTaskInput struct {
+	TaskID string `json:"task_id"`
+	Start  string `json:"start"`
+}
+
+type scheduleTaskOutput struct {
+	TaskID    string `json:"task_id"`
+	Start     string `json:"start"`
+	End       string `json:"end"`
+	Late      bool   `json:"late"`
+	EventLink string `json:"event_link,omitempty"`
+	Summary   string `json:"summary"`
+}
+
+func (t *scheduleTaskTool) Name() string { return "schedule_task" }
+
+func (t *scheduleTaskTool) Description() string {
+	return "Book a calendar block to work on a task, at the given start time or in the best free slot before its deadline. " +
+		"Replaces the block the task already had."
+}
+
+func (t *scheduleTaskTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"task_id": map[string]interface{}{
+				"type":        "string",
+				"description": "Memos task ID (UID)",
+			},
+			"start": map[string]interface{}{
+				"type":        "string",
+				"description": "Optional start time \"YYYY-MM-DD HH:MM\" (local) or RFC3339, e.g. a slot from find_free_slots",
+			},
+		},
+		"required": []string{"task_id"},
+	}
+}
+
+func (t *scheduleTaskTool) Execute(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	var params scheduleTaskInput
+	if err := decodeInput(input, &params); err != nil {
+		return nil, err
+	}
+
+	t.l.Infof(ctx, "schedule_task: task_id=%s start=%s", params.TaskID, params.Start)
+
+	ctx = history.WithActor(ctx, history.ActorAgent)
+	out, err := t.uc.Book(ctx, agentScope(ctx), schedule.BookInput{TaskID: params.TaskID, At: params.Start})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := fmt.Sprintf("✅ Đã xếp lịch \"%s\": %s", out.Title, formatSlot(out.Slot))
+	return scheduleTaskOutput{
+		TaskID:    out.TaskID,
+		Start:     out.Slot.Start.Format(time.RFC3339),
+		End:       out.Slot.End.Format(time.RFC3339),
+		Late:      out.Slot.Late,
+		EventLink: out.EventLink,
+		Summary:   summary,
+	}, nil
+}
+
+var _ agent.Tool = (*scheduleTaskTool)(nil)
+
+// agentScope returns the scope of the user the agent acts for.
+func agentScope(ctx context.Context) model.Scope {
+	_, userID := history.ActorFrom(ctx)
+	return model.Scope{UserID: userID}
+}
+
+// formatSlot renders a slot as "14:00–15:30 10/03", flagging slots past the deadline.
+func formatSlot(s schedule.Slot) string {
+	text := fmt.Sprintf("%s–%s %s", s.Start.Format("15:04"), s.End.Format("15:04"), s.Start.Format("02/01"))
+	if s.Late {
+		text += " (sau hạn)"
+	}
+	return text
+}
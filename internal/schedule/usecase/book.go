@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/schedule"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/gcalendar"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// Book books a work block for a task and records it on the task. The task's previous block
+// is excluded from the busy time, so rebooking can reuse or move it, and its event is deleted
+// unless the block already ended: past events stay on the calendar as a record.
+func (uc *implUseCase) Book(ctx context.Context, sc model.Scope, input schedule.BookInput) (schedule.BookOutput, error) {
+	if uc.calendar == nil {
+		return schedule.BookOutput{}, schedule.ErrNoCalendar
+	}
+	if strings.TrimSpace(input.TaskID) == "" {
+		return schedule.BookOutput{}, schedule.ErrTaskRequired
+	}
+
+	var start time.Time
+	if at := strings.TrimSpace(input.At); at != "" {
+		var err error
+		if start, err = uc.parseStart(at); err != nil {
+			return schedule.BookOutput{}, err
+		}
+	}
+
+	spec, err := uc.spec(ctx, input.TaskID, input.Duration, time.Time{})
+	if err != nil {
+		return schedule.BookOutput{}, err
+	}
+
+	now := uc.now()
+	until := now.Add(uc.cfg.Horizon)
+	if !start.IsZero() && start.After(until) {
+		until = start.Add(spec.duration)
+	}
+	busy, err := uc.busy(ctx, now, until, spec)
+	if err != nil {
+		return schedule.BookOutput{}, err
+	}
+
+	var slot schedule.Slot
+	if start.IsZero() {
+		slots := uc.freeSlots(now, until, spec.duration, spec.deadline, busy, 1)
+		if len(slots) == 0 {
+			return schedule.BookOutput{}, schedule.ErrNoFreeSlot
+		}
+		slot = slots[0]
+	} else {
+		if start.Before(now) || !uc.fits(start, spec.duration, busy) {
+			return schedule.BookOutput{}, schedule.ErrSlotUnavailable
+		}
+		slot = schedule.Slot{Start: start.In(uc.loc), End: start.In(uc.loc).Add(spec.duration)}
+		slot.Late = !spec.deadline.IsZero() && slot.End.After(spec.deadline)
+	}
+
+	description := "🗓 Thời gian làm task"
+	if spec.task.MemoURL != "" {
+		description += fmt.Sprintf("\n\n📝 Memos: %s", spec.task.MemoURL)
+	}
+	event, err := uc.calendar.CreateEvent(ctx, gcalendar.CreateEventRequest{
+		CalendarID:  calendarID,
+		Summary:     spec.title,
+		Description: strings.TrimSpace(description),
+		StartTime:   slot.Start,
+		EndTime:     slot.End,
+		Timezone:    uc.cfg.Timezone,
+	})
+	if err != nil {
+		return schedule.BookOutput{}, fmt.Errorf("failed to create calendar event: %w", err)
+	}
+
+	block := taskmeta.Block{Start: slot.Start, Minutes: int(spec.duration / time.Minute), EventID: event.ID}
+	_, err = repository.ModifyTask(history.WithAction(ctx, history.ActionReschedule), uc.repo, spec.task.ID, func(current model.Task) (string, error) {
+		return taskmeta.SetField(current.Content, taskmeta.FieldScheduled, taskmeta.FormatBlock(block)), nil
+	})
+	if err != nil {
+		// An event the task does not point to could never be moved or cleaned up
+		if delErr := uc.calendar.DeleteEvent(ctx, calendarID, event.ID); delErr != nil {
+			uc.l.Warnf(ctx, "schedule.Book: failed to delete orphaned event %s: %v", event.ID, delErr)
+		}
+		return schedule.BookOutput{}, fmt.Errorf("failed to record block on task: %w", err)
+	}
+
+	out := schedule.BookOutput{
+		TaskID:    spec.task.ID,
+		Title:     spec.title,
+		Slot:      slot,
+		EventID:   event.ID,
+		EventLink: event.HtmlLink,
+	}
+	if spec.hasBlock && spec.current.EventID != "" && spec.current.EventID != event.ID && spec.current.End().After(now) {
+		out.Replaced = true
+		if err := uc.calendar.DeleteEvent(ctx, calendarID, spec.current.EventID); err != nil {
+			uc.l.Warnf(ctx, "schedule.Book: failed to delete previous event %s: %v", spec.current.EventID, err)
+		}
+	}
+
+	uc.l.Infof(ctx, "schedule.Book: user=%s task=%s start=%s duration=%s late=%v replaced=%v",
+		sc.UserID, spec.task.ID, slot.Start.Format(time.RFC3339), spec.duration, slot.Late, out.Replaced)
+	return out, nil
+}
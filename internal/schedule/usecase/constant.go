@@ -0,0 +1,40 @@
+package usecase
+
+import "time"
+
+const (
+	defaultTimezone  = "Asia/Ho_Chi_Minh"
+	defaultWorkStart = "09:00"
+	defaultWorkEnd   = "18:00"
+	defaultHorizon   = 14 * 24 * time.Hour
+	defaultBlock     = time.Hour // Block length for tasks without an estimate
+
+	// calendarID matches the calendar the task domain books events in.
+	calendarID = "primary"
+	// slotStep aligns proposed start times to quarter hours.
+	slotStep = 15 * time.Minute
+	// missedLookback bounds how old a missed block may be and still be rebooked,
+	// so abandoned tasks do not flood the calendar.
+	missedLookback = 7 * 24 * time.Hour
+
+	defaultSlotLimit = 3
+	maxSlotLimit     = 10
+	maxTasksScanned  = 500
+	maxEventsListed  = 250
+
+	clockLayout = "15:04"
+	startLayout = "2006-01-02 15:04"
+)
+
+var defaultWorkDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+
+// weekdays accepts full and three-letter weekday names.
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday,
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/schedule"
+)
+
+// FindSlots proposes free slots for a block, earliest first. Slots that end after the
+// deadline are still returned (marked Late) when nothing earlier is free.
+func (uc *implUseCase) FindSlots(ctx context.Context, sc model.Scope, input schedule.FindInput) (schedule.FindOutput, error) {
+	if uc.calendar == nil {
+		return schedule.FindOutput{}, schedule.ErrNoCalendar
+	}
+
+	spec, err := uc.spec(ctx, input.TaskID, input.Duration, input.Deadline)
+	if err != nil {
+		return schedule.FindOutput{}, err
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultSlotLimit
+	}
+	limit = min(limit, maxSlotLimit)
+
+	now := uc.now()
+	until := now.Add(uc.cfg.Horizon)
+	busy, err := uc.busy(ctx, now, until, spec)
+	if err != nil {
+		return schedule.FindOutput{}, err
+	}
+
+	out := schedule.FindOutput{
+		Title:    spec.title,
+		Duration: spec.duration,
+		Deadline: spec.deadline,
+		Slots:    uc.freeSlots(now, until, spec.duration, spec.deadline, busy, limit),
+	}
+
+	uc.l.Infof(ctx, "schedule.FindSlots: user=%s task=%s duration=%s slots=%d",
+		sc.UserID, input.TaskID, spec.duration, len(out.Slots))
+
+	if len(out.Slots) == 0 {
+		return out, schedule.ErrNoFreeSlot
+	}
+	return out, nil
+}
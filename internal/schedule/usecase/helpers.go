@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/schedule"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/gcalendar"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// interval is a busy period on the calendar.
+type interval struct {
+	start time.Time
+	end   time.Time
+}
+
+// blockSpec is what a block has to fit: its length, deadline and the task it belongs to.
+type blockSpec struct {
+	task     model.Task // Zero when the block is not for a stored task
+	title    string
+	duration time.Duration
+	deadline time.Time
+	current  taskmeta.Block // The task's booked block, if any
+	hasBlock bool
+}
+
+// parseClock parses "HH:MM" into an offset from midnight.
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse(clockLayout, strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// parseStart parses a requested block start in the scheduler's timezone.
+func (uc *implUseCase) parseStart(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.In(uc.loc), nil
+	}
+	t, err := time.ParseInLocation(startLayout, value, uc.loc)
+	if err != nil {
+		return time.Time{}, schedule.ErrInvalidStart
+	}
+	return t, nil
+}
+
+// spec resolves the block for a task, or for a bare duration when taskID is empty.
+// The deadline of a task due on a date is the end of that working day.
+func (uc *implUseCase) spec(ctx context.Context, taskID string, duration time.Duration, deadline time.Time) (blockSpec, error) {
+	s := blockSpec{duration: duration, deadline: deadline}
+	if taskID != "" {
+		t, err := uc.repo.GetTask(ctx, taskID)
+		if err != nil {
+			return blockSpec{}, fmt.Errorf("failed to fetch task: %w", err)
+		}
+		meta := taskmeta.Parse(t.Content)
+		if meta.Done() {
+			return blockSpec{}, schedule.ErrTaskDone
+		}
+
+		s.task = t
+		s.title = meta.Title
+		if s.duration <= 0 && meta.EstimatedMinutes > 0 {
+			s.duration = time.Duration(meta.EstimatedMinutes) * time.Minute
+		}
+		if s.deadline.IsZero() && meta.HasDue {
+			s.deadline = meta.DueIn(uc.loc).Add(uc.workEnd)
+		}
+		s.current, s.hasBlock = taskmeta.ScheduledBlock(t.Content)
+	}
+
+	if s.duration <= 0 {
+		s.duration = defaultBlock
+	}
+	if s.duration > uc.workEnd-uc.workStart {
+		return blockSpec{}, schedule.ErrInvalidDuration
+	}
+	return s, nil
+}
+
+// busy returns the calendar events and the blocks booked for other open tasks between from and to,
+// widened by the configured buffer. The block of excludeTaskID is left out so it can be moved.
+func (uc *implUseCase) busy(ctx context.Context, from, to time.Time, exclude blockSpec) ([]interval, error) {
+	events, err := uc.calendar.ListEvents(ctx, gcalendar.ListEventsRequest{
+		CalendarID: calendarID,
+		TimeMin:    from,
+		TimeMax:    to,
+		MaxResults: maxEventsListed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calendar events: %w", err)
+	}
+
+	var out []interval
+	for _, e := range events {
+		if exclude.hasBlock && e.ID != "" && e.ID == exclude.current.EventID {
+			continue
+		}
+		if e.EndTime.IsZero() || !e.EndTime.After(e.StartTime) {
+			continue
+		}
+		out = append(out, uc.pad(e.StartTime, e.EndTime))
+	}
+
+	// Blocks recorded on tasks also count, in case their event was not created or listed
+	for t, err := range uc.repo.IterateTasks(ctx, repository.ListTasksOptions{Limit: maxTasksScanned}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tasks: %w", err)
+		}
+		if exclude.task.ID != "" && taskmeta.SameID(t.ID, exclude.task.ID) {
+			continue
+		}
+		block, ok := taskmeta.ScheduledBlock(t.Content)
+		if !ok || !block.End().After(from) || !block.Start.Before(to) {
+			continue
+		}
+		if taskmeta.IsDone(t.Content) {
+			continue
+		}
+		out = append(out, uc.pad(block.Start, block.End()))
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].start.Before(out[j].start) })
+	return out, nil
+}
+
+// pad widens a busy period by the configured buffer.
+func (uc *implUseCase) pad(start, end time.Time) interval {
+	return interval{start: start.Add(-uc.cfg.Buffer), end: end.Add(uc.cfg.Buffer)}
+}
+
+// freeSlots walks the working hours from from until until and returns up to limit slots of
+// length d that avoid busy, at most one per free gap so the proposals are spread out.
+func (uc *implUseCase) freeSlots(from, until time.Time, d time.Duration, deadline time.Time, busy []interval, limit int) []schedule.Slot {
+	var slots []schedule.Slot
+	from = from.In(uc.loc)
+
+	for day := startOfDay(from); day.Before(until) && len(slots) < limit; day = day.AddDate(0, 0, 1) {
+		if !uc.workDays[day.Weekday()] {
+			continue
+		}
+		dayStart, dayEnd := day.Add(uc.workStart), day.Add(uc.workEnd)
+
+		cursor := alignUp(later(dayStart, from), day)
+		for len(slots) < limit && !cursor.Add(d).After(dayEnd) {
+			if b, overlaps := overlapping(busy, cursor, cursor.Add(d)); overlaps {
+				cursor = alignUp(b.end, day)
+				continue
+			}
+
+			slot := schedule.Slot{Start: cursor.In(uc.loc), End: cursor.Add(d).In(uc.loc)}
+			slot.Late = !deadline.IsZero() && slot.End.After(deadline)
+			slots = append(slots, slot)
+
+			cursor = alignUp(nextBusyEnd(busy, slot.End, dayEnd), day)
+		}
+	}
+	return slots
+}
+
+// fits reports whether [start, start+d) lies inside one working day and avoids busy.
+func (uc *implUseCase) fits(start time.Time, d time.Duration, busy []interval) bool {
+	start = start.In(uc.loc)
+	day := startOfDay(start)
+	if !uc.workDays[day.Weekday()] {
+		return false
+	}
+	if start.Before(day.Add(uc.workStart)) || start.Add(d).After(day.Add(uc.workEnd)) {
+		return false
+	}
+	_, overlaps := overlapping(busy, start, start.Add(d))
+	return !overlaps
+}
+
+// overlapping returns the first busy period overlapping [start, end).
+func overlapping(busy []interval, start, end time.Time) (interval, bool) {
+	for _, b := range busy {
+		if b.start.Before(end) && b.end.After(start) {
+			return b, true
+		}
+	}
+	return interval{}, false
+}
+
+// nextBusyEnd returns the end of the first busy period starting at or after t, or limit.
+func nextBusyEnd(busy []interval, t, limit time.Time) time.Time {
+	for _, b := range busy {
+		if !b.start.Before(t) && b.start.Before(limit) {
+			return b.end
+		}
+	}
+	return limit
+}
+
+// alignUp rounds t up to the next slotStep boundary counted from day.
+func alignUp(t, day time.Time) time.Time {
+	if rem := t.Sub(day) % slotStep; rem != 0 {
+		return t.Add(slotStep - rem)
+	}
+	return t
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// startOfDay returns local midnight of t.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
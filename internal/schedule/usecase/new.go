@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/schedule"
+	"autonomous-task-management/internal/task/repository"
+	pkgLog "autonomous-task-management/pkg/log"
+)
+
+type implUseCase struct {
+	l         pkgLog.Logger
+	repo      repository.MemosRepository
+	calendar  schedule.CalendarClient // nil = FindSlots and Book return ErrNoCalendar
+	notifier  schedule.Notifier       // optional; nil = reschedules are not announced
+	cfg       schedule.Config
+	loc       *time.Location
+	workStart time.Duration // Offset of the working day's start from midnight
+	workEnd   time.Duration
+	workDays  map[time.Weekday]bool
+	now       func() time.Time
+}
+
+// New creates a new schedule UseCase instance. Invalid or missing working hours fall back
+// to 09:00-18:00, Monday to Friday.
+func New(
+	l pkgLog.Logger,
+	repo repository.MemosRepository,
+	calendar schedule.CalendarClient,
+	notifier schedule.Notifier,
+	cfg schedule.Config,
+) schedule.UseCase {
+	if cfg.Timezone == "" {
+		cfg.Timezone = defaultTimezone
+	}
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	workStart, errStart := parseClock(cfg.WorkStart)
+	workEnd, errEnd := parseClock(cfg.WorkEnd)
+	if errStart != nil || errEnd != nil || workEnd <= workStart {
+		workStart, _ = parseClock(defaultWorkStart)
+		workEnd, _ = parseClock(defaultWorkEnd)
+	}
+
+	workDays := make(map[time.Weekday]bool)
+	for _, name := range cfg.WorkDays {
+		if day, ok := weekdays[strings.ToLower(strings.TrimSpace(name))]; ok {
+			workDays[day] = true
+		}
+	}
+	if len(workDays) == 0 {
+		for _, name := range defaultWorkDays {
+			workDays[weekdays[name]] = true
+		}
+	}
+
+	if cfg.Horizon <= 0 {
+		cfg.Horizon = defaultHorizon
+	}
+	if cfg.Buffer < 0 {
+		cfg.Buffer = 0
+	}
+
+	return &implUseCase{
+		l:         l,
+		repo:      repo,
+		calendar:  calendar,
+		notifier:  notifier,
+		cfg:       cfg,
+		loc:       loc,
+		workStart: workStart,
+		workEnd:   workEnd,
+		workDays:  workDays,
+		now:       time.Now,
+	}
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/schedule"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/taskmeta"
+	"autonomous-task-management/pkg/telegram"
+)
+
+// RescheduleMissed books a new block for every unfinished task whose block ended within the
+// last week, keeping the block's length. Moves are announced to the configured chats.
+func (uc *implUseCase) RescheduleMissed(ctx context.Context, sc model.Scope) (schedule.RescheduleOutput, error) {
+	if uc.calendar == nil {
+		return schedule.RescheduleOutput{}, schedule.ErrNoCalendar
+	}
+
+	now := uc.now()
+	type missed struct {
+		id    string
+		block taskmeta.Block
+	}
+	var candidates []missed
+	for t, err := range uc.repo.IterateTasks(ctx, repository.ListTasksOptions{Limit: maxTasksScanned}) {
+		if err != nil {
+			return schedule.RescheduleOutput{}, fmt.Errorf("failed to list tasks: %w", err)
+		}
+		block, ok := taskmeta.ScheduledBlock(t.Content)
+		if !ok || block.End().After(now) || block.End().Before(now.Add(-missedLookback)) {
+			continue
+		}
+		if taskmeta.IsDone(t.Content) {
+			continue
+		}
+		candidates = append(candidates, missed{id: t.ID, block: block})
+	}
+
+	var out schedule.RescheduleOutput
+	for _, c := range candidates {
+		booked, err := uc.Book(ctx, sc, schedule.BookInput{
+			TaskID:   c.id,
+			Duration: time.Duration(c.block.Minutes) * time.Minute,
+		})
+		if err != nil {
+			uc.l.Warnf(ctx, "schedule.RescheduleMissed: failed to rebook task %s: %v", c.id, err)
+			out.Failed = append(out.Failed, c.id)
+			continue
+		}
+		out.Moved = append(out.Moved, schedule.Moved{
+			TaskID: c.id,
+			Title:  booked.Title,
+			From:   schedule.Slot{Start: c.block.Start.In(uc.loc), End: c.block.End().In(uc.loc)},
+			To:     booked.Slot,
+		})
+	}
+
+	uc.l.Infof(ctx, "schedule.RescheduleMissed: user=%s missed=%d moved=%d failed=%d",
+		sc.UserID, len(candidates), len(out.Moved), len(out.Failed))
+
+	if len(out.Moved) > 0 {
+		uc.announce(ctx, out)
+	}
+	return out, nil
+}
+
+// announce tells the configured chats which blocks were moved.
+func (uc *implUseCase) announce(ctx context.Context, out schedule.RescheduleOutput) {
+	if uc.notifier == nil || len(uc.cfg.ChatIDs) == 0 {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("🔁 *Đã dời lịch các task chưa xong*\n")
+	for _, m := range out.Moved {
+		sb.WriteString(fmt.Sprintf("• %s: %s → %s", telegram.EscapeMarkdown(m.Title), m.From.Start.Format("15:04 02/01"), m.To.Start.Format("15:04 02/01")))
+		if m.To.Late {
+			sb.WriteString(" ⚠️ trễ hạn")
+		}
+		sb.WriteString("\n")
+	}
+
+	for _, chatID := range uc.cfg.ChatIDs {
+		if err := uc.notifier.SendMessageWithMode(chatID, strings.TrimSpace(sb.String()), "Markdown"); err != nil {
+			uc.l.Warnf(ctx, "schedule.RescheduleMissed: failed to notify chat %d: %v", chatID, err)
+		}
+	}
+}
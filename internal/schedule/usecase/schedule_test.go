@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/schedule"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/internal/task/repository/local"
+	"autonomous-task-management/pkg/gcalendar"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Mock implementations
+// ---------------------------------------------------------------------------
+
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Debugf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Info(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Infof(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Warn(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Warnf(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Error(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Errorf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) DPanic(_ context.Context, _ ...any)            {}
+func (m *mockLogger) DPanicf(_ context.Context, _ string, _ ...any) {}
+func (m *mockLogger) Panic(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Panicf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Fatal(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Fatalf(_ context.Context, _ string, _ ...any)  {}
+
+// newTestRepo stores one task per content in a local Markdown repository and returns their IDs.
+func newTestRepo(t *testing.T, contents ...string) (repository.MemosRepository, []string) {
+	t.Helper()
+	repo, err := local.New(t.TempDir(), "", &mockLogger{})
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(contents))
+	for _, content := range contents {
+		task, err := repo.CreateTask(context.Background(), repository.CreateTaskOptions{Content: content})
+		require.NoError(t, err)
+		ids = append(ids, task.ID)
+	}
+	return repo, ids
+}
+
+// contentOf returns the stored content of task id.
+func contentOf(t *testing.T, repo repository.MemosRepository, id string) string {
+	t.Helper()
+	task, err := repo.GetTask(context.Background(), id)
+	require.NoError(t, err)
+	return task.Content
+}
+
+// fakeCalendar keeps events in memory.
+type fakeCalendar struct {
+	events []gcalendar.Event
+	nextID int
+}
+
+func (c *fakeCalendar) CreateEvent(_ context.Context, req gcalendar.CreateEventRequest) (*gcalendar.Event, error) {
+	c.nextID++
+	e := gcalendar.Event{ID: fmt.Sprintf("evt-%d", c.nextID), Summary: req.Summary, StartTime: req.StartTime, EndTime: req.EndTime}
+	c.events = append(c.events, e)
+	return &e, nil
+}
+
+func (c *fakeCalendar) ListEvents(_ context.Context, req gcalendar.ListEventsRequest) ([]gcalendar.Event, error) {
+	var out []gcalendar.Event
+	for _, e := range c.events {
+		if e.StartTime.Before(req.TimeMax) && e.EndTime.After(req.TimeMin) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (c *fakeCalendar) DeleteEvent(_ context.Context, _, eventID string) error {
+	for i, e := range c.events {
+		if e.ID == eventID {
+			c.events = append(c.events[:i], c.events[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("event not found: " + eventID)
+}
+
+type recordingNotifier struct {
+	sent []string
+}
+
+func (n *recordingNotifier) SendMessageWithMode(_ int64, text string, _ string) error {
+	n.sent = append(n.sent, text)
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+var testLoc = time.FixedZone("ICT", 7*3600)
+
+// at returns a time on the test week (Monday 2026-03-09 is day 9).
+func at(day, hour, minute int) time.Time {
+	return time.Date(2026, 3, day, hour, minute, 0, 0, testLoc)
+}
+
+func newTestUseCase(repo repository.MemosRepository, cal *fakeCalendar, notifier schedule.Notifier) *implUseCase {
+	l := &mockLogger{}
+	uc := New(l, repo, cal, notifier, schedule.Config{
+		Timezone:  "Asia/Ho_Chi_Minh",
+		WorkStart: "09:00",
+		WorkEnd:   "18:00",
+		WorkDays:  []string{"mon", "tue", "wed", "thu", "fri"},
+		ChatIDs:   []int64{7},
+	}).(*implUseCase)
+	uc.loc = testLoc
+	uc.now = func() time.Time { return at(9, 8, 0) }
+	return uc
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestFindSlots_AvoidsEventsAndOtherBlocks(t *testing.T) {
+	repo, _ := newTestRepo(t, "## Other\n\n- **Scheduled:** 2026-03-09 11:00 +07:00 · 60 min")
+	cal := &fakeCalendar{events: []gcalendar.Event{{ID: "standup", StartTime: at(9, 9, 0), EndTime: at(9, 10, 0)}}}
+	uc := newTestUseCase(repo, cal, nil)
+
+	out, err := uc.FindSlots(context.Background(), model.Scope{}, schedule.FindInput{Duration: time.Hour})
+	require.NoError(t, err)
+
+	require.Len(t, out.Slots, 3)
+	assert.Equal(t, at(9, 10, 0), out.Slots[0].Start)
+	assert.Equal(t, at(9, 12, 0), out.Slots[1].Start)
+	assert.Equal(t, at(10, 9, 0), out.Slots[2].Start)
+}
+
+func TestFindSlots_MarksSlotsAfterDeadline(t *testing.T) {
+	repo, ids := newTestRepo(t, "## Report\n\n- **Due:** 2026-03-09\n- **Estimated:** 120 min")
+	cal := &fakeCalendar{events: []gcalendar.Event{{ID: "offsite", StartTime: at(9, 9, 0), EndTime: at(9, 17, 0)}}}
+	uc := newTestUseCase(repo, cal, nil)
+
+	out, err := uc.FindSlots(context.Background(), model.Scope{}, schedule.FindInput{TaskID: ids[0], Limit: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Report", out.Title)
+	assert.Equal(t, 2*time.Hour, out.Duration)
+	require.Len(t, out.Slots, 1)
+	assert.Equal(t, at(10, 9, 0), out.Slots[0].Start)
+	assert.True(t, out.Slots[0].Late)
+
+	_, err = uc.FindSlots(context.Background(), model.Scope{}, schedule.FindInput{Duration: 10 * time.Hour})
+	assert.ErrorIs(t, err, schedule.ErrInvalidDuration)
+}
+
+func TestBook_RecordsBlockAndReplacesPrevious(t *testing.T) {
+	repo, ids := newTestRepo(t, "## Report\n\n- **Estimated:** 90 min")
+	a := ids[0]
+	cal := &fakeCalendar{events: []gcalendar.Event{{ID: "standup", StartTime: at(9, 9, 0), EndTime: at(9, 9, 30)}}}
+	uc := newTestUseCase(repo, cal, nil)
+	ctx := context.Background()
+
+	first, err := uc.Book(ctx, model.Scope{}, schedule.BookInput{TaskID: a})
+	require.NoError(t, err)
+	assert.Equal(t, at(9, 9, 30), first.Slot.Start)
+	assert.Contains(t, contentOf(t, repo, a), "- **Scheduled:** 2026-03-09 09:30 +07:00 · 90 min · event evt-1")
+
+	_, err = uc.Book(ctx, model.Scope{}, schedule.BookInput{TaskID: a, At: "2026-03-09 09:15"})
+	assert.ErrorIs(t, err, schedule.ErrSlotUnavailable)
+	_, err = uc.Book(ctx, model.Scope{}, schedule.BookInput{TaskID: a, At: "2026-03-14 10:00"})
+	assert.ErrorIs(t, err, schedule.ErrSlotUnavailable, "Saturday is not a working day")
+	_, err = uc.Book(ctx, model.Scope{}, schedule.BookInput{TaskID: a, At: "tomorrow"})
+	assert.ErrorIs(t, err, schedule.ErrInvalidStart)
+
+	// The task's own block does not count as busy when moving it
+	second, err := uc.Book(ctx, model.Scope{}, schedule.BookInput{TaskID: a, At: "2026-03-09 10:00"})
+	require.NoError(t, err)
+	assert.True(t, second.Replaced)
+	assert.Equal(t, at(9, 11, 30), second.Slot.End)
+	assert.Contains(t, contentOf(t, repo, a), "- **Scheduled:** 2026-03-09 10:00 +07:00 · 90 min · event evt-2")
+	assert.Len(t, cal.events, 2, "previous event deleted")
+}
+
+func TestRescheduleMissed_RebooksUnfinishedBlocks(t *testing.T) {
+	repo, ids := newTestRepo(t,
+		"## Missed\n\n- **Scheduled:** 2026-03-06 14:00 +07:00 · 60 min · event old",
+		"## Done\n\n- **Scheduled:** 2026-03-06 15:00 +07:00 · 60 min\n\n#status/done",
+		"## Stale\n\n- **Scheduled:** 2026-02-01 15:00 +07:00 · 60 min",
+		"## Upcoming\n\n- **Scheduled:** 2026-03-09 09:00 +07:00 · 60 min",
+	)
+	cal := &fakeCalendar{events: []gcalendar.Event{{ID: "old", StartTime: at(6, 14, 0), EndTime: at(6, 15, 0)}}}
+	notifier := &recordingNotifier{}
+	uc := newTestUseCase(repo, cal, notifier)
+
+	out, err := uc.RescheduleMissed(context.Background(), model.Scope{})
+	require.NoError(t, err)
+
+	require.Len(t, out.Moved, 1)
+	assert.Equal(t, ids[0], out.Moved[0].TaskID)
+	assert.Equal(t, at(6, 14, 0), out.Moved[0].From.Start)
+	assert.Equal(t, at(9, 10, 0), out.Moved[0].To.Start, "the upcoming block keeps 09:00")
+	assert.Empty(t, out.Failed)
+	assert.Equal(t, "old", cal.events[0].ID, "the missed block's event stays on the calendar")
+	require.Len(t, notifier.sent, 1)
+	assert.Contains(t, notifier.sent[0], "Missed")
+}
+
+func TestFindSlots_NoCalendar(t *testing.T) {
+	l := &mockLogger{}
+	repo, _ := newTestRepo(t)
+	uc := New(l, repo, nil, nil, schedule.Config{})
+
+	_, err := uc.FindSlots(context.Background(), model.Scope{}, schedule.FindInput{})
+	assert.ErrorIs(t, err, schedule.ErrNoCalendar)
+}
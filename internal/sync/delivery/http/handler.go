@@ -2,6 +2,9 @@ package http
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -58,3 +61,42 @@ func (h *handler) HandleMemosWebhook(c *gin.Context) {
 	// Acknowledge immediately
 	pkgResponse.OK(c, map[string]string{"status": "accepted"})
 }
+
+// HandleReconcileStatus serves GET /api/admin/reconcile: the last report, or null before the first run.
+func (h *handler) HandleReconcileStatus(c *gin.Context) {
+	report, ok := h.uc.LastReconcile()
+	if !ok {
+		pkgResponse.OK(c, nil)
+		return
+	}
+	pkgResponse.OK(c, report)
+}
+
+// HandleReconcile serves POST /api/admin/reconcile?dry_run=true and responds when the run is done.
+// The run is detached from the request, so a client that disconnects does not abort it halfway;
+// its report is served by HandleReconcileStatus once it finishes.
+func (h *handler) HandleReconcile(c *gin.Context) {
+	ctx := context.WithoutCancel(c.Request.Context())
+
+	var input sync.ReconcileInput
+	if v := c.Query("dry_run"); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			pkgResponse.Error(c, fmt.Errorf("invalid dry_run: %w", err), nil)
+			return
+		}
+		input.DryRun = dryRun
+	}
+
+	report, err := h.uc.Reconcile(ctx, input)
+	if err != nil {
+		if errors.Is(err, sync.ErrReconcileRunning) {
+			pkgResponse.Error(c, err, nil)
+			return
+		}
+		h.l.Errorf(ctx, "reconcile: run failed: %v", err)
+		pkgResponse.InternalError(c, err)
+		return
+	}
+	pkgResponse.OK(c, report)
+}
@@ -0,0 +1,7 @@
+package sync
+
+import "errors"
+
+var (
+	ErrReconcileRunning = errors.New("sync: a reconciliation is already running")
+)
@@ -10,10 +10,22 @@ import (
 type UseCase interface {
 	SyncTask(ctx context.Context, memoID string) error
 	DeleteTask(ctx context.Context, memoID string) error
+	// Reconcile compares every memo with every Qdrant point, re-embeds memos that are missing or
+	// changed and deletes points whose memo is gone. It catches what missed webhooks left behind.
+	Reconcile(ctx context.Context, input ReconcileInput) (ReconcileReport, error)
+	// LastReconcile returns the report of the most recent run, if any.
+	LastReconcile() (ReconcileReport, bool)
+	// RefreshTemporal re-embeds the tasks whose embedded text says "tomorrow", "this week"...
+	// about a due date that has since moved to another bucket, and only those.
+	RefreshTemporal(ctx context.Context) (RefreshReport, error)
 }
 
 // Handler defines the interface for the webhook sync handler.
 type Handler interface {
 	// HandleMemosWebhook processes incoming webhook payloads from Memos.
 	HandleMemosWebhook(c *gin.Context)
+	// HandleReconcileStatus returns the last reconciliation report.
+	HandleReconcileStatus(c *gin.Context)
+	// HandleReconcile runs a reconciliation and returns its report.
+	HandleReconcile(c *gin.Context)
 }
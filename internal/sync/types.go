@@ -1,5 +1,7 @@
 package sync
 
+import "time"
+
 // MemosWebhookPayload matches Memos API v1 webhook format.
 type MemosWebhookPayload struct {
 	ActivityType string `json:"activityType"` // e.g., "memos.memo.created"
@@ -8,3 +10,44 @@ type MemosWebhookPayload struct {
 		UID  string `json:"uid"`  // Short UID (Base58)
 	} `json:"memo"`
 }
+
+// ReconcileInput controls one reconciliation run.
+type ReconcileInput struct {
+	DryRun bool // Report the drift without re-embedding or deleting anything
+}
+
+// ReconcileReport describes the drift between Memos and Qdrant found by one run.
+type ReconcileReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	DryRun     bool      `json:"dry_run"`
+
+	Memos  int `json:"memos"`  // Memos scanned
+	Points int `json:"points"` // Qdrant points scanned
+
+	Missing  int `json:"missing"`  // Memos without a point
+	Stale    int `json:"stale"`    // Points whose update time or content hash differs from the memo
+	Orphaned int `json:"orphaned"` // Points whose memo no longer exists or is archived
+
+	Reembedded int      `json:"reembedded"`
+	Deleted    int      `json:"deleted"`
+	Failed     []string `json:"failed,omitempty"` // Memo IDs that could not be re-embedded or deleted
+
+	Aborted string `json:"aborted,omitempty"` // Why the repair was skipped, "" if it ran
+}
+
+// Drift returns the number of memos and points that were out of sync.
+func (r ReconcileReport) Drift() int {
+	return r.Missing + r.Stale + r.Orphaned
+}
+
+// RefreshReport describes one run of RefreshTemporal.
+type RefreshReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+
+	Checked    int      `json:"checked"` // Points with a due date scanned
+	Changed    int      `json:"changed"` // Points whose humanized due date moved to another bucket
+	Reembedded int      `json:"reembedded"`
+	Failed     []string `json:"failed,omitempty"` // Memo IDs that could not be loaded or re-embedded
+}
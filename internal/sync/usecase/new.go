@@ -13,21 +13,46 @@ import (
 // Nếu có event mới trong thời gian này → timer bị reset → chỉ chạy 1 lần cuối cùng.
 const debounceDelay = 2 * time.Second
 
+// Reconciliation reads Memos this many tasks per page and re-embeds with this many concurrent requests.
+const (
+	reconcilePageSize    = 200
+	reconcileConcurrency = 2
+)
+
+// defaultMaxOrphanRatio is the share of points a reconciliation may delete as orphans before
+// it treats the Memos listing as broken and skips the repair.
+const defaultMaxOrphanRatio = 0.5
+
 type implUseCase struct {
 	memosRepo  repository.MemosRepository
 	vectorRepo repository.VectorRepository
 	l          pkgLog.Logger
 
 	// debounce: mỗi memoID có 1 pending timer
-	mu       sync.Mutex
-	timers   map[string]*time.Timer
+	mu     sync.Mutex
+	timers map[string]*time.Timer
+
+	// reconciliation: at most one run at a time, last report kept for the admin endpoint
+	reconcileMu    sync.Mutex
+	reconciling    bool
+	lastReport     *pkgSync.ReconcileReport
+	maxOrphanRatio float64
+
+	now func() time.Time // Clock of the temporal refresh, replaced in tests
 }
 
-func New(memosRepo repository.MemosRepository, vectorRepo repository.VectorRepository, l pkgLog.Logger) pkgSync.UseCase {
+// New creates the sync use case. maxOrphanRatio caps the share of points one reconciliation may
+// delete as orphans; 0 means defaultMaxOrphanRatio.
+func New(memosRepo repository.MemosRepository, vectorRepo repository.VectorRepository, l pkgLog.Logger, maxOrphanRatio float64) pkgSync.UseCase {
+	if maxOrphanRatio <= 0 {
+		maxOrphanRatio = defaultMaxOrphanRatio
+	}
 	return &implUseCase{
-		memosRepo:  memosRepo,
-		vectorRepo: vectorRepo,
-		l:          l,
-		timers:     make(map[string]*time.Timer),
+		memosRepo:      memosRepo,
+		vectorRepo:     vectorRepo,
+		l:              l,
+		timers:         make(map[string]*time.Timer),
+		now:            time.Now,
+		maxOrphanRatio: maxOrphanRatio,
 	}
 }
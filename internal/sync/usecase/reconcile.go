@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	pkgSync "autonomous-task-management/internal/sync"
+	"autonomous-task-management/internal/task/repository"
+)
+
+// Reconcile loads every point first and then walks Memos, so memos created during the run
+// are at worst re-embedded once more. Nothing is deleted unless both listings completed.
+//
+// An empty listing or more orphans than maxOrphanRatio of the points looks like Memos answering
+// with the wrong account or a broken filter rather than real deletions, so the repair is skipped
+// and the reason is recorded in the report.
+func (uc *implUseCase) Reconcile(ctx context.Context, input pkgSync.ReconcileInput) (pkgSync.ReconcileReport, error) {
+	uc.reconcileMu.Lock()
+	if uc.reconciling {
+		uc.reconcileMu.Unlock()
+		return pkgSync.ReconcileReport{}, pkgSync.ErrReconcileRunning
+	}
+	uc.reconciling = true
+	uc.reconcileMu.Unlock()
+	defer func() {
+		uc.reconcileMu.Lock()
+		uc.reconciling = false
+		uc.reconcileMu.Unlock()
+	}()
+
+	report := pkgSync.ReconcileReport{StartedAt: time.Now(), DryRun: input.DryRun}
+
+	// memo ID -> payload of its point
+	points := make(map[string]map[string]interface{})
+	for p, err := range uc.vectorRepo.IterateFilteredTasks(ctx, repository.FilterTasksOptions{Limit: reconcilePageSize}) {
+		if err != nil {
+			return report, fmt.Errorf("sync: failed to list Qdrant points: %w", err)
+		}
+		points[p.MemoID] = p.Payload
+	}
+	report.Points = len(points)
+
+	var changed []model.Task
+	seen := make(map[string]bool, len(points))
+	for t, err := range uc.memosRepo.IterateTasks(ctx, repository.ListTasksOptions{Limit: reconcilePageSize}) {
+		if err != nil {
+			return report, fmt.Errorf("sync: failed to list memos: %w", err)
+		}
+		report.Memos++
+		seen[t.ID] = true
+
+		payload, ok := points[t.ID]
+		switch {
+		case !ok:
+			report.Missing++
+		case isStale(t, payload):
+			report.Stale++
+		default:
+			continue
+		}
+		changed = append(changed, t)
+	}
+
+	var orphans []string
+	for id := range points {
+		if !seen[id] {
+			orphans = append(orphans, id)
+		}
+	}
+	report.Orphaned = len(orphans)
+
+	report.Aborted = uc.checkOrphans(report)
+	if report.Aborted != "" {
+		uc.l.Warnf(ctx, "sync: reconciliation repair skipped: %s", report.Aborted)
+	} else if !input.DryRun {
+		uc.repair(ctx, &report, changed, orphans)
+	}
+	report.FinishedAt = time.Now()
+
+	uc.l.Infof(ctx, "sync: reconciled %d memos with %d points: %d missing, %d stale, %d orphaned, %d re-embedded, %d deleted, %d failed (dry run: %v)",
+		report.Memos, report.Points, report.Missing, report.Stale, report.Orphaned,
+		report.Reembedded, report.Deleted, len(report.Failed), report.DryRun)
+
+	uc.reconcileMu.Lock()
+	uc.lastReport = &report
+	uc.reconcileMu.Unlock()
+	return report, nil
+}
+
+// LastReconcile returns the report of the most recent completed run.
+func (uc *implUseCase) LastReconcile() (pkgSync.ReconcileReport, bool) {
+	uc.reconcileMu.Lock()
+	defer uc.reconcileMu.Unlock()
+	if uc.lastReport == nil {
+		return pkgSync.ReconcileReport{}, false
+	}
+	return *uc.lastReport, true
+}
+
+// checkOrphans returns why the orphans look like a broken listing, or "" if they may be deleted.
+func (uc *implUseCase) checkOrphans(report pkgSync.ReconcileReport) string {
+	if report.Orphaned == 0 {
+		return ""
+	}
+	if report.Memos == 0 {
+		return fmt.Sprintf("Memos listed no tasks but Qdrant has %d points", report.Points)
+	}
+	if ratio := float64(report.Orphaned) / float64(report.Points); ratio > uc.maxOrphanRatio {
+		return fmt.Sprintf("%d of %d points (%.0f%%) have no memo, above the %.0f%% limit",
+			report.Orphaned, report.Points, ratio*100, uc.maxOrphanRatio*100)
+	}
+	return ""
+}
+
+// repair re-embeds the changed memos and deletes the orphaned points.
+func (uc *implUseCase) repair(ctx context.Context, report *pkgSync.ReconcileReport, changed []model.Task, orphans []string) {
+	if len(changed) > 0 {
+		result, err := uc.vectorRepo.IndexTasks(ctx, changed, repository.IndexOptions{Concurrency: reconcileConcurrency})
+		if err != nil {
+			uc.l.Warnf(ctx, "sync: re-embedding stopped early: %v", err)
+		}
+		report.Reembedded = result.Indexed
+		report.Failed = append(report.Failed, result.Failed...)
+	}
+
+	for _, id := range orphans {
+		if err := uc.vectorRepo.DeleteTask(ctx, id); err != nil {
+			uc.l.Warnf(ctx, "sync: failed to delete orphaned point of %s: %v", id, err)
+			report.Failed = append(report.Failed, id)
+			continue
+		}
+		report.Deleted++
+	}
+}
+
+// isStale reports whether a point no longer matches its memo. Points written before the
+// content hash was stored count as stale, which backfills the hash.
+func isStale(t model.Task, payload map[string]interface{}) bool {
+	updateTime, _ := payload[repository.PayloadUpdateTime].(string)
+	hash, _ := payload[repository.PayloadContentHash].(string)
+	return updateTime != t.UpdateTime || hash != repository.ContentHash(t.Content)
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"iter"
+	"testing"
+
+	"autonomous-task-management/internal/model"
+	pkgSync "autonomous-task-management/internal/sync"
+	"autonomous-task-management/internal/task/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// driftVectorRepo serves a fixed set of points and records what reconciliation changes.
+type driftVectorRepo struct {
+	countingVectorRepo
+	points  []repository.SearchResult
+	indexed []string
+	deleted []string
+}
+
+func (r *driftVectorRepo) IterateFilteredTasks(_ context.Context, _ repository.FilterTasksOptions) iter.Seq2[repository.SearchResult, error] {
+	return func(yield func(repository.SearchResult, error) bool) {
+		for _, p := range r.points {
+			if !yield(p, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (r *driftVectorRepo) IndexTasks(_ context.Context, tasks []model.Task, _ repository.IndexOptions) (repository.IndexResult, error) {
+	for _, t := range tasks {
+		r.indexed = append(r.indexed, t.ID)
+	}
+	return repository.IndexResult{Total: len(tasks), Indexed: len(tasks)}, nil
+}
+
+func (r *driftVectorRepo) DeleteTask(_ context.Context, id string) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+// listedMemosRepo lists a fixed set of memos.
+type listedMemosRepo struct {
+	staticMemosRepo
+	tasks []model.Task
+}
+
+func (r *listedMemosRepo) IterateTasks(_ context.Context, _ repository.ListTasksOptions) iter.Seq2[model.Task, error] {
+	return func(yield func(model.Task, error) bool) {
+		for _, t := range r.tasks {
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
+
+func point(memoID, updateTime, content string) repository.SearchResult {
+	return repository.SearchResult{MemoID: memoID, Payload: map[string]interface{}{
+		repository.PayloadUpdateTime:  updateTime,
+		repository.PayloadContentHash: repository.ContentHash(content),
+	}}
+}
+
+func newDriftFixture() (*listedMemosRepo, *driftVectorRepo) {
+	memos := &listedMemosRepo{tasks: []model.Task{
+		{ID: "memos/ok", Content: "same", UpdateTime: "t1"},
+		{ID: "memos/edited", Content: "new text", UpdateTime: "t1"}, // Update time not bumped
+		{ID: "memos/touched", Content: "same", UpdateTime: "t2"},
+		{ID: "memos/new", Content: "fresh", UpdateTime: "t1"},
+	}}
+	vectors := &driftVectorRepo{points: []repository.SearchResult{
+		point("memos/ok", "t1", "same"),
+		point("memos/edited", "t1", "old text"),
+		point("memos/touched", "t1", "same"),
+		point("memos/gone", "t1", "deleted"),
+	}}
+	return memos, vectors
+}
+
+func TestReconcile_RepairsDrift(t *testing.T) {
+	memos, vectors := newDriftFixture()
+	uc := New(memos, vectors, &mockLogger{}, 0)
+
+	report, err := uc.Reconcile(context.Background(), pkgSync.ReconcileInput{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, report.Memos)
+	assert.Equal(t, 4, report.Points)
+	assert.Equal(t, 1, report.Missing)
+	assert.Equal(t, 2, report.Stale)
+	assert.Equal(t, 1, report.Orphaned)
+	assert.Equal(t, 4, report.Drift())
+	assert.Equal(t, 3, report.Reembedded)
+	assert.Equal(t, 1, report.Deleted)
+	assert.ElementsMatch(t, []string{"memos/edited", "memos/touched", "memos/new"}, vectors.indexed)
+	assert.Equal(t, []string{"memos/gone"}, vectors.deleted)
+
+	last, ok := uc.LastReconcile()
+	assert.True(t, ok)
+	assert.Equal(t, report, last)
+}
+
+func TestReconcile_DryRunChangesNothing(t *testing.T) {
+	memos, vectors := newDriftFixture()
+	uc := New(memos, vectors, &mockLogger{}, 0)
+
+	_, ok := uc.LastReconcile()
+	assert.False(t, ok)
+
+	report, err := uc.Reconcile(context.Background(), pkgSync.ReconcileInput{DryRun: true})
+
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 4, report.Drift())
+	assert.Zero(t, report.Reembedded)
+	assert.Empty(t, vectors.indexed)
+	assert.Empty(t, vectors.deleted)
+}
+
+func TestReconcile_SkipsRepairOnEmptyListing(t *testing.T) {
+	_, vectors := newDriftFixture()
+	uc := New(&listedMemosRepo{}, vectors, &mockLogger{}, 0)
+
+	report, err := uc.Reconcile(context.Background(), pkgSync.ReconcileInput{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, report.Orphaned)
+	assert.NotEmpty(t, report.Aborted)
+	assert.Zero(t, report.Deleted)
+	assert.Empty(t, vectors.deleted)
+}
+
+func TestReconcile_SkipsRepairAboveOrphanRatio(t *testing.T) {
+	memos, vectors := newDriftFixture()
+	memos.tasks = memos.tasks[:1] // Only memos/ok listed: 3 of 4 points look orphaned
+
+	report, err := New(memos, vectors, &mockLogger{}, 0.5).Reconcile(context.Background(), pkgSync.ReconcileInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Orphaned)
+	assert.Contains(t, report.Aborted, "3 of 4 points")
+	assert.Empty(t, vectors.deleted)
+	assert.Empty(t, vectors.indexed)
+
+	report, err = New(memos, vectors, &mockLogger{}, 0.9).Reconcile(context.Background(), pkgSync.ReconcileInput{})
+	assert.NoError(t, err)
+	assert.Empty(t, report.Aborted)
+	assert.Equal(t, 3, report.Deleted)
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	pkgSync "autonomous-task-management/internal/sync"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/indexer"
+)
+
+// RefreshTemporal compares the temporal bucket stored with each point against the bucket of
+// its due date today. The due date is read from the payload, so the scan costs no Memos or
+// embedding calls; only changed tasks are loaded from Memos and re-embedded.
+func (uc *implUseCase) RefreshTemporal(ctx context.Context) (pkgSync.RefreshReport, error) {
+	report := pkgSync.RefreshReport{StartedAt: time.Now()}
+	now := uc.now()
+
+	var changed []string
+	for p, err := range uc.vectorRepo.IterateFilteredTasks(ctx, repository.FilterTasksOptions{Limit: reconcilePageSize}) {
+		if err != nil {
+			return report, fmt.Errorf("sync: failed to list Qdrant points: %w", err)
+		}
+		stored, _ := p.Payload[repository.PayloadTemporalBucket].(string)
+		rawDue, _ := p.Payload[repository.PayloadDue].(string)
+		if rawDue == "" {
+			continue
+		}
+		due, err := time.Parse(time.RFC3339, rawDue)
+		if err != nil {
+			uc.l.Warnf(ctx, "sync: invalid due %q on the point of %s: %v", rawDue, p.MemoID, err)
+			continue
+		}
+		report.Checked++
+
+		// The payload due is the start of the due day in the user's timezone
+		if indexer.DueBucket(due, now.In(due.Location())) != stored {
+			changed = append(changed, p.MemoID)
+		}
+	}
+	report.Changed = len(changed)
+
+	tasks := make([]model.Task, 0, len(changed))
+	for _, id := range changed {
+		t, err := uc.memosRepo.GetTask(ctx, id)
+		if err != nil {
+			uc.l.Warnf(ctx, "sync: failed to load %s for the temporal refresh: %v", id, err)
+			report.Failed = append(report.Failed, id)
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+	if len(tasks) > 0 {
+		result, err := uc.vectorRepo.IndexTasks(ctx, tasks, repository.IndexOptions{Concurrency: reconcileConcurrency})
+		if err != nil {
+			uc.l.Warnf(ctx, "sync: temporal refresh stopped early: %v", err)
+		}
+		report.Reembedded = result.Indexed
+		report.Failed = append(report.Failed, result.Failed...)
+	}
+	report.FinishedAt = time.Now()
+
+	uc.l.Infof(ctx, "sync: temporal refresh checked %d points with a due date: %d changed bucket, %d re-embedded, %d failed",
+		report.Checked, report.Changed, report.Reembedded, len(report.Failed))
+	return report, nil
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// dueMemosRepo returns the memos it holds and fails for any other ID.
+type dueMemosRepo struct {
+	staticMemosRepo
+	tasks map[string]model.Task
+}
+
+func (r *dueMemosRepo) GetTask(_ context.Context, id string) (model.Task, error) {
+	t, ok := r.tasks[id]
+	if !ok {
+		return model.Task{}, errors.New("not found")
+	}
+	return t, nil
+}
+
+func duePoint(memoID, due, bucket string) repository.SearchResult {
+	payload := map[string]interface{}{}
+	if due != "" {
+		payload[repository.PayloadDue] = due + "T00:00:00+07:00"
+	}
+	if bucket != "" {
+		payload[repository.PayloadTemporalBucket] = bucket
+	}
+	return repository.SearchResult{MemoID: memoID, Payload: payload}
+}
+
+func TestRefreshTemporal_ReembedsOnlyChangedBuckets(t *testing.T) {
+	// Embedded on Wednesday 11/03/2026
+	vectors := &driftVectorRepo{points: []repository.SearchResult{
+		duePoint("memos/friday", "2026-03-20", "tuan sau"),
+		duePoint("memos/tomorrow", "2026-03-12", "ngay mai, tuan nay"),
+		duePoint("memos/overdue", "2026-03-01", "qua han"),
+		duePoint("memos/next-month", "2026-04-10", "thang sau"),
+		duePoint("memos/legacy", "2026-03-18", ""), // Embedded before the bucket was stored
+		duePoint("memos/no-due", "", ""),
+		duePoint("memos/gone", "2026-03-16", "tuan sau"),
+	}}
+	memos := &dueMemosRepo{tasks: map[string]model.Task{
+		"memos/friday":   {ID: "memos/friday"},
+		"memos/tomorrow": {ID: "memos/tomorrow"},
+		"memos/legacy":   {ID: "memos/legacy"},
+	}}
+	uc := New(memos, vectors, &mockLogger{}, 0).(*implUseCase)
+
+	// Monday 16/03, 8h in Vietnam: friday is now this week, tomorrow is overdue
+	uc.now = func() time.Time { return time.Date(2026, time.March, 16, 1, 0, 0, 0, time.UTC) }
+
+	report, err := uc.RefreshTemporal(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, report.Checked)
+	assert.Equal(t, 4, report.Changed)
+	assert.Equal(t, 3, report.Reembedded)
+	assert.ElementsMatch(t, []string{"memos/friday", "memos/tomorrow", "memos/legacy"}, vectors.indexed)
+	assert.Equal(t, []string{"memos/gone"}, report.Failed)
+}
+
+func TestRefreshTemporal_NothingChanged(t *testing.T) {
+	vectors := &driftVectorRepo{points: []repository.SearchResult{
+		duePoint("memos/friday", "2026-03-20", "tuan sau"),
+	}}
+	uc := New(&dueMemosRepo{}, vectors, &mockLogger{}, 0).(*implUseCase)
+
+	// Still the same week: the stored text is accurate, so nothing is embedded
+	uc.now = func() time.Time { return time.Date(2026, time.March, 15, 12, 0, 0, 0, time.UTC) }
+
+	report, err := uc.RefreshTemporal(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Checked)
+	assert.Zero(t, report.Changed)
+	assert.Empty(t, vectors.indexed)
+}
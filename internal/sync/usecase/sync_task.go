@@ -4,6 +4,11 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/taskmeta"
 )
 
 // SyncTask debounces re-embed requests for the same memoID.
@@ -36,7 +41,11 @@ func (uc *implUseCase) doSync(ctx context.Context, memoID string) error {
 	backoff := 2 * time.Second
 
 	for i := 0; i < maxRetries; i++ {
-		task, err := uc.memosRepo.GetTask(ctx, memoID)
+		// Ticking the last box in Memos itself completes the task without going through the app,
+		// so the completion time is stamped here
+		task, err := repository.ModifyTask(history.WithAction(ctx, history.ActionAutomation), uc.memosRepo, memoID, func(current model.Task) (string, error) {
+			return taskmeta.StampDone(current.Content, time.Now()), nil
+		})
 		if err != nil {
 			uc.l.Warnf(ctx, "sync: fetch memo failed (retry %d/%d): %v", i+1, maxRetries, err)
 			time.Sleep(backoff)
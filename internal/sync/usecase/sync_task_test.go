@@ -2,14 +2,18 @@ package usecase
 
 import (
 	"context"
+	"iter"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"autonomous-task-management/internal/model"
 	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/internal/task/repository/local"
+	"autonomous-task-management/pkg/taskmeta"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ---------------------------------------------------------------------------
@@ -41,11 +45,24 @@ func (r *countingVectorRepo) EmbedTask(_ context.Context, _ model.Task) error {
 	r.embedCalls.Add(1)
 	return nil
 }
+func (r *countingVectorRepo) IndexTasks(_ context.Context, tasks []model.Task, _ repository.IndexOptions) (repository.IndexResult, error) {
+	r.embedCalls.Add(int32(len(tasks)))
+	return repository.IndexResult{Total: len(tasks), Indexed: len(tasks)}, nil
+}
 func (r *countingVectorRepo) SearchTasks(_ context.Context, _ repository.SearchTasksOptions) ([]repository.SearchResult, error) {
 	return nil, nil
 }
-func (r *countingVectorRepo) SearchTasksWithFilter(_ context.Context, _ repository.SearchTasksOptions) ([]repository.SearchResult, error) {
-	return nil, nil
+func (r *countingVectorRepo) FindSimilarTasks(_ context.Context, queries []string, _ int) ([][]repository.SearchResult, error) {
+	return make([][]repository.SearchResult, len(queries)), nil
+}
+func (r *countingVectorRepo) FilterTaskPage(_ context.Context, _ repository.FilterTasksOptions) (repository.SearchPage, error) {
+	return repository.SearchPage{}, nil
+}
+func (r *countingVectorRepo) IterateFilteredTasks(_ context.Context, _ repository.FilterTasksOptions) iter.Seq2[repository.SearchResult, error] {
+	return func(func(repository.SearchResult, error) bool) {}
+}
+func (r *countingVectorRepo) CountTasks(_ context.Context, _ repository.PayloadFilter) (int, error) {
+	return 0, nil
 }
 func (r *countingVectorRepo) DeleteTask(_ context.Context, _ string) error { return nil }
 
@@ -65,13 +82,33 @@ func (r *staticMemosRepo) CreateTasksBatch(_ context.Context, _ []repository.Cre
 }
 func (r *staticMemosRepo) UpdateTask(_ context.Context, _ string, _ string) error { return nil }
 
+func (r *staticMemosRepo) UpdateTaskIfUnchanged(_ context.Context, _, _, _ string) (model.Task, error) {
+	return model.Task{}, nil
+}
+func (r *staticMemosRepo) DeleteTask(_ context.Context, _ string) error      { return nil }
+func (r *staticMemosRepo) ArchiveTask(_ context.Context, _ string) error     { return nil }
+func (r *staticMemosRepo) RestoreTask(_ context.Context, _ string) error     { return nil }
+func (r *staticMemosRepo) PinTask(_ context.Context, _ string, _ bool) error { return nil }
+func (r *staticMemosRepo) ListTaskPage(_ context.Context, _ repository.ListTasksOptions) (repository.TaskPage, error) {
+	return repository.TaskPage{}, nil
+}
+func (r *staticMemosRepo) IterateTasks(_ context.Context, _ repository.ListTasksOptions) iter.Seq2[model.Task, error] {
+	return func(yield func(model.Task, error) bool) {}
+}
+func (r *staticMemosRepo) ListRelations(_ context.Context, _ string) ([]repository.TaskRelation, error) {
+	return nil, nil
+}
+func (r *staticMemosRepo) SetRelations(_ context.Context, _ string, _ []repository.TaskRelation) error {
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // Tests
 // ---------------------------------------------------------------------------
 
 func TestSyncTask_Debounce_CoalescesRapidCalls(t *testing.T) {
 	vectorRepo := &countingVectorRepo{}
-	uc := New(&staticMemosRepo{}, vectorRepo, &mockLogger{})
+	uc := New(&staticMemosRepo{}, vectorRepo, &mockLogger{}, 0)
 
 	ctx := context.Background()
 
@@ -91,7 +128,7 @@ func TestSyncTask_Debounce_CoalescesRapidCalls(t *testing.T) {
 
 func TestSyncTask_Debounce_DifferentIDs_RunIndependently(t *testing.T) {
 	vectorRepo := &countingVectorRepo{}
-	uc := New(&staticMemosRepo{}, vectorRepo, &mockLogger{})
+	uc := New(&staticMemosRepo{}, vectorRepo, &mockLogger{}, 0)
 
 	ctx := context.Background()
 
@@ -109,7 +146,7 @@ func TestSyncTask_Debounce_DifferentIDs_RunIndependently(t *testing.T) {
 
 func TestSyncTask_Debounce_ReturnsImmediately(t *testing.T) {
 	vectorRepo := &countingVectorRepo{}
-	uc := New(&staticMemosRepo{}, vectorRepo, &mockLogger{})
+	uc := New(&staticMemosRepo{}, vectorRepo, &mockLogger{}, 0)
 
 	start := time.Now()
 	err := uc.SyncTask(context.Background(), "memos/1")
@@ -119,3 +156,19 @@ func TestSyncTask_Debounce_ReturnsImmediately(t *testing.T) {
 	// Should return in < 50ms (async, no blocking)
 	assert.Less(t, elapsed, 50*time.Millisecond)
 }
+
+func TestDoSync_StampsCompletionMadeInMemos(t *testing.T) {
+	repo, err := local.New(t.TempDir(), "", &mockLogger{})
+	require.NoError(t, err)
+	ctx := context.Background()
+	task, err := repo.CreateTask(ctx, repository.CreateTaskOptions{Content: "## Pack\n\n- [x] Passport"})
+	require.NoError(t, err)
+
+	uc := New(repo, &countingVectorRepo{}, &mockLogger{}, 0).(*implUseCase)
+	require.NoError(t, uc.doSync(ctx, task.ID))
+
+	synced, err := repo.GetTask(ctx, task.ID)
+	require.NoError(t, err)
+	_, ok := taskmeta.DoneAt(synced.Content)
+	assert.True(t, ok, "a task completed outside the app gets its completion time")
+}
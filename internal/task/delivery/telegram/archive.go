@@ -0,0 +1,68 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/automation"
+	"autonomous-task-management/internal/model"
+	pkgTelegram "autonomous-task-management/pkg/telegram"
+)
+
+// archivePreviewLimit caps how many candidates the /archive preview lists.
+const archivePreviewLimit = 20
+
+// handleArchive handles "/archive" (preview) and "/archive confirm".
+func (h *handler) handleArchive(ctx context.Context, sc model.Scope, args string, chatID int64) error {
+	switch args {
+	case "":
+		output, err := h.automationUC.ArchiveCompletedTasks(ctx, sc, automation.ArchiveInput{DryRun: true})
+		if err != nil {
+			h.l.Errorf(ctx, "telegram handler: archive preview failed: %v", err)
+			return h.bot.SendMessage(chatID, "❌ Không thể lấy danh sách task cần lưu trữ.")
+		}
+		return h.bot.SendMessageWithMode(chatID, formatArchivePreview(output.Candidates), "Markdown")
+
+	case "confirm":
+		output, err := h.automationUC.ArchiveCompletedTasks(ctx, sc, automation.ArchiveInput{})
+		if err != nil {
+			h.l.Errorf(ctx, "telegram handler: archive failed: %v", err)
+			if output.Archived == 0 {
+				return h.bot.SendMessage(chatID, "❌ Không thể lưu trữ. Vui lòng thử lại.")
+			}
+			return h.bot.SendMessage(chatID, fmt.Sprintf("⚠️ Đã lưu trữ %d/%d task, một số task bị lỗi.", output.Archived, len(output.Candidates)))
+		}
+		return h.bot.SendMessage(chatID, fmt.Sprintf("🗄 Đã lưu trữ %d task đã hoàn thành.", output.Archived))
+	}
+
+	return h.bot.SendMessage(chatID, "❌ Lệnh không hợp lệ.\n\nDùng: `/archive` (xem trước) hoặc `/archive confirm`")
+}
+
+// formatArchivePreview lists the tasks /archive confirm would archive.
+func formatArchivePreview(candidates []automation.ArchiveCandidate) string {
+	if len(candidates) == 0 {
+		return "✨ Không có task hoàn thành nào cần lưu trữ."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🗄 *%d task đã hoàn thành sẽ được lưu trữ:*\n\n", len(candidates)))
+	for i, c := range candidates {
+		if i == archivePreviewLimit {
+			sb.WriteString(fmt.Sprintf("… và %d task khác\n", len(candidates)-archivePreviewLimit))
+			break
+		}
+		title := c.Title
+		if title == "" {
+			title = c.TaskID
+		}
+		if c.MemoURL != "" {
+			sb.WriteString("• " + pkgTelegram.MarkdownLink(title, c.MemoURL))
+		} else {
+			sb.WriteString("• " + pkgTelegram.EscapeMarkdown(title))
+		}
+		sb.WriteString(fmt.Sprintf(" — xong %s\n", c.DoneAt.Format("02/01")))
+	}
+	sb.WriteString("\nGửi `/archive confirm` để lưu trữ.")
+	return sb.String()
+}
@@ -0,0 +1,83 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task"
+)
+
+// outcomesByMemo indexes CreateBulk outcomes by memo ID.
+func outcomesByMemo(outcomes []task.TaskOutcome) map[string]task.TaskOutcome {
+	m := make(map[string]task.TaskOutcome, len(outcomes))
+	for _, o := range outcomes {
+		if o.MemoID != "" {
+			m[o.MemoID] = o
+		}
+	}
+	return m
+}
+
+// formatStepWarnings renders the side effects of a created task that failed and are being retried.
+func formatStepWarnings(o task.TaskOutcome) string {
+	var sb strings.Builder
+	if o.Embed == task.StepFailed {
+		sb.WriteString("\n   ⚠️ Chưa lập chỉ mục tìm kiếm (đang thử lại)")
+	}
+	if o.Calendar == task.StepFailed {
+		sb.WriteString("\n   ⚠️ Chưa tạo được lịch (đang thử lại)")
+	}
+	return sb.String()
+}
+
+// formatFailedTasks renders the parsed tasks whose memo could not be created.
+func formatFailedTasks(outcomes []task.TaskOutcome) string {
+	var sb strings.Builder
+	for _, o := range outcomes {
+		if o.Memo != task.StepFailed {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("❌ *Không tạo được:*\n")
+		}
+		sb.WriteString(fmt.Sprintf("• %s\n", o.Title))
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("_Vui lòng gửi lại các task này._\n\n")
+	}
+	return sb.String()
+}
+
+// handleRollback handles "/rollback <batch_id>".
+func (h *handler) handleRollback(ctx context.Context, sc model.Scope, batchID string, chatID int64) error {
+	if batchID == "" {
+		return h.bot.SendMessage(chatID, "❌ Cú pháp: `/rollback <mã lô>`")
+	}
+
+	output, err := h.uc.RollbackBatch(ctx, sc, batchID)
+	switch {
+	case errors.Is(err, task.ErrBatchNotFound):
+		return h.bot.SendMessage(chatID, "❌ Không tìm thấy lô này (có thể đã hết hạn hoặc đã hoàn tác).")
+	case err != nil:
+		h.l.Errorf(ctx, "telegram handler: RollbackBatch failed: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không thể hoàn tác. Vui lòng thử lại.")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("↩️ Đã hoàn tác lô `%s`:\n", output.BatchID))
+	for _, t := range output.Tasks {
+		mark := "✅"
+		if t.Memo != task.StepRolledBack {
+			mark = "⚠️"
+		}
+		sb.WriteString(fmt.Sprintf("%s %s\n", mark, t.Title))
+	}
+	if len(output.Errors) > 0 {
+		sb.WriteString(fmt.Sprintf("\n⚠️ %d thao tác chưa hoàn tác được, vui lòng kiểm tra thủ công.", len(output.Errors)))
+	}
+
+	return h.bot.SendMessageWithMode(chatID, sb.String(), "Markdown")
+}
@@ -0,0 +1,119 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/model"
+)
+
+// handleLink handles /block, /unblock, /subtask and /unsubtask: "<command> <task_id> <target_id>".
+func (h *handler) handleLink(ctx context.Context, sc model.Scope, text string, chatID int64, relation dependency.Relation, link bool) error {
+	if h.dependency == nil {
+		return h.bot.SendMessage(chatID, "❌ Tính năng phụ thuộc chưa được bật.")
+	}
+
+	parts := strings.Fields(text)
+	if len(parts) != 3 {
+		return h.bot.SendMessage(chatID, fmt.Sprintf("❌ Vui lòng nhập đủ 2 task ID.\n\nVí dụ: `%s abc123 def456`", parts[0]))
+	}
+
+	input := dependency.LinkInput{TaskID: parts[1], TargetID: parts[2], Relation: relation}
+	var err error
+	if link {
+		err = h.dependency.Link(ctx, sc, input)
+	} else {
+		err = h.dependency.Unlink(ctx, sc, input)
+	}
+
+	switch {
+	case errors.Is(err, dependency.ErrCycle):
+		return h.bot.SendMessage(chatID, "❌ Không thể liên kết: sẽ tạo vòng lặp phụ thuộc.")
+	case errors.Is(err, dependency.ErrSelfLink):
+		return h.bot.SendMessage(chatID, "❌ Một task không thể liên kết với chính nó.")
+	case errors.Is(err, dependency.ErrNotLinked):
+		return h.bot.SendMessage(chatID, "ℹ️ Hai task này chưa được liên kết.")
+	case err != nil:
+		h.l.Errorf(ctx, "telegram: dependency update failed: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không thể cập nhật liên kết. Vui lòng kiểm tra task ID.")
+	}
+
+	switch {
+	case !link && relation == dependency.RelationParent:
+		return h.bot.SendMessage(chatID, fmt.Sprintf("✅ %s không còn là subtask của %s", input.TaskID, input.TargetID))
+	case !link:
+		return h.bot.SendMessage(chatID, fmt.Sprintf("✅ %s không còn bị chặn bởi %s", input.TaskID, input.TargetID))
+	case relation == dependency.RelationParent:
+		return h.bot.SendMessage(chatID, fmt.Sprintf("✅ %s là subtask của %s", input.TaskID, input.TargetID))
+	default:
+		return h.bot.SendMessage(chatID, fmt.Sprintf("⛔ %s bị chặn bởi %s", input.TaskID, input.TargetID))
+	}
+}
+
+// handleDeps shows a task's blockers, what it blocks and its subtask rollup.
+func (h *handler) handleDeps(ctx context.Context, sc model.Scope, taskID string, chatID int64) error {
+	if h.dependency == nil {
+		return h.bot.SendMessage(chatID, "❌ Tính năng phụ thuộc chưa được bật.")
+	}
+	if taskID == "" {
+		return h.bot.SendMessage(chatID, "❌ Vui lòng nhập task ID.\n\nVí dụ: `/deps abc123`")
+	}
+
+	blockers, err := h.dependency.GetBlockers(ctx, sc, taskID)
+	if err != nil {
+		h.l.Errorf(ctx, "telegram: get blockers failed: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không thể lấy thông tin phụ thuộc. Vui lòng kiểm tra task ID.")
+	}
+	rollup, err := h.dependency.GetRollup(ctx, sc, taskID)
+	if err != nil {
+		h.l.Errorf(ctx, "telegram: get rollup failed: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không thể lấy tiến độ subtask. Vui lòng thử lại.")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🔗 *%s*\n\n", blockers.Task.Title))
+
+	if len(blockers.Blockers) == 0 {
+		sb.WriteString("Không bị chặn bởi task nào.\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("⛔ *Bị chặn bởi* (%d chưa xong):\n", blockers.OpenBlockers))
+		for _, r := range blockers.Blockers {
+			sb.WriteString(formatRef(r))
+		}
+	}
+	if len(blockers.Blocking) > 0 {
+		sb.WriteString("\n⏳ *Đang chặn:*\n")
+		for _, r := range blockers.Blocking {
+			sb.WriteString(formatRef(r))
+		}
+	}
+	if rollup.Total > 0 {
+		sb.WriteString(fmt.Sprintf("\n📊 *Subtask:* %d/%d hoàn thành (%.0f%%)\n", rollup.Completed, rollup.Total, rollup.Progress))
+		for _, r := range rollup.Children {
+			sb.WriteString(formatRef(r))
+		}
+	}
+
+	return h.bot.SendMessageWithMode(chatID, sb.String(), "Markdown")
+}
+
+// notifyCompleted tells the chat which tasks a completion unblocked. Failures are only logged.
+func (h *handler) notifyCompleted(ctx context.Context, sc model.Scope, taskID string, chatID int64) {
+	if h.dependency == nil {
+		return
+	}
+	if _, err := h.dependency.OnTaskCompleted(ctx, sc, dependency.CompletedInput{TaskID: taskID, ChatID: chatID}); err != nil {
+		h.l.Warnf(ctx, "telegram: dependency notification failed for %s: %v", taskID, err)
+	}
+}
+
+func formatRef(r dependency.TaskRef) string {
+	mark := "☐"
+	if r.Done {
+		mark = "☑"
+	}
+	return fmt.Sprintf("%s %s (`%s`) · %.0f%%\n", mark, r.Title, r.ID, r.Progress)
+}
@@ -0,0 +1,71 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/digest"
+	"autonomous-task-management/internal/model"
+)
+
+// handleDigest handles /digest [weekly|on [HH:MM]|off].
+func (h *handler) handleDigest(ctx context.Context, sc model.Scope, args string, chatID int64) error {
+	if h.digest == nil {
+		return h.bot.SendMessage(chatID, "❌ Tính năng báo cáo chưa được bật.")
+	}
+
+	fields := strings.Fields(args)
+	sub := ""
+	if len(fields) > 0 {
+		sub = strings.ToLower(fields[0])
+	}
+
+	switch sub {
+	case "":
+		out, err := h.digest.Daily(ctx, sc, digest.DailyInput{})
+		if err != nil {
+			h.l.Errorf(ctx, "telegram: daily digest failed: %v", err)
+			return h.bot.SendMessage(chatID, "❌ Không thể tạo báo cáo hôm nay. Vui lòng thử lại.")
+		}
+		return h.bot.SendMessageWithMode(chatID, out.Text, "Markdown")
+
+	case "weekly", "week", "tuan":
+		out, err := h.digest.Weekly(ctx, sc, digest.WeeklyInput{})
+		if err != nil {
+			h.l.Errorf(ctx, "telegram: weekly digest failed: %v", err)
+			return h.bot.SendMessage(chatID, "❌ Không thể tạo tổng kết tuần. Vui lòng thử lại.")
+		}
+		return h.bot.SendMessageWithMode(chatID, out.Text, "Markdown")
+
+	case "on":
+		input := digest.SubscribeInput{ChatID: chatID, Weekly: true}
+		if len(fields) > 1 {
+			input.DailyTime = fields[1]
+		}
+		s, err := h.digest.Subscribe(ctx, sc, input)
+		if err != nil {
+			if errors.Is(err, digest.ErrInvalidTime) {
+				return h.bot.SendMessage(chatID, "❌ Giờ không hợp lệ.\n\nVí dụ: `/digest on 07:30`")
+			}
+			h.l.Errorf(ctx, "telegram: digest subscribe failed: %v", err)
+			return h.bot.SendMessage(chatID, "❌ Không thể bật báo cáo tự động. Vui lòng thử lại.")
+		}
+		return h.bot.SendMessage(chatID, fmt.Sprintf(
+			"✅ Đã bật báo cáo: mỗi ngày lúc %s và tổng kết tuần vào %s lúc %s (%s).",
+			s.DailyTime, s.WeeklyDay, s.WeeklyTime, s.Timezone))
+
+	case "off":
+		if err := h.digest.Unsubscribe(ctx, sc, chatID); err != nil {
+			if errors.Is(err, digest.ErrNotSubscribed) {
+				return h.bot.SendMessage(chatID, "ℹ️ Bạn chưa bật báo cáo tự động.")
+			}
+			h.l.Errorf(ctx, "telegram: digest unsubscribe failed: %v", err)
+			return h.bot.SendMessage(chatID, "❌ Không thể tắt báo cáo tự động. Vui lòng thử lại.")
+		}
+		return h.bot.SendMessage(chatID, "✅ Đã tắt báo cáo tự động.")
+	}
+
+	return h.bot.SendMessage(chatID, "❌ Lệnh không hợp lệ.\n\nDùng: `/digest`, `/digest weekly`, `/digest on 07:30`, `/digest off`")
+}
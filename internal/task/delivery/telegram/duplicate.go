@@ -0,0 +1,77 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task"
+	pkgTelegram "autonomous-task-management/pkg/telegram"
+)
+
+// formatDuplicates renders the tasks CreateBulk held back, with the commands to resolve them.
+func formatDuplicates(duplicates []task.DuplicateCandidate) string {
+	if len(duplicates) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("⚠️ *%d task có vẻ đã tồn tại:*\n\n", len(duplicates)))
+	for i, d := range duplicates {
+		sb.WriteString(fmt.Sprintf("%d. %s giống ", i+1, pkgTelegram.MarkdownBold(d.Title)))
+		if d.ExistingMemoURL != "" {
+			sb.WriteString(pkgTelegram.MarkdownLink(d.ExistingTitle, d.ExistingMemoURL))
+		} else {
+			sb.WriteString(pkgTelegram.EscapeMarkdown(d.ExistingTitle))
+		}
+		sb.WriteString(fmt.Sprintf(" (%.0f%%)\n", d.Score*100))
+		sb.WriteString(fmt.Sprintf("   `/dup merge %s` · `/dup update %s` · `/dup create %s`\n\n", d.PendingID, d.PendingID, d.PendingID))
+	}
+	sb.WriteString("_merge: gộp mô tả/tag · update: cập nhật hạn & ưu tiên · create: vẫn tạo mới_")
+	return sb.String()
+}
+
+// handleResolveDuplicate handles "/dup <merge|update|create> <pending_id>".
+func (h *handler) handleResolveDuplicate(ctx context.Context, sc model.Scope, text string, chatID int64) error {
+	parts := strings.Fields(text)
+	if len(parts) != 3 {
+		return h.bot.SendMessage(chatID, "❌ Cú pháp: `/dup merge|update|create <mã>`")
+	}
+
+	output, err := h.uc.ResolveDuplicate(ctx, sc, task.ResolveDuplicateInput{
+		Action:    task.DuplicateAction(strings.ToLower(parts[1])),
+		PendingID: parts[2],
+	})
+	switch {
+	case errors.Is(err, task.ErrInvalidDuplicateAction):
+		return h.bot.SendMessage(chatID, "❌ Hành động không hợp lệ. Dùng: merge, update hoặc create.")
+	case errors.Is(err, task.ErrDuplicateNotFound):
+		return h.bot.SendMessage(chatID, "❌ Không tìm thấy yêu cầu này (có thể đã hết hạn). Vui lòng gửi lại task.")
+	case err != nil:
+		h.l.Errorf(ctx, "telegram handler: ResolveDuplicate failed: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không thể xử lý. Vui lòng thử lại.")
+	}
+
+	var reply string
+	switch output.Action {
+	case task.DuplicateActionCreate:
+		reply = "✅ Đã tạo task mới: " + pkgTelegram.MarkdownBold(output.Task.Title)
+	case task.DuplicateActionMerge:
+		reply = "🔀 Đã gộp vào task: " + pkgTelegram.MarkdownBold(output.Task.Title)
+	default:
+		reply = "✏️ Đã cập nhật task: " + pkgTelegram.MarkdownBold(output.Task.Title)
+	}
+	if output.Task.MemoURL != "" {
+		reply += fmt.Sprintf("\n📝 [Xem Memo](%s)", output.Task.MemoURL)
+	}
+	if output.Task.CalendarLink != "" {
+		reply += fmt.Sprintf("\n📅 [Xem Calendar](%s)", output.Task.CalendarLink)
+	}
+	if output.BatchID != "" {
+		reply += fmt.Sprintf("\n↩️ Hoàn tác: `/rollback %s`", output.BatchID)
+	}
+
+	return h.bot.SendMessageWithMode(chatID, reply, "Markdown")
+}
@@ -0,0 +1,92 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/export"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/taskexport"
+)
+
+// exportDateLayouts are the accepted from:/to: date formats.
+var exportDateLayouts = []string{"2006-01-02", "02/01/2006"}
+
+// handleExport handles "/export [format] [#tag ...] [open|done] [from:DATE] [to:DATE] [archived] [events]"
+// and sends the result as a document.
+func (h *handler) handleExport(ctx context.Context, sc model.Scope, args string, chatID int64) error {
+	input, invalid := parseExportArgs(args)
+	if invalid != "" {
+		return h.bot.SendMessageWithMode(chatID, fmt.Sprintf("❌ Tham số không hợp lệ: `%s`\n\n%s", invalid, exportUsage), "Markdown")
+	}
+
+	output, err := h.export.Export(ctx, sc, input)
+	switch {
+	case errors.Is(err, export.ErrInvalidDateRange):
+		return h.bot.SendMessage(chatID, "❌ Ngày kết thúc phải sau ngày bắt đầu.")
+	case err != nil:
+		h.l.Errorf(ctx, "telegram handler: Export failed: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không thể xuất task. Vui lòng thử lại.")
+	}
+
+	if output.Count == 0 {
+		return h.bot.SendMessage(chatID, "📭 Không có task nào khớp bộ lọc.")
+	}
+	caption := fmt.Sprintf("📤 %d task", output.Count)
+	if err := h.bot.SendDocument(chatID, output.Filename, output.Data, caption); err != nil {
+		h.l.Errorf(ctx, "telegram handler: failed to send export: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không gửi được file xuất. Vui lòng thử lại.")
+	}
+	return nil
+}
+
+const exportUsage = "Dùng: `/export [markdown|csv|json|ics] [#tag] [open|done] [from:YYYY-MM-DD] [to:YYYY-MM-DD] [archived] [events]`"
+
+// parseExportArgs reads the /export arguments and returns the first one it does not understand.
+// The format defaults to Markdown.
+func parseExportArgs(args string) (export.Input, string) {
+	input := export.Input{Format: string(taskexport.FormatMarkdown)}
+	for _, arg := range strings.Fields(args) {
+		lower := strings.ToLower(arg)
+		switch {
+		case strings.HasPrefix(arg, "#"):
+			input.Tags = append(input.Tags, arg)
+		case lower == string(export.StatusOpen) || lower == string(export.StatusDone):
+			input.Status = export.Status(lower)
+		case lower == "archived":
+			input.IncludeArchived = true
+		case lower == "events":
+			input.ICSEvents = true
+		case strings.HasPrefix(lower, "from:") || strings.HasPrefix(lower, "to:"):
+			key, value, _ := strings.Cut(arg, ":")
+			day, ok := parseExportDate(value)
+			if !ok {
+				return export.Input{}, arg
+			}
+			if strings.EqualFold(key, "from") {
+				input.DueFrom = day
+			} else {
+				input.DueTo = day
+			}
+		default:
+			format, ok := taskexport.ParseFormat(arg)
+			if !ok {
+				return export.Input{}, arg
+			}
+			input.Format = string(format)
+		}
+	}
+	return input, ""
+}
+
+func parseExportDate(value string) (time.Time, bool) {
+	for _, layout := range exportDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -11,12 +11,21 @@ import (
 	"autonomous-task-management/internal/agent"
 	"autonomous-task-management/internal/automation"
 	"autonomous-task-management/internal/checklist"
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/digest"
+	"autonomous-task-management/internal/export"
+	"autonomous-task-management/internal/history"
 	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/ranking"
 	"autonomous-task-management/internal/router"
+	"autonomous-task-management/internal/schedule"
 	"autonomous-task-management/internal/task"
 	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/internal/timetrack"
+	"autonomous-task-management/pkg/idempotency"
 	pkgLog "autonomous-task-management/pkg/log"
 	pkgResponse "autonomous-task-management/pkg/response"
+	"autonomous-task-management/pkg/taskmeta"
 	pkgTelegram "autonomous-task-management/pkg/telegram"
 )
 
@@ -29,6 +38,14 @@ type handler struct {
 	checklistSvc checklist.UseCase
 	memosRepo    repository.MemosRepository
 	router       router.UseCase
+	digest       digest.UseCase
+	dependency   dependency.UseCase
+	history      history.UseCase
+	export       export.UseCase
+	timetrack    timetrack.UseCase
+	schedule     schedule.UseCase
+	ranking      ranking.UseCase
+	seen         idempotency.IStore[struct{}] // Processed update / message keys
 }
 
 // HandleWebhook is the Gin handler for incoming Telegram webhook updates.
@@ -54,6 +71,13 @@ func (h *handler) HandleWebhook(c *gin.Context) {
 	// Snapshot the message before spawning goroutine to avoid data races on gin context
 	msg := update.Message
 
+	// Telegram redelivers updates it considers unacknowledged; process each one once
+	if !h.claimUpdate(update.UpdateID, msg) {
+		h.l.Infof(ctx, "telegram handler: duplicate update %d (chat=%d message=%d) ignored", update.UpdateID, msg.Chat.ID, msg.MessageID)
+		pkgResponse.OK(c, map[string]string{"status": "duplicate"})
+		return
+	}
+
 	// Critical: process in goroutine, return 200 immediately to Telegram
 	go func() {
 		// Detach from HTTP request context (which gets cancelled after response)
@@ -69,16 +93,53 @@ func (h *handler) HandleWebhook(c *gin.Context) {
 	pkgResponse.OK(c, map[string]string{"status": "accepted"})
 }
 
+// claimUpdate reports whether this update is new. Both the update ID and the chat+message
+// pair are claimed, since a re-sent webhook may carry a new update ID for the same message.
+func (h *handler) claimUpdate(updateID int64, msg *pkgTelegram.Message) bool {
+	if h.seen == nil {
+		return true
+	}
+	return h.seen.Claim(fmt.Sprintf("update:%d", updateID), struct{}{}) &&
+		h.seen.Claim(messageKey(msg), struct{}{})
+}
+
+// messageKey identifies a message across update redeliveries.
+func messageKey(msg *pkgTelegram.Message) string {
+	return fmt.Sprintf("telegram:%d:%d", msg.Chat.ID, msg.MessageID)
+}
+
 // processMessage handles a single Telegram message.
 func (h *handler) processMessage(ctx context.Context, msg *pkgTelegram.Message) error {
 	// Convention: Construct scope from message
 	sc := model.Scope{UserID: fmt.Sprintf("telegram_%d", msg.From.ID)}
+	ctx = history.WithActor(ctx, sc.UserID)
 
 	// Handle explicit slash commands first (backward compatibility)
 	// Convention: Simple switch-case for command routing
 	switch {
+	case msg.Document != nil:
+		if !isImportCommand(msg.Caption) {
+			return h.bot.SendMessageWithMode(msg.Chat.ID, "📎 Gửi file kèm chú thích `/import` để nhập task.", "Markdown")
+		}
+		return h.handleImport(ctx, sc, msg)
+	case isImportCommand(msg.Text):
+		return h.handleImport(ctx, sc, msg)
 	case msg.Text == "/start":
 		return h.handleStart(ctx, msg.Chat.ID)
+	case msg.Text == "/track" || strings.HasPrefix(msg.Text, "/track "):
+		taskID := strings.TrimSpace(strings.TrimPrefix(msg.Text, "/track"))
+		return h.handleStartTimer(ctx, sc, taskID, msg.Chat.ID)
+	case msg.Text == "/stop":
+		return h.handleStopTimer(ctx, sc, msg.Chat.ID)
+	case strings.HasPrefix(msg.Text, "/slots "):
+		taskID := strings.TrimSpace(strings.TrimPrefix(msg.Text, "/slots"))
+		return h.handleSlots(ctx, sc, taskID, msg.Chat.ID)
+	case strings.HasPrefix(msg.Text, "/schedule "):
+		args := strings.TrimSpace(strings.TrimPrefix(msg.Text, "/schedule"))
+		return h.handleSchedule(ctx, sc, args, msg.Chat.ID)
+	case msg.Text == "/next" || strings.HasPrefix(msg.Text, "/next "):
+		args := strings.TrimSpace(strings.TrimPrefix(msg.Text, "/next"))
+		return h.handleNext(ctx, sc, args, msg.Chat.ID)
 	case msg.Text == "/help":
 		return h.handleHelp(ctx, msg.Chat.ID)
 	case msg.Text == "/reset":
@@ -100,12 +161,42 @@ func (h *handler) processMessage(ctx context.Context, msg *pkgTelegram.Message)
 		return h.handleCheckItem(ctx, sc, msg.Text, msg.Chat.ID, true)
 	case strings.HasPrefix(msg.Text, "/uncheck "):
 		return h.handleCheckItem(ctx, sc, msg.Text, msg.Chat.ID, false)
+	case msg.Text == "/digest" || strings.HasPrefix(msg.Text, "/digest "):
+		args := strings.TrimSpace(strings.TrimPrefix(msg.Text, "/digest"))
+		return h.handleDigest(ctx, sc, args, msg.Chat.ID)
+	case msg.Text == "/archive" || strings.HasPrefix(msg.Text, "/archive "):
+		args := strings.TrimSpace(strings.TrimPrefix(msg.Text, "/archive"))
+		return h.handleArchive(ctx, sc, args, msg.Chat.ID)
+	case strings.HasPrefix(msg.Text, "/rollback "):
+		batchID := strings.TrimSpace(strings.TrimPrefix(msg.Text, "/rollback"))
+		return h.handleRollback(ctx, sc, batchID, msg.Chat.ID)
+	case strings.HasPrefix(msg.Text, "/dup "):
+		return h.handleResolveDuplicate(ctx, sc, msg.Text, msg.Chat.ID)
+	case strings.HasPrefix(msg.Text, "/block "):
+		return h.handleLink(ctx, sc, msg.Text, msg.Chat.ID, dependency.RelationBlockedBy, true)
+	case strings.HasPrefix(msg.Text, "/unblock "):
+		return h.handleLink(ctx, sc, msg.Text, msg.Chat.ID, dependency.RelationBlockedBy, false)
+	case strings.HasPrefix(msg.Text, "/subtask "):
+		return h.handleLink(ctx, sc, msg.Text, msg.Chat.ID, dependency.RelationParent, true)
+	case strings.HasPrefix(msg.Text, "/unsubtask "):
+		return h.handleLink(ctx, sc, msg.Text, msg.Chat.ID, dependency.RelationParent, false)
+	case strings.HasPrefix(msg.Text, "/history "):
+		taskID := strings.TrimSpace(strings.TrimPrefix(msg.Text, "/history"))
+		return h.handleHistory(ctx, sc, taskID, msg.Chat.ID)
+	case msg.Text == "/export" || strings.HasPrefix(msg.Text, "/export "):
+		args := strings.TrimSpace(strings.TrimPrefix(msg.Text, "/export"))
+		return h.handleExport(ctx, sc, args, msg.Chat.ID)
+	case msg.Text == "/undo":
+		return h.handleUndo(ctx, sc, msg.Chat.ID)
+	case strings.HasPrefix(msg.Text, "/deps "):
+		taskID := strings.TrimSpace(strings.TrimPrefix(msg.Text, "/deps"))
+		return h.handleDeps(ctx, sc, taskID, msg.Chat.ID)
 	}
 
 	// 🆕 Use Semantic Router for natural language messages
 	// Convention: Get conversation history for context
 	messages := h.agent.GetSessionMessages(sc.UserID)
-	history := []string{}
+	recent := []string{}
 	if len(messages) > 0 {
 		// Get last 3 messages (3 turns)
 		start := len(messages) - 6
@@ -114,14 +205,14 @@ func (h *handler) processMessage(ctx context.Context, msg *pkgTelegram.Message)
 		}
 		for i := start; i < len(messages); i++ {
 			if len(messages[i].Parts) > 0 {
-				history = append(history, messages[i].Parts[0].Text)
+				recent = append(recent, messages[i].Parts[0].Text)
 			}
 		}
 	}
 
 	// Classify intent using router
 	// Convention: Pass context as first parameter
-	routerOutput, err := h.router.Classify(ctx, msg.Text, history)
+	routerOutput, err := h.router.Classify(ctx, msg.Text, recent)
 	if err != nil {
 		h.l.Errorf(ctx, "router: Classification failed, falling back to CONVERSATION: %v", err)
 		// 🔧 PRO-TIP #2: Fallback to CONVERSATION (safer than CREATE_TASK)
@@ -160,6 +251,7 @@ func (h *handler) handleCreateTask(ctx context.Context, sc model.Scope, msg *pkg
 	input := task.CreateBulkInput{
 		RawText:        msg.Text,
 		TelegramChatID: msg.Chat.ID,
+		IdempotencyKey: messageKey(msg),
 	}
 
 	output, err := h.uc.CreateBulk(ctx, sc, input)
@@ -173,12 +265,16 @@ func (h *handler) handleCreateTask(ctx context.Context, sc model.Scope, msg *pkg
 		return h.bot.SendMessage(msg.Chat.ID, fmt.Sprintf("Không thể xử lý yêu cầu: %v", err))
 	}
 
-	if output.TaskCount == 0 {
+	if output.TaskCount == 0 && len(output.Duplicates) == 0 && len(output.Outcomes) == 0 {
 		return h.bot.SendMessage(msg.Chat.ID, "⚠️ Không tìm thấy tasks nào trong tin nhắn của bạn. Vui lòng thử lại với mô tả rõ ràng hơn.")
 	}
 
 	// Build success reply
-	reply := fmt.Sprintf("Đã tạo *%d task(s)* thành công!\n\n", output.TaskCount)
+	reply := ""
+	if output.TaskCount > 0 {
+		reply = fmt.Sprintf("Đã tạo *%d task(s)* thành công!\n\n", output.TaskCount)
+	}
+	outcomes := outcomesByMemo(output.Outcomes)
 	for i, t := range output.Tasks {
 		reply += fmt.Sprintf("%d. *%s*", i+1, t.Title)
 		if t.MemoURL != "" {
@@ -187,8 +283,14 @@ func (h *handler) handleCreateTask(ctx context.Context, sc model.Scope, msg *pkg
 		if t.CalendarLink != "" {
 			reply += fmt.Sprintf("\n   📅 [Xem Calendar](%s)", t.CalendarLink)
 		}
+		reply += formatStepWarnings(outcomes[t.MemoID])
 		reply += "\n\n"
 	}
+	reply += formatFailedTasks(output.Outcomes)
+	if output.BatchID != "" {
+		reply += fmt.Sprintf("↩️ Hoàn tác cả lô: `/rollback %s`\n\n", output.BatchID)
+	}
+	reply += formatDuplicates(output.Duplicates)
 
 	return h.bot.SendMessageWithMode(msg.Chat.ID, reply, "Markdown")
 }
@@ -209,12 +311,17 @@ func (h *handler) handleSearch(ctx context.Context, sc model.Scope, query string
 		return h.bot.SendMessage(chatID, "❌ Lỗi tìm kiếm. Vui lòng thử lại.")
 	}
 
+	understood := formatInterpretation(result.Interpretation, query)
 	if len(result.Results) == 0 {
+		if understood != "" {
+			return h.bot.SendMessageWithMode(chatID, understood+"🤷‍♂️ Không tìm thấy task nào phù hợp.", "Markdown")
+		}
 		return h.bot.SendMessage(chatID, "🤷‍♂️ Không tìm thấy task nào phù hợp.")
 	}
 
 	// Format results
 	var response strings.Builder
+	response.WriteString(understood)
 	response.WriteString(fmt.Sprintf("🎯 Tìm thấy %d task:\n\n", len(result.Results)))
 
 	for i, taskResult := range result.Results {
@@ -222,8 +329,14 @@ func (h *handler) handleSearch(ctx context.Context, sc model.Scope, query string
 		response.WriteString(fmt.Sprintf("**%d. [%s](%s)**\n", i+1, title, taskResult.MemoURL))
 		response.WriteString(fmt.Sprintf("🎯 %.0f%%\n", taskResult.Score*100))
 
-		// Show preview (first 100 chars)
+		// Show preview (first 100 chars); for long tasks, of the section that matched
 		preview := taskResult.Content
+		if taskResult.Snippet != "" {
+			preview = taskResult.Snippet
+			if taskResult.MatchedSection != "" {
+				response.WriteString(fmt.Sprintf("📍 %s\n", taskResult.MatchedSection))
+			}
+		}
 		if len(preview) > 100 {
 			preview = preview[:100] + "..."
 		}
@@ -233,6 +346,68 @@ func (h *handler) handleSearch(ctx context.Context, sc model.Scope, query string
 	return h.bot.SendMessageWithMode(chatID, response.String(), "Markdown")
 }
 
+// formatInterpretation tells the user which filters were read from the query and what was
+// searched for, e.g. "🔎 Task `p1` · hạn `tuần sau` (16/03–22/03) · từ khóa `deploy`".
+// It is empty when the query was searched as typed.
+func formatInterpretation(in task.SearchInterpretation, query string) string {
+	code := func(s string) string { return "`" + strings.ReplaceAll(s, "`", "") + "`" }
+	codes := func(values []string) string {
+		out := make([]string, 0, len(values))
+		for _, v := range values {
+			out = append(out, code(v))
+		}
+		return strings.Join(out, ", ")
+	}
+
+	var parts []string
+	if len(in.Priorities) > 0 {
+		parts = append(parts, "ưu tiên "+codes(in.Priorities))
+	}
+	if len(in.Projects) > 0 {
+		parts = append(parts, "dự án "+codes(in.Projects))
+	}
+	if len(in.Tags) > 0 {
+		parts = append(parts, "tag "+codes(in.Tags))
+	}
+	if len(in.Statuses) > 0 {
+		parts = append(parts, "trạng thái "+codes(in.Statuses))
+	}
+	for _, s := range in.ExcludeStatuses {
+		if s == taskmeta.StatusDone {
+			parts = append(parts, "chưa xong")
+		} else {
+			parts = append(parts, "không "+code(s))
+		}
+	}
+	if !in.DueFrom.IsZero() || !in.DueTo.IsZero() {
+		due := "hạn"
+		if in.DuePhrase != "" {
+			due += " " + code(in.DuePhrase)
+		}
+		switch {
+		case in.DueFrom.IsZero():
+			due += fmt.Sprintf(" (trước %s)", in.DueTo.Format("02/01"))
+		case in.DueTo.IsZero():
+			due += fmt.Sprintf(" (từ %s)", in.DueFrom.Format("02/01"))
+		default:
+			due += fmt.Sprintf(" (%s–%s)", in.DueFrom.Format("02/01"), in.DueTo.AddDate(0, 0, -1).Format("02/01"))
+		}
+		parts = append(parts, due)
+	}
+
+	if in.Text != query || len(in.Expansions) > 0 {
+		keywords := "từ khóa " + code(in.Text)
+		if len(in.Expansions) > 0 {
+			keywords += " (+ " + codes(in.Expansions) + ")"
+		}
+		parts = append(parts, keywords)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "🔎 Task " + strings.Join(parts, " · ") + "\n\n"
+}
+
 // handleAgentOrchestrator forwards the input to the intelligent ReAct agent.
 func (h *handler) handleAgentOrchestrator(ctx context.Context, sc model.Scope, query string, chatID int64) error {
 	// Notify user that the agent is thinking
@@ -290,6 +465,9 @@ func (h *handler) handleProgress(ctx context.Context, sc model.Scope, taskID str
 	return h.bot.SendMessageWithMode(chatID, response.String(), "Markdown")
 }
 
+// conflictMessage is sent when a task kept changing elsewhere while an update was being applied.
+const conflictMessage = "⚠️ Task vừa được chỉnh sửa ở nơi khác nên chưa thể áp dụng thay đổi. Vui lòng thử lại."
+
 // handleComplete marks all checkboxes as complete
 func (h *handler) handleComplete(ctx context.Context, sc model.Scope, taskID string, chatID int64) error {
 	if taskID == "" {
@@ -298,20 +476,25 @@ func (h *handler) handleComplete(ctx context.Context, sc model.Scope, taskID str
 
 	h.bot.SendMessage(chatID, "✅ Đang đánh dấu hoàn thành...")
 
-	task, err := h.memosRepo.GetTask(ctx, taskID)
+	ctx = history.WithAction(ctx, history.ActionComplete)
+	_, err := repository.ModifyTask(ctx, h.memosRepo, taskID, func(current model.Task) (string, error) {
+		return h.checklistSvc.UpdateAllCheckboxes(current.Content, true), nil
+	})
+	if errors.Is(err, repository.ErrConflict) {
+		h.l.Warnf(ctx, "Failed to complete task %s: %v", taskID, err)
+		return h.bot.SendMessage(chatID, conflictMessage)
+	}
 	if err != nil {
-		h.l.Errorf(ctx, "Failed to get task: %v", err)
-		return h.bot.SendMessage(chatID, "❌ Không thể đánh dấu. Vui lòng kiểm tra task ID.")
+		h.l.Errorf(ctx, "Failed to complete task: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không thể hoàn thành task. Vui lòng kiểm tra task ID và thử lại.")
 	}
 
-	content := h.checklistSvc.UpdateAllCheckboxes(task.Content, true)
-
-	if err := h.memosRepo.UpdateTask(ctx, taskID, content); err != nil {
-		h.l.Errorf(ctx, "Failed to complete task: %v", err)
-		return h.bot.SendMessage(chatID, "❌ Không thể hoàn thành task. Vui lòng thử lại.")
+	if err := h.bot.SendMessage(chatID, fmt.Sprintf("✅ Đã đánh dấu toàn bộ checklist hoàn thành: %s", taskID)); err != nil {
+		return err
 	}
 
-	return h.bot.SendMessage(chatID, fmt.Sprintf("✅ Đã đánh dấu toàn bộ checklist hoàn thành: %s", taskID))
+	h.notifyCompleted(ctx, sc, taskID, chatID)
+	return nil
 }
 
 // handleCheckItem checks/unchecks specific checklist item
@@ -335,17 +518,32 @@ func (h *handler) handleCheckItem(ctx context.Context, sc model.Scope, text stri
 	}
 	h.bot.SendMessage(chatID, fmt.Sprintf("⏳ Đang %s...", actionStr))
 
-	task, err := h.memosRepo.GetTask(ctx, taskID)
-	if err != nil {
-		h.l.Errorf(ctx, "Failed to get task: %v", err)
-		return h.bot.SendMessage(chatID, "❌ Không thấy task. Vui lòng thử lại.")
-	}
-
-	output, err := h.checklistSvc.UpdateCheckbox(ctx, checklist.UpdateCheckboxInput{
-		Content:      task.Content,
-		CheckboxText: itemText,
-		Checked:      checked,
+	ctx = history.WithAction(ctx, history.ActionCheck)
+	if !checked {
+		ctx = history.WithAction(ctx, history.ActionUncheck)
+	}
+
+	// The checkbox change is re-applied to the latest content if the task changes meanwhile
+	var output checklist.UpdateCheckboxOutput
+	updated, err := repository.ModifyTask(ctx, h.memosRepo, taskID, func(current model.Task) (string, error) {
+		out, err := h.checklistSvc.UpdateCheckbox(ctx, checklist.UpdateCheckboxInput{
+			Content:      current.Content,
+			CheckboxText: itemText,
+			Checked:      checked,
+		})
+		if err != nil {
+			return "", err
+		}
+		output = out
+		if !out.Updated {
+			return current.Content, nil
+		}
+		return out.Content, nil
 	})
+	if errors.Is(err, repository.ErrConflict) {
+		h.l.Warnf(ctx, "Failed to update item of task %s: %v", taskID, err)
+		return h.bot.SendMessage(chatID, conflictMessage)
+	}
 	if err != nil {
 		h.l.Errorf(ctx, "Failed to update item: %v", err)
 		return h.bot.SendMessage(chatID, "❌ Không thể cập nhật. Vui lòng thử lại.")
@@ -355,11 +553,6 @@ func (h *handler) handleCheckItem(ctx context.Context, sc model.Scope, text stri
 		return h.bot.SendMessage(chatID, fmt.Sprintf("❌ Không tìm thấy checkbox với text: %q", itemText))
 	}
 
-	if err := h.memosRepo.UpdateTask(ctx, taskID, output.Content); err != nil {
-		h.l.Errorf(ctx, "Failed to complete task check item: %v", err)
-		return h.bot.SendMessage(chatID, "❌ Không thể hoàn thành check task. Vui lòng thử lại.")
-	}
-
 	emoji := "☑"
 	if !checked {
 		emoji = "☐"
@@ -370,7 +563,14 @@ func (h *handler) handleCheckItem(ctx context.Context, sc model.Scope, text stri
 		warningMsg = fmt.Sprintf("\n\n⚠️ Lưu ý: %d checkboxes được cập nhật. Nếu không đúng ý, hãy gõ text cụ thể hơn.", output.Count)
 	}
 
-	return h.bot.SendMessage(chatID, fmt.Sprintf("%s Đã cập nhật %d checkbox(es) matching %q%s", emoji, output.Count, itemText, warningMsg))
+	if err := h.bot.SendMessage(chatID, fmt.Sprintf("%s Đã cập nhật %d checkbox(es) matching %q%s", emoji, output.Count, itemText, warningMsg)); err != nil {
+		return err
+	}
+
+	if checked && h.checklistSvc.IsFullyCompleted(updated.Content) {
+		h.notifyCompleted(ctx, sc, taskID, chatID)
+	}
+	return nil
 }
 
 // handleReset clears the session memory for the current user.
@@ -412,6 +612,17 @@ Gửi tin nhắn bình thường:
 • "Deadline dự án ABC vào 15/3"
 • "Gọi điện cho khách hàng XYZ"
 
+**📥 Nhập task**
+Gửi file kèm chú thích /import (Markdown checklist, CSV, JSON Todoist/Trello)
+/import [định dạng] - Chỉ định định dạng: markdown, csv, todoist, trello
+/import all - Nhập cả các task trùng với task đã có
+Hoặc gõ /import rồi dán danh sách ở các dòng tiếp theo
+
+**📤 Xuất task**
+/export [markdown|csv|json|ics] - Nhận file chứa toàn bộ task
+Lọc thêm: #tag, open/done, from:YYYY-MM-DD, to:YYYY-MM-DD, archived
+/export ics events - Xuất lịch dạng sự kiện cả ngày thay vì to-do
+
 **🔍 Tìm kiếm nhanh**
 /search [từ khóa]
 • /search meeting - Tìm tất cả meeting
@@ -424,6 +635,41 @@ Gửi tin nhắn bình thường:
 • /ask Deadline nào gần nhất?
 • /ask Tóm tắt công việc hôm nay
 
+**📰 Báo cáo**
+/digest - Tổng quan hôm nay
+/digest weekly - Tổng kết tuần
+/digest on [HH:MM] - Nhận báo cáo tự động mỗi sáng (và tổng kết cuối tuần)
+/digest off - Tắt báo cáo tự động
+
+**🔗 Phụ thuộc & subtask**
+/block [task] [task chặn] - Đánh dấu task bị chặn bởi task khác
+/unblock [task] [task chặn] - Bỏ liên kết chặn
+/subtask [task con] [task cha] - Gắn task con vào task cha
+/unsubtask [task con] [task cha] - Tách task con khỏi task cha
+/deps [task] - Xem task chặn và tiến độ subtask
+
+**🎯 Làm gì tiếp?**
+/next - Gợi ý task nên làm ngay, kèm lý do
+/next [số lượng] [project] - Ví dụ: /next 5 smap
+
+**⏱ Bấm giờ**
+/track [task] - Bắt đầu tính giờ làm task (tự dừng task đang chạy)
+/stop - Dừng và ghi thời gian vào task
+
+**🗓 Xếp lịch**
+/slots [task] - Gợi ý khoảng trống trong lịch để làm task
+/schedule [task] - Đặt lịch làm task vào khoảng trống tốt nhất
+/schedule [task] [YYYY-MM-DD HH:MM] - Đặt lịch vào giờ chỉ định
+
+**📜 Lịch sử & hoàn tác**
+/history [task] - Xem các thay đổi của task
+/undo - Hoàn tác thay đổi gần nhất của bạn
+/rollback [mã lô] - Hoàn tác toàn bộ task vừa tạo trong một lô
+
+**🗄 Lưu trữ**
+/archive - Xem trước các task đã hoàn thành sẽ được lưu trữ
+/archive confirm - Lưu trữ các task đó
+
 **💡 Mẹo:**
 • Agent mode (/ask) thông minh hơn nhưng chậm hơn
 • Search mode (/search) nhanh hơn cho truy vấn đơn giản
@@ -2,14 +2,18 @@ package telegram_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+
+	tgDelivery "autonomous-task-management/internal/task/delivery/telegram"
 )
 
 // TestTelegramWebhook_InvalidJSON tests that invalid JSON returns error
@@ -111,5 +115,80 @@ func TestWebhookResponse_Structure(t *testing.T) {
 	assert.Equal(t, "Success", result["message"])
 }
 
+type countingBot struct {
+	mu    sync.Mutex
+	sends int
+}
+
+func (b *countingBot) SetWebhook(string) error                          { return nil }
+func (b *countingBot) SendMessage(int64, string) error                  { return b.count() }
+func (b *countingBot) SendMessageHTML(int64, string) error              { return b.count() }
+func (b *countingBot) SendMessagePlain(int64, string) error             { return b.count() }
+func (b *countingBot) SendMessageWithMode(int64, string, string) error  { return b.count() }
+func (b *countingBot) SendDocument(int64, string, []byte, string) error { return b.count() }
+func (b *countingBot) DownloadFile(string) ([]byte, error)              { return nil, nil }
+
+func (b *countingBot) count() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sends++
+	return nil
+}
+
+func (b *countingBot) total() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.sends
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(context.Context, ...any)           {}
+func (nopLogger) Debugf(context.Context, string, ...any)  {}
+func (nopLogger) Info(context.Context, ...any)            {}
+func (nopLogger) Infof(context.Context, string, ...any)   {}
+func (nopLogger) Warn(context.Context, ...any)            {}
+func (nopLogger) Warnf(context.Context, string, ...any)   {}
+func (nopLogger) Error(context.Context, ...any)           {}
+func (nopLogger) Errorf(context.Context, string, ...any)  {}
+func (nopLogger) DPanic(context.Context, ...any)          {}
+func (nopLogger) DPanicf(context.Context, string, ...any) {}
+func (nopLogger) Panic(context.Context, ...any)           {}
+func (nopLogger) Panicf(context.Context, string, ...any)  {}
+func (nopLogger) Fatal(context.Context, ...any)           {}
+func (nopLogger) Fatalf(context.Context, string, ...any)  {}
+
+// TestTelegramWebhook_ReplayedUpdateProcessedOnce tests update_id / message deduplication
+func TestTelegramWebhook_ReplayedUpdateProcessedOnce(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	bot := &countingBot{}
+	h := tgDelivery.New(nopLogger{}, nil, bot, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	router := gin.New()
+	router.POST("/webhook/telegram", h.HandleWebhook)
+
+	post := func(updateID int64) {
+		body := `{"update_id":` + jsonInt(updateID) + `,"message":{"message_id":7,"from":{"id":1},"chat":{"id":1},"date":0,"text":"/help"}}`
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/webhook/telegram", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	post(100)
+	post(100) // Telegram retry
+	post(101) // Same message re-sent under a new update ID
+
+	assert.Eventually(t, func() bool { return bot.total() == 1 }, time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 1, bot.total())
+}
+
+func jsonInt(v int64) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
 // Note: Full E2E tests with mocks are in handler_test.go
 // These simplified tests verify basic functionality without complex dependencies
@@ -0,0 +1,109 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+)
+
+// historyDiffLines caps how many added/removed lines are shown per change.
+const historyDiffLines = 3
+
+// actionLabels are the Vietnamese names of recorded actions.
+var actionLabels = map[history.Action]string{
+	history.ActionUpdate:     "cập nhật",
+	history.ActionCheck:      "đánh dấu checkbox",
+	history.ActionUncheck:    "bỏ đánh dấu checkbox",
+	history.ActionComplete:   "hoàn thành",
+	history.ActionReschedule: "đổi lịch",
+	history.ActionMerge:      "gộp task",
+	history.ActionLink:       "liên kết",
+	history.ActionAutomation: "tự động hóa",
+	history.ActionArchive:    "lưu trữ",
+	history.ActionRestore:    "khôi phục",
+	history.ActionUndo:       "hoàn tác",
+	history.ActionTimeLog:    "ghi thời gian",
+}
+
+// handleHistory handles "/history <task_id>".
+func (h *handler) handleHistory(ctx context.Context, sc model.Scope, taskID string, chatID int64) error {
+	if h.history == nil {
+		return h.bot.SendMessage(chatID, "❌ Tính năng lịch sử chưa được bật.")
+	}
+	if taskID == "" {
+		return h.bot.SendMessage(chatID, "❌ Vui lòng nhập task ID.\n\nVí dụ: `/history abc123`")
+	}
+
+	events, err := h.history.List(ctx, sc, history.ListInput{TaskID: taskID})
+	if err != nil {
+		h.l.Errorf(ctx, "telegram handler: history failed: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không thể lấy lịch sử. Vui lòng thử lại.")
+	}
+	return h.bot.SendMessage(chatID, formatHistory(taskID, events))
+}
+
+// handleUndo handles "/undo".
+func (h *handler) handleUndo(ctx context.Context, sc model.Scope, chatID int64) error {
+	if h.history == nil {
+		return h.bot.SendMessage(chatID, "❌ Tính năng hoàn tác chưa được bật.")
+	}
+
+	output, err := h.history.Undo(ctx, sc)
+	switch {
+	case errors.Is(err, history.ErrNothingToUndo):
+		return h.bot.SendMessage(chatID, "ℹ️ Không có thay đổi nào để hoàn tác.")
+	case errors.Is(err, history.ErrUndoConflict):
+		return h.bot.SendMessage(chatID, fmt.Sprintf("⚠️ Task %s đã được chỉnh sửa sau thay đổi này nên không thể hoàn tác. Xem `/history %s`.",
+			output.Event.TaskID, output.Event.TaskID))
+	case err != nil:
+		h.l.Errorf(ctx, "telegram handler: undo failed: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không thể hoàn tác. Vui lòng thử lại.")
+	}
+
+	title := extractTitle(output.Task.Content)
+	if title == "" {
+		title = output.Event.TaskID
+	}
+	return h.bot.SendMessage(chatID, fmt.Sprintf("↩️ Đã hoàn tác %s trên task: %s", actionLabel(output.Event.Action), title))
+}
+
+// formatHistory renders the changes of a task, newest first.
+func formatHistory(taskID string, events []history.ChangeEvent) string {
+	if len(events) == 0 {
+		return fmt.Sprintf("📜 Chưa có thay đổi nào được ghi lại cho task %s.", taskID)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📜 Lịch sử task %s:\n", taskID))
+	for _, e := range events {
+		sb.WriteString(fmt.Sprintf("\n• %s — %s bởi %s", e.At.Format("02/01 15:04"), actionLabel(e.Action), e.Actor))
+		if e.Undone {
+			sb.WriteString(" (đã hoàn tác)")
+		}
+		sb.WriteString("\n")
+		writeDiffLines(&sb, "-", e.Removed)
+		writeDiffLines(&sb, "+", e.Added)
+	}
+	return sb.String()
+}
+
+func writeDiffLines(sb *strings.Builder, sign string, lines []string) {
+	for i, line := range lines {
+		if i == historyDiffLines {
+			sb.WriteString(fmt.Sprintf("   %s … (%d dòng)\n", sign, len(lines)-historyDiffLines))
+			return
+		}
+		sb.WriteString(fmt.Sprintf("   %s %s\n", sign, line))
+	}
+}
+
+func actionLabel(action history.Action) string {
+	if label, ok := actionLabels[action]; ok {
+		return label
+	}
+	return string(action)
+}
@@ -0,0 +1,121 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task"
+	"autonomous-task-management/pkg/taskimport"
+	pkgTelegram "autonomous-task-management/pkg/telegram"
+)
+
+// importSkipPreviewLimit caps how many skipped items the /import summary lists.
+const importSkipPreviewLimit = 10
+
+// isImportCommand reports whether text starts with the /import command.
+func isImportCommand(text string) bool {
+	cmd, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	cmd, _, _ = strings.Cut(cmd, " ")
+	return cmd == "/import"
+}
+
+// handleImport handles a file sent with the caption "/import [format] [all]", or "/import" followed by
+// pasted Markdown or CSV on the next lines. "all" skips the duplicate check.
+func (h *handler) handleImport(ctx context.Context, sc model.Scope, msg *pkgTelegram.Message) error {
+	command := msg.Text
+	if msg.Document != nil {
+		command = msg.Caption
+	}
+	header, body, _ := strings.Cut(strings.TrimSpace(command), "\n")
+
+	input := task.ImportInput{}
+	for _, arg := range strings.Fields(strings.TrimPrefix(header, "/import")) {
+		if arg == "all" {
+			input.SkipDuplicateCheck = true
+		} else {
+			input.Format = arg
+		}
+	}
+
+	if msg.Document != nil {
+		data, err := h.bot.DownloadFile(msg.Document.FileID)
+		if err != nil {
+			h.l.Errorf(ctx, "telegram handler: failed to download import file: %v", err)
+			return h.bot.SendMessage(msg.Chat.ID, "❌ Không tải được file. File tối đa 20MB.")
+		}
+		input.Filename = msg.Document.FileName
+		input.Data = data
+	} else {
+		input.Data = []byte(body)
+	}
+
+	if len(strings.TrimSpace(string(input.Data))) == 0 {
+		return h.bot.SendMessageWithMode(msg.Chat.ID, "❌ Cú pháp: gửi file kèm chú thích `/import`, hoặc `/import` rồi dán danh sách task ở các dòng tiếp theo.\n\n"+
+			"Hỗ trợ: Markdown checklist, CSV (title, due, priority, tags), JSON xuất từ Todoist hoặc Trello.", "Markdown")
+	}
+
+	if err := h.bot.SendMessage(msg.Chat.ID, "⏳ Đang nhập task..."); err != nil {
+		h.l.Warnf(ctx, "telegram handler: failed to send ack message: %v", err)
+	}
+
+	output, err := h.uc.Import(ctx, sc, input)
+	switch {
+	case errors.Is(err, task.ErrNothingToImport):
+		return h.bot.SendMessage(msg.Chat.ID, "⚠️ Không tìm thấy task nào trong file.")
+	case errors.Is(err, taskimport.ErrUnknownFormat):
+		return h.bot.SendMessage(msg.Chat.ID, "❌ Định dạng không hỗ trợ. Dùng: markdown, csv, todoist hoặc trello.")
+	case errors.Is(err, taskimport.ErrInvalidFile):
+		return h.bot.SendMessage(msg.Chat.ID, fmt.Sprintf("❌ File không hợp lệ: %v", err))
+	case err != nil:
+		h.l.Errorf(ctx, "telegram handler: Import failed: %v", err)
+		return h.bot.SendMessage(msg.Chat.ID, "❌ Không thể nhập task. Vui lòng thử lại.")
+	}
+
+	return h.bot.SendMessageWithMode(msg.Chat.ID, formatImportSummary(output), "Markdown")
+}
+
+// formatImportSummary renders the counts of an import and the items that were skipped.
+func formatImportSummary(output task.ImportOutput) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📥 *Nhập task (%s):* %d mục trong file\n\n", output.Format, output.Total))
+	sb.WriteString(fmt.Sprintf("✅ Đã tạo: %d\n", len(output.Tasks)))
+	if len(output.Tasks) > 0 && output.Embedded < len(output.Tasks) {
+		sb.WriteString(fmt.Sprintf("⚠️ Chưa lập chỉ mục tìm kiếm: %d (đang thử lại)\n", len(output.Tasks)-output.Embedded))
+	}
+	if len(output.Skipped) > 0 {
+		sb.WriteString(fmt.Sprintf("⏭ Bỏ qua: %d\n", len(output.Skipped)))
+	}
+	sb.WriteString("\n")
+
+	for i, s := range output.Skipped {
+		if i == importSkipPreviewLimit {
+			sb.WriteString(fmt.Sprintf("… và %d mục khác\n", len(output.Skipped)-importSkipPreviewLimit))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("• %s — %s\n", s.Title, importSkipReason(s.Reason)))
+	}
+	if len(output.Skipped) > 0 {
+		sb.WriteString("_Gửi lại với_ `/import all` _để nhập cả các mục trùng._\n\n")
+	}
+
+	sb.WriteString(formatFailedTasks(output.Outcomes))
+	if output.BatchID != "" {
+		sb.WriteString(fmt.Sprintf("↩️ Hoàn tác cả lô: `/rollback %s`", output.BatchID))
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func importSkipReason(reason task.ImportSkipReason) string {
+	switch reason {
+	case task.ImportSkipDuplicateInFile:
+		return "trùng trong file"
+	case task.ImportSkipExisting:
+		return "đã có task cùng tên"
+	case task.ImportSkipSimilar:
+		return "giống task đã có"
+	}
+	return string(reason)
+}
@@ -1,18 +1,34 @@
 package telegram
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
 
 	"autonomous-task-management/internal/agent"
 	"autonomous-task-management/internal/automation"
 	"autonomous-task-management/internal/checklist"
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/digest"
+	"autonomous-task-management/internal/export"
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/ranking"
 	"autonomous-task-management/internal/router"
+	"autonomous-task-management/internal/schedule"
 	"autonomous-task-management/internal/task"
 	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/internal/timetrack"
+	"autonomous-task-management/pkg/idempotency"
 	pkgLog "autonomous-task-management/pkg/log"
 	pkgTelegram "autonomous-task-management/pkg/telegram"
 )
 
+const (
+	// Telegram retries an update until it gets a 200; remember update IDs well past its retry window.
+	seenUpdatesSize = 10000
+	seenUpdatesTTL  = time.Hour
+)
+
 // Handler is the interface for the Telegram delivery handler.
 type Handler interface {
 	HandleWebhook(c *gin.Context)
@@ -28,6 +44,13 @@ func New(
 	checklistUC checklist.UseCase,
 	memosRepo repository.MemosRepository,
 	routerUC router.UseCase,
+	digestUC digest.UseCase,
+	dependencyUC dependency.UseCase,
+	historyUC history.UseCase,
+	exportUC export.UseCase,
+	timetrackUC timetrack.UseCase,
+	scheduleUC schedule.UseCase,
+	rankingUC ranking.UseCase,
 ) Handler {
 	return &handler{
 		l:            l,
@@ -38,5 +61,13 @@ func New(
 		checklistSvc: checklistUC,
 		memosRepo:    memosRepo,
 		router:       routerUC,
+		digest:       digestUC,
+		dependency:   dependencyUC,
+		history:      historyUC,
+		export:       exportUC,
+		timetrack:    timetrackUC,
+		schedule:     scheduleUC,
+		ranking:      rankingUC,
+		seen:         idempotency.New[struct{}](seenUpdatesSize, seenUpdatesTTL),
 	}
 }
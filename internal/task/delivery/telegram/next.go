@@ -0,0 +1,65 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/ranking"
+)
+
+// handleNext handles "/next [limit] [project]": the tasks worth doing now, with the reasons.
+func (h *handler) handleNext(ctx context.Context, sc model.Scope, args string, chatID int64) error {
+	if h.ranking == nil {
+		return h.bot.SendMessage(chatID, "❌ Tính năng gợi ý chưa được bật.")
+	}
+
+	input := ranking.NextInput{}
+	for _, arg := range strings.Fields(args) {
+		if n, err := strconv.Atoi(arg); err == nil {
+			input.Limit = n
+		} else {
+			input.Project = strings.TrimPrefix(strings.TrimPrefix(arg, "#"), "project/")
+		}
+	}
+
+	out, err := h.ranking.Next(ctx, sc, input)
+	if errors.Is(err, ranking.ErrNoOpenTasks) {
+		if out.Blocked > 0 {
+			return h.bot.SendMessage(chatID, fmt.Sprintf("⛔ Cả %d task đang mở đều bị chặn. Dùng /deps để xem task nào cần làm trước.", out.Blocked))
+		}
+		return h.bot.SendMessage(chatID, "🎉 Không còn task nào đang mở!")
+	}
+	if err != nil {
+		h.l.Errorf(ctx, "telegram handler: Next failed: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không thể gợi ý task. Vui lòng thử lại.")
+	}
+
+	return h.bot.SendMessageWithMode(chatID, formatNext(out), "Markdown")
+}
+
+// formatNext renders the ranked tasks with their reasons and a ready-to-send /track command.
+func formatNext(out ranking.NextOutput) string {
+	var sb strings.Builder
+	sb.WriteString("🎯 *Nên làm tiếp*\n")
+	if !out.FreeUntil.IsZero() {
+		sb.WriteString(fmt.Sprintf("🕒 Rảnh đến %s\n", out.FreeUntil.Format("15:04")))
+	}
+	sb.WriteString("\n")
+
+	for i, t := range out.Tasks {
+		sb.WriteString(fmt.Sprintf("%d. *%s* — %.0f điểm\n", i+1, t.Title, t.Score))
+		if len(t.Reasons) > 0 {
+			sb.WriteString(fmt.Sprintf("   %s\n", strings.Join(t.Reasons, " · ")))
+		}
+		sb.WriteString(fmt.Sprintf("   `/track %s`\n", t.TaskID))
+	}
+
+	if out.Blocked > 0 {
+		sb.WriteString(fmt.Sprintf("\n⛔ %d task đang bị chặn không được tính.", out.Blocked))
+	}
+	return strings.TrimSpace(sb.String())
+}
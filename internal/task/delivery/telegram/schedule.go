@@ -0,0 +1,87 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/schedule"
+)
+
+// handleSlots handles "/slots <task_id>": proposes free slots with a ready-to-send /schedule command each.
+func (h *handler) handleSlots(ctx context.Context, sc model.Scope, taskID string, chatID int64) error {
+	if h.schedule == nil {
+		return h.bot.SendMessage(chatID, "❌ Tính năng xếp lịch chưa được bật.")
+	}
+
+	out, err := h.schedule.FindSlots(ctx, sc, schedule.FindInput{TaskID: taskID})
+	if err != nil {
+		return h.sendScheduleError(ctx, chatID, err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🗓 *%s* (%d phút)\n", out.Title, int(out.Duration.Minutes())))
+	if !out.Deadline.IsZero() {
+		sb.WriteString(fmt.Sprintf("Hạn: %s\n", out.Deadline.Format("02/01")))
+	}
+	sb.WriteString("\n")
+	for i, s := range out.Slots {
+		sb.WriteString(fmt.Sprintf("%d. %s\n   `/schedule %s %s`\n", i+1, formatSlot(s), taskID, s.Start.Format("2006-01-02 15:04")))
+	}
+	return h.bot.SendMessageWithMode(chatID, strings.TrimSpace(sb.String()), "Markdown")
+}
+
+// handleSchedule handles "/schedule <task_id> [YYYY-MM-DD HH:MM]".
+func (h *handler) handleSchedule(ctx context.Context, sc model.Scope, args string, chatID int64) error {
+	if h.schedule == nil {
+		return h.bot.SendMessage(chatID, "❌ Tính năng xếp lịch chưa được bật.")
+	}
+
+	taskID, at, _ := strings.Cut(args, " ")
+	out, err := h.schedule.Book(ctx, sc, schedule.BookInput{TaskID: taskID, At: strings.TrimSpace(at)})
+	if err != nil {
+		return h.sendScheduleError(ctx, chatID, err)
+	}
+
+	text := fmt.Sprintf("✅ Đã xếp lịch %s: %s", out.Title, formatSlot(out.Slot))
+	if out.Replaced {
+		text += "\n↪️ Lịch cũ đã được xóa."
+	}
+	if out.EventLink != "" {
+		text += "\n" + out.EventLink
+	}
+	return h.bot.SendMessage(chatID, text)
+}
+
+// sendScheduleError explains a scheduling failure.
+func (h *handler) sendScheduleError(ctx context.Context, chatID int64, err error) error {
+	switch {
+	case errors.Is(err, schedule.ErrNoCalendar):
+		return h.bot.SendMessage(chatID, "❌ Chưa kết nối Google Calendar.")
+	case errors.Is(err, schedule.ErrTaskRequired):
+		return h.bot.SendMessageWithMode(chatID, "❌ Vui lòng nhập task ID.\n\nVí dụ: `/slots abc123`", "Markdown")
+	case errors.Is(err, schedule.ErrTaskDone):
+		return h.bot.SendMessage(chatID, "ℹ️ Task này đã hoàn thành.")
+	case errors.Is(err, schedule.ErrNoFreeSlot):
+		return h.bot.SendMessage(chatID, "😕 Không còn khoảng trống nào trong giờ làm việc sắp tới.")
+	case errors.Is(err, schedule.ErrInvalidDuration):
+		return h.bot.SendMessage(chatID, "❌ Ước tính của task dài hơn một ngày làm việc. Hãy chia nhỏ task.")
+	case errors.Is(err, schedule.ErrInvalidStart):
+		return h.bot.SendMessageWithMode(chatID, "❌ Sai định dạng giờ. Ví dụ: `/schedule abc123 2026-03-10 14:00`", "Markdown")
+	case errors.Is(err, schedule.ErrSlotUnavailable):
+		return h.bot.SendMessage(chatID, "❌ Giờ này nằm ngoài giờ làm việc hoặc trùng lịch khác. Dùng /slots để xem khoảng trống.")
+	}
+	h.l.Errorf(ctx, "telegram: scheduling failed: %v", err)
+	return h.bot.SendMessage(chatID, "❌ Không thể xếp lịch. Vui lòng kiểm tra task ID.")
+}
+
+// formatSlot renders a slot as "14:00–15:30 10/03", flagging slots past the deadline.
+func formatSlot(s schedule.Slot) string {
+	text := fmt.Sprintf("%s–%s %s", s.Start.Format("15:04"), s.End.Format("15:04"), s.Start.Format("02/01"))
+	if s.Late {
+		text += " ⚠️ sau hạn"
+	}
+	return text
+}
@@ -0,0 +1,75 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/timetrack"
+)
+
+// handleStartTimer handles "/track <task_id>".
+func (h *handler) handleStartTimer(ctx context.Context, sc model.Scope, taskID string, chatID int64) error {
+	if h.timetrack == nil {
+		return h.bot.SendMessage(chatID, "❌ Tính năng bấm giờ chưa được bật.")
+	}
+
+	out, err := h.timetrack.Start(ctx, sc, timetrack.StartInput{TaskID: taskID})
+	switch {
+	case errors.Is(err, timetrack.ErrTaskRequired):
+		return h.bot.SendMessageWithMode(chatID, "❌ Vui lòng nhập task ID.\n\nVí dụ: `/track abc123`", "Markdown")
+	case errors.Is(err, timetrack.ErrAlreadyRunning):
+		return h.bot.SendMessage(chatID, fmt.Sprintf("⏱ Đang bấm giờ \"%s\" từ %s.",
+			out.Session.Title, out.Session.Start.Format("15:04")))
+	case err != nil:
+		h.l.Errorf(ctx, "telegram: start timer failed: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không thể bắt đầu bấm giờ. Vui lòng kiểm tra task ID.")
+	}
+
+	var sb strings.Builder
+	if out.Stopped != nil {
+		sb.WriteString(formatStoppedTimer(*out.Stopped))
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString(fmt.Sprintf("▶️ Bắt đầu bấm giờ: %s\nGõ /stop khi xong.", out.Session.Title))
+	return h.bot.SendMessage(chatID, sb.String())
+}
+
+// handleStopTimer handles "/stop".
+func (h *handler) handleStopTimer(ctx context.Context, sc model.Scope, chatID int64) error {
+	if h.timetrack == nil {
+		return h.bot.SendMessage(chatID, "❌ Tính năng bấm giờ chưa được bật.")
+	}
+
+	out, err := h.timetrack.Stop(ctx, sc)
+	switch {
+	case errors.Is(err, timetrack.ErrNoActiveTimer):
+		return h.bot.SendMessage(chatID, "ℹ️ Không có đồng hồ nào đang chạy. Dùng /track [task] để bắt đầu.")
+	case errors.Is(err, timetrack.ErrSessionTooShort):
+		return h.bot.SendMessage(chatID, fmt.Sprintf("⏹ Đã dừng \"%s\" (dưới 1 phút, không ghi lại).", out.Session.Title))
+	case err != nil:
+		h.l.Errorf(ctx, "telegram: stop timer failed: %v", err)
+		return h.bot.SendMessage(chatID, "❌ Không thể ghi thời gian vào task. Đồng hồ vẫn chạy, vui lòng thử lại /stop.")
+	}
+
+	return h.bot.SendMessage(chatID, formatStoppedTimer(out))
+}
+
+// formatStoppedTimer summarizes a logged session with the task's total against its estimate.
+func formatStoppedTimer(out timetrack.StopOutput) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("⏹ %s: %s", out.Session.Title, timetrack.FormatMinutes(out.Minutes)))
+	if out.Capped {
+		sb.WriteString(" (đã giới hạn vì quên dừng)")
+	}
+	sb.WriteString(fmt.Sprintf("\n⏱ Tổng: %s", timetrack.FormatMinutes(out.TotalMinutes)))
+	if out.Estimated > 0 {
+		sb.WriteString(fmt.Sprintf(" / ước tính %s", timetrack.FormatMinutes(out.Estimated)))
+		if out.TotalMinutes > out.Estimated {
+			sb.WriteString(" ⚠️")
+		}
+	}
+	return sb.String()
+}
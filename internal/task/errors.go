@@ -8,4 +8,11 @@ var (
 	ErrNoTasksParsed = errors.New("no tasks parsed from input")
 	ErrMemoCreate    = errors.New("failed to create memo")
 	ErrEmptyQuery    = errors.New("search query is empty")
+
+	ErrDuplicateNotFound      = errors.New("pending duplicate not found or expired")
+	ErrInvalidDuplicateAction = errors.New("invalid duplicate action")
+
+	ErrBatchNotFound = errors.New("batch not found, expired or already rolled back")
+
+	ErrNothingToImport = errors.New("no tasks found in import file")
 )
@@ -5,6 +5,7 @@ import (
 
 	"autonomous-task-management/internal/agent"
 	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/schedule"
 	"autonomous-task-management/pkg/gcalendar"
 )
 
@@ -12,6 +13,12 @@ import (
 type CalendarClient interface {
 	CreateEvent(ctx context.Context, req gcalendar.CreateEventRequest) (*gcalendar.Event, error)
 	ListEvents(ctx context.Context, req gcalendar.ListEventsRequest) ([]gcalendar.Event, error)
+	DeleteEvent(ctx context.Context, calendarID, eventID string) error
+}
+
+// Scheduler books work blocks in free calendar time (satisfied by schedule.UseCase).
+type Scheduler interface {
+	Book(ctx context.Context, sc model.Scope, input schedule.BookInput) (schedule.BookOutput, error)
 }
 
 // UseCase defines the business logic interface for the task domain.
@@ -19,6 +26,15 @@ type UseCase interface {
 	// CreateBulk parses raw text from the user, creates tasks in Memos, and optionally schedules events in Google Calendar.
 	CreateBulk(ctx context.Context, sc model.Scope, input CreateBulkInput) (CreateBulkOutput, error)
 
+	// ResolveDuplicate merges, updates or force-creates a task that CreateBulk held back as a likely duplicate.
+	ResolveDuplicate(ctx context.Context, sc model.Scope, input ResolveDuplicateInput) (ResolveDuplicateOutput, error)
+
+	// RollbackBatch undoes every side effect (memo, vector, calendar event) of a CreateBulk batch.
+	RollbackBatch(ctx context.Context, sc model.Scope, batchID string) (RollbackOutput, error)
+
+	// Import creates tasks from a Markdown, CSV, Todoist or Trello export, skipping ones that already exist.
+	Import(ctx context.Context, sc model.Scope, input ImportInput) (ImportOutput, error)
+
 	// Search performs semantic search on tasks.
 	Search(ctx context.Context, sc model.Scope, input SearchInput) (SearchOutput, error)
 
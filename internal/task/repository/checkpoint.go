@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"fmt"
+	"sync"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/jsonfile"
+)
+
+// FileCheckpoint is an IndexCheckpoint kept in a JSON file mapping task IDs to the UpdateTime
+// that was indexed. A task edited since then counts as not indexed.
+type FileCheckpoint struct {
+	mu      sync.Mutex
+	path    string
+	indexed map[string]string
+}
+
+// NewFileCheckpoint loads the checkpoint at path, or starts an empty one if the file is missing.
+func NewFileCheckpoint(path string) (*FileCheckpoint, error) {
+	cp := &FileCheckpoint{path: path, indexed: make(map[string]string)}
+	if err := jsonfile.Load(path, &cp.indexed); err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	return cp, nil
+}
+
+// Len returns the number of tasks recorded as indexed.
+func (c *FileCheckpoint) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.indexed)
+}
+
+// Indexed reports whether this version of the task was indexed.
+func (c *FileCheckpoint) Indexed(task model.Task) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	updateTime, ok := c.indexed[task.ID]
+	return ok && updateTime == task.UpdateTime
+}
+
+// MarkIndexed records the tasks and rewrites the file atomically.
+func (c *FileCheckpoint) MarkIndexed(tasks []model.Task) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, t := range tasks {
+		c.indexed[t.ID] = t.UpdateTime
+	}
+
+	if err := jsonfile.Save(c.path, c.indexed); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+var _ IndexCheckpoint = (*FileCheckpoint)(nil)
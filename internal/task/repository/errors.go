@@ -0,0 +1,6 @@
+package repository
+
+import "errors"
+
+// ErrConflict is returned when a task changed between being read and being written back.
+var ErrConflict = errors.New("task was modified concurrently")
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"iter"
 
 	"autonomous-task-management/internal/model"
 )
@@ -12,13 +13,44 @@ type MemosRepository interface {
 	CreateTasksBatch(ctx context.Context, opts []CreateTaskOptions) ([]model.Task, error)
 	GetTask(ctx context.Context, id string) (model.Task, error)
 	ListTasks(ctx context.Context, opt ListTasksOptions) ([]model.Task, error)
+	ListTaskPage(ctx context.Context, opt ListTasksOptions) (TaskPage, error)
+	IterateTasks(ctx context.Context, opt ListTasksOptions) iter.Seq2[model.Task, error]
 	UpdateTask(ctx context.Context, id string, content string) error
+	// UpdateTaskIfUnchanged writes content only if the task's UpdateTime still equals expectedUpdateTime,
+	// returning ErrConflict otherwise. Use ModifyTask for read-modify-write with retries.
+	UpdateTaskIfUnchanged(ctx context.Context, id, content, expectedUpdateTime string) (model.Task, error)
+	DeleteTask(ctx context.Context, id string) error
+	ArchiveTask(ctx context.Context, id string) error
+	RestoreTask(ctx context.Context, id string) error
+	PinTask(ctx context.Context, id string, pinned bool) error
+	ListRelations(ctx context.Context, id string) ([]TaskRelation, error)
+	SetRelations(ctx context.Context, id string, relations []TaskRelation) error
 }
 
 // VectorRepository handles vector operations (Qdrant).
 type VectorRepository interface {
 	EmbedTask(ctx context.Context, task model.Task) error
+	// IndexTasks embeds and upserts many tasks in batches with bounded concurrency, for backfills
+	// and bulk imports. A failed batch does not stop the run; its tasks are listed in the result.
+	// The error is set only if the run was cancelled or the checkpoint could not be saved.
+	IndexTasks(ctx context.Context, tasks []model.Task, opt IndexOptions) (IndexResult, error)
 	SearchTasks(ctx context.Context, opt SearchTasksOptions) ([]SearchResult, error)
-	SearchTasksWithFilter(ctx context.Context, opt SearchTasksOptions) ([]SearchResult, error)
+	// FindSimilarTasks returns the limit tasks closest to each query by vector similarity alone,
+	// with cosine scores, in query order. Queries are embedded and searched in batches, so
+	// checking many texts costs a few requests rather than one per text.
+	FindSimilarTasks(ctx context.Context, queries []string, limit int) ([][]SearchResult, error)
+	// FilterTaskPage returns one page of the tasks matching a payload filter; IterateFilteredTasks
+	// walks every page. Results are unscored.
+	FilterTaskPage(ctx context.Context, opt FilterTasksOptions) (SearchPage, error)
+	IterateFilteredTasks(ctx context.Context, opt FilterTasksOptions) iter.Seq2[SearchResult, error]
+	// CountTasks returns the exact number of tasks matching a payload filter.
+	CountTasks(ctx context.Context, filter PayloadFilter) (int, error)
 	DeleteTask(ctx context.Context, taskID string) error
 }
+
+// IndexCheckpoint remembers which task versions are indexed, so an interrupted bulk run resumes
+// where it stopped. Implementations must be safe for concurrent use.
+type IndexCheckpoint interface {
+	Indexed(task model.Task) bool
+	MarkIndexed(tasks []model.Task) error
+}
@@ -0,0 +1,105 @@
+package local
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	frontMatterDelimiter = "---"
+	fileExt              = ".md"
+)
+
+// record is one task as stored on disk: a front matter block followed by the Markdown content.
+type record struct {
+	UID        string
+	Visibility string
+	Archived   bool
+	Pinned     bool
+	CreateTime string
+	UpdateTime string
+	Relations  []relation
+	Content    string
+}
+
+type relation struct {
+	Type      string
+	RelatedID string
+}
+
+// encode renders the record as front matter plus content.
+func (rec record) encode() []byte {
+	var sb strings.Builder
+	sb.WriteString(frontMatterDelimiter + "\n")
+	fmt.Fprintf(&sb, "uid: %s\n", rec.UID)
+	fmt.Fprintf(&sb, "visibility: %s\n", rec.Visibility)
+	fmt.Fprintf(&sb, "archived: %t\n", rec.Archived)
+	fmt.Fprintf(&sb, "pinned: %t\n", rec.Pinned)
+	fmt.Fprintf(&sb, "create_time: %s\n", rec.CreateTime)
+	fmt.Fprintf(&sb, "update_time: %s\n", rec.UpdateTime)
+	for _, rel := range rec.Relations {
+		fmt.Fprintf(&sb, "relation: %s %s\n", rel.Type, rel.RelatedID)
+	}
+	sb.WriteString(frontMatterDelimiter + "\n")
+	sb.WriteString(rec.Content)
+	return []byte(sb.String())
+}
+
+// decode parses a file written by encode. Unknown front matter keys are ignored.
+func decode(raw []byte) (record, error) {
+	header, content, ok := strings.Cut(string(raw), "\n"+frontMatterDelimiter+"\n")
+	if !ok || !strings.HasPrefix(header, frontMatterDelimiter+"\n") {
+		return record{}, fmt.Errorf("missing front matter")
+	}
+
+	rec := record{Content: content}
+	scanner := bufio.NewScanner(strings.NewReader(strings.TrimPrefix(header, frontMatterDelimiter+"\n")))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "uid":
+			rec.UID = value
+		case "visibility":
+			rec.Visibility = value
+		case "archived":
+			rec.Archived, _ = strconv.ParseBool(value)
+		case "pinned":
+			rec.Pinned, _ = strconv.ParseBool(value)
+		case "create_time":
+			rec.CreateTime = value
+		case "update_time":
+			rec.UpdateTime = value
+		case "relation":
+			if relType, relatedID, ok := strings.Cut(value, " "); ok {
+				rec.Relations = append(rec.Relations, relation{Type: relType, RelatedID: strings.TrimSpace(relatedID)})
+			}
+		}
+	}
+	return rec, scanner.Err()
+}
+
+// writeFile replaces path atomically, so a crash never leaves a half-written task behind.
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,451 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	pkgLog "autonomous-task-management/pkg/log"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+const (
+	defaultListLimit = 20
+	// namePrefix keeps task IDs in the Memos resource name format the rest of the service expects.
+	namePrefix = "memos/"
+	uidLength  = 12
+	// timeLayout is RFC3339 with fixed-width microseconds, so stored times also sort as strings.
+	timeLayout = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// ErrNotFound is returned when no task file exists for an ID.
+var ErrNotFound = errors.New("local repository: task not found")
+
+type implRepository struct {
+	dir     string
+	baseURL string // Optional; links are only generated when a UI serves the directory
+	l       pkgLog.Logger
+
+	mu         sync.RWMutex
+	lastUpdate time.Time // Keeps update times strictly increasing for conflict detection
+}
+
+// New creates a repository storing one Markdown file per task in dir, creating dir if needed.
+func New(dir, baseURL string, l pkgLog.Logger) (repository.MemosRepository, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create task directory %s: %w", dir, err)
+	}
+	return &implRepository{
+		dir:     dir,
+		baseURL: baseURL,
+		l:       l,
+	}, nil
+}
+
+func (r *implRepository) CreateTask(ctx context.Context, opt repository.CreateTaskOptions) (model.Task, error) {
+	content := opt.Content
+	if len(opt.Tags) > 0 {
+		content += "\n\n" + strings.Join(opt.Tags, " ")
+	}
+	visibility := opt.Visibility
+	if visibility == "" {
+		visibility = "PRIVATE"
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	uid, err := r.newUID()
+	if err != nil {
+		return model.Task{}, err
+	}
+	now := r.nextTime()
+	rec := record{
+		UID:        uid,
+		Visibility: visibility,
+		CreateTime: now,
+		UpdateTime: now,
+		Content:    content,
+	}
+	if err := r.write(rec); err != nil {
+		r.l.Errorf(ctx, "local repository: failed to create task: %v", err)
+		return model.Task{}, err
+	}
+	return r.toTask(rec), nil
+}
+
+func (r *implRepository) CreateTasksBatch(ctx context.Context, opts []repository.CreateTaskOptions) ([]model.Task, error) {
+	tasks := make([]model.Task, 0, len(opts))
+	for i, opt := range opts {
+		t, err := r.CreateTask(ctx, opt)
+		if err != nil {
+			r.l.Errorf(ctx, "local repository: batch item %d failed: %v", i, err)
+			continue // partial success, same as the Memos repository
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, nil
+}
+
+func (r *implRepository) GetTask(ctx context.Context, id string) (model.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rec, err := r.read(id)
+	if err != nil {
+		return model.Task{}, err
+	}
+	return r.toTask(rec), nil
+}
+
+func (r *implRepository) ListTasks(ctx context.Context, opt repository.ListTasksOptions) ([]model.Task, error) {
+	limit := opt.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	tasks, err := r.list(opt)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Offset >= len(tasks) {
+		return []model.Task{}, nil
+	}
+	tasks = tasks[opt.Offset:]
+	return tasks[:min(limit, len(tasks))], nil
+}
+
+// ListTaskPage pages by position; the token is the offset of the next page.
+func (r *implRepository) ListTaskPage(ctx context.Context, opt repository.ListTasksOptions) (repository.TaskPage, error) {
+	offset := 0
+	if opt.PageToken != "" {
+		var err error
+		if offset, err = strconv.Atoi(opt.PageToken); err != nil || offset < 0 {
+			return repository.TaskPage{}, fmt.Errorf("local repository: invalid page token %q", opt.PageToken)
+		}
+	}
+	opt.Offset = offset
+
+	tasks, err := r.ListTasks(ctx, opt)
+	if err != nil {
+		return repository.TaskPage{}, err
+	}
+
+	page := repository.TaskPage{Tasks: tasks}
+	limit := opt.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if len(tasks) == limit {
+		page.NextPageToken = strconv.Itoa(offset + limit)
+	}
+	return page, nil
+}
+
+func (r *implRepository) IterateTasks(ctx context.Context, opt repository.ListTasksOptions) iter.Seq2[model.Task, error] {
+	return func(yield func(model.Task, error) bool) {
+		for {
+			page, err := r.ListTaskPage(ctx, opt)
+			if err != nil {
+				yield(model.Task{}, err)
+				return
+			}
+			for _, t := range page.Tasks {
+				if !yield(t, nil) {
+					return
+				}
+			}
+			if page.NextPageToken == "" {
+				return
+			}
+			opt.PageToken = page.NextPageToken
+		}
+	}
+}
+
+func (r *implRepository) UpdateTask(ctx context.Context, id string, content string) error {
+	err := r.modify(id, func(rec *record) error {
+		rec.Content = content
+		return nil
+	})
+	if err != nil {
+		r.l.Errorf(ctx, "local repository: failed to update task %s: %v", id, err)
+	}
+	return err
+}
+
+// UpdateTaskIfUnchanged checks and writes under the same lock, so unlike Memos there is no lost-update window.
+func (r *implRepository) UpdateTaskIfUnchanged(ctx context.Context, id, content, expectedUpdateTime string) (model.Task, error) {
+	var updated record
+	err := r.modify(id, func(rec *record) error {
+		if rec.UpdateTime != expectedUpdateTime {
+			return repository.ErrConflict
+		}
+		rec.Content = content
+		updated = *rec
+		return nil
+	})
+	if err != nil {
+		return model.Task{}, err
+	}
+	return r.toTask(updated), nil
+}
+
+func (r *implRepository) DeleteTask(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path, err := r.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrNotFound
+		}
+		r.l.Errorf(ctx, "local repository: failed to delete task %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+func (r *implRepository) ArchiveTask(ctx context.Context, id string) error {
+	return r.modify(id, func(rec *record) error {
+		rec.Archived = true
+		return nil
+	})
+}
+
+func (r *implRepository) RestoreTask(ctx context.Context, id string) error {
+	return r.modify(id, func(rec *record) error {
+		rec.Archived = false
+		return nil
+	})
+}
+
+func (r *implRepository) PinTask(ctx context.Context, id string, pinned bool) error {
+	return r.modify(id, func(rec *record) error {
+		rec.Pinned = pinned
+		return nil
+	})
+}
+
+func (r *implRepository) ListRelations(ctx context.Context, id string) ([]repository.TaskRelation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rec, err := r.read(id)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]repository.TaskRelation, 0, len(rec.Relations))
+	for _, rel := range rec.Relations {
+		out = append(out, repository.TaskRelation{
+			TaskID:    namePrefix + rec.UID,
+			RelatedID: rel.RelatedID,
+			Type:      rel.Type,
+		})
+	}
+	return out, nil
+}
+
+func (r *implRepository) SetRelations(ctx context.Context, id string, relations []repository.TaskRelation) error {
+	return r.modify(id, func(rec *record) error {
+		rec.Relations = rec.Relations[:0]
+		for _, rel := range relations {
+			relType := rel.Type
+			if relType == "" {
+				relType = "REFERENCE"
+			}
+			rec.Relations = append(rec.Relations, relation{Type: relType, RelatedID: rel.RelatedID})
+		}
+		return nil
+	})
+}
+
+// list reads every task matching the filters of opt, pinned first, then newest first.
+func (r *implRepository) list(opt repository.ListTasksOptions) ([]model.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task directory: %w", err)
+	}
+
+	tags := opt.Tags
+	if opt.Tag != "" {
+		tags = append([]string{opt.Tag}, tags...)
+	}
+
+	var tasks []model.Task
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, fileExt) {
+			continue
+		}
+		rec, err := r.read(strings.TrimSuffix(name, fileExt))
+		if err != nil {
+			return nil, err
+		}
+		if matches(rec, opt, tags) {
+			tasks = append(tasks, r.toTask(rec))
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Pinned != tasks[j].Pinned {
+			return tasks[i].Pinned
+		}
+		return tasks[i].CreateTime > tasks[j].CreateTime
+	})
+	return tasks, nil
+}
+
+// matches applies the same filters the Memos repository sends to the server.
+func matches(rec record, opt repository.ListTasksOptions, tags []string) bool {
+	if rec.Archived != opt.Archived {
+		return false
+	}
+	if opt.Query != "" && !strings.Contains(rec.Content, opt.Query) {
+		return false
+	}
+	created := taskmeta.ParseTime(rec.CreateTime)
+	if !opt.CreatedAfter.IsZero() && created.Before(opt.CreatedAfter) {
+		return false
+	}
+	if !opt.CreatedBefore.IsZero() && !created.Before(opt.CreatedBefore) {
+		return false
+	}
+	if len(tags) == 0 {
+		return true
+	}
+
+	contentTags := taskmeta.ExtractTags(rec.Content)
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag == "" {
+			continue
+		}
+		if !strings.HasPrefix(tag, "#") {
+			tag = "#" + tag
+		}
+		if taskmeta.HasTag(contentTags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// modify applies change to the stored record under the write lock and bumps its update time.
+func (r *implRepository) modify(id string, change func(rec *record) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, err := r.read(id)
+	if err != nil {
+		return err
+	}
+	if err := change(&rec); err != nil {
+		return err
+	}
+	rec.UpdateTime = r.nextTime()
+	return r.write(rec)
+}
+
+func (r *implRepository) read(id string) (record, error) {
+	path, err := r.path(id)
+	if err != nil {
+		return record{}, err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return record{}, ErrNotFound
+		}
+		return record{}, err
+	}
+
+	rec, err := decode(raw)
+	if err != nil {
+		return record{}, fmt.Errorf("local repository: invalid task file %s: %w", filepath.Base(path), err)
+	}
+	if rec.UID == "" {
+		rec.UID = strings.TrimSuffix(filepath.Base(path), fileExt)
+	}
+	return rec, nil
+}
+
+func (r *implRepository) write(rec record) error {
+	path, err := r.path(rec.UID)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, rec.encode())
+}
+
+// path maps a task ID ("memos/<uid>" or "<uid>") to its file, rejecting IDs that would escape dir.
+func (r *implRepository) path(id string) (string, error) {
+	uid := strings.TrimPrefix(id, namePrefix)
+	if uid == "" || uid != filepath.Base(uid) || strings.HasPrefix(uid, ".") {
+		return "", fmt.Errorf("local repository: invalid task id %q", id)
+	}
+	return filepath.Join(r.dir, uid+fileExt), nil
+}
+
+// newUID returns a short random UID not used by an existing file. Callers hold the write lock.
+func (r *implRepository) newUID() (string, error) {
+	for range 5 {
+		uid := strings.ReplaceAll(uuid.NewString(), "-", "")[:uidLength]
+		path, err := r.path(uid)
+		if err != nil {
+			return "", err
+		}
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return uid, nil
+		}
+	}
+	return "", errors.New("local repository: failed to allocate a task id")
+}
+
+// nextTime returns the current time, nudged forward so no two writes share an update time.
+// Callers hold the write lock.
+func (r *implRepository) nextTime() string {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	if !now.After(r.lastUpdate) {
+		now = r.lastUpdate.Add(time.Microsecond)
+	}
+	r.lastUpdate = now
+	return now.Format(timeLayout)
+}
+
+func (r *implRepository) toTask(rec record) model.Task {
+	memoURL := ""
+	if r.baseURL != "" {
+		memoURL = fmt.Sprintf("%s/m/%s", r.baseURL, rec.UID)
+	}
+	return model.Task{
+		ID:         namePrefix + rec.UID,
+		UID:        rec.UID,
+		Content:    rec.Content,
+		Tags:       taskmeta.ExtractTags(rec.Content),
+		MemoURL:    memoURL,
+		Visibility: rec.Visibility,
+		Archived:   rec.Archived,
+		Pinned:     rec.Pinned,
+		CreateTime: rec.CreateTime,
+		UpdateTime: rec.UpdateTime,
+	}
+}
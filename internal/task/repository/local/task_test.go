@@ -0,0 +1,160 @@
+package local_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/internal/task/repository/local"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Debugf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Info(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Infof(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Warn(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Warnf(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Error(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Errorf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) DPanic(_ context.Context, _ ...any)            {}
+func (m *mockLogger) DPanicf(_ context.Context, _ string, _ ...any) {}
+func (m *mockLogger) Panic(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Panicf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Fatal(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Fatalf(_ context.Context, _ string, _ ...any)  {}
+
+func newRepo(t *testing.T) (repository.MemosRepository, string) {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := local.New(dir, "http://tasks.local", &mockLogger{})
+	require.NoError(t, err)
+	return repo, dir
+}
+
+func TestCreateGetUpdate_PersistsToDisk(t *testing.T) {
+	repo, dir := newRepo(t)
+	ctx := context.Background()
+
+	created, err := repo.CreateTask(ctx, repository.CreateTaskOptions{
+		Content: "# Review PR\n---\n- [ ] read diff",
+		Tags:    []string{"#pr/1", "#priority/p1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "memos/"+created.UID, created.ID)
+	assert.Equal(t, "http://tasks.local/m/"+created.UID, created.MemoURL)
+	assert.Equal(t, []string{"#pr/1", "#priority/p1"}, created.Tags)
+	assert.FileExists(t, filepath.Join(dir, created.UID+".md"))
+
+	require.NoError(t, repo.UpdateTask(ctx, created.ID, "# Review PR\n---\n- [x] read diff"))
+
+	// A fresh repository over the same directory sees the change
+	reopened, err := local.New(dir, "", &mockLogger{})
+	require.NoError(t, err)
+	got, err := reopened.GetTask(ctx, created.UID)
+	require.NoError(t, err)
+	assert.Equal(t, "# Review PR\n---\n- [x] read diff", got.Content)
+	assert.Equal(t, created.CreateTime, got.CreateTime)
+	assert.Greater(t, got.UpdateTime, created.UpdateTime)
+
+	_, err = repo.GetTask(ctx, "memos/missing")
+	assert.ErrorIs(t, err, local.ErrNotFound)
+}
+
+func TestUpdateTaskIfUnchanged_DetectsConflict(t *testing.T) {
+	repo, _ := newRepo(t)
+	ctx := context.Background()
+
+	task, err := repo.CreateTask(ctx, repository.CreateTaskOptions{Content: "v1"})
+	require.NoError(t, err)
+
+	updated, err := repo.UpdateTaskIfUnchanged(ctx, task.ID, "v2", task.UpdateTime)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", updated.Content)
+
+	_, err = repo.UpdateTaskIfUnchanged(ctx, task.ID, "v3", task.UpdateTime)
+	assert.ErrorIs(t, err, repository.ErrConflict)
+
+	// ModifyTask works unchanged on top of the local backend
+	modified, err := repository.ModifyTask(ctx, repo, task.ID, func(current model.Task) (string, error) {
+		return current.Content + "+", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v2+", modified.Content)
+}
+
+func TestListTasks_FiltersAndPaginates(t *testing.T) {
+	repo, _ := newRepo(t)
+	ctx := context.Background()
+
+	var ids []string
+	for _, content := range []string{"alpha #work", "beta #home", "gamma #work", "delta #work"} {
+		task, err := repo.CreateTask(ctx, repository.CreateTaskOptions{Content: content})
+		require.NoError(t, err)
+		ids = append(ids, task.ID)
+	}
+	require.NoError(t, repo.ArchiveTask(ctx, ids[3]))
+	require.NoError(t, repo.PinTask(ctx, ids[0], true))
+
+	work, err := repo.ListTasks(ctx, repository.ListTasksOptions{Tag: "work"})
+	require.NoError(t, err)
+	require.Len(t, work, 2)
+	assert.Equal(t, ids[0], work[0].ID, "pinned first")
+	assert.Equal(t, ids[2], work[1].ID)
+
+	archived, err := repo.ListTasks(ctx, repository.ListTasksOptions{Archived: true})
+	require.NoError(t, err)
+	require.Len(t, archived, 1)
+	assert.True(t, archived[0].Archived)
+
+	found, err := repo.ListTasks(ctx, repository.ListTasksOptions{Query: "beta"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, ids[1], found[0].ID)
+
+	none, err := repo.ListTasks(ctx, repository.ListTasksOptions{CreatedAfter: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	var iterated []string
+	for task, err := range repo.IterateTasks(ctx, repository.ListTasksOptions{Limit: 2}) {
+		require.NoError(t, err)
+		iterated = append(iterated, task.ID)
+	}
+	assert.ElementsMatch(t, ids[:3], iterated)
+}
+
+func TestRelationsAndDelete(t *testing.T) {
+	repo, dir := newRepo(t)
+	ctx := context.Background()
+
+	task, err := repo.CreateTask(ctx, repository.CreateTaskOptions{Content: "task"})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.SetRelations(ctx, task.ID, []repository.TaskRelation{{RelatedID: "memos/other"}}))
+	relations, err := repo.ListRelations(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []repository.TaskRelation{{TaskID: task.ID, RelatedID: "memos/other", Type: "REFERENCE"}}, relations)
+
+	require.NoError(t, repo.DeleteTask(ctx, task.ID))
+	_, err = os.Stat(filepath.Join(dir, task.UID+".md"))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+	assert.ErrorIs(t, repo.DeleteTask(ctx, task.ID), local.ErrNotFound)
+}
+
+func TestRejectsPathTraversal(t *testing.T) {
+	repo, _ := newRepo(t)
+
+	_, err := repo.GetTask(context.Background(), "memos/../../etc/passwd")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, local.ErrNotFound)
+}
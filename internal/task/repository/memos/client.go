@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 // Client is the HTTP wrapper for the Memos REST API.
@@ -27,154 +30,158 @@ func NewClient(baseURL, accessToken string) *Client {
 
 // CreateMemo creates a new memo via POST /api/v1/memos.
 func (c *Client) CreateMemo(ctx context.Context, req CreateMemoRequest) (*Memo, error) {
-	url := fmt.Sprintf("%s/api/v1/memos", c.baseURL)
-
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal create memo request: %w", err)
-	}
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to build create memo request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
-
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call memos create API: %w", err)
+	var memo Memo
+	if err := c.do(ctx, http.MethodPost, "memos", "create", req, &memo); err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return &memo, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		raw, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("memos API create error %d: %s", resp.StatusCode, string(raw))
+// UpdateMemo updates an existing memo via PATCH /api/v1/{name}.
+// Only the fields named in req.UpdateMask are changed.
+func (c *Client) UpdateMemo(ctx context.Context, name string, req UpdateMemoRequest) (*Memo, error) {
+	var memo Memo
+	if err := c.do(ctx, http.MethodPatch, name, "update", req, &memo); err != nil {
+		return nil, err
 	}
+	return &memo, nil
+}
 
+// GetMemo fetches a single memo by its resource name (e.g. "memos/{uid}").
+func (c *Client) GetMemo(ctx context.Context, name string) (*Memo, error) {
 	var memo Memo
-	if err := json.NewDecoder(resp.Body).Decode(&memo); err != nil {
-		return nil, fmt.Errorf("failed to decode memos create response: %w", err)
+	if err := c.do(ctx, http.MethodGet, name, "get", nil, &memo); err != nil {
+		return nil, err
 	}
 	return &memo, nil
 }
 
-// UpdateMemo updates an existing memo via PATCH /api/v1/{name}.
-func (c *Client) UpdateMemo(ctx context.Context, name string, req UpdateMemoRequest) (*Memo, error) {
-	url := fmt.Sprintf("%s/api/v1/%s", c.baseURL, name)
+// DeleteMemo deletes a memo via DELETE /api/v1/{name}.
+func (c *Client) DeleteMemo(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, name, "delete", nil, nil)
+}
 
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal update memo request: %w", err)
-	}
+// SetMemoState archives or restores a memo (state is StateNormal or StateArchived).
+func (c *Client) SetMemoState(ctx context.Context, name, state string) (*Memo, error) {
+	return c.UpdateMemo(ctx, name, UpdateMemoRequest{State: state, UpdateMask: "state"})
+}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewBuffer(body))
-	if err != nil {
-		return nil, fmt.Errorf("failed to build update memo request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
+// SetMemoPinned pins or unpins a memo.
+func (c *Client) SetMemoPinned(ctx context.Context, name string, pinned bool) (*Memo, error) {
+	return c.UpdateMemo(ctx, name, UpdateMemoRequest{Pinned: &pinned, UpdateMask: "pinned"})
+}
 
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call memos update API: %w", err)
+// ListMemos fetches one page of memos. Pass the returned NextPageToken back in
+// req.PageToken to fetch the next page; an empty token means the last page.
+func (c *Client) ListMemos(ctx context.Context, req ListMemosRequest) (*ListMemosResponse, error) {
+	query := url.Values{}
+	if req.PageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(req.PageSize))
+	}
+	if req.PageToken != "" {
+		query.Set("pageToken", req.PageToken)
+	}
+	if req.Filter != "" {
+		query.Set("filter", req.Filter)
+	}
+	if req.State != "" {
+		query.Set("state", req.State)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		raw, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("memos API update error %d: %s", resp.StatusCode, string(raw))
+	path := "memos"
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
 	}
 
-	var memo Memo
-	if err := json.NewDecoder(resp.Body).Decode(&memo); err != nil {
-		return nil, fmt.Errorf("failed to decode memos update response: %w", err)
+	var resp ListMemosResponse
+	if err := c.do(ctx, http.MethodGet, path, "list", nil, &resp); err != nil {
+		return nil, err
 	}
-	return &memo, nil
+	return &resp, nil
 }
 
-// GetMemo fetches a single memo by its resource name (e.g. "memos/{uid}").
-func (c *Client) GetMemo(ctx context.Context, name string) (*Memo, error) {
-	url := fmt.Sprintf("%s/api/v1/%s", c.baseURL, name)
-
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build get memo request: %w", err)
+// AllMemos iterates over every memo matching req, following page tokens.
+// Iteration stops at the first error, which is yielded with a zero Memo.
+func (c *Client) AllMemos(ctx context.Context, req ListMemosRequest) iter.Seq2[Memo, error] {
+	return func(yield func(Memo, error) bool) {
+		for {
+			page, err := c.ListMemos(ctx, req)
+			if err != nil {
+				yield(Memo{}, err)
+				return
+			}
+			for _, m := range page.Memos {
+				if !yield(m, nil) {
+					return
+				}
+			}
+			if page.NextPageToken == "" || page.NextPageToken == req.PageToken {
+				return
+			}
+			req.PageToken = page.NextPageToken
+		}
 	}
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
+}
 
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call memos get API: %w", err)
+// ListMemoRelations lists the relations of a memo via GET /api/v1/{name}/relations.
+func (c *Client) ListMemoRelations(ctx context.Context, name string) ([]MemoRelation, error) {
+	var resp struct {
+		Relations []MemoRelation `json:"relations"`
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		raw, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("memos API get error %d: %s", resp.StatusCode, string(raw))
+	if err := c.do(ctx, http.MethodGet, name+"/relations", "list relations", nil, &resp); err != nil {
+		return nil, err
 	}
+	return resp.Relations, nil
+}
 
-	var memo Memo
-	if err := json.NewDecoder(resp.Body).Decode(&memo); err != nil {
-		return nil, fmt.Errorf("failed to decode memos get response: %w", err)
-	}
-	return &memo, nil
+// SetMemoRelations replaces the relations of a memo via PATCH /api/v1/{name}/relations.
+func (c *Client) SetMemoRelations(ctx context.Context, name string, relations []MemoRelation) error {
+	req := struct {
+		Name      string         `json:"name"`
+		Relations []MemoRelation `json:"relations"`
+	}{Name: name, Relations: relations}
+	return c.do(ctx, http.MethodPatch, name+"/relations", "set relations", req, nil)
 }
 
-// ListMemos lists memos with an optional tag filter.
-func (c *Client) ListMemos(ctx context.Context, tag string, limit, offset int) ([]Memo, error) {
-	url := fmt.Sprintf("%s/api/v1/memos?pageSize=%d", c.baseURL, limit)
-	if tag != "" {
-		url += fmt.Sprintf("&filter=tag='%s'", tag)
+// do sends a request to /api/v1/{path}, encoding body (if any) as JSON and decoding
+// the response into out (if non-nil). op names the operation in error messages.
+func (c *Client) do(ctx context.Context, method, path, op string, body, out any) error {
+	endpoint := fmt.Sprintf("%s/api/v1/%s", c.baseURL, path)
+
+	var reader io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s memo request: %w", op, err)
+		}
+		reader = bytes.NewReader(raw)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	httpReq, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build list memos request: %w", err)
+		return fmt.Errorf("failed to build %s memo request: %w", op, err)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
 	}
 	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call memos list API: %w", err)
+		return fmt.Errorf("failed to call memos %s API: %w", op, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		raw, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("memos API list error %d: %s", resp.StatusCode, string(raw))
+		return fmt.Errorf("memos API %s error %d: %s", op, resp.StatusCode, string(raw))
 	}
 
-	var listResp struct {
-		Memos []Memo `json:"memos"`
+	if out == nil {
+		return nil
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&listResp); err != nil {
-		return nil, fmt.Errorf("failed to decode memos list response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode memos %s response: %w", op, err)
 	}
-	return listResp.Memos, nil
-}
-
-// ---- Request/Response types scoped to this package ----
-
-// CreateMemoRequest is the body for POST /api/v1/memos.
-type CreateMemoRequest struct {
-	Content    string `json:"content"`
-	Visibility string `json:"visibility"`
-}
-
-// UpdateMemoRequest is the body for PATCH /api/v1/{name}.
-type UpdateMemoRequest struct {
-	Content    string `json:"content"`
-	UpdateMask string `json:"updateMask"`
-}
-
-// Memo is the Memos API memo object.
-type Memo struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	UID        string `json:"uid"`
-	Content    string `json:"content"`
-	Visibility string `json:"visibility"`
-	CreateTime string `json:"createTime"`
-	UpdateTime string `json:"updateTime"`
+	return nil
 }
@@ -3,6 +3,7 @@ package memos_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -98,16 +99,16 @@ func TestMemosClient(t *testing.T) {
 	})
 
 	t.Run("ListMemos", func(t *testing.T) {
-		res, err := client.ListMemos(ctx, "test", 10, 0)
+		res, err := client.ListMemos(ctx, memos.ListMemosRequest{PageSize: 10, Filter: memos.Filter{Tags: []string{"test"}}.String()})
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
-		if len(res) != 1 || res[0].Content != "List item" {
+		if len(res.Memos) != 1 || res.Memos[0].Content != "List item" {
 			t.Errorf("unexpected list result: %+v", res)
 		}
 
 		// test error prop
-		_, err = client.ListMemos(ctx, "error", 10, 0)
+		_, err = client.ListMemos(ctx, memos.ListMemosRequest{PageSize: 10, Filter: memos.Filter{Tags: []string{"error"}}.String()})
 		if err == nil {
 			t.Errorf("expected error from filter")
 		}
@@ -195,3 +196,125 @@ func TestGetMemo_404ReturnsError(t *testing.T) {
 		t.Errorf("error should contain '404', got: %v", err)
 	}
 }
+
+// TestAllMemos_FollowsPageTokens verifies the iterator walks every page and forwards the query.
+func TestAllMemos_FollowsPageTokens(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		resp := memos.ListMemosResponse{Memos: []memos.Memo{{Name: "memos/a"}}, NextPageToken: "page-2"}
+		if r.URL.Query().Get("pageToken") == "page-2" {
+			resp = memos.ListMemosResponse{Memos: []memos.Memo{{Name: "memos/b"}, {Name: "memos/c"}}}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	client := memos.NewClient(ts.URL, "test-token")
+	var names []string
+	for m, err := range client.AllMemos(context.Background(), memos.ListMemosRequest{PageSize: 1, State: memos.StateArchived}) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		names = append(names, m.Name)
+	}
+
+	if strings.Join(names, ",") != "memos/a,memos/b,memos/c" {
+		t.Errorf("unexpected memos: %v", names)
+	}
+	if len(queries) != 2 || !strings.Contains(queries[1], "pageToken=page-2") || !strings.Contains(queries[1], "state=ARCHIVED") {
+		t.Errorf("unexpected queries: %v", queries)
+	}
+}
+
+// TestFilter_EscapesValues verifies user input cannot break out of the CEL string literals.
+func TestFilter_EscapesValues(t *testing.T) {
+	after := time.Unix(1700000000, 0)
+	got := memos.Filter{
+		Tags:          []string{"#work", `a"b`},
+		ContentSearch: `say "hi" \ bye`,
+		CreatedAfter:  after,
+	}.String()
+
+	want := `tag in ["work", "a\"b"] && content.contains("say \"hi\" \\ bye") && created_ts >= 1700000000`
+	if got != want {
+		t.Errorf("unexpected filter:\n got: %s\nwant: %s", got, want)
+	}
+	if (memos.Filter{}).String() != "" {
+		t.Errorf("empty filter should render as empty string")
+	}
+}
+
+// TestMemoMutations verifies delete, archive and pin requests.
+func TestMemoMutations(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, body))
+		json.NewEncoder(w).Encode(memos.Memo{Name: "memos/abc"})
+	}))
+	defer ts.Close()
+
+	client := memos.NewClient(ts.URL, "test-token")
+	ctx := context.Background()
+
+	if err := client.DeleteMemo(ctx, "memos/abc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.SetMemoState(ctx, "memos/abc", memos.StateArchived); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.SetMemoPinned(ctx, "memos/abc", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"DELETE /api/v1/memos/abc map[]",
+		"PATCH /api/v1/memos/abc map[state:ARCHIVED updateMask:state]",
+		"PATCH /api/v1/memos/abc map[pinned:false updateMask:pinned]",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected requests:\n%s", strings.Join(requests, "\n"))
+	}
+}
+
+// TestMemoRelations verifies relations are read and replaced on the relations sub-resource.
+func TestMemoRelations(t *testing.T) {
+	var setBody struct {
+		Relations []memos.MemoRelation `json:"relations"`
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/memos/abc/relations" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodPatch {
+			json.NewDecoder(r.Body).Decode(&setBody)
+			w.Write([]byte(`{}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"relations": []memos.MemoRelation{{
+			Memo: memos.MemoRef{Name: "memos/abc"}, RelatedMemo: memos.MemoRef{Name: "memos/def"}, Type: memos.RelationReference,
+		}}})
+	}))
+	defer ts.Close()
+
+	client := memos.NewClient(ts.URL, "test-token")
+	ctx := context.Background()
+
+	relations, err := client.ListMemoRelations(ctx, "memos/abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(relations) != 1 || relations[0].RelatedMemo.Name != "memos/def" {
+		t.Errorf("unexpected relations: %+v", relations)
+	}
+
+	if err := client.SetMemoRelations(ctx, "memos/abc", relations); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(setBody.Relations) != 1 || setBody.Relations[0].Type != memos.RelationReference {
+		t.Errorf("unexpected relations sent: %+v", setBody.Relations)
+	}
+}
@@ -0,0 +1,67 @@
+package memos
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Filter describes a server-side memo filter. Zero fields are ignored.
+type Filter struct {
+	Tags          []string  // Memo must carry any of these tags (with or without the leading '#')
+	ContentSearch string    // Substring the content must contain
+	CreatedAfter  time.Time // Inclusive
+	CreatedBefore time.Time // Exclusive
+}
+
+// String renders the filter as a Memos CEL expression, quoting every user-supplied value.
+func (f Filter) String() string {
+	var clauses []string
+
+	if len(f.Tags) > 0 {
+		quoted := make([]string, 0, len(f.Tags))
+		for _, tag := range f.Tags {
+			if tag = strings.TrimPrefix(strings.TrimSpace(tag), "#"); tag != "" {
+				quoted = append(quoted, quoteCEL(tag))
+			}
+		}
+		if len(quoted) > 0 {
+			clauses = append(clauses, fmt.Sprintf("tag in [%s]", strings.Join(quoted, ", ")))
+		}
+	}
+	if f.ContentSearch != "" {
+		clauses = append(clauses, fmt.Sprintf("content.contains(%s)", quoteCEL(f.ContentSearch)))
+	}
+	if !f.CreatedAfter.IsZero() {
+		clauses = append(clauses, fmt.Sprintf("created_ts >= %d", f.CreatedAfter.Unix()))
+	}
+	if !f.CreatedBefore.IsZero() {
+		clauses = append(clauses, fmt.Sprintf("created_ts < %d", f.CreatedBefore.Unix()))
+	}
+
+	return strings.Join(clauses, " && ")
+}
+
+// quoteCEL returns s as a double-quoted CEL string literal.
+func quoteCEL(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '"':
+			sb.WriteString(`\"`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
@@ -3,6 +3,7 @@ package memos
 import (
 	"context"
 	"fmt"
+	"iter"
 	"strings"
 
 	"autonomous-task-management/internal/model"
@@ -10,6 +11,12 @@ import (
 	pkgLog "autonomous-task-management/pkg/log"
 )
 
+const (
+	defaultListLimit = 20
+	// maxPageSize caps the page size requested from the Memos API.
+	maxPageSize = 1000
+)
+
 type implRepository struct {
 	client      *Client
 	memoBaseURL string // e.g. "http://localhost:5230" for deep link generation
@@ -75,6 +82,35 @@ func (r *implRepository) UpdateTask(ctx context.Context, id string, content stri
 	return nil
 }
 
+// UpdateTaskIfUnchanged compares the memo's current update time with the expected one before
+// patching it. Memos has no conditional update, so a write landing between the check and the
+// patch can still be lost; the window is one round trip instead of the caller's whole edit.
+func (r *implRepository) UpdateTaskIfUnchanged(ctx context.Context, id, content, expectedUpdateTime string) (model.Task, error) {
+	current, err := r.client.GetMemo(ctx, id)
+	if err != nil {
+		return model.Task{}, err
+	}
+	if current.UpdateTime != expectedUpdateTime {
+		r.l.Warnf(ctx, "memos repository: task %s changed since read (expected %s, got %s)", id, expectedUpdateTime, current.UpdateTime)
+		return model.Task{}, repository.ErrConflict
+	}
+
+	memo, err := r.client.UpdateMemo(ctx, id, UpdateMemoRequest{Content: content, UpdateMask: "content"})
+	if err != nil {
+		r.l.Errorf(ctx, "memos repository: failed to update task %s: %v", id, err)
+		return model.Task{}, err
+	}
+	return r.memoToTask(memo), nil
+}
+
+func (r *implRepository) DeleteTask(ctx context.Context, id string) error {
+	if err := r.client.DeleteMemo(ctx, id); err != nil {
+		r.l.Errorf(ctx, "memos repository: failed to delete task %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
 func (r *implRepository) GetTask(ctx context.Context, id string) (model.Task, error) {
 	memo, err := r.client.GetMemo(ctx, id)
 	if err != nil {
@@ -86,19 +122,146 @@ func (r *implRepository) GetTask(ctx context.Context, id string) (model.Task, er
 func (r *implRepository) ListTasks(ctx context.Context, opt repository.ListTasksOptions) ([]model.Task, error) {
 	limit := opt.Limit
 	if limit == 0 {
-		limit = 20
+		limit = defaultListLimit
 	}
 
-	memos, err := r.client.ListMemos(ctx, opt.Tag, limit, opt.Offset)
+	// The Memos API paginates by token, so the offset is applied while iterating
+	pageOpt := opt
+	pageOpt.Limit = min(limit+opt.Offset, maxPageSize)
+
+	tasks := make([]model.Task, 0, limit)
+	skipped := 0
+	for t, err := range r.IterateTasks(ctx, pageOpt) {
+		if err != nil {
+			return nil, err
+		}
+		if skipped < opt.Offset {
+			skipped++
+			continue
+		}
+		tasks = append(tasks, t)
+		if len(tasks) == limit {
+			break
+		}
+	}
+	return tasks, nil
+}
+
+func (r *implRepository) ListTaskPage(ctx context.Context, opt repository.ListTasksOptions) (repository.TaskPage, error) {
+	resp, err := r.client.ListMemos(ctx, listRequest(opt))
 	if err != nil {
-		return nil, err
+		return repository.TaskPage{}, err
 	}
 
-	tasks := make([]model.Task, 0, len(memos))
-	for _, m := range memos {
+	tasks := make([]model.Task, 0, len(resp.Memos))
+	for _, m := range resp.Memos {
 		tasks = append(tasks, r.memoToTask(&m))
 	}
-	return tasks, nil
+	return repository.TaskPage{Tasks: tasks, NextPageToken: resp.NextPageToken}, nil
+}
+
+func (r *implRepository) IterateTasks(ctx context.Context, opt repository.ListTasksOptions) iter.Seq2[model.Task, error] {
+	return func(yield func(model.Task, error) bool) {
+		for m, err := range r.client.AllMemos(ctx, listRequest(opt)) {
+			if err != nil {
+				yield(model.Task{}, err)
+				return
+			}
+			if !yield(r.memoToTask(&m), nil) {
+				return
+			}
+		}
+	}
+}
+
+func (r *implRepository) ArchiveTask(ctx context.Context, id string) error {
+	if _, err := r.client.SetMemoState(ctx, id, StateArchived); err != nil {
+		r.l.Errorf(ctx, "memos repository: failed to archive task %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+func (r *implRepository) RestoreTask(ctx context.Context, id string) error {
+	if _, err := r.client.SetMemoState(ctx, id, StateNormal); err != nil {
+		r.l.Errorf(ctx, "memos repository: failed to restore task %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+func (r *implRepository) PinTask(ctx context.Context, id string, pinned bool) error {
+	if _, err := r.client.SetMemoPinned(ctx, id, pinned); err != nil {
+		r.l.Errorf(ctx, "memos repository: failed to pin task %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+func (r *implRepository) ListRelations(ctx context.Context, id string) ([]repository.TaskRelation, error) {
+	relations, err := r.client.ListMemoRelations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]repository.TaskRelation, 0, len(relations))
+	for _, rel := range relations {
+		out = append(out, repository.TaskRelation{
+			TaskID:    rel.Memo.Name,
+			RelatedID: rel.RelatedMemo.Name,
+			Type:      rel.Type,
+		})
+	}
+	return out, nil
+}
+
+func (r *implRepository) SetRelations(ctx context.Context, id string, relations []repository.TaskRelation) error {
+	memoRelations := make([]MemoRelation, 0, len(relations))
+	for _, rel := range relations {
+		relType := rel.Type
+		if relType == "" {
+			relType = RelationReference
+		}
+		memoRelations = append(memoRelations, MemoRelation{
+			Memo:        MemoRef{Name: id},
+			RelatedMemo: MemoRef{Name: rel.RelatedID},
+			Type:        relType,
+		})
+	}
+
+	if err := r.client.SetMemoRelations(ctx, id, memoRelations); err != nil {
+		r.l.Errorf(ctx, "memos repository: failed to set relations of task %s: %v", id, err)
+		return err
+	}
+	return nil
+}
+
+// listRequest converts list options into a Memos list request with an escaped filter.
+func listRequest(opt repository.ListTasksOptions) ListMemosRequest {
+	pageSize := opt.Limit
+	if pageSize <= 0 {
+		pageSize = defaultListLimit
+	}
+
+	tags := opt.Tags
+	if opt.Tag != "" {
+		tags = append([]string{opt.Tag}, tags...)
+	}
+
+	req := ListMemosRequest{
+		PageSize:  min(pageSize, maxPageSize),
+		PageToken: opt.PageToken,
+		Filter: Filter{
+			Tags:          tags,
+			ContentSearch: opt.Query,
+			CreatedAfter:  opt.CreatedAfter,
+			CreatedBefore: opt.CreatedBefore,
+		}.String(),
+	}
+	if opt.Archived {
+		req.State = StateArchived
+	}
+	return req
 }
 
 // buildMarkdownContent builds the Markdown body for a Memos memo from options.
@@ -134,6 +297,8 @@ func (r *implRepository) memoToTask(m *Memo) model.Task {
 		Content:    m.Content,
 		MemoURL:    memoURL,
 		Visibility: m.Visibility,
+		Archived:   m.State == StateArchived,
+		Pinned:     m.Pinned,
 		CreateTime: m.CreateTime,
 		UpdateTime: m.UpdateTime,
 	}
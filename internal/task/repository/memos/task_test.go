@@ -3,12 +3,15 @@ package memos_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"autonomous-task-management/internal/model"
 	"autonomous-task-management/internal/task/repository"
 	"autonomous-task-management/internal/task/repository/memos"
 )
@@ -174,3 +177,92 @@ func TestMemosRepository(t *testing.T) {
 		}
 	})
 }
+
+// TestListTasks_AppliesOffsetAcrossPages verifies the offset is honoured with token pagination.
+func TestListTasks_AppliesOffsetAcrossPages(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := memos.ListMemosResponse{Memos: []memos.Memo{{Name: "memos/1"}, {Name: "memos/2"}}, NextPageToken: "p2"}
+		if r.URL.Query().Get("pageToken") == "p2" {
+			resp = memos.ListMemosResponse{Memos: []memos.Memo{{Name: "memos/3", State: memos.StateArchived, Pinned: true}, {Name: "memos/4"}}}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	repo := memos.New(memos.NewClient(ts.URL, "test-token"), "http://memos.local", &mockLogger{})
+	tasks, err := repo.ListTasks(context.Background(), repository.ListTasksOptions{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != "memos/2" || tasks[1].ID != "memos/3" {
+		t.Fatalf("unexpected tasks: %+v", tasks)
+	}
+	if !tasks[1].Archived || !tasks[1].Pinned {
+		t.Errorf("state and pin not mapped: %+v", tasks[1])
+	}
+}
+
+// TestModifyTask_ReappliesChangeAfterConflict verifies a concurrent edit is detected through the
+// update time and the mutation is re-applied on top of it instead of overwriting it.
+func TestModifyTask_ReappliesChangeAfterConflict(t *testing.T) {
+	var (
+		content = "- [ ] a"
+		version = 1
+		gets    int
+		patches int
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gets++
+			// Someone else edits the memo right after our first read
+			if gets == 2 {
+				content += "\n- [ ] b"
+				version++
+			}
+		case http.MethodPatch:
+			var req memos.UpdateMemoRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			content = req.Content
+			version++
+			patches++
+		}
+		json.NewEncoder(w).Encode(memos.Memo{Name: "memos/1", Content: content, UpdateTime: strconv.Itoa(version)})
+	}))
+	defer ts.Close()
+
+	repo := memos.New(memos.NewClient(ts.URL, "test-token"), "http://memos.local", &mockLogger{})
+	task, err := repository.ModifyTask(context.Background(), repo, "memos/1", func(current model.Task) (string, error) {
+		return strings.Replace(current.Content, "- [ ] a", "- [x] a", 1), nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if task.Content != "- [x] a\n- [ ] b" {
+		t.Errorf("concurrent edit lost, got content %q", task.Content)
+	}
+	if patches != 1 {
+		t.Errorf("expected exactly 1 write, got %d", patches)
+	}
+}
+
+// TestModifyTask_GivesUpOnPersistentConflict verifies ErrConflict is surfaced when the memo keeps changing.
+func TestModifyTask_GivesUpOnPersistentConflict(t *testing.T) {
+	version := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			t.Error("memo must not be written while it keeps changing")
+		}
+		version++
+		json.NewEncoder(w).Encode(memos.Memo{Name: "memos/1", Content: "- [ ] a", UpdateTime: strconv.Itoa(version)})
+	}))
+	defer ts.Close()
+
+	repo := memos.New(memos.NewClient(ts.URL, "test-token"), "http://memos.local", &mockLogger{})
+	_, err := repository.ModifyTask(context.Background(), repo, "memos/1", func(current model.Task) (string, error) {
+		return "- [x] a", nil
+	})
+	if !errors.Is(err, repository.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+}
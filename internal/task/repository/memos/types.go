@@ -0,0 +1,68 @@
+package memos
+
+// Memo states (row status) used by archive/restore and list filters.
+const (
+	StateNormal   = "NORMAL"
+	StateArchived = "ARCHIVED"
+)
+
+// Memo relation types.
+const (
+	RelationReference = "REFERENCE"
+	RelationComment   = "COMMENT"
+)
+
+// CreateMemoRequest is the body for POST /api/v1/memos.
+type CreateMemoRequest struct {
+	Content    string `json:"content"`
+	Visibility string `json:"visibility"`
+}
+
+// UpdateMemoRequest is the body for PATCH /api/v1/{name}.
+// UpdateMask lists the fields to change (e.g. "content", "state", "pinned").
+type UpdateMemoRequest struct {
+	Content    string `json:"content,omitempty"`
+	State      string `json:"state,omitempty"`
+	Pinned     *bool  `json:"pinned,omitempty"`
+	UpdateMask string `json:"updateMask"`
+}
+
+// ListMemosRequest holds the query parameters for GET /api/v1/memos.
+type ListMemosRequest struct {
+	PageSize  int
+	PageToken string
+	Filter    string // CEL expression, see Filter.String
+	State     string // StateNormal (server default) or StateArchived
+}
+
+// ListMemosResponse is one page of GET /api/v1/memos.
+type ListMemosResponse struct {
+	Memos         []Memo `json:"memos"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// Memo is the Memos API memo object.
+type Memo struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	UID        string   `json:"uid"`
+	Content    string   `json:"content"`
+	Visibility string   `json:"visibility"`
+	State      string   `json:"state"`
+	Pinned     bool     `json:"pinned"`
+	Tags       []string `json:"tags"`
+	CreateTime string   `json:"createTime"`
+	UpdateTime string   `json:"updateTime"`
+}
+
+// MemoRelation links two memos.
+type MemoRelation struct {
+	Memo        MemoRef `json:"memo"`
+	RelatedMemo MemoRef `json:"relatedMemo"`
+	Type        string  `json:"type"`
+}
+
+// MemoRef identifies a memo by resource name inside a relation.
+type MemoRef struct {
+	Name string `json:"name"`
+}
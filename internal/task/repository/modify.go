@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// maxConflictRetries is how many times ModifyTask re-applies a change after a conflict.
+const maxConflictRetries = 3
+
+// Mutation computes the new content of a task from its latest version.
+// Returning the content unchanged skips the write.
+type Mutation func(current model.Task) (string, error)
+
+// ModifyTask reads a task, applies mutate and writes the result back only if the task has not
+// changed since it was read. On a conflict the task is re-read and mutate re-applied to the fresh
+// content, so the intended change (e.g. "check item X") survives concurrent edits.
+// A change that flips the task's status also stamps or clears its "- **Done:**" completion time.
+// It returns ErrConflict if the task keeps changing after maxConflictRetries attempts.
+func ModifyTask(ctx context.Context, repo MemosRepository, id string, mutate Mutation) (model.Task, error) {
+	for attempt := 0; ; attempt++ {
+		current, err := repo.GetTask(ctx, id)
+		if err != nil {
+			return model.Task{}, fmt.Errorf("failed to fetch task: %w", err)
+		}
+
+		content, err := mutate(current)
+		if err != nil {
+			return model.Task{}, err
+		}
+		if content == current.Content {
+			return current, nil
+		}
+		content = taskmeta.StampDone(content, time.Now())
+
+		updated, err := repo.UpdateTaskIfUnchanged(ctx, id, content, current.UpdateTime)
+		if err == nil {
+			return updated, nil
+		}
+		if !errors.Is(err, ErrConflict) {
+			return model.Task{}, fmt.Errorf("failed to update task: %w", err)
+		}
+		if attempt == maxConflictRetries {
+			return model.Task{}, fmt.Errorf("task %s: %w (gave up after %d retries)", id, ErrConflict, maxConflictRetries)
+		}
+	}
+}
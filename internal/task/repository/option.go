@@ -1,5 +1,13 @@
 package repository
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"autonomous-task-management/internal/model"
+)
+
 // CreateTaskOptions holds the parameters for creating a task in Memos.
 type CreateTaskOptions struct {
 	Content    string   // Full Markdown content body
@@ -8,37 +16,142 @@ type CreateTaskOptions struct {
 }
 
 // ListTasksOptions holds the parameters for listing tasks from Memos.
+// All filters are applied server-side.
 type ListTasksOptions struct {
-	Tag    string // Filter by a specific tag
-	Limit  int    // Max number of results (default 20)
-	Offset int    // Pagination offset
+	Tag           string    // Filter by a specific tag
+	Tags          []string  // Filter by any of these tags
+	Query         string    // Content must contain this text
+	CreatedAfter  time.Time // Inclusive lower bound on creation time
+	CreatedBefore time.Time // Exclusive upper bound on creation time
+	Archived      bool      // List archived tasks instead of active ones
+	Limit         int       // Max number of results (default 20); page size for ListTaskPage and IterateTasks
+	Offset        int       // Pagination offset (ListTasks only)
+	PageToken     string    // Resume token from a previous TaskPage
+}
+
+// TaskPage is one page of tasks and the token for the next one (empty on the last page).
+type TaskPage struct {
+	Tasks         []model.Task
+	NextPageToken string
+}
+
+// TaskRelation links a task to another memo.
+type TaskRelation struct {
+	TaskID    string // Memos resource name of the task, e.g. "memos/abc"
+	RelatedID string // Memos resource name of the related memo
+	Type      string // "REFERENCE" or "COMMENT"
 }
 
 // SearchTasksOptions defines search parameters.
 type SearchTasksOptions struct {
-	Query  string   // Natural language query
-	Limit  int      // Top-K results
-	Tags   []string // Filter by tags (optional)
+	Query     string   // Natural language query, embedded for the dense track
+	TextQuery string   // Words every full-text hit must contain; defaults to Query
+	Limit     int      // Top-K results
+	Tags      []string // Only tasks with any of these tags (optional)
+	Filter    PayloadFilter
+}
+
+// IndexOptions tunes a bulk IndexTasks run. Zero values select the defaults.
+type IndexOptions struct {
+	BatchSize         int               // Texts per embedding request, capped at the provider limit
+	Concurrency       int               // Batches embedded and upserted at once
+	RequestsPerSecond float64           // Embedding requests per second; 0 = unlimited
+	Checkpoint        IndexCheckpoint   // Tasks it reports as indexed are skipped (optional)
+	Progress          func(IndexResult) // Called after every batch with the running totals (optional)
+}
+
+// IndexResult summarizes a bulk IndexTasks run.
+type IndexResult struct {
+	Total   int      // Tasks given
+	Skipped int      // Already indexed according to the checkpoint
+	Indexed int      // Embedded and upserted by this run
+	Failed  []string // IDs of the tasks whose batch failed
+}
+
+// FilterTasksOptions selects embedded tasks by payload alone, without a query vector.
+type FilterTasksOptions struct {
+	Tags   []string // Only tasks with any of these tags (optional)
 	Filter PayloadFilter
+	Limit  int    // Page size; 0 = repository default
+	Offset string // NextOffset of the previous page; empty for the first page
+}
+
+// SearchPage is one page of filter-only results, in no particular order.
+type SearchPage struct {
+	Results    []SearchResult
+	NextOffset string // Empty on the last page
 }
 
-// PayloadFilter represents Qdrant filter condition structure
+// Payload fields stored with every embedded task that filters can use.
+const (
+	PayloadTags     = "tags"     // All hashtags, e.g. "#pr/123"
+	PayloadDue      = "due"      // Start of the due day (RFC3339); absent without a due date
+	PayloadPriority = "priority" // "p0".."p3"
+	PayloadStatus   = "status"   // Value of the #status/* tag, "todo" if none
+	PayloadProject  = "project"  // Values of the #project/* tags, lower case
+
+	PayloadUpdateTime  = "update_time"  // Memos update time of the embedded version
+	PayloadContentHash = "content_hash" // ContentHash of the embedded content
+
+	PayloadEmbeddingModel    = "embedding_model"    // Model that produced the vector
+	PayloadEnrichmentVersion = "enrichment_version" // indexer.EnrichmentVersion of the embedded text
+
+	// Long tasks are stored as several points, one per chunk. Chunk 0 is the task's own point;
+	// the others repeat its filter fields and hold the chunk's markdown as content.
+	PayloadChunkIndex = "chunk_index" // Position of the chunk; absent on points embedded before chunking
+	PayloadChunkCount = "chunk_count" // Number of chunks, on chunk 0 only
+	PayloadSection    = "section"     // Heading of the section the chunk comes from
+
+	// indexer.DueBucket of the due date when the text was embedded ("tuan nay"...); the text
+	// is stale once the bucket of today differs. Absent without a due date.
+	PayloadTemporalBucket = "temporal_bucket"
+)
+
+// ContentHash fingerprints task content, so a stale vector is detected even when Memos
+// did not bump the update time.
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// PayloadFilter is a Qdrant payload filter. A task matches if it satisfies every Must
+// condition, at least one Should condition (when there are any) and no MustNot condition.
 type PayloadFilter struct {
-	Should []Condition
+	Must    []Condition
+	Should  []Condition
+	MustNot []Condition
+}
+
+// IsEmpty reports whether the filter has no conditions.
+func (f PayloadFilter) IsEmpty() bool {
+	return len(f.Must) == 0 && len(f.Should) == 0 && len(f.MustNot) == 0
 }
 
+// Condition tests one payload field: Match for keyword fields, or Range for datetime fields.
 type Condition struct {
 	Key   string
 	Match MatchAny
+	Range *DateRange
 }
 
+// MatchAny matches if the field equals any of the values (or, for arrays, contains one).
 type MatchAny struct {
 	Values []string
 }
 
+// DateRange bounds a datetime field. A zero bound is open.
+type DateRange struct {
+	From time.Time // Inclusive
+	To   time.Time // Exclusive
+}
+
 // SearchResult represents a semantic search result.
 type SearchResult struct {
 	MemoID  string
 	Score   float64
 	Payload map[string]interface{}
+
+	// Best-matching part of a long task; both are empty when the task as a whole matched.
+	Section string // Heading of the matched section, "" for the top of the task
+	Snippet string // Markdown of the matched chunk
 }
@@ -0,0 +1,8 @@
+package qdrant
+
+import "errors"
+
+var (
+	ErrReindexIncomplete    = errors.New("qdrant repository: reindex did not embed every task, alias left unchanged")
+	ErrNoPreviousGeneration = errors.New("qdrant repository: no previous collection to roll back to")
+)
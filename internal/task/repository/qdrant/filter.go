@@ -0,0 +1,143 @@
+package qdrant
+
+import (
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/indexer"
+	pkgQdrant "autonomous-task-management/pkg/qdrant"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// taskPayload builds the payload stored with a task's vector. Besides the content used by the
+// full-text track it carries the typed fields that search filters run on. now is the time the
+// text was enriched at; its location is the user's timezone.
+func taskPayload(task model.Task, now time.Time, embeddingModel string) map[string]interface{} {
+	meta := taskmeta.Parse(task.Content)
+
+	// Same rule as archive, export and ranking: a fully ticked checklist also means done
+	status := meta.Status
+	switch {
+	case meta.Done():
+		status = taskmeta.StatusDone
+	case status == "":
+		status = taskmeta.StatusTodo
+	}
+	projects := make([]string, 0, len(meta.Projects))
+	for _, p := range meta.Projects {
+		projects = append(projects, strings.ToLower(p))
+	}
+
+	payload := map[string]interface{}{
+		"memo_id":                     task.ID, // Store original Memos ID in payload
+		"memo_url":                    task.MemoURL,
+		"content":                     task.Content,
+		"title":                       meta.Title,
+		repository.PayloadTags:        meta.Tags, // taskmeta.ExtractTags, like every other domain
+		repository.PayloadPriority:    strings.ToLower(meta.Priority),
+		repository.PayloadStatus:      status,
+		repository.PayloadProject:     projects,
+		repository.PayloadContentHash: repository.ContentHash(task.Content),
+		"create_time":                 task.CreateTime,
+		repository.PayloadUpdateTime:  task.UpdateTime,
+
+		repository.PayloadEmbeddingModel:    embeddingModel,
+		repository.PayloadEnrichmentVersion: indexer.EnrichmentVersion,
+	}
+	if meta.HasDue {
+		payload[repository.PayloadDue] = meta.DueIn(now.Location()).Format(time.RFC3339)
+		payload[repository.PayloadTemporalBucket] = indexer.TemporalBucket(task.Content, now)
+	}
+	return payload
+}
+
+// toQdrantFilter converts a PayloadFilter into Qdrant's filter JSON, or nil if it is empty.
+func toQdrantFilter(f repository.PayloadFilter) map[string]interface{} {
+	if f.IsEmpty() {
+		return nil
+	}
+	filter := map[string]interface{}{}
+	if len(f.Must) > 0 {
+		filter["must"] = toConditions(f.Must)
+	}
+	if len(f.Should) > 0 {
+		filter["should"] = toConditions(f.Should)
+	}
+	if len(f.MustNot) > 0 {
+		filter["must_not"] = toConditions(f.MustNot)
+	}
+	return filter
+}
+
+// chunkPayload builds the payload of a chunk point from its task's payload: the same filter
+// fields, so filtered searches reach chunks too, with the chunk's markdown as content.
+func chunkPayload(task map[string]interface{}, chunk indexer.Chunk) map[string]interface{} {
+	payload := make(map[string]interface{}, len(task)+2)
+	for k, v := range task {
+		payload[k] = v
+	}
+	delete(payload, repository.PayloadContentHash) // Reconciliation reads it from chunk 0 only
+	delete(payload, repository.PayloadChunkCount)
+	payload["content"] = chunk.Content
+	payload[repository.PayloadChunkIndex] = chunk.Index
+	payload[repository.PayloadSection] = chunk.Section
+	return payload
+}
+
+// taskPointsOnly restricts a filter-only listing or count to one point per task: it excludes
+// the schema version point and the chunk points after chunk 0.
+func taskPointsOnly(filter map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range filter {
+		out[k] = v
+	}
+	mustNot, _ := out["must_not"].([]map[string]interface{})
+	out["must_not"] = append(append([]map[string]interface{}(nil), mustNot...),
+		map[string]interface{}{"has_id": []string{pkgQdrant.SchemaPointID}},
+		map[string]interface{}{"key": repository.PayloadChunkIndex, "range": map[string]interface{}{"gte": 1}})
+	return out
+}
+
+// chunksFrom matches the chunk points of a task from index on.
+func chunksFrom(memoID string, index int) map[string]interface{} {
+	return map[string]interface{}{"must": []map[string]interface{}{
+		{"key": "memo_id", "match": map[string]interface{}{"value": memoID}},
+		{"key": repository.PayloadChunkIndex, "range": map[string]interface{}{"gte": index}},
+	}}
+}
+
+func toConditions(conds []repository.Condition) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(conds))
+	for _, c := range conds {
+		if c.Range != nil {
+			r := map[string]interface{}{}
+			if !c.Range.From.IsZero() {
+				r["gte"] = c.Range.From.Format(time.RFC3339)
+			}
+			if !c.Range.To.IsZero() {
+				r["lt"] = c.Range.To.Format(time.RFC3339)
+			}
+			out = append(out, map[string]interface{}{"key": c.Key, "range": r})
+			continue
+		}
+		out = append(out, map[string]interface{}{
+			"key":   c.Key,
+			"match": map[string]interface{}{"any": c.Match.Values},
+		})
+	}
+	return out
+}
+
+// withTags adds the tag restriction of a search to its filter.
+func withTags(f repository.PayloadFilter, tags []string) repository.PayloadFilter {
+	if len(tags) == 0 {
+		return f
+	}
+	f.Must = append(append([]repository.Condition(nil), f.Must...), repository.Condition{
+		Key:   repository.PayloadTags,
+		Match: repository.MatchAny{Values: tags},
+	})
+	return f
+}
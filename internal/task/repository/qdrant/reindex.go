@@ -0,0 +1,262 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/embedding"
+	"autonomous-task-management/pkg/indexer"
+	pkgLog "autonomous-task-management/pkg/log"
+	pkgQdrant "autonomous-task-management/pkg/qdrant"
+)
+
+// generationLayout is the timestamp suffix of collections built by Reindex, e.g. tasks_20261018T093000.
+// It sorts chronologically as text.
+const generationLayout = "20060102T150405"
+
+// defaultKeepGenerations is how many previous collections Reindex keeps for rollback.
+const defaultKeepGenerations = 1
+
+// copyPageSize is the number of points read per scroll page when a collection is copied.
+const copyPageSize = 256
+
+// ReindexOptions configures a blue/green rebuild of the task collection.
+type ReindexOptions struct {
+	Alias    string // Configured collection name; the app reads and writes through it
+	Keep     int    // Previous collections kept for rollback; 0 = defaultKeepGenerations
+	Timezone string // User's timezone, which due dates in the embedding text are read in
+	Index    repository.IndexOptions
+}
+
+// ReindexResult reports what Reindex built and switched.
+type ReindexResult struct {
+	Previous string // Collection the alias pointed to before, "" if none
+	Current  string // Collection the alias points to now
+	Index    repository.IndexResult
+	Pruned   []string // Older collections deleted
+}
+
+// Reindex embeds tasks into a new collection while the current one keeps serving, then points
+// the alias at it in one atomic switch and keeps the previous collection for Rollback. If any
+// task fails to embed, the new collection is dropped and the alias is left alone.
+//
+// Writes that reach the old collection during the rebuild are not copied over; run a
+// reconciliation after the switch to pick them up.
+//
+// A collection that predates aliases and carries the alias name itself cannot coexist with the
+// alias. It is first copied to a generation of its own, which becomes the rollback target, and
+// only deleted right before the first switch. If that switch fails, the alias is pointed at the
+// copy so search keeps working on the old vectors.
+//
+// The new collection is sized for the embedder, so switching to a model with another dimension
+// also goes through Reindex.
+func Reindex(ctx context.Context, client *pkgQdrant.Client, embedder embedding.Embedder, tasks []model.Task, opt ReindexOptions, l pkgLog.Logger) (ReindexResult, error) {
+	var result ReindexResult
+	previous, err := client.ResolveAlias(ctx, opt.Alias)
+	if err != nil {
+		return result, fmt.Errorf("failed to resolve alias %s: %w", opt.Alias, err)
+	}
+	legacy := false
+	if previous == "" {
+		_, err := client.GetCollection(ctx, opt.Alias)
+		switch {
+		case err == nil:
+			legacy = true
+			previous = opt.Alias
+		case !errors.Is(err, pkgQdrant.ErrNotFound):
+			return result, fmt.Errorf("failed to get collection %s: %w", opt.Alias, err)
+		}
+	}
+	started := time.Now().UTC()
+	if legacy {
+		// One second older than the new generation, so Rollback finds it
+		previous = fmt.Sprintf("%s_%s", opt.Alias, started.Add(-time.Second).Format(generationLayout))
+		if err := copyCollection(ctx, client, opt.Alias, previous, l); err != nil {
+			if dropErr := client.DeleteCollection(context.WithoutCancel(ctx), previous); dropErr != nil {
+				l.Warnf(ctx, "qdrant repository: failed to drop partial copy %s: %v", previous, dropErr)
+			}
+			return result, fmt.Errorf("failed to copy legacy collection %s to %s: %w", opt.Alias, previous, err)
+		}
+	}
+	result.Previous = previous
+
+	result.Current = fmt.Sprintf("%s_%s", opt.Alias, started.Format(generationLayout))
+	if err := Bootstrap(ctx, client, result.Current, embedder.Dimension(), l); err != nil {
+		return result, fmt.Errorf("failed to create collection %s: %w", result.Current, err)
+	}
+
+	l.Infof(ctx, "qdrant repository: reindexing %d tasks into %s with %s (enrichment v%d)",
+		len(tasks), result.Current, embedder.Model(), indexer.EnrichmentVersion)
+	repo := New(client, embedder, result.Current, opt.Timezone, l)
+	result.Index, err = repo.IndexTasks(ctx, tasks, opt.Index)
+	if err == nil && len(result.Index.Failed) > 0 {
+		err = fmt.Errorf("%w: %d of %d failed", ErrReindexIncomplete, len(result.Index.Failed), len(tasks))
+	}
+	if err != nil {
+		if dropErr := client.DeleteCollection(context.WithoutCancel(ctx), result.Current); dropErr != nil {
+			l.Warnf(ctx, "qdrant repository: failed to drop incomplete collection %s: %v", result.Current, dropErr)
+		}
+		return result, err
+	}
+
+	if legacy {
+		l.Warnf(ctx, "qdrant repository: deleting legacy collection %s so the alias can take its name (copy kept as %s)", opt.Alias, previous)
+		if err := client.DeleteCollection(ctx, opt.Alias); err != nil {
+			return result, fmt.Errorf("failed to delete legacy collection %s: %w", opt.Alias, err)
+		}
+	}
+	if err := client.SwitchAlias(ctx, opt.Alias, result.Current); err != nil {
+		if legacy {
+			if restoreErr := client.SwitchAlias(context.WithoutCancel(ctx), opt.Alias, previous); restoreErr != nil {
+				l.Errorf(ctx, "qdrant repository: failed to point alias %s at the legacy copy %s: %v", opt.Alias, previous, restoreErr)
+			}
+		}
+		return result, fmt.Errorf("failed to switch alias %s to %s: %w", opt.Alias, result.Current, err)
+	}
+	l.Infof(ctx, "qdrant repository: alias %s now points to %s (was %q)", opt.Alias, result.Current, previous)
+
+	keep := opt.Keep
+	if keep <= 0 {
+		keep = defaultKeepGenerations
+	}
+	result.Pruned, err = pruneGenerations(ctx, client, opt.Alias, result.Current, keep)
+	if err != nil {
+		// The switch succeeded; leftover collections only cost disk
+		l.Warnf(ctx, "qdrant repository: failed to prune old collections: %v", err)
+	}
+	return result, nil
+}
+
+// Rollback points the alias back at the newest collection older than the current one and
+// returns both names. The newer collection is kept, so the rollback can itself be undone by
+// switching the alias again.
+func Rollback(ctx context.Context, client *pkgQdrant.Client, alias string, l pkgLog.Logger) (from, to string, err error) {
+	from, err = client.ResolveAlias(ctx, alias)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve alias %s: %w", alias, err)
+	}
+	generations, err := listGenerations(ctx, client, alias)
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, g := range slices.Backward(generations) {
+		if g < from {
+			to = g
+			break
+		}
+	}
+	if from == "" || to == "" {
+		return from, "", ErrNoPreviousGeneration
+	}
+	if err := client.SwitchAlias(ctx, alias, to); err != nil {
+		return from, "", fmt.Errorf("failed to switch alias %s to %s: %w", alias, to, err)
+	}
+	l.Infof(ctx, "qdrant repository: rolled alias %s back from %s to %s", alias, from, to)
+	return from, to, nil
+}
+
+// StaleEmbeddings counts the points of a collection that were not embedded with the given
+// model and the current enrichment version. Points from before the version markers count too.
+func StaleEmbeddings(ctx context.Context, client *pkgQdrant.Client, collectionName, embeddingModel string) (int, error) {
+	current := map[string]interface{}{
+		"must": []map[string]interface{}{
+			{"key": repository.PayloadEmbeddingModel, "match": map[string]interface{}{"value": embeddingModel}},
+			{"key": repository.PayloadEnrichmentVersion, "match": map[string]interface{}{"value": indexer.EnrichmentVersion}},
+		},
+	}
+	return client.CountPoints(ctx, collectionName, pkgQdrant.CountRequest{
+		Filter: taskPointsOnly(map[string]interface{}{"must_not": []map[string]interface{}{current}}),
+		Exact:  true,
+	})
+}
+
+// copyCollection copies every point of from, vectors included, into a new collection to with
+// the same vector size and the current schema.
+func copyCollection(ctx context.Context, client *pkgQdrant.Client, from, to string, l pkgLog.Logger) error {
+	info, err := client.GetCollection(ctx, from)
+	if err != nil {
+		return err
+	}
+	if err := Bootstrap(ctx, client, to, info.Config.Params.Vectors.Size, l); err != nil {
+		return err
+	}
+
+	copied := 0
+	var offset interface{}
+	for {
+		resp, err := client.ScrollPoints(ctx, from, pkgQdrant.ScrollRequest{
+			Limit:       copyPageSize,
+			Offset:      offset,
+			WithPayload: true,
+			WithVector:  true,
+		})
+		if err != nil {
+			return err
+		}
+		points := make([]pkgQdrant.Point, 0, len(resp.Result.Points))
+		for _, p := range resp.Result.Points {
+			if p.ID == pkgQdrant.SchemaPointID {
+				continue // The copy carries its own schema version
+			}
+			points = append(points, pkgQdrant.Point{ID: p.ID, Vector: p.Vector, Payload: p.Payload})
+		}
+		if len(points) > 0 {
+			if err := client.UpsertPoints(ctx, to, pkgQdrant.UpsertPointsRequest{Points: points}); err != nil {
+				return err
+			}
+			copied += len(points)
+		}
+		if resp.Result.NextPageOffset == nil {
+			break
+		}
+		offset = resp.Result.NextPageOffset
+	}
+	l.Infof(ctx, "qdrant repository: copied %d points from %s to %s", copied, from, to)
+	return nil
+}
+
+// listGenerations returns the collections Reindex built for alias, oldest first.
+func listGenerations(ctx context.Context, client *pkgQdrant.Client, alias string) ([]string, error) {
+	names, err := client.ListCollections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+	pattern := regexp.MustCompile("^" + regexp.QuoteMeta(alias) + `_\d{8}T\d{6}$`)
+
+	var generations []string
+	for _, name := range names {
+		if pattern.MatchString(name) {
+			generations = append(generations, name)
+		}
+	}
+	slices.Sort(generations)
+	return generations, nil
+}
+
+// pruneGenerations deletes the collections older than the keep generations before current.
+func pruneGenerations(ctx context.Context, client *pkgQdrant.Client, alias, current string, keep int) ([]string, error) {
+	generations, err := listGenerations(ctx, client, alias)
+	if err != nil {
+		return nil, err
+	}
+	idx := slices.Index(generations, current)
+	if idx < 0 || idx <= keep {
+		return nil, nil
+	}
+
+	var pruned []string
+	for _, name := range generations[:idx-keep] {
+		if err := client.DeleteCollection(ctx, name); err != nil {
+			return pruned, fmt.Errorf("failed to delete collection %s: %w", name, err)
+		}
+		pruned = append(pruned, name)
+	}
+	return pruned, nil
+}
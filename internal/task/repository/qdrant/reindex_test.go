@@ -0,0 +1,281 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/internal/task/repository/qdrant"
+	"autonomous-task-management/pkg/indexer"
+	pkgQdrant "autonomous-task-management/pkg/qdrant"
+	"autonomous-task-management/pkg/voyage"
+)
+
+// fakeCluster keeps several collections and their aliases in memory.
+type fakeCluster struct {
+	mu          sync.Mutex
+	collections map[string]map[string]pkgQdrant.Point
+	aliases     map[string]string
+	failAliases int // Alias updates still to reject
+}
+
+func newFakeCluster(collections ...string) *fakeCluster {
+	f := &fakeCluster{collections: map[string]map[string]pkgQdrant.Point{}, aliases: map[string]string{}}
+	for _, name := range collections {
+		f.collections[name] = map[string]pkgQdrant.Point{}
+	}
+	return f
+}
+
+func (f *fakeCluster) resolve(name string) string {
+	if target, ok := f.aliases[name]; ok {
+		return target
+	}
+	return name
+}
+
+func (f *fakeCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	reply := func(result interface{}) { json.NewEncoder(w).Encode(map[string]interface{}{"result": result}) }
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case r.URL.Path == "/aliases":
+		var aliases []pkgQdrant.Alias
+		for a, c := range f.aliases {
+			aliases = append(aliases, pkgQdrant.Alias{AliasName: a, CollectionName: c})
+		}
+		reply(map[string]interface{}{"aliases": aliases})
+
+	case r.URL.Path == "/collections/aliases":
+		var req struct {
+			Actions []pkgQdrant.AliasAction `json:"actions"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if f.failAliases > 0 {
+			f.failAliases--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		for _, a := range req.Actions {
+			if a.DeleteAlias != nil {
+				delete(f.aliases, a.DeleteAlias.AliasName)
+			}
+			if a.CreateAlias != nil {
+				if _, clash := f.collections[a.CreateAlias.AliasName]; clash {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				f.aliases[a.CreateAlias.AliasName] = a.CreateAlias.CollectionName
+			}
+		}
+		reply(true)
+
+	case r.URL.Path == "/collections":
+		var cols []map[string]string
+		for name := range f.collections {
+			cols = append(cols, map[string]string{"name": name})
+		}
+		reply(map[string]interface{}{"collections": cols})
+
+	case len(parts) == 2:
+		name := f.resolve(parts[1])
+		_, exists := f.collections[name]
+		switch r.Method {
+		case http.MethodGet:
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			info := pkgQdrant.CollectionInfo{}
+			info.Config.Params.Vectors = pkgQdrant.VectorConfig{Size: 2}
+			reply(info)
+		case http.MethodPut:
+			f.collections[name] = map[string]pkgQdrant.Point{}
+			reply(true)
+		case http.MethodDelete:
+			delete(f.collections, name)
+			reply(true)
+		}
+
+	case len(parts) >= 3:
+		points, ok := f.collections[f.resolve(parts[1])]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch strings.Join(parts[2:], "/") {
+		case "index":
+			reply(true)
+		case "points":
+			if r.Method == http.MethodPut {
+				var req pkgQdrant.UpsertPointsRequest
+				json.NewDecoder(r.Body).Decode(&req)
+				for _, p := range req.Points {
+					points[p.ID.(string)] = p
+				}
+				reply(true)
+				return
+			}
+			reply([]pkgQdrant.ScoredPoint{}) // No schema point yet
+		case "points/scroll":
+			page := pkgQdrant.ScrollResult{Points: []pkgQdrant.ScoredPoint{}}
+			for id, p := range points {
+				page.Points = append(page.Points, pkgQdrant.ScoredPoint{ID: id, Payload: p.Payload, Vector: p.Vector})
+			}
+			reply(page)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func newReindexEmbedder(t *testing.T, failOn string) *voyage.Client {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req voyage.EmbedRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		resp := voyage.EmbedResponse{}
+		for _, text := range req.Input {
+			if failOn != "" && strings.Contains(text, failOn) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			resp.Data = append(resp.Data, voyage.EmbeddingData{Embedding: []float32{0.1, 0.2}})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(ts.Close)
+	client, _ := voyage.New("test-key")
+	return client.WithBaseURL(ts.URL).WithModel("voyage-test").WithDimension(2)
+}
+
+var reindexTasks = []model.Task{
+	{ID: "memos/1", Content: "## One"},
+	{ID: "memos/2", Content: "## Two"},
+}
+
+func TestReindex_SwitchesAliasAndRollsBack(t *testing.T) {
+	fake := newFakeCluster("tasks_20250101T000000", "tasks_20260101T000000")
+	fake.aliases["tasks"] = "tasks_20260101T000000"
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+	client := pkgQdrant.NewClient(ts.URL)
+	ctx := context.Background()
+
+	result, err := qdrant.Reindex(ctx, client, newReindexEmbedder(t, ""), reindexTasks,
+		qdrant.ReindexOptions{Alias: "tasks"}, &mockLogger{})
+	if err != nil {
+		t.Fatalf("unexpected reindex error: %v", err)
+	}
+	if result.Previous != "tasks_20260101T000000" || fake.aliases["tasks"] != result.Current || result.Index.Indexed != 2 {
+		t.Errorf("unexpected result %+v, alias -> %s", result, fake.aliases["tasks"])
+	}
+	if len(result.Pruned) != 1 || result.Pruned[0] != "tasks_20250101T000000" {
+		t.Errorf("expected only the oldest collection pruned, got %v", result.Pruned)
+	}
+	if _, kept := fake.collections["tasks_20260101T000000"]; !kept {
+		t.Error("the previous collection must be kept for rollback")
+	}
+	for _, p := range fake.collections[result.Current] {
+		if p.Payload["memo_id"] == nil {
+			continue // Schema point
+		}
+		if p.Payload[repository.PayloadEmbeddingModel] != "voyage-test" ||
+			p.Payload[repository.PayloadEnrichmentVersion] != float64(indexer.EnrichmentVersion) {
+			t.Errorf("point lacks version markers: %v", p.Payload)
+		}
+	}
+
+	from, to, err := qdrant.Rollback(ctx, client, "tasks", &mockLogger{})
+	if err != nil || from != result.Current || to != "tasks_20260101T000000" || fake.aliases["tasks"] != to {
+		t.Errorf("unexpected rollback %s -> %s: %v", from, to, err)
+	}
+	if _, _, err := qdrant.Rollback(ctx, client, "tasks", &mockLogger{}); !errors.Is(err, qdrant.ErrNoPreviousGeneration) {
+		t.Errorf("expected ErrNoPreviousGeneration, got %v", err)
+	}
+}
+
+// newLegacyCluster returns a cluster with a pre-alias "tasks" collection holding one task point.
+func newLegacyCluster() *fakeCluster {
+	fake := newFakeCluster("tasks")
+	fake.collections["tasks"]["legacy-point"] = pkgQdrant.Point{
+		ID:      "legacy-point",
+		Vector:  []float32{0.3, 0.4},
+		Payload: map[string]interface{}{"memo_id": "memos/9"},
+	}
+	return fake
+}
+
+func TestReindex_ReplacesLegacyCollection(t *testing.T) {
+	fake := newLegacyCluster()
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+	client := pkgQdrant.NewClient(ts.URL)
+
+	result, err := qdrant.Reindex(context.Background(), client, newReindexEmbedder(t, ""), reindexTasks,
+		qdrant.ReindexOptions{Alias: "tasks"}, &mockLogger{})
+	if err != nil {
+		t.Fatalf("unexpected reindex error: %v", err)
+	}
+	if _, still := fake.collections["tasks"]; still || fake.aliases["tasks"] != result.Current {
+		t.Errorf("legacy collection should give its name to the alias: %v, %v", fake.collections, fake.aliases)
+	}
+	if result.Previous == "tasks" || result.Previous >= result.Current {
+		t.Fatalf("legacy data should be kept as an older generation, got previous %q", result.Previous)
+	}
+	if p, ok := fake.collections[result.Previous]["legacy-point"]; !ok || len(p.Vector) != 2 {
+		t.Errorf("legacy points should be copied with their vectors, got %v", fake.collections[result.Previous])
+	}
+
+	_, to, err := qdrant.Rollback(context.Background(), client, "tasks", &mockLogger{})
+	if err != nil || to != result.Previous {
+		t.Errorf("expected rollback to the legacy copy %s, got %s: %v", result.Previous, to, err)
+	}
+}
+
+func TestReindex_LegacySwitchFailureKeepsData(t *testing.T) {
+	fake := newLegacyCluster()
+	fake.failAliases = 1
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	result, err := qdrant.Reindex(context.Background(), pkgQdrant.NewClient(ts.URL), newReindexEmbedder(t, ""), reindexTasks,
+		qdrant.ReindexOptions{Alias: "tasks"}, &mockLogger{})
+	if err == nil {
+		t.Fatal("expected the failed switch to be reported")
+	}
+	if _, ok := fake.collections[result.Previous]["legacy-point"]; !ok {
+		t.Fatalf("legacy points must survive a failed switch, got %v", fake.collections)
+	}
+	if fake.aliases["tasks"] != result.Previous {
+		t.Errorf("alias should fall back to the legacy copy %s, points to %q", result.Previous, fake.aliases["tasks"])
+	}
+}
+
+func TestReindex_IncompleteKeepsAlias(t *testing.T) {
+	fake := newFakeCluster("tasks_20260101T000000")
+	fake.aliases["tasks"] = "tasks_20260101T000000"
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	result, err := qdrant.Reindex(context.Background(), pkgQdrant.NewClient(ts.URL), newReindexEmbedder(t, "Two"), reindexTasks,
+		qdrant.ReindexOptions{Alias: "tasks", Index: repository.IndexOptions{BatchSize: 1}}, &mockLogger{})
+	if !errors.Is(err, qdrant.ErrReindexIncomplete) {
+		t.Fatalf("expected ErrReindexIncomplete, got %v", err)
+	}
+	if fake.aliases["tasks"] != "tasks_20260101T000000" {
+		t.Errorf("alias must not move, points to %s", fake.aliases["tasks"])
+	}
+	if _, exists := fake.collections[result.Current]; exists {
+		t.Errorf("incomplete collection %s should be dropped", result.Current)
+	}
+}
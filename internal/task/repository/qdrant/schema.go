@@ -0,0 +1,92 @@
+package qdrant
+
+import (
+	"context"
+	"fmt"
+
+	"autonomous-task-management/internal/task/repository"
+	pkgLog "autonomous-task-management/pkg/log"
+	pkgQdrant "autonomous-task-management/pkg/qdrant"
+)
+
+// Migrations is the schema history of the task collection. Append new versions at the end;
+// never change one that has shipped, since it is recorded as applied.
+var Migrations = []pkgQdrant.Migration{
+	{
+		Version: 1,
+		Name:    "index content, memo_id and tags",
+		Up: pkgQdrant.PayloadIndexes(
+			pkgQdrant.CreatePayloadIndexRequest{FieldName: "content", FieldSchema: "text"}, // Full-text track of SearchTasks
+			pkgQdrant.CreatePayloadIndexRequest{FieldName: "memo_id", FieldSchema: "keyword"},
+			pkgQdrant.CreatePayloadIndexRequest{FieldName: "tags", FieldSchema: "keyword"}, // Tag filters
+		),
+	},
+	{
+		Version: 2,
+		Name:    "index create_time and update_time",
+		Up: pkgQdrant.PayloadIndexes(
+			pkgQdrant.CreatePayloadIndexRequest{FieldName: "create_time", FieldSchema: "datetime"},
+			pkgQdrant.CreatePayloadIndexRequest{FieldName: "update_time", FieldSchema: "datetime"},
+		),
+	},
+	// Tasks embedded before version 3 lack these fields until they are embedded again
+	// (scripts/backfill-embeddings), so filtered searches skip them meanwhile.
+	{
+		Version: 3,
+		Name:    "index due, priority, status and project",
+		Up: pkgQdrant.PayloadIndexes(
+			pkgQdrant.CreatePayloadIndexRequest{FieldName: repository.PayloadDue, FieldSchema: "datetime"},
+			pkgQdrant.CreatePayloadIndexRequest{FieldName: repository.PayloadPriority, FieldSchema: "keyword"},
+			pkgQdrant.CreatePayloadIndexRequest{FieldName: repository.PayloadStatus, FieldSchema: "keyword"},
+			pkgQdrant.CreatePayloadIndexRequest{FieldName: repository.PayloadProject, FieldSchema: "keyword"},
+		),
+	},
+	{
+		Version: 4,
+		Name:    "index embedding model and enrichment version",
+		Up: pkgQdrant.PayloadIndexes(
+			pkgQdrant.CreatePayloadIndexRequest{FieldName: repository.PayloadEmbeddingModel, FieldSchema: "keyword"},
+			pkgQdrant.CreatePayloadIndexRequest{FieldName: repository.PayloadEnrichmentVersion, FieldSchema: "integer"},
+		),
+	},
+	// Listings and counts skip chunk points by this field; points embedded before chunking
+	// lack it and still count as the task's own point.
+	{
+		Version: 5,
+		Name:    "index chunk index",
+		Up: pkgQdrant.PayloadIndexes(
+			pkgQdrant.CreatePayloadIndexRequest{FieldName: repository.PayloadChunkIndex, FieldSchema: "integer"},
+		),
+	},
+}
+
+// Bootstrap makes sure the task collection exists with vectors of vectorSize and brings its
+// payload indexes up to date. It is safe to run on every start. If collectionName is an alias
+// (see Reindex), the collection it points to is bootstrapped.
+func Bootstrap(ctx context.Context, client *pkgQdrant.Client, collectionName string, vectorSize int, l pkgLog.Logger) error {
+	target, err := client.ResolveAlias(ctx, collectionName)
+	if err != nil {
+		return fmt.Errorf("failed to resolve alias %s: %w", collectionName, err)
+	}
+	if target != "" {
+		collectionName = target
+	}
+
+	result, err := pkgQdrant.Bootstrap(ctx, client, pkgQdrant.Schema{
+		Collection: collectionName,
+		VectorSize: vectorSize,
+		Migrations: Migrations,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.Created {
+		l.Infof(ctx, "qdrant repository: created collection %s (vector size %d)", collectionName, vectorSize)
+	}
+	for _, name := range result.Applied {
+		l.Infof(ctx, "qdrant repository: applied migration %q to %s", name, collectionName)
+	}
+	l.Infof(ctx, "qdrant repository: collection %s at schema version %d", collectionName, result.ToVersion)
+	return nil
+}
@@ -3,84 +3,215 @@ package qdrant
 import (
 	"context"
 	"fmt"
-	"regexp"
-	"strings"
+	"iter"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/time/rate"
 
 	"autonomous-task-management/internal/model"
 	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/embedding"
 	"autonomous-task-management/pkg/indexer"
 	pkgLog "autonomous-task-management/pkg/log"
 	pkgQdrant "autonomous-task-management/pkg/qdrant"
-	"autonomous-task-management/pkg/voyage"
 )
 
-// tagRegex matches hashtags like #repo/myproject, #pr/123, #issue/456.
-var tagRegex = regexp.MustCompile(`#[a-zA-Z0-9_/]+`)
+// defaultIndexConcurrency is how many batches IndexTasks embeds at once by default.
+const defaultIndexConcurrency = 4
+
+// filterPageSize is the scroll page size of filter-only listings.
+const filterPageSize = 100
+
+// searchChunkOverfetch multiplies the per-track limit of SearchTasks, since several chunks of
+// one task can rank before the next task.
+const searchChunkOverfetch = 3
 
 type implRepository struct {
 	client         *pkgQdrant.Client
-	embedder       *voyage.Client
+	embedder       embedding.Embedder
 	collectionName string
+	loc            *time.Location // Due dates are read in it for the embedding text and payload
 	l              pkgLog.Logger
 }
 
-// New creates a new Qdrant repository.
-func New(client *pkgQdrant.Client, embedder *voyage.Client, collectionName string, l pkgLog.Logger) repository.VectorRepository {
+// New creates a new Qdrant repository. timezone is the user's timezone (LLMConfig.Timezone);
+// an empty or unknown one falls back to UTC.
+func New(client *pkgQdrant.Client, embedder embedding.Embedder, collectionName, timezone string, l pkgLog.Logger) repository.VectorRepository {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
 	return &implRepository{
 		client:         client,
 		embedder:       embedder,
 		collectionName: collectionName,
+		loc:            loc,
 		l:              l,
 	}
 }
 
-// EmbedTask generates embedding and stores in Qdrant.
+// EmbedTask generates embedding and stores in Qdrant. Bulk callers use IndexTasks.
 func (r *implRepository) EmbedTask(ctx context.Context, task model.Task) error {
-	// Build text to embed: title + tags + summary (NOT full content)
-	textToEmbed := buildEmbeddingText(task)
+	now := time.Now().In(r.loc)
+	if err := r.upsertTasks(ctx, []model.Task{task}, [][]indexer.Chunk{buildChunks(task, now)}, now); err != nil {
+		return err
+	}
 
-	// Generate embedding
-	vectors, err := r.embedder.Embed(ctx, []string{textToEmbed})
-	if err != nil || len(vectors) == 0 {
-		r.l.Errorf(ctx, "qdrant repository: failed to generate embedding: %v", err)
-		return fmt.Errorf("failed to generate embedding: %w", err)
+	r.l.Infof(ctx, "qdrant repository: embedded task %s (qdrant_id=%s)", task.ID, memoIDToUUID(task.ID))
+	return nil
+}
+
+// IndexTasks embeds tasks in request-sized batches on opt.Concurrency workers. The rate limit
+// applies to embedding requests, which is where provider quotas bite.
+func (r *implRepository) IndexTasks(ctx context.Context, tasks []model.Task, opt repository.IndexOptions) (repository.IndexResult, error) {
+	result := repository.IndexResult{Total: len(tasks)}
+	pending := make([]model.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if opt.Checkpoint != nil && opt.Checkpoint.Indexed(t) {
+			result.Skipped++
+			continue
+		}
+		pending = append(pending, t)
+	}
+	// One clock for the whole run, so the text and the temporal bucket of every task agree
+	now := time.Now().In(r.loc)
+	chunks := make([][]indexer.Chunk, 0, len(pending))
+	texts := make([][]string, 0, len(pending))
+	for _, t := range pending {
+		c := buildChunks(t, now)
+		chunks = append(chunks, c)
+		texts = append(texts, chunkTexts(c))
 	}
-	vector := vectors[0]
 
-	// CRITICAL FIX: Convert Memos ID to UUID for Qdrant
-	// Qdrant requires ID to be UUID or uint64, NOT arbitrary string
-	qdrantID := memoIDToUUID(task.ID)
+	concurrency := opt.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultIndexConcurrency
+	}
+	limiter := rate.NewLimiter(rate.Inf, 1)
+	if opt.RequestsPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(opt.RequestsPerSecond), 1)
+	}
 
-	// NEW: Extract tags from content
-	tags := extractTags(task.Content)
+	var (
+		mu      sync.Mutex
+		saveErr error
+		wg      sync.WaitGroup
+	)
+	batches := make(chan [2]int)
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range batches {
+				batch := pending[b[0]:b[1]]
+				err := limiter.Wait(ctx)
+				if err == nil {
+					err = r.upsertTasks(ctx, batch, chunks[b[0]:b[1]], now)
+				}
+
+				mu.Lock()
+				if err != nil {
+					r.l.Warnf(ctx, "qdrant repository: failed to index %d task(s): %v", len(batch), err)
+					for _, t := range batch {
+						result.Failed = append(result.Failed, t.ID)
+					}
+				} else {
+					result.Indexed += len(batch)
+					if opt.Checkpoint != nil {
+						if err := opt.Checkpoint.MarkIndexed(batch); err != nil && saveErr == nil {
+							saveErr = err
+						}
+					}
+				}
+				if opt.Progress != nil {
+					progress := result
+					progress.Failed = slices.Clone(result.Failed)
+					opt.Progress(progress)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
 
-	// Create point
-	point := pkgQdrant.Point{
-		ID:     qdrantID, // UUID string
-		Vector: vector,
-		Payload: map[string]interface{}{
-			"memo_id":     task.ID, // Store original Memos ID in payload
-			"memo_url":    task.MemoURL,
-			"content":     task.Content,
-			"tags":        tags,
-			"create_time": task.CreateTime,
-			"update_time": task.UpdateTime,
-		},
+dispatch:
+	for _, b := range embedding.GroupBatches(texts, opt.BatchSize) {
+		select {
+		case batches <- b:
+		case <-ctx.Done():
+			break dispatch
+		}
 	}
+	close(batches)
+	wg.Wait()
 
-	// Upsert to Qdrant
-	req := pkgQdrant.UpsertPointsRequest{
-		Points: []pkgQdrant.Point{point},
+	r.l.Infof(ctx, "qdrant repository: indexed %d/%d tasks (%d skipped, %d failed)",
+		result.Indexed, result.Total, result.Skipped, len(result.Failed))
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	if saveErr != nil {
+		return result, fmt.Errorf("failed to save index checkpoint: %w", saveErr)
+	}
+	return result, nil
+}
+
+// upsertTasks embeds the chunks of tasks with one request and upserts their points with
+// another, then drops chunks left over from longer versions of the tasks. The chunks must
+// have been built at now.
+func (r *implRepository) upsertTasks(ctx context.Context, tasks []model.Task, chunks [][]indexer.Chunk, now time.Time) error {
+	var texts []string
+	for _, c := range chunks {
+		texts = append(texts, chunkTexts(c)...)
+	}
+
+	// Generate embeddings
+	vectors, err := r.embedder.Embed(ctx, texts)
+	if err == nil && len(vectors) != len(texts) {
+		err = fmt.Errorf("got %d embeddings for %d chunks", len(vectors), len(texts))
+	}
+	if err != nil {
+		r.l.Errorf(ctx, "qdrant repository: failed to generate embedding: %v", err)
+		return fmt.Errorf("failed to generate embedding: %w", err)
+	}
+
+	points := make([]pkgQdrant.Point, 0, len(texts))
+	stale := make([]map[string]interface{}, 0, len(tasks))
+	for i, task := range tasks {
+		payload := taskPayload(task, now, r.embedder.Model())
+		for _, chunk := range chunks[i] {
+			p := pkgQdrant.Point{
+				// CRITICAL FIX: Convert Memos ID to UUID for Qdrant
+				// Qdrant requires ID to be UUID or uint64, NOT arbitrary string
+				ID:     chunkPointID(task.ID, chunk.Index),
+				Vector: vectors[0],
+			}
+			vectors = vectors[1:]
+			if chunk.Index == 0 {
+				p.Payload = payload
+				p.Payload[repository.PayloadChunkIndex] = 0
+				p.Payload[repository.PayloadChunkCount] = len(chunks[i])
+				p.Payload[repository.PayloadSection] = chunk.Section
+			} else {
+				p.Payload = chunkPayload(payload, chunk)
+			}
+			points = append(points, p)
+		}
+		stale = append(stale, chunksFrom(task.ID, len(chunks[i])))
 	}
 
-	if err := r.client.UpsertPoints(ctx, r.collectionName, req); err != nil {
-		r.l.Errorf(ctx, "qdrant repository: failed to upsert point: %v", err)
+	// Upsert to Qdrant
+	if err := r.client.UpsertPoints(ctx, r.collectionName, pkgQdrant.UpsertPointsRequest{Points: points}); err != nil {
+		r.l.Errorf(ctx, "qdrant repository: failed to upsert points: %v", err)
 		return fmt.Errorf("failed to upsert point: %w", err)
 	}
 
-	r.l.Infof(ctx, "qdrant repository: embedded task %s (qdrant_id=%s)", task.ID, qdrantID)
+	// The tasks are searchable either way; leftover chunks only add noise until the next embed
+	if err := r.client.DeletePointsByFilter(ctx, r.collectionName, map[string]interface{}{"should": stale}); err != nil {
+		r.l.Warnf(ctx, "qdrant repository: failed to delete stale chunks of %d task(s): %v", len(tasks), err)
+	}
 	return nil
 }
 
@@ -94,6 +225,12 @@ func (r *implRepository) SearchTasks(ctx context.Context, opt repository.SearchT
 		return nil, fmt.Errorf("failed to generate query embedding: %w", err)
 	}
 	queryVector := vectors[0]
+	filter := toQdrantFilter(withTags(opt.Filter, opt.Tags))
+	fetchLimit := opt.Limit * searchChunkOverfetch
+	textQuery := opt.TextQuery
+	if textQuery == "" {
+		textQuery = opt.Query
+	}
 
 	// --- Run dense + text search in parallel ---
 	type trackResult struct {
@@ -107,8 +244,9 @@ func (r *implRepository) SearchTasks(ctx context.Context, opt repository.SearchT
 	go func() {
 		req := pkgQdrant.SearchRequest{
 			Vector:      queryVector,
-			Limit:       opt.Limit,
+			Limit:       fetchLimit,
 			WithPayload: true,
+			Filter:      filter,
 		}
 		resp, err := r.client.SearchPoints(ctx, r.collectionName, req)
 		if err != nil {
@@ -121,26 +259,33 @@ func (r *implRepository) SearchTasks(ctx context.Context, opt repository.SearchT
 	// Track 2: Full-text scroll search using Qdrant text match filter
 	go func() {
 		// Build text match filter: match any word in the query against "content" field.
-		// Requires a text index on "content" field (created once via CreatePayloadIndex).
-		filter := map[string]interface{}{
-			"must": []map[string]interface{}{
-				{
-					"key": "content",
-					"match": map[string]interface{}{
-						"text": opt.Query,
-					},
+		// Requires the text index on "content" created by Bootstrap (migration 1).
+		// The payload filter applies to this track too.
+		must := []map[string]interface{}{
+			{
+				"key": "content",
+				"match": map[string]interface{}{
+					"text": textQuery,
 				},
 			},
 		}
+		textFilter := map[string]interface{}{}
+		for k, v := range filter {
+			textFilter[k] = v
+		}
+		if extra, ok := filter["must"].([]map[string]interface{}); ok {
+			must = append(must, extra...)
+		}
+		textFilter["must"] = must
 		req := pkgQdrant.ScrollRequest{
-			Filter:      filter,
-			Limit:       opt.Limit,
+			Filter:      textFilter,
+			Limit:       fetchLimit,
 			WithPayload: true,
 			WithVector:  false,
 		}
 		resp, err := r.client.ScrollPoints(ctx, r.collectionName, req)
 		if err != nil {
-			// Text index may not exist yet — graceful degradation, not a fatal error
+			// The text index is created by Bootstrap; if it failed, degrade to dense-only
 			r.l.Warnf(ctx, "qdrant repository: text search failed (index missing?), skipping: %v", err)
 			textCh <- trackResult{points: nil}
 			return
@@ -159,9 +304,14 @@ func (r *implRepository) SearchTasks(ctx context.Context, opt repository.SearchT
 	// RRF fusion of both tracks
 	fused := pkgQdrant.ReciprocateRankFusion(denseRes.points, textRes.points, 60)
 
-	// Convert fused results → SearchResult, extracting memo_id from payload
+	// Convert fused results → SearchResult, extracting memo_id from payload.
+	// Chunks of the same task collapse into the task's best-ranked hit.
 	results := make([]repository.SearchResult, 0, len(fused))
+	seen := make(map[string]bool, len(fused))
 	for _, hr := range fused {
+		if hr.ID == pkgQdrant.SchemaPointID {
+			continue
+		}
 		memoIDRaw, exists := hr.Payload["memo_id"]
 		if !exists {
 			r.l.Errorf(ctx, "qdrant repository: memo_id missing in payload for point %v", hr.ID)
@@ -172,11 +322,24 @@ func (r *implRepository) SearchTasks(ctx context.Context, opt repository.SearchT
 			r.l.Errorf(ctx, "qdrant repository: memo_id type assertion failed for point %v", hr.ID)
 			continue
 		}
-		results = append(results, repository.SearchResult{
+		if seen[memoID] {
+			continue
+		}
+		seen[memoID] = true
+
+		result := repository.SearchResult{
 			MemoID:  memoID,
 			Score:   hr.RRFScore,
 			Payload: hr.Payload,
-		})
+		}
+		if index, _ := hr.Payload[repository.PayloadChunkIndex].(float64); index > 0 {
+			result.Section, _ = hr.Payload[repository.PayloadSection].(string)
+			result.Snippet, _ = hr.Payload["content"].(string)
+		}
+		results = append(results, result)
+		if opt.Limit > 0 && len(results) == opt.Limit {
+			break
+		}
 	}
 
 	r.l.Infof(ctx, "qdrant repository: hybrid search found %d results (dense=%d, text=%d) for query %q",
@@ -184,57 +347,114 @@ func (r *implRepository) SearchTasks(ctx context.Context, opt repository.SearchT
 	return results, nil
 }
 
-// SearchTasksWithFilter performs semantic search with payload filtering.
-func (r *implRepository) SearchTasksWithFilter(ctx context.Context, opt repository.SearchTasksOptions) ([]repository.SearchResult, error) {
-	dummyVector := make([]float32, 1024)
+// FindSimilarTasks embeds the queries in as few requests as the provider allows and runs one
+// Qdrant batch search per embedding batch. Only the first point of each task is searched, so
+// every task appears at most once per query.
+func (r *implRepository) FindSimilarTasks(ctx context.Context, queries []string, limit int) ([][]repository.SearchResult, error) {
+	results := make([][]repository.SearchResult, len(queries))
+	filter := taskPointsOnly(nil)
+	for _, b := range embedding.Batches(queries, 0) {
+		vectors, err := r.embedder.Embed(ctx, queries[b[0]:b[1]])
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate query embeddings: %w", err)
+		}
+		if len(vectors) != b[1]-b[0] {
+			return nil, fmt.Errorf("failed to generate query embeddings: got %d vectors for %d queries", len(vectors), b[1]-b[0])
+		}
 
-	var shouldConditions []map[string]interface{}
-	for _, cond := range opt.Filter.Should {
-		shouldConditions = append(shouldConditions, map[string]interface{}{
-			"key": cond.Key,
-			"match": map[string]interface{}{
-				"any": cond.Match.Values,
-			},
-		})
-	}
+		searches := make([]pkgQdrant.SearchRequest, len(vectors))
+		for i, v := range vectors {
+			searches[i] = pkgQdrant.SearchRequest{Vector: v, Limit: limit, WithPayload: true, Filter: filter}
+		}
+		resp, err := r.client.SearchPointsBatch(ctx, r.collectionName, pkgQdrant.SearchBatchRequest{Searches: searches})
+		if err != nil {
+			return nil, fmt.Errorf("failed to search: %w", err)
+		}
 
-	filter := map[string]interface{}{}
-	if len(shouldConditions) > 0 {
-		filter["should"] = shouldConditions
+		for i, points := range resp.Result {
+			hits := make([]repository.SearchResult, 0, len(points))
+			for _, p := range points {
+				memoID, ok := p.Payload["memo_id"].(string)
+				if !ok {
+					continue
+				}
+				hits = append(hits, repository.SearchResult{MemoID: memoID, Score: p.Score, Payload: p.Payload})
+			}
+			results[b[0]+i] = hits
+		}
 	}
+	return results, nil
+}
 
-	searchReq := pkgQdrant.SearchRequest{
-		Vector:      dummyVector,
-		Limit:       opt.Limit,
+// FilterTaskPage lists one page of the tasks matching a payload filter with a Qdrant scroll.
+// No vector is involved, so every match is found regardless of the embedding model.
+func (r *implRepository) FilterTaskPage(ctx context.Context, opt repository.FilterTasksOptions) (repository.SearchPage, error) {
+	limit := opt.Limit
+	if limit <= 0 {
+		limit = filterPageSize
+	}
+	req := pkgQdrant.ScrollRequest{
+		Filter:      taskPointsOnly(toQdrantFilter(withTags(opt.Filter, opt.Tags))),
+		Limit:       limit,
 		WithPayload: true,
-		Filter:      filter,
+	}
+	if opt.Offset != "" {
+		req.Offset = opt.Offset
 	}
 
-	resp, err := r.client.SearchPoints(ctx, r.collectionName, searchReq)
+	resp, err := r.client.ScrollPoints(ctx, r.collectionName, req)
 	if err != nil {
-		r.l.Errorf(ctx, "qdrant repository: failed to search with filter: %v", err)
-		return nil, fmt.Errorf("failed to search with filter: %w", err)
+		r.l.Errorf(ctx, "qdrant repository: failed to scroll with filter: %v", err)
+		return repository.SearchPage{}, fmt.Errorf("failed to filter tasks: %w", err)
 	}
 
-	results := make([]repository.SearchResult, 0, len(resp.Result))
-	for _, scored := range resp.Result {
-		memoIDRaw, exists := scored.Payload["memo_id"]
-		if !exists {
-			continue
-		}
-		memoID, ok := memoIDRaw.(string)
+	page := repository.SearchPage{Results: make([]repository.SearchResult, 0, len(resp.Result.Points))}
+	for _, p := range resp.Result.Points {
+		memoID, ok := p.Payload["memo_id"].(string)
 		if !ok {
 			continue
 		}
-		results = append(results, repository.SearchResult{
-			MemoID:  memoID,
-			Score:   scored.Score,
-			Payload: scored.Payload,
-		})
+		page.Results = append(page.Results, repository.SearchResult{MemoID: memoID, Payload: p.Payload})
 	}
+	if resp.Result.NextPageOffset != nil {
+		page.NextOffset = fmt.Sprint(resp.Result.NextPageOffset)
+	}
+	return page, nil
+}
 
-	r.l.Infof(ctx, "qdrant repository: found %d results using filter", len(results))
-	return results, nil
+// IterateFilteredTasks yields every task matching a payload filter, fetching page by page.
+func (r *implRepository) IterateFilteredTasks(ctx context.Context, opt repository.FilterTasksOptions) iter.Seq2[repository.SearchResult, error] {
+	return func(yield func(repository.SearchResult, error) bool) {
+		for {
+			page, err := r.FilterTaskPage(ctx, opt)
+			if err != nil {
+				yield(repository.SearchResult{}, err)
+				return
+			}
+			for _, res := range page.Results {
+				if !yield(res, nil) {
+					return
+				}
+			}
+			if page.NextOffset == "" {
+				return
+			}
+			opt.Offset = page.NextOffset
+		}
+	}
+}
+
+// CountTasks counts the tasks matching a payload filter.
+func (r *implRepository) CountTasks(ctx context.Context, filter repository.PayloadFilter) (int, error) {
+	count, err := r.client.CountPoints(ctx, r.collectionName, pkgQdrant.CountRequest{
+		Filter: taskPointsOnly(toQdrantFilter(filter)),
+		Exact:  true,
+	})
+	if err != nil {
+		r.l.Errorf(ctx, "qdrant repository: failed to count with filter: %v", err)
+		return 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+	return count, nil
 }
 
 // DeleteTask removes a task from Qdrant.
@@ -246,6 +466,10 @@ func (r *implRepository) DeleteTask(ctx context.Context, taskID string) error {
 		r.l.Errorf(ctx, "qdrant repository: failed to delete point: %v", err)
 		return fmt.Errorf("failed to delete point: %w", err)
 	}
+	if err := r.client.DeletePointsByFilter(ctx, r.collectionName, chunksFrom(taskID, 1)); err != nil {
+		r.l.Errorf(ctx, "qdrant repository: failed to delete chunks: %v", err)
+		return fmt.Errorf("failed to delete chunks: %w", err)
+	}
 
 	r.l.Infof(ctx, "qdrant repository: deleted task %s (qdrant_id=%s)", taskID, qdrantID)
 	return nil
@@ -261,32 +485,28 @@ func memoIDToUUID(memoID string) string {
 	return uuid.NewSHA1(namespace, []byte(memoID)).String()
 }
 
-// buildEmbeddingText constructs enriched text for embedding from task.
+// chunkPointID returns the Qdrant ID of a task's chunk. Chunk 0 keeps the task's own ID.
+func chunkPointID(memoID string, index int) string {
+	if index == 0 {
+		return memoIDToUUID(memoID)
+	}
+	return memoIDToUUID(fmt.Sprintf("%s#chunk/%d", memoID, index))
+}
+
+// buildChunks constructs the enriched texts for embedding from task, one per chunk.
 // V2.0: Dung Contextual Enrichment thay vi chi embed title+tags.
 // Ket qua: vector capture duoc "tuan nay", "ngay mai", "qua han" →
 // query "deadline tuan nay" match chinh xac hon.
-func buildEmbeddingText(task model.Task) string {
-	return indexer.EnrichTaskContent(task.Content, task.Tags, "Asia/Ho_Chi_Minh")
+// V3.0: Task dai duoc chia chunk theo section, chi tiet cuoi meeting notes van tim duoc.
+// V4.0: "Hom nay" la tham so, payload ghi lai bucket (temporal_bucket) de embed lai khi bucket doi.
+func buildChunks(task model.Task, now time.Time) []indexer.Chunk {
+	return indexer.ChunkTaskContentAt(task.Content, task.Tags, now)
 }
 
-// extractTags extracts hashtags from markdown content.
-// Matches patterns like: #repo/myproject, #pr/123, #issue/456
-func extractTags(content string) []string {
-	var tags []string
-	seen := make(map[string]bool)
-
-	lines := strings.Split(content, "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		matches := tagRegex.FindAllString(line, -1)
-
-		for _, tag := range matches {
-			if !seen[tag] {
-				tags = append(tags, tag)
-				seen[tag] = true
-			}
-		}
+func chunkTexts(chunks []indexer.Chunk) []string {
+	texts := make([]string, 0, len(chunks))
+	for _, c := range chunks {
+		texts = append(texts, c.Text)
 	}
-
-	return tags
+	return texts
 }
@@ -3,14 +3,20 @@ package qdrant_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"autonomous-task-management/internal/model"
 	"autonomous-task-management/internal/task/repository"
 	"autonomous-task-management/internal/task/repository/qdrant"
+	"autonomous-task-management/pkg/embedding"
 	pkgQdrant "autonomous-task-management/pkg/qdrant"
 	"autonomous-task-management/pkg/voyage"
 )
@@ -74,25 +80,6 @@ func TestQdrantRepository(t *testing.T) {
 		var req pkgQdrant.SearchRequest
 		json.NewDecoder(r.Body).Decode(&req)
 
-		if req.Filter != nil {
-			// SearchTasksWithFilter flow
-			resp := pkgQdrant.SearchResponse{
-				Result: []pkgQdrant.ScoredPoint{
-					{
-						ID:    "123e4567-e89b-12d3-a456-426614174000",
-						Score: 0.95,
-						Payload: map[string]interface{}{
-							"memo_id":  "memos/1",
-							"memo_url": "http://example.com/1",
-							"content":  "Filtered Task",
-						},
-					},
-				},
-			}
-			json.NewEncoder(w).Encode(resp)
-			return
-		}
-
 		if req.Limit == 99 { // dummy condition to trigger error
 			w.WriteHeader(http.StatusInternalServerError)
 			return
@@ -124,6 +111,21 @@ func TestQdrantRepository(t *testing.T) {
 			return
 		}
 
+		// Filter-only listing: two pages, one point each
+		if req.Filter["should"] != nil {
+			memoID, next := "memos/1", interface{}("page-2")
+			if req.Offset != nil {
+				memoID, next = "memos/3", nil
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"result": map[string]interface{}{
+					"points":           []map[string]interface{}{{"id": memoID, "payload": map[string]interface{}{"memo_id": memoID}}},
+					"next_page_offset": next,
+				},
+			})
+			return
+		}
+
 		// Return same point as dense search to simulate text match
 		resp := map[string]interface{}{
 			"result": map[string]interface{}{
@@ -143,6 +145,9 @@ func TestQdrantRepository(t *testing.T) {
 		}
 		json.NewEncoder(w).Encode(resp)
 	})
+	qdrantMux.HandleFunc("/collections/test_tasks/points/count", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":{"count":2}}`))
+	})
 	qdrantMux.HandleFunc("/collections/test_tasks/points/delete", func(w http.ResponseWriter, r *http.Request) {
 		var req pkgQdrant.DeletePointsRequest
 		json.NewDecoder(r.Body).Decode(&req)
@@ -161,7 +166,7 @@ func TestQdrantRepository(t *testing.T) {
 	vClient.WithBaseURL(voyageTS.URL)
 
 	qClient := pkgQdrant.NewClient(qdrantTS.URL)
-	repo := qdrant.New(qClient, vClient, "test_tasks", &mockLogger{})
+	repo := qdrant.New(qClient, vClient, "test_tasks", "Asia/Ho_Chi_Minh", &mockLogger{})
 	ctx := context.Background()
 
 	t.Run("EmbedTask", func(t *testing.T) {
@@ -203,32 +208,36 @@ func TestQdrantRepository(t *testing.T) {
 
 		// DB error
 		opts.Query = "clean"
-		opts.Limit = 99
+		opts.Limit = 33 // Over-fetched to 99 per track
 		_, err = repo.SearchTasks(ctx, opts)
 		if err == nil {
 			t.Errorf("expected db search error")
 		}
 	})
 
-	t.Run("SearchTasksWithFilter", func(t *testing.T) {
-		opts := repository.SearchTasksOptions{
-			Query: "find with filter",
-			Limit: 10,
-			Filter: repository.PayloadFilter{
-				Should: []repository.Condition{
-					{
-						Key:   "status",
-						Match: repository.MatchAny{Values: []string{"active"}},
-					},
+	t.Run("FilterTasks", func(t *testing.T) {
+		filter := repository.PayloadFilter{
+			Should: []repository.Condition{
+				{
+					Key:   "status",
+					Match: repository.MatchAny{Values: []string{"active"}},
 				},
 			},
 		}
-		results, err := repo.SearchTasksWithFilter(ctx, opts)
-		if err != nil {
-			t.Fatalf("unexpected err: %v", err)
+		var ids []string
+		for res, err := range repo.IterateFilteredTasks(ctx, repository.FilterTasksOptions{Filter: filter}) {
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			ids = append(ids, res.MemoID)
 		}
-		if len(results) != 1 || results[0].MemoID != "memos/1" {
-			t.Errorf("unexpected filter result")
+		if strings.Join(ids, ",") != "memos/1,memos/3" {
+			t.Errorf("expected both pages, got %v", ids)
+		}
+
+		count, err := repo.CountTasks(ctx, filter)
+		if err != nil || count != 2 {
+			t.Errorf("unexpected count %d, err %v", count, err)
 		}
 	})
 
@@ -244,3 +253,376 @@ func TestQdrantRepository(t *testing.T) {
 		// It's easier just to let it be. Only 1 error path to cover.
 	})
 }
+
+func TestQdrantRepository_TypedPayloadAndFilters(t *testing.T) {
+	voyageTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(voyage.EmbedResponse{Data: []voyage.EmbeddingData{{Embedding: []float32{0.1, 0.2, 0.3}}}})
+	}))
+	defer voyageTS.Close()
+
+	var upserted pkgQdrant.UpsertPointsRequest
+	var denseFilter, textFilter map[string]interface{}
+	qdrantMux := http.NewServeMux()
+	qdrantMux.HandleFunc("/collections/test_tasks/points", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&upserted)
+	})
+	qdrantMux.HandleFunc("/collections/test_tasks/points/search", func(w http.ResponseWriter, r *http.Request) {
+		var req pkgQdrant.SearchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		denseFilter = req.Filter
+		json.NewEncoder(w).Encode(pkgQdrant.SearchResponse{Result: []pkgQdrant.ScoredPoint{
+			{ID: pkgQdrant.SchemaPointID, Payload: map[string]interface{}{"schema_version": 3}},
+			{ID: "p1", Score: 0.9, Payload: map[string]interface{}{"memo_id": "memos/1"}},
+		}})
+	})
+	qdrantMux.HandleFunc("/collections/test_tasks/points/scroll", func(w http.ResponseWriter, r *http.Request) {
+		var req pkgQdrant.ScrollRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		textFilter = req.Filter
+		w.Write([]byte(`{"result":{"points":[],"next_page_offset":null}}`))
+	})
+	qdrantTS := httptest.NewServer(qdrantMux)
+	defer qdrantTS.Close()
+
+	vClient, _ := voyage.New("test-key")
+	vClient.WithBaseURL(voyageTS.URL)
+	repo := qdrant.New(pkgQdrant.NewClient(qdrantTS.URL), vClient, "test_tasks", "Asia/Ho_Chi_Minh", &mockLogger{})
+	ctx := context.Background()
+
+	content := "## Ship report\n\n- **Due:** 2026-03-15\n- **Priority:** p1\n\n#priority/p1 #project/SMAP"
+	if err := repo.EmbedTask(ctx, model.Task{ID: "memos/1", Content: content}); err != nil {
+		t.Fatalf("unexpected embed error: %v", err)
+	}
+	payload := upserted.Points[0].Payload
+	if payload["priority"] != "p1" || payload["status"] != "todo" || payload["title"] != "Ship report" {
+		t.Errorf("unexpected typed payload: %v", payload)
+	}
+	if payload["due"] != "2026-03-15T00:00:00+07:00" {
+		t.Errorf("unexpected due: %v", payload["due"])
+	}
+	if bucket := payload[repository.PayloadTemporalBucket]; bucket != "qua han" {
+		t.Errorf("unexpected temporal bucket: %v", bucket)
+	}
+	if projects, _ := payload["project"].([]interface{}); len(projects) != 1 || projects[0] != "smap" {
+		t.Errorf("unexpected project: %v", payload["project"])
+	}
+
+	// A fully ticked checklist is done without a #status/done tag, as everywhere else
+	checklist := "## Pack\n\n- **Due:** 2026-03-15\n\n- [x] Passport\n- [x] Charger"
+	if err := repo.EmbedTask(ctx, model.Task{ID: "memos/2", Content: checklist}); err != nil {
+		t.Fatalf("unexpected embed error: %v", err)
+	}
+	if status := upserted.Points[0].Payload["status"]; status != "done" {
+		t.Errorf("fully ticked checklist should be stored as done, got %v", status)
+	}
+
+	// Due dates are read in the configured timezone
+	utcRepo := qdrant.New(pkgQdrant.NewClient(qdrantTS.URL), vClient, "test_tasks", "UTC", &mockLogger{})
+	if err := utcRepo.EmbedTask(ctx, model.Task{ID: "memos/1", Content: content}); err != nil {
+		t.Fatalf("unexpected embed error: %v", err)
+	}
+	if due := upserted.Points[0].Payload["due"]; due != "2026-03-15T00:00:00Z" {
+		t.Errorf("due should follow the repository timezone, got %v", due)
+	}
+
+	loc := time.FixedZone("ICT", 7*3600)
+	results, err := repo.SearchTasks(ctx, repository.SearchTasksOptions{
+		Query:     "report báo cáo",
+		TextQuery: "report",
+		Limit:     5,
+		Tags:      []string{"#pr/1"},
+		Filter: repository.PayloadFilter{
+			Must: []repository.Condition{
+				{Key: repository.PayloadPriority, Match: repository.MatchAny{Values: []string{"p1"}}},
+				{Key: repository.PayloadDue, Range: &repository.DateRange{
+					From: time.Date(2026, 3, 9, 0, 0, 0, 0, loc),
+					To:   time.Date(2026, 3, 16, 0, 0, 0, 0, loc),
+				}},
+			},
+			MustNot: []repository.Condition{
+				{Key: repository.PayloadStatus, Match: repository.MatchAny{Values: []string{"done"}}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected search error: %v", err)
+	}
+	if len(results) != 1 || results[0].MemoID != "memos/1" {
+		t.Errorf("the schema point must not be returned: %+v", results)
+	}
+
+	dense, _ := json.Marshal(denseFilter)
+	for _, want := range []string{
+		`{"key":"priority","match":{"any":["p1"]}}`,
+		`{"key":"due","range":{"gte":"2026-03-09T00:00:00+07:00","lt":"2026-03-16T00:00:00+07:00"}}`,
+		`{"key":"tags","match":{"any":["#pr/1"]}}`,
+		`"must_not":[{"key":"status","match":{"any":["done"]}}]`,
+	} {
+		if !strings.Contains(string(dense), want) {
+			t.Errorf("dense filter %s lacks %s", dense, want)
+		}
+	}
+
+	text, _ := json.Marshal(textFilter)
+	for _, want := range []string{`{"key":"content","match":{"text":"report"}}`, `"key":"priority"`, `"must_not"`} {
+		if !strings.Contains(string(text), want) {
+			t.Errorf("text filter %s lacks %s", text, want)
+		}
+	}
+}
+
+func TestQdrantRepository_IndexTasks(t *testing.T) {
+	var embedCalls atomic.Int32
+	voyageTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		embedCalls.Add(1)
+		var req voyage.EmbedRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		resp := voyage.EmbedResponse{}
+		for _, text := range req.Input {
+			if strings.Contains(text, "broken") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			resp.Data = append(resp.Data, voyage.EmbeddingData{Embedding: []float32{0.1, 0.2}})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer voyageTS.Close()
+
+	var mu sync.Mutex
+	upserted := map[string]bool{}
+	qdrantTS := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req pkgQdrant.UpsertPointsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, p := range req.Points {
+			upserted[p.Payload["memo_id"].(string)] = true
+		}
+	}))
+	defer qdrantTS.Close()
+
+	vClient, _ := voyage.New("test-key")
+	vClient.WithBaseURL(voyageTS.URL)
+	repo := qdrant.New(pkgQdrant.NewClient(qdrantTS.URL), vClient, "test_tasks", "Asia/Ho_Chi_Minh", &mockLogger{})
+
+	tasks := make([]model.Task, 0, 7)
+	for i := range 7 {
+		content := fmt.Sprintf("## Task %d", i)
+		if i == 6 {
+			content = "## broken"
+		}
+		tasks = append(tasks, model.Task{ID: fmt.Sprintf("memos/%d", i), Content: content, UpdateTime: "v1"})
+	}
+
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+	checkpoint, err := repository.NewFileCheckpoint(checkpointPath)
+	if err != nil {
+		t.Fatalf("unexpected checkpoint error: %v", err)
+	}
+	var progressCalls int
+	opt := repository.IndexOptions{
+		BatchSize:   3,
+		Concurrency: 2,
+		Checkpoint:  checkpoint,
+		Progress:    func(repository.IndexResult) { progressCalls++ },
+	}
+
+	result, err := repo.IndexTasks(context.Background(), tasks, opt)
+	if err != nil {
+		t.Fatalf("unexpected index error: %v", err)
+	}
+	if result.Total != 7 || result.Indexed != 6 || len(result.Failed) != 1 || result.Failed[0] != "memos/6" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if embedCalls.Load() != 3 || progressCalls != 3 || len(upserted) != 6 {
+		t.Errorf("expected 3 batches, got %d embed calls, %d progress calls, %d upserts",
+			embedCalls.Load(), progressCalls, len(upserted))
+	}
+
+	// A second run resumes: only the failed task and an edited one are embedded again
+	reloaded, err := repository.NewFileCheckpoint(checkpointPath)
+	if err != nil || reloaded.Len() != 6 {
+		t.Fatalf("checkpoint not persisted: len %d, err %v", reloaded.Len(), err)
+	}
+	tasks[0].UpdateTime = "v2"
+	tasks[6].Content = "## Task 6"
+	embedCalls.Store(0)
+	opt.Checkpoint = reloaded
+	result, err = repo.IndexTasks(context.Background(), tasks, opt)
+	if err != nil {
+		t.Fatalf("unexpected index error: %v", err)
+	}
+	if result.Skipped != 5 || result.Indexed != 2 || len(result.Failed) != 0 || embedCalls.Load() != 1 {
+		t.Errorf("unexpected resumed result: %+v, %d embed calls", result, embedCalls.Load())
+	}
+}
+
+func TestQdrantRepository_LocalEmbedder(t *testing.T) {
+	var upserted pkgQdrant.UpsertPointsRequest
+	var searched pkgQdrant.SearchRequest
+	qdrantMux := http.NewServeMux()
+	qdrantMux.HandleFunc("/collections/test_tasks/points", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&upserted)
+	})
+	qdrantMux.HandleFunc("/collections/test_tasks/points/search", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&searched)
+		w.Write([]byte(`{"result":[]}`))
+	})
+	qdrantMux.HandleFunc("/collections/test_tasks/points/scroll", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":{"points":[],"next_page_offset":null}}`))
+	})
+	qdrantTS := httptest.NewServer(qdrantMux)
+	defer qdrantTS.Close()
+
+	embedder, _ := embedding.NewLocal(16)
+	repo := qdrant.New(pkgQdrant.NewClient(qdrantTS.URL), embedder, "test_tasks", "Asia/Ho_Chi_Minh", &mockLogger{})
+	ctx := context.Background()
+
+	if err := repo.EmbedTask(ctx, model.Task{ID: "memos/1", Content: "## Write offline tests"}); err != nil {
+		t.Fatalf("unexpected embed error: %v", err)
+	}
+	if len(upserted.Points) != 1 || len(upserted.Points[0].Vector) != 16 {
+		t.Fatalf("expected one 16-dimensional point, got %+v", upserted.Points)
+	}
+	if name := upserted.Points[0].Payload[repository.PayloadEmbeddingModel]; name != embedding.LocalModel {
+		t.Errorf("unexpected embedding model: %v", name)
+	}
+
+	if _, err := repo.SearchTasks(ctx, repository.SearchTasksOptions{Query: "offline tests", Limit: 5}); err != nil {
+		t.Fatalf("unexpected search error: %v", err)
+	}
+	if len(searched.Vector) != 16 {
+		t.Errorf("expected a 16-dimensional query vector, got %d", len(searched.Vector))
+	}
+}
+
+func TestQdrantRepository_FindSimilarTasks(t *testing.T) {
+	var batches []pkgQdrant.SearchBatchRequest
+	qdrantMux := http.NewServeMux()
+	qdrantMux.HandleFunc("/collections/test_tasks/points/search/batch", func(w http.ResponseWriter, r *http.Request) {
+		var req pkgQdrant.SearchBatchRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		batches = append(batches, req)
+		resp := pkgQdrant.SearchBatchResponse{Result: make([][]pkgQdrant.ScoredPoint, len(req.Searches))}
+		resp.Result[1] = []pkgQdrant.ScoredPoint{{ID: "p", Score: 0.93, Payload: map[string]interface{}{"memo_id": "memos/7"}}}
+		json.NewEncoder(w).Encode(resp)
+	})
+	qdrantTS := httptest.NewServer(qdrantMux)
+	defer qdrantTS.Close()
+
+	embedder, _ := embedding.NewLocal(16)
+	repo := qdrant.New(pkgQdrant.NewClient(qdrantTS.URL), embedder, "test_tasks", "Asia/Ho_Chi_Minh", &mockLogger{})
+
+	results, err := repo.FindSimilarTasks(context.Background(), []string{"buy milk", "call mom", "pay rent"}, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(batches) != 1 || len(batches[0].Searches) != 3 || batches[0].Searches[0].Limit != 3 {
+		t.Fatalf("expected one batch of three searches, got %+v", batches)
+	}
+	if len(results) != 3 || len(results[0]) != 0 || len(results[1]) != 1 || results[1][0].MemoID != "memos/7" || results[1][0].Score != 0.93 {
+		t.Errorf("results should keep query order and cosine scores, got %+v", results)
+	}
+}
+
+func TestQdrantRepository_ChunkedTask(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		upserted []pkgQdrant.Point
+		deleted  []map[string]interface{}
+		searched pkgQdrant.SearchRequest
+	)
+	qdrantMux := http.NewServeMux()
+	qdrantMux.HandleFunc("/collections/test_tasks/points", func(w http.ResponseWriter, r *http.Request) {
+		var req pkgQdrant.UpsertPointsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		upserted = append(upserted, req.Points...)
+		mu.Unlock()
+	})
+	qdrantMux.HandleFunc("/collections/test_tasks/points/delete", func(w http.ResponseWriter, r *http.Request) {
+		var req pkgQdrant.DeletePointsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		mu.Lock()
+		if req.Filter != nil {
+			deleted = append(deleted, req.Filter)
+		}
+		mu.Unlock()
+	})
+	qdrantMux.HandleFunc("/collections/test_tasks/points/search", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&searched)
+		json.NewEncoder(w).Encode(pkgQdrant.SearchResponse{Result: []pkgQdrant.ScoredPoint{
+			{ID: "c3", Score: 0.9, Payload: map[string]interface{}{
+				"memo_id": "memos/1", "chunk_index": 3, "section": "Ghi chu cuoi", "content": "Nho gia han chung chi SSL",
+			}},
+			{ID: "p1", Score: 0.8, Payload: map[string]interface{}{"memo_id": "memos/1", "chunk_index": 0}},
+			{ID: "p2", Score: 0.7, Payload: map[string]interface{}{"memo_id": "memos/2"}},
+			{ID: "p3", Score: 0.6, Payload: map[string]interface{}{"memo_id": "memos/3"}},
+		}})
+	})
+	qdrantMux.HandleFunc("/collections/test_tasks/points/scroll", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"result":{"points":[],"next_page_offset":null}}`))
+	})
+	qdrantTS := httptest.NewServer(qdrantMux)
+	defer qdrantTS.Close()
+
+	embedder, _ := embedding.NewLocal(8)
+	repo := qdrant.New(pkgQdrant.NewClient(qdrantTS.URL), embedder, "test_tasks", "Asia/Ho_Chi_Minh", &mockLogger{})
+	ctx := context.Background()
+
+	var notes strings.Builder
+	notes.WriteString("## Hop sprint planning\n\n#priority/p1\n\n### Thao luan\n\n")
+	for i := range 40 {
+		fmt.Fprintf(&notes, "- Y kien %d ve tien do cua nhom backend va frontend\n", i)
+	}
+	notes.WriteString("\n### Ghi chu cuoi\n\nNho gia han chung chi SSL\n")
+
+	if err := repo.EmbedTask(ctx, model.Task{ID: "memos/1", Content: notes.String()}); err != nil {
+		t.Fatalf("unexpected embed error: %v", err)
+	}
+	if len(upserted) < 3 {
+		t.Fatalf("expected the long task to be chunked, got %d points", len(upserted))
+	}
+	head := upserted[0].Payload
+	if head["content"] != notes.String() || head["chunk_count"] != float64(len(upserted)) || head["content_hash"] == nil {
+		t.Errorf("chunk 0 must carry the whole task: %v", head)
+	}
+	last := upserted[len(upserted)-1].Payload
+	if last["memo_id"] != "memos/1" || last["section"] != "Ghi chu cuoi" || last["priority"] != "p1" ||
+		!strings.Contains(last["content"].(string), "chung chi SSL") || last["content_hash"] != nil {
+		t.Errorf("unexpected last chunk payload: %v", last)
+	}
+	ids := map[string]bool{}
+	for _, p := range upserted {
+		ids[p.ID.(string)] = true
+	}
+	if len(ids) != len(upserted) {
+		t.Errorf("chunk point IDs must be unique")
+	}
+	if stale, _ := json.Marshal(deleted); !strings.Contains(string(stale), fmt.Sprintf(`{"gte":%d}`, len(upserted))) {
+		t.Errorf("expected chunks past the new count to be deleted, got %s", stale)
+	}
+
+	results, err := repo.SearchTasks(ctx, repository.SearchTasksOptions{Query: "SSL", Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected search error: %v", err)
+	}
+	if searched.Limit != 2*3 {
+		t.Errorf("expected the search to over-fetch chunks, got limit %d", searched.Limit)
+	}
+	if len(results) != 2 || results[0].MemoID != "memos/1" || results[1].MemoID != "memos/2" {
+		t.Fatalf("expected chunks collapsed into their task, got %+v", results)
+	}
+	if results[0].Section != "Ghi chu cuoi" || results[0].Snippet != "Nho gia han chung chi SSL" || results[1].Section != "" {
+		t.Errorf("unexpected highlight: %+v", results)
+	}
+
+	deleted = nil
+	if err := repo.DeleteTask(ctx, "memos/1"); err != nil {
+		t.Fatalf("unexpected delete error: %v", err)
+	}
+	if filter, _ := json.Marshal(deleted); !strings.Contains(string(filter), `"memos/1"`) {
+		t.Errorf("expected the task's chunks to be deleted, got %s", filter)
+	}
+}
@@ -1,10 +1,14 @@
 package task
 
+import "time"
+
 // CreateBulkInput is the input for bulk task creation.
 // UserID is stored in models.Scope, not here (per convention fixes).
 type CreateBulkInput struct {
-	RawText        string // Natural language task descriptions from the user
-	TelegramChatID int64  // Used to send response back to user
+	RawText            string // Natural language task descriptions from the user
+	TelegramChatID     int64  // Used to send response back to user
+	SkipDuplicateCheck bool   // Create every parsed task even if a similar one exists
+	IdempotencyKey     string // Identifies the originating request (e.g. Telegram chat+message); retries reuse tasks already created under it
 }
 
 // CreatedTask represents a single task that was successfully created.
@@ -15,11 +19,18 @@ type CreatedTask struct {
 	Title        string
 }
 
-// SearchInput is the input for semantic search.
+// SearchInput is the input for semantic search. The filters are optional; values within a
+// filter are alternatives, and all filters must hold.
 type SearchInput struct {
-	Query string   `json:"query"` // Natural language query
-	Limit int      `json:"limit"` // Max results (default 10)
-	Tags  []string `json:"tags"`  // Filter by tags (optional)
+	Query           string    `json:"query"`            // Natural language query
+	Limit           int       `json:"limit"`            // Max results (default 10)
+	Tags            []string  `json:"tags"`             // Tasks with any of these tags
+	Priorities      []string  `json:"priorities"`       // e.g. "p0", "p1"
+	Projects        []string  `json:"projects"`         // Values of #project/* tags
+	Statuses        []string  `json:"statuses"`         // e.g. "todo", "in_progress"
+	ExcludeStatuses []string  `json:"exclude_statuses"` // e.g. "done" for open tasks only
+	DueFrom         time.Time `json:"due_from"`         // Due on or after this time
+	DueTo           time.Time `json:"due_to"`           // Due before this time
 }
 
 // SearchResultItem represents a single search result.
@@ -28,18 +39,135 @@ type SearchResultItem struct {
 	MemoURL string  `json:"memo_url"`
 	Content string  `json:"content"`
 	Score   float64 `json:"score"` // Similarity score (0-1)
+
+	// Part of a long task that matched; empty when the task as a whole matched
+	MatchedSection string `json:"matched_section,omitempty"` // Heading, "" for the top of the task
+	Snippet        string `json:"snippet,omitempty"`
 }
 
 // SearchOutput is the result of semantic search.
 type SearchOutput struct {
-	Results []SearchResultItem `json:"results"`
-	Count   int                `json:"count"`
+	Results        []SearchResultItem   `json:"results"`
+	Count          int                  `json:"count"`
+	Interpretation SearchInterpretation `json:"interpretation"`
+}
+
+// SearchInterpretation is how Search understood the query: the filters it applied, whether
+// given in SearchInput or read from the query ("p1", "tuần sau"...), and the text it searched for.
+type SearchInterpretation struct {
+	Text            string    `json:"text"`                 // Query left after the filters were taken out
+	Expansions      []string  `json:"expansions,omitempty"` // Synonyms added to the semantic track
+	Priorities      []string  `json:"priorities,omitempty"`
+	Projects        []string  `json:"projects,omitempty"`
+	Tags            []string  `json:"tags,omitempty"`
+	Statuses        []string  `json:"statuses,omitempty"`
+	ExcludeStatuses []string  `json:"exclude_statuses,omitempty"`
+	DueFrom         time.Time `json:"due_from,omitzero"`
+	DueTo           time.Time `json:"due_to,omitzero"`
+	DuePhrase       string    `json:"due_phrase,omitempty"` // Words of the query the due range came from, e.g. "tuần sau"
 }
 
 // CreateBulkOutput is the result of the bulk task creation operation.
 type CreateBulkOutput struct {
-	Tasks     []CreatedTask
-	TaskCount int
+	BatchID    string // Reference passed to RollbackBatch; empty when nothing was written
+	Tasks      []CreatedTask
+	TaskCount  int
+	Outcomes   []TaskOutcome        // Per-task result of each side effect, including failed memos
+	Duplicates []DuplicateCandidate // Parsed tasks held back because a similar task exists
+}
+
+// StepStatus is the result of one side effect of creating a task.
+type StepStatus string
+
+const (
+	StepOK         StepStatus = "ok"
+	StepFailed     StepStatus = "failed"      // Embedding and calendar failures are retried in the background
+	StepSkipped    StepStatus = "skipped"     // Not configured, or not attempted because an earlier step failed
+	StepRolledBack StepStatus = "rolled_back" // Undone by RollbackBatch
+)
+
+// TaskOutcome reports what happened to each side effect of one parsed task.
+type TaskOutcome struct {
+	Title    string
+	MemoID   string
+	Memo     StepStatus
+	Embed    StepStatus
+	Calendar StepStatus
+	Error    string // Memos error when the task could not be created
+}
+
+// RollbackOutput is the result of undoing a CreateBulk batch.
+type RollbackOutput struct {
+	BatchID string
+	Tasks   []TaskOutcome // Tasks that were removed, with the status of each undo step
+	Errors  []string      // Side effects that could not be undone
+}
+
+// DuplicateAction is how the user resolves a held-back duplicate.
+type DuplicateAction string
+
+const (
+	DuplicateActionMerge  DuplicateAction = "merge"  // Append the new details to the existing task
+	DuplicateActionUpdate DuplicateAction = "update" // Overwrite the existing task's due date, priority and estimate
+	DuplicateActionCreate DuplicateAction = "create" // Create the new task anyway
+)
+
+// DuplicateCandidate is a parsed task that looks like an existing one.
+type DuplicateCandidate struct {
+	PendingID       string  // Reference passed back to ResolveDuplicate
+	Title           string  // Title of the new (not yet created) task
+	ExistingMemoID  string  // Memos ID of the similar task
+	ExistingMemoURL string  // Deep link to the similar task
+	ExistingTitle   string  // Title of the similar task
+	Score           float64 // Semantic similarity (0-1)
+}
+
+// ResolveDuplicateInput resolves a held-back duplicate.
+type ResolveDuplicateInput struct {
+	PendingID string
+	Action    DuplicateAction
+}
+
+// ResolveDuplicateOutput is the task that was created or changed.
+type ResolveDuplicateOutput struct {
+	Action  DuplicateAction
+	Task    CreatedTask
+	BatchID string // Set for DuplicateActionCreate so the new task can be rolled back
+}
+
+// ImportInput is a task file exported from another tool.
+type ImportInput struct {
+	Format             string // "markdown", "csv", "todoist" or "trello"; empty detects it from Filename and Data
+	Filename           string
+	Data               []byte
+	SkipDuplicateCheck bool // Import every item even if a task with the same title or meaning exists
+}
+
+// ImportSkip is an item of the file that was not imported.
+type ImportSkip struct {
+	Title          string
+	Reason         ImportSkipReason
+	ExistingMemoID string // Task the item duplicates, if any
+}
+
+// ImportSkipReason is why an item was not imported.
+type ImportSkipReason string
+
+const (
+	ImportSkipDuplicateInFile ImportSkipReason = "duplicate_in_file" // Same title earlier in the file
+	ImportSkipExisting        ImportSkipReason = "existing"          // A task with the same title already exists
+	ImportSkipSimilar         ImportSkipReason = "similar"           // Semantically close to an existing task
+)
+
+// ImportOutput summarizes an import.
+type ImportOutput struct {
+	BatchID  string // Reference passed to RollbackBatch; empty when nothing was written
+	Format   string
+	Total    int // Items found in the file
+	Tasks    []CreatedTask
+	Skipped  []ImportSkip
+	Outcomes []TaskOutcome // Per-task result, including memos that could not be created
+	Embedded int           // Tasks indexed in Qdrant; failed batches are retried in the background
 }
 
 // QueryInput is the input for RAG-based question answering.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"autonomous-task-management/internal/agent"
@@ -11,6 +12,7 @@ import (
 	"autonomous-task-management/internal/task"
 	"autonomous-task-management/pkg/gcalendar"
 	pkgLog "autonomous-task-management/pkg/log"
+	"autonomous-task-management/pkg/taskmeta"
 )
 
 // RegisterAgentTools registers the task domain's agent tools into the registry.
@@ -28,8 +30,9 @@ func (uc *implUseCase) RegisterAgentTools(registry *agent.ToolRegistry) {
 
 // searchTasksTool implements semantic search over tasks.
 type searchTasksTool struct {
-	uc task.UseCase
-	l  pkgLog.Logger
+	uc  task.UseCase
+	l   pkgLog.Logger
+	loc *time.Location // Timezone of the due date bounds
 }
 
 func (t *searchTasksTool) Name() string {
@@ -37,7 +40,10 @@ func (t *searchTasksTool) Name() string {
 }
 
 func (t *searchTasksTool) Description() string {
-	return "Search for tasks using natural language query. Returns relevant tasks with similarity scores."
+	return "Search for tasks using natural language query. Returns relevant tasks with similarity scores " +
+		"and the interpretation: the filters read from the query and the text actually searched for. " +
+		"Use the filters for anything structured in the question (priority, project, status, tags, due date range) " +
+		"instead of leaving it in the query."
 }
 
 func (t *searchTasksTool) Parameters() map[string]interface{} {
@@ -52,6 +58,30 @@ func (t *searchTasksTool) Parameters() map[string]interface{} {
 				"type":        "integer",
 				"description": "Maximum number of results (default 10)",
 			},
+			"priority": map[string]interface{}{
+				"type":        "string",
+				"description": "Only these priorities, comma separated (e.g. \"p0,p1\")",
+			},
+			"project": map[string]interface{}{
+				"type":        "string",
+				"description": "Only tasks of these projects (#project/<name>), comma separated",
+			},
+			"tags": map[string]interface{}{
+				"type":        "string",
+				"description": "Only tasks with any of these hashtags, comma separated (e.g. \"#pr/123\")",
+			},
+			"status": map[string]interface{}{
+				"type":        "string",
+				"description": "\"open\" for unfinished tasks, \"done\" for completed ones, or a status such as \"in_progress\"",
+			},
+			"due_from": map[string]interface{}{
+				"type":        "string",
+				"description": "Only tasks due on or after this date (YYYY-MM-DD)",
+			},
+			"due_to": map[string]interface{}{
+				"type":        "string",
+				"description": "Only tasks due on or before this date (YYYY-MM-DD)",
+			},
 		},
 		"required": []string{"query"},
 	}
@@ -68,36 +98,89 @@ func (t *searchTasksTool) Execute(ctx context.Context, params map[string]interfa
 		limit = int(l)
 	}
 
+	input := task.SearchInput{Query: query, Limit: limit}
+	if err := t.applyFilters(&input, params); err != nil {
+		return nil, err
+	}
+
 	sc := model.Scope{UserID: "agent"}
-	output, err := t.uc.Search(ctx, sc, task.SearchInput{
-		Query: query,
-		Limit: limit,
-	})
+	output, err := t.uc.Search(ctx, sc, input)
 	if err != nil {
 		return nil, fmt.Errorf("search failed: %w", err)
 	}
 
 	results := make([]map[string]interface{}, 0, len(output.Results))
 	for _, r := range output.Results {
-		results = append(results, map[string]interface{}{
+		result := map[string]interface{}{
 			"memo_id":  r.MemoID,
 			"memo_url": r.MemoURL,
 			"content":  r.Content,
 			"score":    r.Score,
-		})
+		}
+		if r.Snippet != "" {
+			result["matched_section"] = r.MatchedSection
+			result["snippet"] = r.Snippet
+		}
+		results = append(results, result)
 	}
 
 	return map[string]interface{}{
-		"count":   output.Count,
-		"results": results,
+		"count":          output.Count,
+		"results":        results,
+		"interpretation": output.Interpretation,
 	}, nil
 }
 
+// applyFilters reads the optional filter parameters into input.
+func (t *searchTasksTool) applyFilters(input *task.SearchInput, params map[string]interface{}) error {
+	list := func(key string) []string {
+		raw, _ := params[key].(string)
+		var out []string
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				out = append(out, v)
+			}
+		}
+		return out
+	}
+	input.Priorities = list("priority")
+	input.Projects = list("project")
+	input.Tags = list("tags")
+
+	switch status, _ := params["status"].(string); strings.ToLower(strings.TrimSpace(status)) {
+	case "":
+	case "open":
+		input.ExcludeStatuses = []string{taskmeta.StatusDone}
+	default:
+		input.Statuses = []string{status}
+	}
+
+	for key, bound := range map[string]*time.Time{"due_from": &input.DueFrom, "due_to": &input.DueTo} {
+		raw, _ := params[key].(string)
+		if raw == "" {
+			continue
+		}
+		day, err := time.ParseInLocation(taskmeta.DateLayout, raw, t.loc)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q, expected YYYY-MM-DD", key, raw)
+		}
+		if key == "due_to" {
+			day = day.AddDate(0, 0, 1) // Inclusive for the caller, exclusive in the range
+		}
+		*bound = day
+	}
+	return nil
+}
+
 var _ agent.Tool = (*searchTasksTool)(nil)
 
 // newSearchTasksTool creates the search_tasks agent tool.
 func (uc *implUseCase) newSearchTasksTool() agent.Tool {
-	return &searchTasksTool{uc: uc, l: uc.l}
+	loc, err := time.LoadLocation(uc.timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return &searchTasksTool{uc: uc, l: uc.l, loc: loc}
 }
 
 // ============================================================================
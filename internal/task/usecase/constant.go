@@ -0,0 +1,41 @@
+package usecase
+
+import "time"
+
+const (
+	// duplicateScoreThreshold is the minimum similarity for a task to count as a possible duplicate.
+	duplicateScoreThreshold = 0.85
+	// duplicateStrongScore marks near-identical text, flagged regardless of due dates.
+	duplicateStrongScore = 0.95
+	// duplicateDueWindow is how far apart two due dates may be for similar tasks to be duplicates.
+	duplicateDueWindow = 3 * 24 * time.Hour
+	// duplicateSearchLimit is how many neighbours are checked per new task.
+	duplicateSearchLimit = 3
+
+	pendingDuplicateCacheSize = 500
+	pendingDuplicateTTL       = 30 * time.Minute
+
+	// Tasks created under an idempotency key are remembered for a day of retries.
+	createdCacheSize = 1000
+	createdCacheTTL  = 24 * time.Hour
+
+	// Batches stay available for retries and rollback for a day.
+	batchCacheSize = 500
+	batchCacheTTL  = 24 * time.Hour
+	// Failed embedding and calendar steps are retried with exponential backoff.
+	sideEffectMaxRetries = 4
+	sideEffectRetryDelay = 30 * time.Second
+
+	// Imported tasks are embedded this many per request, importEmbedConcurrency requests at a time.
+	importEmbedBatchSize   = 32
+	importEmbedConcurrency = 2
+	// importListPageSize is the page size used to load existing titles for deduplication.
+	importListPageSize = 200
+	// importDefaultPriority applies to items whose source has no priority.
+	importDefaultPriority = "p2"
+
+	// queryExpansionMax caps the phrasings the LLM may add to a search query.
+	queryExpansionMax = 5
+
+	defaultCalendarID = "primary"
+)
@@ -2,11 +2,13 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/schedule"
 	"autonomous-task-management/internal/task"
 	"autonomous-task-management/internal/task/repository"
 	"autonomous-task-management/pkg/gcalendar"
@@ -35,63 +37,138 @@ func (uc *implUseCase) CreateBulk(ctx context.Context, sc model.Scope, input tas
 	// Step 2: Resolve relative dates to absolute times
 	tasksWithDates := uc.resolveDueDates(parsedTasks)
 
-	// Step 3: Create each task in Memos and optionally in Google Calendar
+	// Step 3: Create each task in Memos and optionally in Google Calendar,
+	// holding back likely duplicates of existing tasks for the user to resolve.
+	// Every side effect is recorded in the batch so it can be retried or rolled back.
+	batch := newSagaBatch(sc.UserID)
 	createdTasks := make([]task.CreatedTask, 0, len(tasksWithDates))
+	var (
+		outcomes   []task.TaskOutcome
+		duplicates []task.DuplicateCandidate
+	)
 
 	for _, t := range tasksWithDates {
-		// Build markdown content
-		content := buildMarkdownContent(t)
-
-		// Create in Memos
-		memoTask, memoErr := uc.repo.CreateTask(ctx, repository.CreateTaskOptions{
-			Content:    content,
-			Tags:       allTags(t),
-			Visibility: "PRIVATE",
-		})
-		if memoErr != nil {
-			uc.l.Errorf(ctx, "CreateBulk: failed to create Memos task %q: %v", t.Title, memoErr)
-			continue
+		// A retried request must not recreate (or flag as duplicate) what it already created
+		itemKey := createdKey(input.IdempotencyKey, t)
+		if itemKey != "" {
+			if prev, ok := uc.created.Get(itemKey); ok {
+				uc.l.Infof(ctx, "CreateBulk: task %q already created for key %s, reusing memoID=%s", t.Title, input.IdempotencyKey, prev.MemoID)
+				createdTasks = append(createdTasks, prev)
+				continue
+			}
 		}
 
-		// Embed task to Qdrant (non-blocking on failure)
-		if uc.vectorRepo != nil {
-			if embedErr := uc.vectorRepo.EmbedTask(ctx, memoTask); embedErr != nil {
-				uc.l.Warnf(ctx, "CreateBulk: failed to embed task %s to Qdrant: %v", memoTask.ID, embedErr)
-				// Don't fail the whole operation
+		if !input.SkipDuplicateCheck {
+			if match, found := uc.findDuplicate(ctx, t); found {
+				duplicates = append(duplicates, uc.holdDuplicate(ctx, sc, t, match))
+				continue
 			}
 		}
 
-		// Attempt to create Google Calendar event (non-blocking on failure)
-		calendarLink := uc.tryCreateCalendarEvent(ctx, t, memoTask)
-
-		createdTasks = append(createdTasks, task.CreatedTask{
-			MemoID:       memoTask.ID,
-			MemoURL:      memoTask.MemoURL,
-			CalendarLink: calendarLink,
-			Title:        t.Title,
-		})
-
-		uc.l.Infof(ctx, "CreateBulk: created task %q memoID=%s", t.Title, memoTask.ID)
+		step, err := uc.createOne(ctx, sc.UserID, t)
+		outcomes = append(outcomes, step.outcome)
+		if err != nil {
+			uc.l.Errorf(ctx, "CreateBulk: %v", err)
+			continue
+		}
+		if itemKey != "" {
+			step.createdKey = itemKey
+			uc.created.Put(itemKey, step.created())
+		}
+		batch.steps = append(batch.steps, step)
+		createdTasks = append(createdTasks, step.created())
 	}
 
 	return task.CreateBulkOutput{
-		Tasks:     createdTasks,
-		TaskCount: len(createdTasks),
+		BatchID:    uc.startBatch(ctx, batch),
+		Tasks:      createdTasks,
+		TaskCount:  len(createdTasks),
+		Outcomes:   outcomes,
+		Duplicates: duplicates,
 	}, nil
 }
 
-// tryCreateCalendarEvent attempts to create a Google Calendar event.
-// Returns the event HTML link, or empty string on failure (graceful degradation).
-func (uc *implUseCase) tryCreateCalendarEvent(ctx context.Context, t taskWithDate, memoTask model.Task) string {
+// createOne creates a single task in Memos, schedules its calendar event and embeds it.
+// Only the Memos write is fatal; embedding and calendar failures are recorded in the
+// returned step and retried once the batch is started.
+func (uc *implUseCase) createOne(ctx context.Context, userID string, t taskWithDate) (*sagaStep, error) {
+	step := &sagaStep{
+		input: t,
+		outcome: task.TaskOutcome{
+			Title:    t.Title,
+			Memo:     task.StepFailed,
+			Embed:    task.StepSkipped,
+			Calendar: task.StepSkipped,
+		},
+	}
+
+	// Build markdown content
+	content := buildMarkdownContent(t)
+
+	// Create in Memos
+	memoTask, err := uc.repo.CreateTask(ctx, repository.CreateTaskOptions{
+		Content:    content,
+		Tags:       allTags(t),
+		Visibility: "PRIVATE",
+	})
+	if err != nil {
+		step.outcome.Error = err.Error()
+		return step, fmt.Errorf("failed to create Memos task %q: %w", t.Title, err)
+	}
+	step.memo = memoTask
+	step.outcome.MemoID = memoTask.ID
+	step.outcome.Memo = task.StepOK
+
+	// Create Google Calendar event first: a booked work block adds its Scheduled line to the memo
+	event, memoTask, status := uc.createCalendarEvent(ctx, userID, t, memoTask)
+	step.memo = memoTask
+	step.outcome.Calendar = status
+	if event != nil {
+		step.eventID = event.ID
+		step.calendarLink = event.HtmlLink
+	}
+
+	// Embed task to Qdrant
+	step.outcome.Embed = uc.embedTask(ctx, memoTask)
+
+	uc.l.Infof(ctx, "CreateBulk: created task %q memoID=%s embed=%s calendar=%s",
+		t.Title, memoTask.ID, step.outcome.Embed, step.outcome.Calendar)
+
+	return step, nil
+}
+
+// embedTask indexes a task in Qdrant. Failures are logged and reported, not returned.
+func (uc *implUseCase) embedTask(ctx context.Context, memoTask model.Task) task.StepStatus {
+	if uc.vectorRepo == nil {
+		return task.StepSkipped
+	}
+	if err := uc.vectorRepo.EmbedTask(ctx, memoTask); err != nil {
+		uc.l.Warnf(ctx, "CreateBulk: failed to embed task %s to Qdrant: %v", memoTask.ID, err)
+		return task.StepFailed
+	}
+	return task.StepOK
+}
+
+// createCalendarEvent attempts to create a Google Calendar event for userID's task.
+// Failures are logged and reported as StepFailed (graceful degradation).
+// It also returns the memo as it stands afterwards, which differs once a work block is booked.
+func (uc *implUseCase) createCalendarEvent(ctx context.Context, userID string, t taskWithDate, memoTask model.Task) (*gcalendar.Event, model.Task, task.StepStatus) {
 	if uc.calendar == nil {
-		return ""
+		return nil, memoTask, task.StepSkipped
 	}
 
-	startTime := t.DueDateAbsolute
 	duration := t.EstimatedDurationMinutes
 	if duration <= 0 {
 		duration = 60 // default 1 hour
 	}
+
+	// A task with only a deadline gets a work block in free time before it; a task
+	// with a specific time is an appointment and is booked at that time.
+	if uc.scheduler != nil && isDeadlineOnly(t.DueDateAbsolute) {
+		return uc.bookWorkBlock(ctx, userID, t, memoTask, duration)
+	}
+
+	startTime := t.DueDateAbsolute
 	endTime := startTime.Add(time.Duration(duration) * time.Minute)
 
 	description := t.Description
@@ -100,7 +177,7 @@ func (uc *implUseCase) tryCreateCalendarEvent(ctx context.Context, t taskWithDat
 	}
 
 	event, err := uc.calendar.CreateEvent(ctx, gcalendar.CreateEventRequest{
-		CalendarID:  "primary",
+		CalendarID:  defaultCalendarID,
 		Summary:     t.Title,
 		Description: strings.TrimSpace(description),
 		StartTime:   startTime,
@@ -108,9 +185,51 @@ func (uc *implUseCase) tryCreateCalendarEvent(ctx context.Context, t taskWithDat
 		Timezone:    uc.timezone,
 	})
 	if err != nil {
-		uc.l.Warnf(ctx, "CreateBulk: calendar event creation failed for %q (non-fatal): %v", t.Title, err)
-		return ""
+		uc.l.Warnf(ctx, "CreateBulk: calendar event creation failed for %q: %v", t.Title, err)
+		return nil, memoTask, task.StepFailed
+	}
+
+	return event, memoTask, task.StepOK
+}
+
+// bookWorkBlock asks the scheduler for the best free slot before the task's deadline and
+// returns the memo re-read with the block recorded on it.
+// userID is passed in because background retries carry no request actor.
+func (uc *implUseCase) bookWorkBlock(ctx context.Context, userID string, t taskWithDate, memoTask model.Task, duration int) (*gcalendar.Event, model.Task, task.StepStatus) {
+	out, err := uc.scheduler.Book(ctx, model.Scope{UserID: userID}, schedule.BookInput{
+		TaskID:   memoTask.ID,
+		Duration: time.Duration(duration) * time.Minute,
+	})
+	if errors.Is(err, schedule.ErrNoFreeSlot) || errors.Is(err, schedule.ErrInvalidDuration) {
+		uc.l.Infof(ctx, "CreateBulk: no work block booked for %q: %v", t.Title, err)
+		return nil, memoTask, task.StepSkipped
+	}
+	if err != nil {
+		uc.l.Warnf(ctx, "CreateBulk: scheduling failed for %q: %v", t.Title, err)
+		return nil, memoTask, task.StepFailed
 	}
 
-	return event.HtmlLink
+	event := &gcalendar.Event{ID: out.EventID, HtmlLink: out.EventLink, StartTime: out.Slot.Start, EndTime: out.Slot.End}
+	updated, err := uc.repo.GetTask(ctx, memoTask.ID)
+	if err != nil {
+		// The block is booked; only the embedding misses its Scheduled line until the next edit
+		uc.l.Warnf(ctx, "CreateBulk: failed to re-read task %s after booking: %v", memoTask.ID, err)
+		return event, memoTask, task.StepOK
+	}
+	return event, updated, task.StepOK
+}
+
+// isDeadlineOnly reports whether a due time is the end-of-day default the parser uses
+// when no time of day was given.
+func isDeadlineOnly(due time.Time) bool {
+	return due.Hour() == 23 && due.Minute() == 59
+}
+
+// createdKey derives the per-task idempotency key. The LLM may reorder tasks on a retry,
+// so the key uses the normalized title rather than the position.
+func createdKey(requestKey string, t taskWithDate) string {
+	if requestKey == "" {
+		return ""
+	}
+	return requestKey + "|" + normalizeTitle(t.Title)
 }
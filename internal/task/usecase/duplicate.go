@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/schedule"
+	"autonomous-task-management/internal/task"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// pendingDuplicate is a parsed task held back until the user decides what to do with it.
+type pendingDuplicate struct {
+	userID     string
+	task       taskWithDate
+	existingID string
+}
+
+// duplicateMatch is an existing task similar to a new one.
+type duplicateMatch struct {
+	memoID string
+	score  float64
+}
+
+// findDuplicate looks for an existing task that is semantically similar to t and,
+// unless the text is near-identical, due within duplicateDueWindow of it.
+// Search failures are logged and treated as "no duplicate" so creation is never blocked.
+func (uc *implUseCase) findDuplicate(ctx context.Context, t taskWithDate) (duplicateMatch, bool) {
+	match, found := uc.findDuplicates(ctx, []taskWithDate{t})[0]
+	return match, found
+}
+
+// findDuplicates is findDuplicate for many tasks at once, with one batched similarity lookup.
+// The result maps the index of every task that has a duplicate to its match.
+func (uc *implUseCase) findDuplicates(ctx context.Context, ts []taskWithDate) map[int]duplicateMatch {
+	matches := make(map[int]duplicateMatch)
+	if uc.vectorRepo == nil || len(ts) == 0 {
+		return matches
+	}
+
+	queries := make([]string, len(ts))
+	for i, t := range ts {
+		queries[i] = strings.TrimSpace(t.Title + " " + t.Description)
+	}
+	results, err := uc.vectorRepo.FindSimilarTasks(ctx, queries, duplicateSearchLimit)
+	if err == nil && len(results) != len(ts) {
+		err = fmt.Errorf("got %d result lists for %d queries", len(results), len(ts))
+	}
+	if err != nil {
+		uc.l.Warnf(ctx, "Duplicate check failed for %d task(s) (non-fatal): %v", len(ts), err)
+		return matches
+	}
+
+	for i, t := range ts {
+		for _, r := range results[i] {
+			if r.Score < duplicateScoreThreshold {
+				continue
+			}
+			if r.Score >= duplicateStrongScore || dueDatesClose(t.DueDateAbsolute, r.Payload) {
+				matches[i] = duplicateMatch{memoID: r.MemoID, score: r.Score}
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// dueDatesClose compares the new due date with the one stored in the search payload.
+// A task without a due date on either side is considered close.
+func dueDatesClose(due time.Time, payload map[string]interface{}) bool {
+	content, _ := payload["content"].(string)
+	existing, ok := taskmeta.ExtractDueDate(content)
+	if !ok || due.IsZero() {
+		return true
+	}
+	newDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	diff := newDay.Sub(existing)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= duplicateDueWindow
+}
+
+// holdDuplicate stores t until the user resolves it and returns the candidate shown to them.
+func (uc *implUseCase) holdDuplicate(ctx context.Context, sc model.Scope, t taskWithDate, match duplicateMatch) task.DuplicateCandidate {
+	pendingID := uuid.NewString()[:8]
+	uc.pending.Add(pendingID, pendingDuplicate{userID: sc.UserID, task: t, existingID: match.memoID})
+
+	candidate := task.DuplicateCandidate{
+		PendingID:      pendingID,
+		Title:          t.Title,
+		ExistingMemoID: match.memoID,
+		ExistingTitle:  match.memoID,
+		Score:          match.score,
+	}
+	if existing, err := uc.repo.GetTask(ctx, match.memoID); err == nil {
+		candidate.ExistingMemoURL = existing.MemoURL
+		if title := taskmeta.ExtractTitle(existing.Content); title != "" {
+			candidate.ExistingTitle = title
+		}
+	}
+
+	uc.l.Infof(ctx, "CreateBulk: held %q as possible duplicate of %s (score=%.2f, pending=%s)",
+		t.Title, match.memoID, match.score, pendingID)
+	return candidate
+}
+
+// ResolveDuplicate applies the user's decision to a held-back duplicate.
+func (uc *implUseCase) ResolveDuplicate(ctx context.Context, sc model.Scope, input task.ResolveDuplicateInput) (task.ResolveDuplicateOutput, error) {
+	p, ok := uc.pending.Get(input.PendingID)
+	if !ok || p.userID != sc.UserID {
+		return task.ResolveDuplicateOutput{}, task.ErrDuplicateNotFound
+	}
+
+	uc.l.Infof(ctx, "ResolveDuplicate: user=%s pending=%s action=%s", sc.UserID, input.PendingID, input.Action)
+
+	out := task.ResolveDuplicateOutput{Action: input.Action}
+	switch input.Action {
+	case task.DuplicateActionCreate:
+		step, err := uc.createOne(ctx, sc.UserID, p.task)
+		if err != nil {
+			return task.ResolveDuplicateOutput{}, err
+		}
+		batch := newSagaBatch(sc.UserID)
+		batch.steps = append(batch.steps, step)
+		out.Task = step.created()
+		out.BatchID = uc.startBatch(ctx, batch)
+	case task.DuplicateActionMerge, task.DuplicateActionUpdate:
+		created, err := uc.applyToExisting(ctx, sc, p, input.Action)
+		if err != nil {
+			return task.ResolveDuplicateOutput{}, err
+		}
+		out.Task = created
+	default:
+		return task.ResolveDuplicateOutput{}, task.ErrInvalidDuplicateAction
+	}
+
+	uc.pending.Remove(input.PendingID)
+	return out, nil
+}
+
+// applyToExisting folds the new task into the existing memo.
+// Merge appends the new description and tags; update also overwrites due date, priority and estimate.
+// The write is a ModifyTask, so edits made meanwhile are kept. The task is re-embedded, and when
+// the due date moved its booked work block is booked again before the new deadline.
+func (uc *implUseCase) applyToExisting(ctx context.Context, sc model.Scope, p pendingDuplicate, action task.DuplicateAction) (task.CreatedTask, error) {
+	// Updating the due date and priority is a reschedule of the existing task
+	change := history.ActionMerge
+	if action == task.DuplicateActionUpdate {
+		change = history.ActionReschedule
+	}
+
+	var changed, dueMoved bool
+	updated, err := repository.ModifyTask(history.WithAction(ctx, change), uc.repo, p.existingID, func(current model.Task) (string, error) {
+		content := mergeDuplicate(current.Content, p.task, action)
+		changed = content != current.Content
+		oldDue, hadDue := taskmeta.ExtractDueDate(current.Content)
+		newDue, hasDue := taskmeta.ExtractDueDate(content)
+		dueMoved = hasDue && (!hadDue || !oldDue.Equal(newDue))
+		return content, nil
+	})
+	if err != nil {
+		return task.CreatedTask{}, fmt.Errorf("failed to update existing task: %w", err)
+	}
+
+	if changed {
+		uc.embedTask(ctx, updated)
+	}
+	if dueMoved {
+		uc.rebookBlock(ctx, sc, updated)
+	}
+
+	title := taskmeta.ExtractTitle(updated.Content)
+	if title == "" {
+		title = p.task.Title
+	}
+	return task.CreatedTask{
+		MemoID:  updated.ID,
+		MemoURL: updated.MemoURL,
+		Title:   title,
+	}, nil
+}
+
+// mergeDuplicate returns content with the held-back task t folded in as applyToExisting describes.
+// A zero due date leaves the existing one alone.
+func mergeDuplicate(content string, t taskWithDate, action task.DuplicateAction) string {
+	if action == task.DuplicateActionUpdate {
+		if !t.DueDateAbsolute.IsZero() {
+			content = taskmeta.SetField(content, taskmeta.FieldDue, t.DueDateAbsolute.Format(taskmeta.DateLayout))
+		}
+		if old := taskmeta.Parse(content).Priority; old != "" {
+			// The priority tag appears both in the metadata line and in the tag line
+			content = strings.ReplaceAll(content, priorityTag(old), priorityTag(t.Priority))
+		} else {
+			content = taskmeta.SetField(content, taskmeta.FieldPriority, priorityTag(t.Priority))
+		}
+		if t.EstimatedDurationMinutes > 0 {
+			content = taskmeta.SetField(content, taskmeta.FieldEstimated, fmt.Sprintf("%d min", t.EstimatedDurationMinutes))
+		}
+	}
+
+	if desc := strings.TrimSpace(t.Description); desc != "" && !strings.Contains(content, desc) {
+		content = strings.TrimRight(content, "\n") + "\n\n" + desc
+	}
+
+	existingTags := taskmeta.ExtractTags(content)
+	var newTags []string
+	for _, tag := range allTags(t) {
+		if !taskmeta.HasTag(existingTags, tag) {
+			newTags = append(newTags, tag)
+		}
+	}
+	// Merge keeps the existing priority
+	if action == task.DuplicateActionMerge {
+		newTags = withoutPriorityTags(newTags)
+	}
+	if len(newTags) > 0 {
+		content = strings.TrimRight(content, "\n") + "\n" + strings.Join(newTags, " ")
+	}
+	return content
+}
+
+// rebookBlock moves the work block booked for a task after its due date changed, so the
+// calendar event lands before the new deadline. Tasks without a block have no linked event.
+// Failures are logged; the task itself is already updated.
+func (uc *implUseCase) rebookBlock(ctx context.Context, sc model.Scope, t model.Task) {
+	block, ok := taskmeta.ScheduledBlock(t.Content)
+	if !ok || uc.scheduler == nil {
+		return
+	}
+	out, err := uc.scheduler.Book(ctx, sc, schedule.BookInput{
+		TaskID:   t.ID,
+		Duration: time.Duration(block.Minutes) * time.Minute,
+	})
+	if err != nil {
+		uc.l.Warnf(ctx, "ResolveDuplicate: failed to move the work block of %s: %v", t.ID, err)
+		return
+	}
+	uc.l.Infof(ctx, "ResolveDuplicate: moved the work block of %s to %s", t.ID, out.Slot.Start.Format(time.RFC3339))
+}
+
+func withoutPriorityTags(tags []string) []string {
+	out := tags[:0]
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, taskmeta.TagPrefixPriority) {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
@@ -178,8 +178,10 @@ func buildMarkdownContent(t taskWithDate) string {
 		sb.WriteString("\n\n")
 	}
 
-	// Metadata block
-	sb.WriteString(fmt.Sprintf("- **Due:** %s\n", t.DueDateAbsolute.Format("2006-01-02")))
+	// Metadata block; imported tasks may have no due date
+	if !t.DueDateAbsolute.IsZero() {
+		sb.WriteString(fmt.Sprintf("- **Due:** %s\n", t.DueDateAbsolute.Format("2006-01-02")))
+	}
 	sb.WriteString(fmt.Sprintf("- **Priority:** #priority/%s\n", t.Priority))
 
 	if t.EstimatedDurationMinutes > 0 {
@@ -239,11 +241,8 @@ func (uc *implUseCase) hybridRerank(ctx context.Context, query string, denseResu
 			for _, rr := range rerankResults {
 				if rr.Index < len(candidates) {
 					c := candidates[rr.Index]
-					reranked = append(reranked, repository.SearchResult{
-						MemoID:  c.MemoID,
-						Score:   rr.RelevanceScore,
-						Payload: c.Payload,
-					})
+					c.Score = rr.RelevanceScore
+					reranked = append(reranked, c)
 				}
 			}
 			return reranked
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/taskimport"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// Import creates tasks from an exported file. Items whose title matches an earlier item or an
+// existing task are skipped, as are items semantically close to an existing task. The similarity
+// check runs once for all remaining items. Memos are written one by one and embedded in batches;
+// no calendar events are scheduled for imports.
+func (uc *implUseCase) Import(ctx context.Context, sc model.Scope, input task.ImportInput) (task.ImportOutput, error) {
+	format := taskimport.Format(strings.ToLower(strings.TrimSpace(input.Format)))
+	if format == "" {
+		format = taskimport.Detect(input.Filename, input.Data)
+	}
+
+	loc, err := time.LoadLocation(uc.timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	items, err := taskimport.Parse(format, input.Filename, input.Data, loc)
+	if err != nil {
+		return task.ImportOutput{}, err
+	}
+	if len(items) == 0 {
+		return task.ImportOutput{}, task.ErrNothingToImport
+	}
+
+	uc.l.Infof(ctx, "Import: user=%s format=%s file=%q items=%d", sc.UserID, format, input.Filename, len(items))
+
+	out := task.ImportOutput{Format: string(format), Total: len(items)}
+
+	var existing map[string]string
+	if !input.SkipDuplicateCheck {
+		if existing, err = uc.existingTitles(ctx); err != nil {
+			return task.ImportOutput{}, err
+		}
+	}
+
+	candidates := make([]taskWithDate, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		t := uc.importedTask(item, loc)
+		key := normalizeTitle(t.Title)
+
+		if seen[key] {
+			out.Skipped = append(out.Skipped, task.ImportSkip{Title: t.Title, Reason: task.ImportSkipDuplicateInFile})
+			continue
+		}
+		seen[key] = true
+
+		if id, ok := existing[key]; ok {
+			out.Skipped = append(out.Skipped, task.ImportSkip{Title: t.Title, Reason: task.ImportSkipExisting, ExistingMemoID: id})
+			continue
+		}
+		candidates = append(candidates, t)
+	}
+
+	var similar map[int]duplicateMatch
+	if !input.SkipDuplicateCheck {
+		similar = uc.findDuplicates(ctx, candidates)
+	}
+
+	batch := newSagaBatch(sc.UserID)
+	var attempted []*sagaStep
+	for i, t := range candidates {
+		if match, found := similar[i]; found {
+			out.Skipped = append(out.Skipped, task.ImportSkip{Title: t.Title, Reason: task.ImportSkipSimilar, ExistingMemoID: match.memoID})
+			continue
+		}
+
+		step, err := uc.createImported(ctx, t)
+		attempted = append(attempted, step)
+		if err != nil {
+			uc.l.Errorf(ctx, "Import: %v", err)
+			continue
+		}
+		batch.steps = append(batch.steps, step)
+		out.Tasks = append(out.Tasks, step.created())
+	}
+
+	out.Embedded = uc.embedImported(ctx, batch.steps)
+	for _, s := range attempted {
+		out.Outcomes = append(out.Outcomes, s.outcome)
+	}
+	out.BatchID = uc.startBatch(ctx, batch)
+
+	uc.l.Infof(ctx, "Import: user=%s batch=%s created=%d skipped=%d embedded=%d",
+		sc.UserID, out.BatchID, len(out.Tasks), len(out.Skipped), out.Embedded)
+	return out, nil
+}
+
+// importedTask maps an import item onto the task shape used by CreateBulk. Date-only due dates
+// are placed at the end of the day in loc, the configured timezone.
+func (uc *implUseCase) importedTask(item taskimport.Item, loc *time.Location) taskWithDate {
+	t := taskWithDate{
+		Title:       item.Title,
+		Description: item.Description,
+		Priority:    item.Priority,
+		Tags:        item.Tags,
+	}
+	if t.Priority == "" {
+		t.Priority = importDefaultPriority
+	}
+	if !item.Due.IsZero() {
+		if item.DueHasTime {
+			t.DueDateAbsolute = item.Due.In(loc)
+		} else {
+			t.DueDateAbsolute = time.Date(item.Due.Year(), item.Due.Month(), item.Due.Day(), 23, 59, 59, 0, loc)
+		}
+	}
+	if item.Done {
+		t.Tags = append(t.Tags, taskmeta.TagPrefixStatus+taskmeta.StatusDone)
+	}
+
+	// Sub-items are kept as a checklist the checklist domain can tick off
+	if len(item.Checklist) > 0 {
+		lines := make([]string, 0, len(item.Checklist))
+		for _, c := range item.Checklist {
+			box := "[ ]"
+			if c.Done {
+				box = "[x]"
+			}
+			lines = append(lines, fmt.Sprintf("- %s %s", box, c.Text))
+		}
+		t.Description = strings.TrimSpace(t.Description + "\n\n" + strings.Join(lines, "\n"))
+	}
+	return t
+}
+
+// createImported writes one imported task to Memos. Embedding happens afterwards in batches.
+func (uc *implUseCase) createImported(ctx context.Context, t taskWithDate) (*sagaStep, error) {
+	step := &sagaStep{
+		input: t,
+		outcome: task.TaskOutcome{
+			Title:    t.Title,
+			Memo:     task.StepFailed,
+			Embed:    task.StepSkipped,
+			Calendar: task.StepSkipped,
+		},
+	}
+
+	memoTask, err := uc.repo.CreateTask(ctx, repository.CreateTaskOptions{
+		Content:    buildMarkdownContent(t),
+		Tags:       allTags(t),
+		Visibility: "PRIVATE",
+	})
+	if err != nil {
+		step.outcome.Error = err.Error()
+		return step, fmt.Errorf("failed to create Memos task %q: %w", t.Title, err)
+	}
+	step.memo = memoTask
+	step.outcome.MemoID = memoTask.ID
+	step.outcome.Memo = task.StepOK
+	return step, nil
+}
+
+// embedImported indexes the created tasks with the bulk pipeline and returns how many were
+// embedded. Tasks of a failed batch are marked failed so startBatch retries them one by one.
+func (uc *implUseCase) embedImported(ctx context.Context, steps []*sagaStep) int {
+	if uc.vectorRepo == nil || len(steps) == 0 {
+		return 0
+	}
+
+	tasks := make([]model.Task, 0, len(steps))
+	for _, s := range steps {
+		tasks = append(tasks, s.memo)
+	}
+	result, err := uc.vectorRepo.IndexTasks(ctx, tasks, repository.IndexOptions{
+		BatchSize:   importEmbedBatchSize,
+		Concurrency: importEmbedConcurrency,
+	})
+	if err != nil {
+		uc.l.Warnf(ctx, "Import: indexing stopped early: %v", err)
+	}
+	if len(result.Failed) > 0 {
+		uc.l.Warnf(ctx, "Import: failed to embed %d task(s) to Qdrant", len(result.Failed))
+	}
+
+	failed := make(map[string]bool, len(result.Failed))
+	for _, id := range result.Failed {
+		failed[id] = true
+	}
+	for _, s := range steps {
+		// A stopped run does not say which batches finished; upserts are idempotent, so retry all
+		if err != nil || failed[s.memo.ID] {
+			s.outcome.Embed = task.StepFailed
+			continue
+		}
+		s.outcome.Embed = task.StepOK
+	}
+	return result.Indexed
+}
+
+// existingTitles maps the normalized title of every task to its ID.
+func (uc *implUseCase) existingTitles(ctx context.Context) (map[string]string, error) {
+	titles := make(map[string]string)
+	for t, err := range uc.repo.IterateTasks(ctx, repository.ListTasksOptions{Limit: importListPageSize}) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list existing tasks: %w", err)
+		}
+		if title := taskmeta.ExtractTitle(t.Content); title != "" {
+			titles[normalizeTitle(title)] = t.ID
+		}
+	}
+	return titles, nil
+}
+
+// normalizeTitle compares titles case- and whitespace-insensitively.
+func normalizeTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
@@ -1,9 +1,14 @@
 package usecase
 
 import (
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+
 	"autonomous-task-management/internal/task"
 	"autonomous-task-management/internal/task/repository"
 	"autonomous-task-management/pkg/datemath"
+	"autonomous-task-management/pkg/idempotency"
 	"autonomous-task-management/pkg/llmprovider"
 	pkgLog "autonomous-task-management/pkg/log"
 	"autonomous-task-management/pkg/voyage"
@@ -13,16 +18,25 @@ type implUseCase struct {
 	l          pkgLog.Logger
 	llm        llmprovider.IManager
 	calendar   task.CalendarClient
+	scheduler  task.Scheduler // optional; nil = events are booked at the due time
 	repo       repository.MemosRepository
 	vectorRepo repository.VectorRepository
 	dateMath   datemath.IParser
 	reranker   *voyage.Reranker // optional; nil = skip reranking
 	timezone   string
 	memosURL   string
+	pending    *expirable.LRU[string, pendingDuplicate] // Duplicates awaiting the user's decision
+	created    idempotency.IStore[task.CreatedTask]     // Tasks created per idempotency key, for retried requests
+	batches    *expirable.LRU[string, *sagaBatch]       // Side effects per CreateBulk batch, for retries and rollback
+	retryDelay time.Duration                            // Initial backoff before retrying a failed side effect
+
+	expandQueries bool // Ask the LLM for synonyms of search queries
 }
 
 // New creates a new task UseCase instance.
-// reranker is optional — pass nil to disable cross-encoder reranking.
+// reranker and scheduler are optional — pass nil to disable cross-encoder reranking
+// or to book calendar events at the due time instead of in free slots. expandQueries adds
+// an LLM call to every search to widen the query with synonyms.
 func New(
 	l pkgLog.Logger,
 	llm llmprovider.IManager,
@@ -33,16 +47,25 @@ func New(
 	reranker *voyage.Reranker,
 	timezone string,
 	memosURL string,
+	scheduler task.Scheduler,
+	expandQueries bool,
 ) task.UseCase {
 	return &implUseCase{
 		l:          l,
 		llm:        llm,
 		calendar:   calendar,
+		scheduler:  scheduler,
 		repo:       repo,
 		vectorRepo: vectorRepo,
 		dateMath:   dateMath,
 		reranker:   reranker,
 		timezone:   timezone,
 		memosURL:   memosURL,
+		pending:    expirable.NewLRU[string, pendingDuplicate](pendingDuplicateCacheSize, nil, pendingDuplicateTTL),
+		created:    idempotency.New[task.CreatedTask](createdCacheSize, createdCacheTTL),
+		batches:    expirable.NewLRU[string, *sagaBatch](batchCacheSize, nil, batchCacheTTL),
+		retryDelay: sideEffectRetryDelay,
+
+		expandQueries: expandQueries,
 	}
 }
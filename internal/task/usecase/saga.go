@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task"
+	"autonomous-task-management/pkg/gcalendar"
+)
+
+// sagaBatch records every side effect written by one CreateBulk call, so failed steps
+// can be retried in the background and the whole batch can be undone.
+type sagaBatch struct {
+	mu         sync.Mutex
+	id         string
+	userID     string
+	steps      []*sagaStep
+	rolledBack bool
+	cancel     context.CancelFunc // Stops the background retries; set by startBatch
+}
+
+// sagaStep holds the side effects of creating one task. Fields are guarded by the batch mutex
+// once the batch has been started.
+type sagaStep struct {
+	input        taskWithDate
+	memo         model.Task
+	eventID      string
+	calendarLink string
+	createdKey   string // Idempotency key the task was stored under, released on rollback
+	outcome      task.TaskOutcome
+}
+
+func newSagaBatch(userID string) *sagaBatch {
+	return &sagaBatch{id: uuid.NewString()[:8], userID: userID}
+}
+
+// created converts the step into the CreatedTask reported to the caller.
+func (s *sagaStep) created() task.CreatedTask {
+	return task.CreatedTask{
+		MemoID:       s.memo.ID,
+		MemoURL:      s.memo.MemoURL,
+		CalendarLink: s.calendarLink,
+		Title:        s.input.Title,
+	}
+}
+
+// startBatch stores a batch that wrote at least one memo and schedules retries for its failed steps.
+// It returns the batch ID, or "" when there is nothing to track.
+func (uc *implUseCase) startBatch(ctx context.Context, b *sagaBatch) string {
+	if len(b.steps) == 0 {
+		return ""
+	}
+
+	// Retries outlive the request; the batch context only ends on rollback
+	retryCtx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+
+	uc.batches.Add(b.id, b)
+	for _, s := range b.steps {
+		if s.outcome.Embed == task.StepFailed || s.outcome.Calendar == task.StepFailed {
+			uc.l.Infof(ctx, "CreateBulk: scheduling retry for task %s in batch %s", s.memo.ID, b.id)
+			go uc.retryStep(retryCtx, b, s)
+		}
+	}
+	return b.id
+}
+
+// retryStep re-runs the failed embedding and calendar steps of s with exponential backoff.
+// It stops once both succeed, the retries are exhausted, or ctx is cancelled by a rollback.
+func (uc *implUseCase) retryStep(ctx context.Context, b *sagaBatch, s *sagaStep) {
+	delay := uc.retryDelay
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for attempt := 1; attempt <= sideEffectMaxRetries; attempt++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		b.mu.Lock()
+		if b.rolledBack {
+			b.mu.Unlock()
+			return
+		}
+		needEmbed := s.outcome.Embed == task.StepFailed
+		needCalendar := s.outcome.Calendar == task.StepFailed
+		memoTask, input := s.memo, s.input
+		b.mu.Unlock()
+
+		if !needEmbed && !needCalendar {
+			return
+		}
+
+		var (
+			event     *gcalendar.Event
+			calStatus = task.StepFailed
+		)
+		if needCalendar {
+			var updated model.Task
+			event, updated, calStatus = uc.createCalendarEvent(ctx, b.userID, input, memoTask)
+			// A booked work block rewrote the memo, so its vector is rebuilt from the new content
+			if updated.Content != memoTask.Content {
+				memoTask, needEmbed = updated, true
+			}
+		}
+		embedStatus := task.StepFailed
+		if needEmbed {
+			embedStatus = uc.embedTask(ctx, memoTask)
+		}
+
+		b.mu.Lock()
+		rolledBack := b.rolledBack
+		if !rolledBack {
+			s.memo = memoTask
+			if needEmbed {
+				s.outcome.Embed = embedStatus
+			}
+			// A skipped booking (e.g. no free slot) is final too; only a failure is retried
+			if needCalendar && calStatus != task.StepFailed {
+				s.outcome.Calendar = calStatus
+				if event != nil {
+					s.eventID = event.ID
+					s.calendarLink = event.HtmlLink
+				}
+			}
+		}
+		b.mu.Unlock()
+
+		// The batch was rolled back while this attempt was in flight: undo what it just wrote.
+		// ctx is cancelled by then, so the undo runs without it.
+		if rolledBack {
+			undoCtx := context.WithoutCancel(ctx)
+			if needEmbed && embedStatus == task.StepOK {
+				_ = uc.vectorRepo.DeleteTask(undoCtx, memoTask.ID)
+			}
+			if event != nil {
+				_ = uc.calendar.DeleteEvent(undoCtx, defaultCalendarID, event.ID)
+			}
+			return
+		}
+
+		uc.l.Infof(ctx, "CreateBulk: retry %d for task %s in batch %s: embed=%s calendar=%s",
+			attempt, memoTask.ID, b.id, s.outcome.Embed, s.outcome.Calendar)
+		delay *= 2
+		timer.Reset(delay)
+	}
+
+	uc.l.Warnf(ctx, "CreateBulk: giving up on side effects of task %s in batch %s after %d retries",
+		s.memo.ID, b.id, sideEffectMaxRetries)
+}
+
+// RollbackBatch deletes the calendar events, vectors and memos written by a CreateBulk batch.
+// Undo failures are reported in the output rather than aborting the rollback.
+func (uc *implUseCase) RollbackBatch(ctx context.Context, sc model.Scope, batchID string) (task.RollbackOutput, error) {
+	b, ok := uc.batches.Get(batchID)
+	if !ok || b.userID != sc.UserID {
+		return task.RollbackOutput{}, task.ErrBatchNotFound
+	}
+
+	b.mu.Lock()
+	if b.rolledBack {
+		b.mu.Unlock()
+		return task.RollbackOutput{}, task.ErrBatchNotFound
+	}
+	b.rolledBack = true
+	if b.cancel != nil {
+		b.cancel()
+	}
+	steps := make([]sagaStep, 0, len(b.steps))
+	for _, s := range b.steps {
+		steps = append(steps, *s)
+	}
+	b.mu.Unlock()
+	uc.batches.Remove(batchID)
+
+	uc.l.Infof(ctx, "RollbackBatch: user=%s batch=%s tasks=%d", sc.UserID, batchID, len(steps))
+
+	out := task.RollbackOutput{BatchID: batchID}
+	for _, s := range steps {
+		outcome := s.outcome
+
+		if s.eventID != "" {
+			if err := uc.calendar.DeleteEvent(ctx, defaultCalendarID, s.eventID); err != nil {
+				out.Errors = append(out.Errors, fmt.Sprintf("%s: calendar: %v", s.input.Title, err))
+			} else {
+				outcome.Calendar = task.StepRolledBack
+			}
+		}
+
+		if s.outcome.Embed == task.StepOK {
+			if err := uc.vectorRepo.DeleteTask(ctx, s.memo.ID); err != nil {
+				out.Errors = append(out.Errors, fmt.Sprintf("%s: qdrant: %v", s.input.Title, err))
+			} else {
+				outcome.Embed = task.StepRolledBack
+			}
+		}
+
+		if err := uc.repo.DeleteTask(ctx, s.memo.ID); err != nil {
+			out.Errors = append(out.Errors, fmt.Sprintf("%s: memos: %v", s.input.Title, err))
+		} else {
+			outcome.Memo = task.StepRolledBack
+		}
+
+		// Let a retried request create the task again
+		if s.createdKey != "" {
+			uc.created.Release(s.createdKey)
+		}
+
+		out.Tasks = append(out.Tasks, outcome)
+	}
+
+	if len(out.Errors) > 0 {
+		uc.l.Warnf(ctx, "RollbackBatch: batch=%s finished with %d error(s)", batchID, len(out.Errors))
+	}
+
+	return out, nil
+}
@@ -2,13 +2,19 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"autonomous-task-management/internal/model"
 	"autonomous-task-management/internal/task"
 	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/llmprovider"
+	"autonomous-task-management/pkg/searchquery"
+	"autonomous-task-management/pkg/taskmeta"
 )
 
 // Search performs semantic search on tasks.
@@ -17,7 +23,10 @@ func (uc *implUseCase) Search(ctx context.Context, sc model.Scope, input task.Se
 		return task.SearchOutput{}, task.ErrEmptyQuery
 	}
 
-	uc.l.Infof(ctx, "Search: user=%s query=%q", sc.UserID, input.Query)
+	input, interpretation := uc.interpretQuery(ctx, input)
+	uc.l.Infof(ctx, "Search: user=%s query=%q text=%q expansions=%v tags=%v priorities=%v projects=%v statuses=%v/not %v due=[%s, %s)",
+		sc.UserID, input.Query, interpretation.Text, interpretation.Expansions, input.Tags, input.Priorities, input.Projects,
+		input.Statuses, input.ExcludeStatuses, formatBound(input.DueFrom), formatBound(input.DueTo))
 
 	// Default limit
 	limit := input.Limit
@@ -31,10 +40,13 @@ func (uc *implUseCase) Search(ctx context.Context, sc model.Scope, input task.Se
 		return task.SearchOutput{}, fmt.Errorf("semantic search is currently unavailable")
 	}
 
+	// Synonyms only widen the dense track: every full-text hit must contain all the words
 	searchResults, err := uc.vectorRepo.SearchTasks(ctx, repository.SearchTasksOptions{
-		Query: input.Query,
-		Limit: limit,
-		Tags:  input.Tags,
+		Query:     strings.Join(append([]string{interpretation.Text}, interpretation.Expansions...), " "),
+		TextQuery: interpretation.Text,
+		Limit:     limit,
+		Tags:      input.Tags,
+		Filter:    searchFilter(input),
 	})
 	if err != nil {
 		uc.l.Errorf(ctx, "Search: failed to search in Qdrant: %v", err)
@@ -44,8 +56,9 @@ func (uc *implUseCase) Search(ctx context.Context, sc model.Scope, input task.Se
 	if len(searchResults) == 0 {
 		uc.l.Infof(ctx, "Search: no results found for query %q", input.Query)
 		return task.SearchOutput{
-			Results: []task.SearchResultItem{},
-			Count:   0,
+			Results:        []task.SearchResultItem{},
+			Count:          0,
+			Interpretation: interpretation,
 		}, nil
 	}
 
@@ -96,10 +109,12 @@ func (uc *implUseCase) Search(ctx context.Context, sc model.Scope, input task.Se
 
 		memoTask := fr.task
 		results = append(results, task.SearchResultItem{
-			MemoID:  memoTask.ID,
-			MemoURL: memoTask.MemoURL,
-			Content: memoTask.Content,
-			Score:   sr.Score,
+			MemoID:         memoTask.ID,
+			MemoURL:        memoTask.MemoURL,
+			Content:        memoTask.Content,
+			Score:          sr.Score,
+			MatchedSection: sr.Section,
+			Snippet:        sr.Snippet,
 		})
 	}
 
@@ -111,7 +126,141 @@ func (uc *implUseCase) Search(ctx context.Context, sc model.Scope, input task.Se
 	uc.l.Infof(ctx, "Search: found %d results (filtered from %d raw results)", len(results), len(searchResults))
 
 	return task.SearchOutput{
-		Results: results,
-		Count:   len(results),
+		Results:        results,
+		Count:          len(results),
+		Interpretation: interpretation,
 	}, nil
 }
+
+// interpretQuery reads filters out of the query text with searchquery.Parse. A filter given in
+// input wins over the same filter read from the text. If only filters were typed, the whole
+// query is still searched for: its words ("deadline tuần này") match the enriched task text.
+func (uc *implUseCase) interpretQuery(ctx context.Context, input task.SearchInput) (task.SearchInput, task.SearchInterpretation) {
+	loc, err := time.LoadLocation(uc.timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	q := searchquery.Parse(input.Query, time.Now().In(loc))
+
+	fill := func(field *[]string, parsed []string) {
+		if len(*field) == 0 {
+			*field = parsed
+		}
+	}
+	fill(&input.Priorities, q.Priorities)
+	fill(&input.Projects, q.Projects)
+	fill(&input.Tags, q.Tags)
+	fill(&input.Statuses, q.Statuses)
+	fill(&input.ExcludeStatuses, q.ExcludeStatuses)
+	duePhrase := ""
+	if input.DueFrom.IsZero() && input.DueTo.IsZero() {
+		input.DueFrom, input.DueTo = q.DueFrom, q.DueTo
+		duePhrase = q.DuePhrase
+	}
+
+	text := q.Text
+	if text == "" {
+		text = input.Query
+	}
+	expansions := q.Expansions
+	if uc.expandQueries {
+		for _, e := range uc.expandQueryWithLLM(ctx, text) {
+			if !slices.ContainsFunc(expansions, func(x string) bool { return strings.EqualFold(x, e) }) {
+				expansions = append(expansions, e)
+			}
+		}
+	}
+
+	return input, task.SearchInterpretation{
+		Text:            text,
+		Expansions:      expansions,
+		Priorities:      input.Priorities,
+		Projects:        input.Projects,
+		Tags:            input.Tags,
+		Statuses:        input.Statuses,
+		ExcludeStatuses: input.ExcludeStatuses,
+		DueFrom:         input.DueFrom,
+		DueTo:           input.DueTo,
+		DuePhrase:       duePhrase,
+	}
+}
+
+// expandQueryWithLLM asks the LLM for other ways to say text, in Vietnamese and English.
+// Expansion is best effort: on any failure the search goes on without it.
+func (uc *implUseCase) expandQueryWithLLM(ctx context.Context, text string) []string {
+	if uc.llm == nil {
+		return nil
+	}
+	prompt := fmt.Sprintf(`Bạn mở rộng truy vấn tìm kiếm task cá nhân (tiếng Việt và tiếng Anh).
+Trả về tối đa %d từ khóa hoặc cụm từ ngắn cùng nghĩa với truy vấn, ưu tiên bản dịch Việt/Anh và thuật ngữ liên quan.
+Không lặp lại truy vấn, không thêm ngày tháng hay độ ưu tiên. Chỉ trả về JSON array of strings.
+
+Truy vấn: %q`, queryExpansionMax, text)
+
+	resp, err := uc.llm.GenerateContent(ctx, &llmprovider.Request{
+		Messages:    []llmprovider.Message{{Role: "user", Parts: []llmprovider.Part{{Text: prompt}}}},
+		Temperature: 0.2,
+		MaxTokens:   256,
+	})
+	if err != nil || len(resp.Content.Parts) == 0 {
+		uc.l.Warnf(ctx, "Search: query expansion failed, searching without it: %v", err)
+		return nil
+	}
+
+	var expansions []string
+	if err := json.Unmarshal([]byte(sanitizeJSONResponse(resp.Content.Parts[0].Text)), &expansions); err != nil {
+		uc.l.Warnf(ctx, "Search: failed to parse query expansion %q: %v", resp.Content.Parts[0].Text, err)
+		return nil
+	}
+	out := make([]string, 0, queryExpansionMax)
+	for _, e := range expansions {
+		if e = strings.TrimSpace(e); e != "" && !strings.EqualFold(e, text) && len(out) < queryExpansionMax {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// searchFilter turns the structured filters of a search into a payload filter. Values are
+// normalized the way they are stored: lower case, without the tag prefix.
+func searchFilter(input task.SearchInput) repository.PayloadFilter {
+	var f repository.PayloadFilter
+	match := func(key, prefix string, values []string) (repository.Condition, bool) {
+		var normalized []string
+		for _, v := range values {
+			v = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(v), prefix))
+			if v != "" {
+				normalized = append(normalized, v)
+			}
+		}
+		return repository.Condition{Key: key, Match: repository.MatchAny{Values: normalized}}, len(normalized) > 0
+	}
+
+	if c, ok := match(repository.PayloadPriority, taskmeta.TagPrefixPriority, input.Priorities); ok {
+		f.Must = append(f.Must, c)
+	}
+	if c, ok := match(repository.PayloadProject, taskmeta.TagPrefixProject, input.Projects); ok {
+		f.Must = append(f.Must, c)
+	}
+	if c, ok := match(repository.PayloadStatus, taskmeta.TagPrefixStatus, input.Statuses); ok {
+		f.Must = append(f.Must, c)
+	}
+	if c, ok := match(repository.PayloadStatus, taskmeta.TagPrefixStatus, input.ExcludeStatuses); ok {
+		f.MustNot = append(f.MustNot, c)
+	}
+	if !input.DueFrom.IsZero() || !input.DueTo.IsZero() {
+		f.Must = append(f.Must, repository.Condition{
+			Key:   repository.PayloadDue,
+			Range: &repository.DateRange{From: input.DueFrom, To: input.DueTo},
+		})
+	}
+	return f
+}
+
+// formatBound renders a range bound for logs; a zero bound is open.
+func formatBound(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(taskmeta.DateLayout)
+}
@@ -3,15 +3,21 @@ package usecase
 import (
 	"context"
 	"errors"
+	"iter"
+	"strings"
 	"testing"
 	"time"
 
 	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/schedule"
 	"autonomous-task-management/internal/task"
 	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/pkg/gcalendar"
 	"autonomous-task-management/pkg/gemini"
+	"autonomous-task-management/pkg/idempotency"
 	"autonomous-task-management/pkg/llmprovider"
 
+	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -47,6 +53,56 @@ func (m *mockMemosRepo) UpdateTask(ctx context.Context, id string, content strin
 	return args.Error(0)
 }
 
+// UpdateTaskIfUnchanged returns the stubbed task, carrying the written content if the stub has none.
+func (m *mockMemosRepo) UpdateTaskIfUnchanged(ctx context.Context, id, content, expectedUpdateTime string) (model.Task, error) {
+	args := m.Called(ctx, id, content, expectedUpdateTime)
+	updated := args.Get(0).(model.Task)
+	if updated.Content == "" {
+		updated.Content = content
+	}
+	return updated, args.Error(1)
+}
+
+func (m *mockMemosRepo) DeleteTask(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockMemosRepo) ListTaskPage(ctx context.Context, opt repository.ListTasksOptions) (repository.TaskPage, error) {
+	args := m.Called(ctx, opt)
+	return args.Get(0).(repository.TaskPage), args.Error(1)
+}
+
+func (m *mockMemosRepo) IterateTasks(ctx context.Context, opt repository.ListTasksOptions) iter.Seq2[model.Task, error] {
+	args := m.Called(ctx, opt)
+	return args.Get(0).(iter.Seq2[model.Task, error])
+}
+
+func (m *mockMemosRepo) ArchiveTask(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockMemosRepo) RestoreTask(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *mockMemosRepo) PinTask(ctx context.Context, id string, pinned bool) error {
+	args := m.Called(ctx, id, pinned)
+	return args.Error(0)
+}
+
+func (m *mockMemosRepo) ListRelations(ctx context.Context, id string) ([]repository.TaskRelation, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).([]repository.TaskRelation), args.Error(1)
+}
+
+func (m *mockMemosRepo) SetRelations(ctx context.Context, id string, relations []repository.TaskRelation) error {
+	args := m.Called(ctx, id, relations)
+	return args.Error(0)
+}
+
 type mockVectorRepo struct {
 	mock.Mock
 }
@@ -56,14 +112,34 @@ func (m *mockVectorRepo) EmbedTask(ctx context.Context, t model.Task) error {
 	return args.Error(0)
 }
 
+func (m *mockVectorRepo) IndexTasks(ctx context.Context, tasks []model.Task, opt repository.IndexOptions) (repository.IndexResult, error) {
+	args := m.Called(ctx, tasks, opt)
+	return args.Get(0).(repository.IndexResult), args.Error(1)
+}
+
 func (m *mockVectorRepo) SearchTasks(ctx context.Context, opt repository.SearchTasksOptions) ([]repository.SearchResult, error) {
 	args := m.Called(ctx, opt)
 	return args.Get(0).([]repository.SearchResult), args.Error(1)
 }
 
-func (m *mockVectorRepo) SearchTasksWithFilter(ctx context.Context, opt repository.SearchTasksOptions) ([]repository.SearchResult, error) {
+func (m *mockVectorRepo) FindSimilarTasks(ctx context.Context, queries []string, limit int) ([][]repository.SearchResult, error) {
+	args := m.Called(ctx, queries, limit)
+	return args.Get(0).([][]repository.SearchResult), args.Error(1)
+}
+
+func (m *mockVectorRepo) FilterTaskPage(ctx context.Context, opt repository.FilterTasksOptions) (repository.SearchPage, error) {
 	args := m.Called(ctx, opt)
-	return args.Get(0).([]repository.SearchResult), args.Error(1)
+	return args.Get(0).(repository.SearchPage), args.Error(1)
+}
+
+func (m *mockVectorRepo) IterateFilteredTasks(ctx context.Context, opt repository.FilterTasksOptions) iter.Seq2[repository.SearchResult, error] {
+	args := m.Called(ctx, opt)
+	return args.Get(0).(iter.Seq2[repository.SearchResult, error])
+}
+
+func (m *mockVectorRepo) CountTasks(ctx context.Context, filter repository.PayloadFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
 }
 
 func (m *mockVectorRepo) DeleteTask(ctx context.Context, taskID string) error {
@@ -94,6 +170,10 @@ func newTestTaskUC(llmManager llmprovider.IManager, repo *mockMemosRepo, vectorR
 		reranker:   nil,
 		timezone:   "Asia/Ho_Chi_Minh",
 		memosURL:   "http://localhost:5230",
+		pending:    expirable.NewLRU[string, pendingDuplicate](pendingDuplicateCacheSize, nil, pendingDuplicateTTL),
+		created:    idempotency.New[task.CreatedTask](createdCacheSize, createdCacheTTL),
+		batches:    expirable.NewLRU[string, *sagaBatch](batchCacheSize, nil, batchCacheTTL),
+		retryDelay: time.Millisecond,
 	}
 }
 
@@ -285,6 +365,7 @@ func TestCreateBulk_Success(t *testing.T) {
 	}, nil)
 
 	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("FindSimilarTasks", mock.Anything, mock.Anything, mock.Anything).Return([][]repository.SearchResult{{}}, nil)
 	vectorRepo.On("EmbedTask", mock.Anything, mock.Anything).Return(nil)
 
 	uc := newTestTaskUC(mgr, repo, vectorRepo)
@@ -308,6 +389,7 @@ func TestCreateBulk_EmbedFails_StillSucceeds(t *testing.T) {
 	}, nil)
 
 	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("FindSimilarTasks", mock.Anything, mock.Anything, mock.Anything).Return([][]repository.SearchResult{{}}, nil)
 	vectorRepo.On("EmbedTask", mock.Anything, mock.Anything).Return(errors.New("qdrant down"))
 
 	uc := newTestTaskUC(mgr, repo, vectorRepo)
@@ -317,6 +399,474 @@ func TestCreateBulk_EmbedFails_StillSucceeds(t *testing.T) {
 	assert.Equal(t, 1, output.TaskCount)
 }
 
+// Tests: duplicate detection
+
+const duplicateLLMResp = `[{"title":"Buy milk","description":"2 liters","due_date_absolute":"2025-06-15T23:59:59+07:00","priority":"p1","tags":["#type/shopping"],"estimated_duration_minutes":30}]`
+
+func TestCreateBulk_HoldsDuplicate(t *testing.T) {
+	repo := new(mockMemosRepo)
+	repo.On("GetTask", mock.Anything, "memo-old").Return(model.Task{
+		ID: "memo-old", MemoURL: "http://localhost:5230/m/memo-old", Content: "## Buy milk\n\n- **Due:** 2025-06-14",
+	}, nil)
+
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("FindSimilarTasks", mock.Anything, mock.Anything, mock.Anything).Return([][]repository.SearchResult{{
+		{MemoID: "memo-old", Score: 0.9, Payload: map[string]interface{}{"content": "## Buy milk\n\n- **Due:** 2025-06-14"}},
+	}}, nil)
+
+	uc := newTestTaskUC(makeLLMManager(duplicateLLMResp), repo, vectorRepo)
+	output, err := uc.CreateBulk(context.Background(), model.Scope{UserID: "u1"}, task.CreateBulkInput{RawText: "Buy milk"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, output.TaskCount)
+	assert.Len(t, output.Duplicates, 1)
+	assert.Equal(t, "memo-old", output.Duplicates[0].ExistingMemoID)
+	assert.Equal(t, "Buy milk", output.Duplicates[0].ExistingTitle)
+	repo.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
+
+func TestCreateBulk_SimilarButFarApartDueDates_NotDuplicate(t *testing.T) {
+	repo := new(mockMemosRepo)
+	repo.On("CreateTask", mock.Anything, mock.Anything).Return(model.Task{ID: "memo-new"}, nil)
+
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("FindSimilarTasks", mock.Anything, mock.Anything, mock.Anything).Return([][]repository.SearchResult{{
+		{MemoID: "memo-old", Score: 0.9, Payload: map[string]interface{}{"content": "## Buy milk\n\n- **Due:** 2025-05-01"}},
+	}}, nil)
+	vectorRepo.On("EmbedTask", mock.Anything, mock.Anything).Return(nil)
+
+	uc := newTestTaskUC(makeLLMManager(duplicateLLMResp), repo, vectorRepo)
+	output, err := uc.CreateBulk(context.Background(), model.Scope{UserID: "u1"}, task.CreateBulkInput{RawText: "Buy milk"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, output.TaskCount)
+	assert.Empty(t, output.Duplicates)
+}
+
+func TestResolveDuplicate_Update(t *testing.T) {
+	existing := "## Buy milk\n\n- **Due:** 2025-06-14\n- **Priority:** #priority/p2\n- **Scheduled:** 2025-06-14 09:00 +07:00 · 45 min · event ev-old\n\n#priority/p2"
+	repo := new(mockMemosRepo)
+	repo.On("GetTask", mock.Anything, "memo-old").Return(model.Task{ID: "memo-old", Content: existing, UpdateTime: "t1"}, nil)
+	repo.On("UpdateTaskIfUnchanged", mock.Anything, "memo-old", mock.MatchedBy(func(content string) bool {
+		return strings.Contains(content, "- **Due:** 2025-06-15") &&
+			!strings.Contains(content, "#priority/p2") &&
+			strings.Contains(content, "2 liters") &&
+			strings.Contains(content, "#type/shopping")
+	}), "t1").Return(model.Task{ID: "memo-old", UpdateTime: "t2"}, nil).Once()
+
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("FindSimilarTasks", mock.Anything, mock.Anything, mock.Anything).Return([][]repository.SearchResult{{
+		{MemoID: "memo-old", Score: 0.97},
+	}}, nil)
+	vectorRepo.On("EmbedTask", mock.Anything, mock.MatchedBy(func(t model.Task) bool { return t.ID == "memo-old" })).Return(nil).Once()
+
+	sched := &fakeScheduler{}
+	uc := newTestTaskUC(makeLLMManager(duplicateLLMResp), repo, vectorRepo)
+	uc.scheduler = sched
+	output, err := uc.CreateBulk(context.Background(), model.Scope{UserID: "u1"}, task.CreateBulkInput{RawText: "Buy milk"})
+	assert.NoError(t, err)
+	assert.Len(t, output.Duplicates, 1)
+
+	pendingID := output.Duplicates[0].PendingID
+	_, err = uc.ResolveDuplicate(context.Background(), model.Scope{UserID: "someone-else"}, task.ResolveDuplicateInput{
+		PendingID: pendingID, Action: task.DuplicateActionUpdate,
+	})
+	assert.ErrorIs(t, err, task.ErrDuplicateNotFound)
+
+	resolved, err := uc.ResolveDuplicate(context.Background(), model.Scope{UserID: "u1"}, task.ResolveDuplicateInput{
+		PendingID: pendingID, Action: task.DuplicateActionUpdate,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "memo-old", resolved.Task.MemoID)
+	repo.AssertExpectations(t)
+	vectorRepo.AssertExpectations(t)
+	// The due date moved, so the work block is booked again with its old length
+	assert.Equal(t, []schedule.BookInput{{TaskID: "memo-old", Duration: 45 * time.Minute}}, sched.booked)
+
+	// Resolved duplicates cannot be replayed
+	_, err = uc.ResolveDuplicate(context.Background(), model.Scope{UserID: "u1"}, task.ResolveDuplicateInput{
+		PendingID: pendingID, Action: task.DuplicateActionCreate,
+	})
+	assert.ErrorIs(t, err, task.ErrDuplicateNotFound)
+}
+
+func TestApplyToExisting_ZeroDueKeepsExistingDue(t *testing.T) {
+	existing := "## Buy milk\n\n- **Due:** 2025-06-14\n- **Scheduled:** 2025-06-14 09:00 +07:00 · 45 min · event ev-old\n\n#priority/p2"
+	repo := new(mockMemosRepo)
+	repo.On("GetTask", mock.Anything, "memo-old").Return(model.Task{ID: "memo-old", Content: existing, UpdateTime: "t1"}, nil)
+	repo.On("UpdateTaskIfUnchanged", mock.Anything, "memo-old", mock.MatchedBy(func(content string) bool {
+		return strings.Contains(content, "- **Due:** 2025-06-14") && strings.Contains(content, "#priority/p1")
+	}), "t1").Return(model.Task{ID: "memo-old"}, nil).Once()
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("EmbedTask", mock.Anything, mock.Anything).Return(nil).Once()
+
+	sched := &fakeScheduler{}
+	uc := newTestTaskUC(nil, repo, vectorRepo)
+	uc.scheduler = sched
+
+	p := pendingDuplicate{existingID: "memo-old", task: taskWithDate{Title: "Buy milk", Priority: "p1"}}
+	_, err := uc.applyToExisting(context.Background(), model.Scope{UserID: "u1"}, p, task.DuplicateActionUpdate)
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+	vectorRepo.AssertExpectations(t)
+	assert.Empty(t, sched.booked, "an unchanged due date keeps the block")
+}
+
+// Tests: idempotency
+
+func TestCreateBulk_RetryWithSameKey_ReusesCreatedTasks(t *testing.T) {
+	llmResp := `[{"title":"Buy milk","description":"","due_date_absolute":"2025-06-15T23:59:59+07:00","priority":"p2","tags":[],"estimated_duration_minutes":30}]`
+
+	repo := new(mockMemosRepo)
+	repo.On("CreateTask", mock.Anything, mock.Anything).Return(model.Task{ID: "memo-1"}, nil).Once()
+
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("FindSimilarTasks", mock.Anything, mock.Anything, mock.Anything).Return([][]repository.SearchResult{{}}, nil).Once()
+	vectorRepo.On("EmbedTask", mock.Anything, mock.Anything).Return(nil).Once()
+
+	uc := newTestTaskUC(makeLLMManager(llmResp), repo, vectorRepo)
+	input := task.CreateBulkInput{RawText: "Buy milk", IdempotencyKey: "telegram:1:42"}
+
+	first, err := uc.CreateBulk(context.Background(), model.Scope{UserID: "u1"}, input)
+	assert.NoError(t, err)
+	second, err := uc.CreateBulk(context.Background(), model.Scope{UserID: "u1"}, input)
+	assert.NoError(t, err)
+
+	assert.Equal(t, first.Tasks, second.Tasks)
+	repo.AssertNumberOfCalls(t, "CreateTask", 1)
+}
+
+// Tests: saga outcomes, retries and rollback
+
+type mockCalendar struct {
+	mock.Mock
+}
+
+func (m *mockCalendar) CreateEvent(ctx context.Context, req gcalendar.CreateEventRequest) (*gcalendar.Event, error) {
+	args := m.Called(ctx, req)
+	ev, _ := args.Get(0).(*gcalendar.Event)
+	return ev, args.Error(1)
+}
+
+func (m *mockCalendar) ListEvents(ctx context.Context, req gcalendar.ListEventsRequest) ([]gcalendar.Event, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).([]gcalendar.Event), args.Error(1)
+}
+
+func (m *mockCalendar) DeleteEvent(ctx context.Context, calendarID, eventID string) error {
+	args := m.Called(ctx, calendarID, eventID)
+	return args.Error(0)
+}
+
+const sagaLLMResp = `[{"title":"Buy milk","description":"","due_date_absolute":"2025-06-15T23:59:59+07:00","priority":"p2","tags":[],"estimated_duration_minutes":30},{"title":"Call mom","description":"","due_date_absolute":"2025-06-15T23:59:59+07:00","priority":"p2","tags":[],"estimated_duration_minutes":30}]`
+
+func TestCreateBulk_ReportsPerTaskOutcomes(t *testing.T) {
+	repo := new(mockMemosRepo)
+	repo.On("CreateTask", mock.Anything, mock.MatchedBy(func(opt repository.CreateTaskOptions) bool {
+		return strings.Contains(opt.Content, "Buy milk")
+	})).Return(model.Task{ID: "memo-1"}, nil)
+	repo.On("CreateTask", mock.Anything, mock.Anything).Return(model.Task{}, errors.New("memos down"))
+
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("FindSimilarTasks", mock.Anything, mock.Anything, mock.Anything).Return([][]repository.SearchResult{{}}, nil)
+	vectorRepo.On("EmbedTask", mock.Anything, mock.Anything).Return(errors.New("qdrant down")).Once()
+	vectorRepo.On("EmbedTask", mock.Anything, mock.Anything).Return(nil)
+
+	cal := new(mockCalendar)
+	cal.On("CreateEvent", mock.Anything, mock.Anything).Return(&gcalendar.Event{ID: "ev-1", HtmlLink: "http://cal/ev-1"}, nil)
+
+	uc := newTestTaskUC(makeLLMManager(sagaLLMResp), repo, vectorRepo)
+	uc.calendar = cal
+
+	output, err := uc.CreateBulk(context.Background(), model.Scope{UserID: "u1"}, task.CreateBulkInput{RawText: "Buy milk, call mom"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, output.TaskCount)
+	assert.NotEmpty(t, output.BatchID)
+
+	if assert.Len(t, output.Outcomes, 2) {
+		assert.Equal(t, task.TaskOutcome{
+			Title: "Buy milk", MemoID: "memo-1",
+			Memo: task.StepOK, Embed: task.StepFailed, Calendar: task.StepOK,
+		}, output.Outcomes[0])
+		assert.Equal(t, task.StepFailed, output.Outcomes[1].Memo)
+		assert.Equal(t, task.StepSkipped, output.Outcomes[1].Embed)
+		assert.Contains(t, output.Outcomes[1].Error, "memos down")
+	}
+
+	// The failed embedding is retried in the background
+	batch, ok := uc.batches.Get(output.BatchID)
+	assert.True(t, ok)
+	assert.Eventually(t, func() bool {
+		batch.mu.Lock()
+		defer batch.mu.Unlock()
+		return batch.steps[0].outcome.Embed == task.StepOK
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestCreateBulk_AllMemosFail_NoBatch(t *testing.T) {
+	repo := new(mockMemosRepo)
+	repo.On("CreateTask", mock.Anything, mock.Anything).Return(model.Task{}, errors.New("memos down"))
+
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("FindSimilarTasks", mock.Anything, mock.Anything, mock.Anything).Return([][]repository.SearchResult{{}}, nil)
+
+	uc := newTestTaskUC(makeLLMManager(sagaLLMResp), repo, vectorRepo)
+	output, err := uc.CreateBulk(context.Background(), model.Scope{UserID: "u1"}, task.CreateBulkInput{RawText: "Buy milk, call mom"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, output.TaskCount)
+	assert.Empty(t, output.BatchID)
+	assert.Len(t, output.Outcomes, 2)
+}
+
+type fakeScheduler struct {
+	booked []schedule.BookInput
+	users  []string
+	err    error
+}
+
+func (f *fakeScheduler) Book(ctx context.Context, sc model.Scope, input schedule.BookInput) (schedule.BookOutput, error) {
+	f.booked = append(f.booked, input)
+	f.users = append(f.users, sc.UserID)
+	if f.err != nil {
+		return schedule.BookOutput{}, f.err
+	}
+	return schedule.BookOutput{TaskID: input.TaskID, EventID: "blk-1"}, nil
+}
+
+func TestCreateCalendarEvent_DeadlineOnlyBooksWorkBlock(t *testing.T) {
+	cal := new(mockCalendar)
+	cal.On("CreateEvent", mock.Anything, mock.Anything).Return(&gcalendar.Event{ID: "ev-1"}, nil).Once()
+	sched := &fakeScheduler{}
+	repo := new(mockMemosRepo)
+	booked := model.Task{ID: "memo-1", Content: "## Write report\n\n- **Scheduled:** 2025-06-14 09:00 · 90 min"}
+	repo.On("GetTask", mock.Anything, "memo-1").Return(booked, nil).Once()
+
+	uc := newTestTaskUC(makeLLMManager(""), repo, new(mockVectorRepo))
+	uc.calendar = cal
+	uc.scheduler = sched
+
+	loc, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
+	deadline := taskWithDate{Title: "Write report", EstimatedDurationMinutes: 90,
+		DueDateAbsolute: time.Date(2025, 6, 15, 23, 59, 59, 0, loc)}
+	ev, memo, status := uc.createCalendarEvent(context.Background(), "u1", deadline, model.Task{ID: "memo-1", Content: "## Write report"})
+	assert.Equal(t, task.StepOK, status)
+	assert.Equal(t, "blk-1", ev.ID)
+	assert.Equal(t, booked, memo, "the memo is re-read with its Scheduled line")
+	if assert.Len(t, sched.booked, 1) {
+		assert.Equal(t, "memo-1", sched.booked[0].TaskID)
+		assert.Equal(t, 90*time.Minute, sched.booked[0].Duration)
+		assert.Equal(t, "u1", sched.users[0])
+	}
+
+	// A task with a time of day is still booked at that time
+	meeting := taskWithDate{Title: "Standup", EstimatedDurationMinutes: 15,
+		DueDateAbsolute: time.Date(2025, 6, 15, 9, 0, 0, 0, loc)}
+	ev, memo, status = uc.createCalendarEvent(context.Background(), "u1", meeting, model.Task{ID: "memo-2"})
+	assert.Equal(t, task.StepOK, status)
+	assert.Equal(t, "ev-1", ev.ID)
+	assert.Equal(t, "memo-2", memo.ID)
+	assert.Len(t, sched.booked, 1)
+	cal.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateOne_EmbedsBookedContent(t *testing.T) {
+	booked := model.Task{ID: "memo-1", Content: "## Write report\n\n- **Scheduled:** 2025-06-14 09:00 · 60 min"}
+	repo := new(mockMemosRepo)
+	repo.On("CreateTask", mock.Anything, mock.Anything).Return(model.Task{ID: "memo-1", Content: "## Write report"}, nil).Once()
+	repo.On("GetTask", mock.Anything, "memo-1").Return(booked, nil).Once()
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("EmbedTask", mock.Anything, booked).Return(nil).Once()
+
+	uc := newTestTaskUC(makeLLMManager(""), repo, vectorRepo)
+	uc.calendar = new(mockCalendar)
+	uc.scheduler = &fakeScheduler{}
+
+	loc, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
+	step, err := uc.createOne(context.Background(), "u1", taskWithDate{Title: "Write report",
+		DueDateAbsolute: time.Date(2025, 6, 15, 23, 59, 59, 0, loc)})
+	assert.NoError(t, err)
+	assert.Equal(t, task.StepOK, step.outcome.Calendar)
+	assert.Equal(t, task.StepOK, step.outcome.Embed)
+	assert.Equal(t, booked, step.memo)
+	repo.AssertExpectations(t)
+	vectorRepo.AssertExpectations(t)
+}
+
+func TestRollbackBatch_UndoesEverySideEffect(t *testing.T) {
+	repo := new(mockMemosRepo)
+	repo.On("CreateTask", mock.Anything, mock.Anything).Return(model.Task{ID: "memo-1"}, nil).Once()
+	repo.On("CreateTask", mock.Anything, mock.Anything).Return(model.Task{ID: "memo-2"}, nil).Once()
+	repo.On("DeleteTask", mock.Anything, "memo-1").Return(nil).Once()
+	repo.On("DeleteTask", mock.Anything, "memo-2").Return(errors.New("memos down")).Once()
+
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("FindSimilarTasks", mock.Anything, mock.Anything, mock.Anything).Return([][]repository.SearchResult{{}}, nil)
+	vectorRepo.On("EmbedTask", mock.Anything, mock.Anything).Return(nil)
+	vectorRepo.On("DeleteTask", mock.Anything, "memo-1").Return(nil).Once()
+	vectorRepo.On("DeleteTask", mock.Anything, "memo-2").Return(nil).Once()
+
+	cal := new(mockCalendar)
+	cal.On("CreateEvent", mock.Anything, mock.Anything).Return(&gcalendar.Event{ID: "ev-1"}, nil)
+	cal.On("DeleteEvent", mock.Anything, "primary", "ev-1").Return(nil).Twice()
+
+	uc := newTestTaskUC(makeLLMManager(sagaLLMResp), repo, vectorRepo)
+	uc.calendar = cal
+
+	input := task.CreateBulkInput{RawText: "Buy milk, call mom", IdempotencyKey: "telegram:1:7"}
+	output, err := uc.CreateBulk(context.Background(), model.Scope{UserID: "u1"}, input)
+	assert.NoError(t, err)
+
+	// Only the owner can roll a batch back
+	_, err = uc.RollbackBatch(context.Background(), model.Scope{UserID: "someone-else"}, output.BatchID)
+	assert.ErrorIs(t, err, task.ErrBatchNotFound)
+
+	rolled, err := uc.RollbackBatch(context.Background(), model.Scope{UserID: "u1"}, output.BatchID)
+	assert.NoError(t, err)
+	if assert.Len(t, rolled.Tasks, 2) {
+		assert.Equal(t, task.StepRolledBack, rolled.Tasks[0].Memo)
+		assert.Equal(t, task.StepRolledBack, rolled.Tasks[0].Embed)
+		assert.Equal(t, task.StepRolledBack, rolled.Tasks[0].Calendar)
+		assert.Equal(t, task.StepOK, rolled.Tasks[1].Memo)
+	}
+	assert.Len(t, rolled.Errors, 1)
+	repo.AssertExpectations(t)
+	vectorRepo.AssertExpectations(t)
+	cal.AssertExpectations(t)
+
+	// A batch is rolled back once, and its idempotency keys are released
+	_, err = uc.RollbackBatch(context.Background(), model.Scope{UserID: "u1"}, output.BatchID)
+	assert.ErrorIs(t, err, task.ErrBatchNotFound)
+	_, ok := uc.created.Get(createdKey(input.IdempotencyKey, taskWithDate{Title: "Buy milk"}))
+	assert.False(t, ok)
+}
+
+func TestRetryStep_StopsWhenCancelled(t *testing.T) {
+	// No expectations: a retry attempt would fail the test
+	uc := newTestTaskUC(nil, new(mockMemosRepo), new(mockVectorRepo))
+	uc.retryDelay = time.Hour
+
+	b := newSagaBatch("u1")
+	s := &sagaStep{memo: model.Task{ID: "memo-1"}, outcome: task.TaskOutcome{Embed: task.StepFailed}}
+	b.steps = append(b.steps, s)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		uc.retryStep(ctx, b, s)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("retryStep kept waiting after its context was cancelled")
+	}
+}
+
+func TestRetryStep_SkippedBookingIsFinal(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
+	sched := &fakeScheduler{err: schedule.ErrNoFreeSlot}
+	uc := newTestTaskUC(nil, new(mockMemosRepo), new(mockVectorRepo))
+	uc.calendar = new(mockCalendar)
+	uc.scheduler = sched
+
+	// Background retries carry no request actor: the batch owner books the block
+	b := newSagaBatch("u1")
+	s := &sagaStep{
+		input:   taskWithDate{Title: "Write report", DueDateAbsolute: time.Date(2025, 6, 15, 23, 59, 59, 0, loc)},
+		memo:    model.Task{ID: "memo-1"},
+		outcome: task.TaskOutcome{Embed: task.StepOK, Calendar: task.StepFailed},
+	}
+	b.steps = append(b.steps, s)
+
+	uc.retryStep(context.Background(), b, s)
+
+	assert.Len(t, sched.booked, 1)
+	assert.Equal(t, []string{"u1"}, sched.users)
+	assert.Equal(t, task.StepSkipped, s.outcome.Calendar)
+}
+
+// Tests: Import
+
+func TestImport_SkipsDuplicatesAndEmbedsInOneBatch(t *testing.T) {
+	data := []byte(`- [ ] Buy milk due:2025-06-15 p1
+  - [ ] 2 liters
+- [ ] Call mom
+- [ ] buy  MILK
+- [x] Pay rent #finance
+- [ ] Water the plants
+`)
+
+	repo := new(mockMemosRepo)
+	repo.On("IterateTasks", mock.Anything, mock.Anything).Return(iter.Seq2[model.Task, error](func(yield func(model.Task, error) bool) {
+		yield(model.Task{ID: "memo-old", Content: "## Call mom\n\n- **Priority:** #priority/p2"}, nil)
+	}))
+	var created []repository.CreateTaskOptions
+	repo.On("CreateTask", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		created = append(created, args.Get(1).(repository.CreateTaskOptions))
+	}).Return(model.Task{ID: "memo-1"}, nil).Once()
+	repo.On("CreateTask", mock.Anything, mock.Anything).Return(model.Task{ID: "memo-2"}, nil).Once()
+
+	vectorRepo := new(mockVectorRepo)
+	// One lookup for every item left after the title checks
+	vectorRepo.On("FindSimilarTasks", mock.Anything, mock.MatchedBy(func(queries []string) bool { return len(queries) == 3 }), mock.Anything).
+		Return([][]repository.SearchResult{{}, {}, {{MemoID: "memo-plants", Score: 0.96}}}, nil).Once()
+	vectorRepo.On("IndexTasks", mock.Anything, mock.MatchedBy(func(tasks []model.Task) bool { return len(tasks) == 2 }), mock.Anything).
+		Return(repository.IndexResult{Total: 2, Indexed: 2}, nil).Once()
+
+	uc := newTestTaskUC(nil, repo, vectorRepo)
+	output, err := uc.Import(context.Background(), model.Scope{UserID: "u1"}, task.ImportInput{Filename: "todo.md", Data: data})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "markdown", output.Format)
+	assert.Equal(t, 5, output.Total)
+	assert.Len(t, output.Tasks, 2)
+	assert.Equal(t, 2, output.Embedded)
+	assert.NotEmpty(t, output.BatchID)
+	if assert.Len(t, output.Skipped, 3) {
+		assert.Equal(t, task.ImportSkip{Title: "Call mom", Reason: task.ImportSkipExisting, ExistingMemoID: "memo-old"}, output.Skipped[0])
+		assert.Equal(t, task.ImportSkipDuplicateInFile, output.Skipped[1].Reason)
+		assert.Equal(t, task.ImportSkip{Title: "Water the plants", Reason: task.ImportSkipSimilar, ExistingMemoID: "memo-plants"}, output.Skipped[2])
+	}
+	if assert.Len(t, created, 1) {
+		assert.Contains(t, created[0].Content, "- **Due:** 2025-06-15")
+		assert.Contains(t, created[0].Content, "- [ ] 2 liters")
+		assert.Contains(t, created[0].Tags, "#priority/p1")
+	}
+	repo.AssertExpectations(t)
+	vectorRepo.AssertExpectations(t)
+}
+
+func TestImport_CSVTimeOfDayIsLocal(t *testing.T) {
+	data := []byte("title,due\nLate review,2026-03-01 23:30\n")
+
+	repo := new(mockMemosRepo)
+	var created repository.CreateTaskOptions
+	repo.On("CreateTask", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(1).(repository.CreateTaskOptions)
+	}).Return(model.Task{ID: "memo-1"}, nil).Once()
+
+	uc := newTestTaskUC(nil, repo, nil)
+	uc.vectorRepo = nil
+	output, err := uc.Import(context.Background(), model.Scope{UserID: "u1"},
+		task.ImportInput{Filename: "tasks.csv", Data: data, SkipDuplicateCheck: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, output.Tasks, 1)
+	// 23:30 in the configured timezone, not 23:30 UTC pushed into the next day
+	assert.Contains(t, created.Content, "- **Due:** 2026-03-01")
+}
+
+func TestImport_EmptyFile(t *testing.T) {
+	uc := newTestTaskUC(nil, new(mockMemosRepo), nil)
+	_, err := uc.Import(context.Background(), model.Scope{UserID: "u1"}, task.ImportInput{Format: "markdown", Data: []byte("no tasks here")})
+	assert.ErrorIs(t, err, task.ErrNothingToImport)
+}
+
 // Tests: Search
 
 func TestSearch_EmptyQuery(t *testing.T) {
@@ -354,7 +904,7 @@ func TestSearch_WithResults(t *testing.T) {
 
 	vectorRepo.On("SearchTasks", mock.Anything, mock.Anything).Return([]repository.SearchResult{
 		{MemoID: "memo-1", Score: 0.95},
-		{MemoID: "memo-2", Score: 0.85},
+		{MemoID: "memo-2", Score: 0.85, Section: "Notes", Snippet: "renew the SSL certificate"},
 	}, nil)
 
 	repo.On("GetTask", mock.Anything, "memo-1").Return(model.Task{
@@ -371,6 +921,9 @@ func TestSearch_WithResults(t *testing.T) {
 	assert.Equal(t, 2, output.Count)
 	assert.Equal(t, "## Task 1", output.Results[0].Content)
 	assert.Equal(t, 0.95, output.Results[0].Score)
+	assert.Empty(t, output.Results[0].Snippet)
+	assert.Equal(t, "Notes", output.Results[1].MatchedSection)
+	assert.Equal(t, "renew the SSL certificate", output.Results[1].Snippet)
 }
 
 func TestSearch_QdrantError(t *testing.T) {
@@ -384,6 +937,153 @@ func TestSearch_QdrantError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to search")
 }
 
+func TestSearch_PassesStructuredFilter(t *testing.T) {
+	dueFrom := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	dueTo := dueFrom.AddDate(0, 0, 7)
+
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("SearchTasks", mock.Anything, mock.MatchedBy(func(opt repository.SearchTasksOptions) bool {
+		return assert.ObjectsAreEqual(repository.PayloadFilter{
+			Must: []repository.Condition{
+				{Key: repository.PayloadPriority, Match: repository.MatchAny{Values: []string{"p0", "p1"}}},
+				{Key: repository.PayloadProject, Match: repository.MatchAny{Values: []string{"smap"}}},
+				{Key: repository.PayloadDue, Range: &repository.DateRange{From: dueFrom, To: dueTo}},
+			},
+			MustNot: []repository.Condition{
+				{Key: repository.PayloadStatus, Match: repository.MatchAny{Values: []string{"done"}}},
+			},
+		}, opt.Filter)
+	})).Return([]repository.SearchResult{}, nil)
+
+	uc := newTestTaskUC(nil, nil, vectorRepo)
+	_, err := uc.Search(context.Background(), model.Scope{UserID: "u1"}, task.SearchInput{
+		Query:           "report",
+		Priorities:      []string{"#priority/P0", " p1 "},
+		Projects:        []string{"#project/SMAP"},
+		ExcludeStatuses: []string{"done"},
+		DueFrom:         dueFrom,
+		DueTo:           dueTo,
+	})
+
+	assert.NoError(t, err)
+	vectorRepo.AssertExpectations(t)
+}
+
+func TestSearch_ReadsFiltersFromQuery(t *testing.T) {
+	var got repository.SearchTasksOptions
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("SearchTasks", mock.Anything, mock.MatchedBy(func(opt repository.SearchTasksOptions) bool {
+		got = opt
+		return true
+	})).Return([]repository.SearchResult{}, nil)
+
+	uc := newTestTaskUC(nil, nil, vectorRepo)
+	output, err := uc.Search(context.Background(), model.Scope{UserID: "u1"}, task.SearchInput{
+		Query: "tìm task p1 chưa xong hạn tuần sau về deploy",
+	})
+
+	assert.NoError(t, err)
+	in := output.Interpretation
+	assert.Equal(t, "deploy", in.Text)
+	assert.Equal(t, []string{"triển khai"}, in.Expansions)
+	assert.Equal(t, []string{"p1"}, in.Priorities)
+	assert.Equal(t, []string{"done"}, in.ExcludeStatuses)
+	assert.Equal(t, "tuần sau", in.DuePhrase)
+	assert.Equal(t, time.Monday, in.DueFrom.Weekday())
+	assert.Equal(t, in.DueFrom.AddDate(0, 0, 7), in.DueTo)
+	assert.True(t, in.DueFrom.After(time.Now()))
+
+	// Synonyms widen the dense track only; the text track needs every word
+	assert.Equal(t, "deploy triển khai", got.Query)
+	assert.Equal(t, "deploy", got.TextQuery)
+	assert.Equal(t, repository.PayloadFilter{
+		Must: []repository.Condition{
+			{Key: repository.PayloadPriority, Match: repository.MatchAny{Values: []string{"p1"}}},
+			{Key: repository.PayloadDue, Range: &repository.DateRange{From: in.DueFrom, To: in.DueTo}},
+		},
+		MustNot: []repository.Condition{
+			{Key: repository.PayloadStatus, Match: repository.MatchAny{Values: []string{"done"}}},
+		},
+	}, got.Filter)
+}
+
+func TestSearchTasksTool_ReturnsInterpretation(t *testing.T) {
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("SearchTasks", mock.Anything, mock.Anything).Return([]repository.SearchResult{}, nil)
+
+	uc := newTestTaskUC(nil, nil, vectorRepo)
+	out, err := uc.newSearchTasksTool().Execute(context.Background(), map[string]interface{}{"query": "task p1 về deploy"})
+
+	assert.NoError(t, err)
+	in, ok := out.(map[string]interface{})["interpretation"].(task.SearchInterpretation)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"p1"}, in.Priorities)
+	assert.Equal(t, "deploy", in.Text)
+}
+
+func TestSearch_ExplicitFiltersWin(t *testing.T) {
+	dueFrom := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	var got repository.SearchTasksOptions
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("SearchTasks", mock.Anything, mock.MatchedBy(func(opt repository.SearchTasksOptions) bool {
+		got = opt
+		return true
+	})).Return([]repository.SearchResult{}, nil)
+
+	uc := newTestTaskUC(nil, nil, vectorRepo)
+	output, err := uc.Search(context.Background(), model.Scope{UserID: "u1"}, task.SearchInput{
+		Query:      "p1 report tomorrow",
+		Priorities: []string{"p0"},
+		DueFrom:    dueFrom,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "report", got.TextQuery)
+	assert.Equal(t, []string{"p0"}, output.Interpretation.Priorities)
+	assert.Equal(t, dueFrom, output.Interpretation.DueFrom)
+	assert.True(t, output.Interpretation.DueTo.IsZero())
+	assert.Empty(t, output.Interpretation.DuePhrase)
+}
+
+func TestSearch_OnlyFiltersSearchesWholeQuery(t *testing.T) {
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("SearchTasks", mock.Anything, mock.MatchedBy(func(opt repository.SearchTasksOptions) bool {
+		return opt.Query == "deadline tuần này" && opt.TextQuery == "deadline tuần này" && len(opt.Filter.Must) == 1
+	})).Return([]repository.SearchResult{}, nil)
+
+	uc := newTestTaskUC(nil, nil, vectorRepo)
+	output, err := uc.Search(context.Background(), model.Scope{UserID: "u1"}, task.SearchInput{Query: "deadline tuần này"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "tuần này", output.Interpretation.DuePhrase)
+	vectorRepo.AssertExpectations(t)
+}
+
+func TestSearch_LLMExpansion(t *testing.T) {
+	vectorRepo := new(mockVectorRepo)
+	vectorRepo.On("SearchTasks", mock.Anything, mock.MatchedBy(func(opt repository.SearchTasksOptions) bool {
+		return opt.Query == "changelog release notes rollout" && opt.TextQuery == "changelog"
+	})).Return([]repository.SearchResult{}, nil)
+
+	uc := newTestTaskUC(makeLLMManager("```json\n[\"release notes\", \"Changelog\", \" rollout \"]\n```"), nil, vectorRepo)
+	uc.expandQueries = true
+	output, err := uc.Search(context.Background(), model.Scope{UserID: "u1"}, task.SearchInput{Query: "changelog"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"release notes", "rollout"}, output.Interpretation.Expansions)
+	vectorRepo.AssertExpectations(t)
+
+	// A failing LLM does not fail the search
+	vectorRepo = new(mockVectorRepo)
+	vectorRepo.On("SearchTasks", mock.Anything, mock.Anything).Return([]repository.SearchResult{}, nil)
+	uc = newTestTaskUC(makeLLMManagerErr(errors.New("LLM down")), nil, vectorRepo)
+	uc.expandQueries = true
+	output, err = uc.Search(context.Background(), model.Scope{UserID: "u1"}, task.SearchInput{Query: "changelog"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, output.Interpretation.Expansions)
+}
+
 func TestSearch_ZombieVectorCleanup(t *testing.T) {
 	repo := new(mockMemosRepo)
 	vectorRepo := new(mockVectorRepo)
@@ -0,0 +1,10 @@
+package timetrack
+
+import "errors"
+
+var (
+	ErrNoActiveTimer   = errors.New("timetrack: no timer is running")
+	ErrAlreadyRunning  = errors.New("timetrack: timer is already running on this task")
+	ErrTaskRequired    = errors.New("timetrack: task ID is required")
+	ErrSessionTooShort = errors.New("timetrack: session shorter than a minute was not logged")
+)
@@ -0,0 +1,14 @@
+package timetrack
+
+import "fmt"
+
+// FormatMinutes renders a duration as "45 phút" or "1h30".
+func FormatMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%d phút", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dh%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,25 @@
+package timetrack
+
+import (
+	"context"
+
+	"autonomous-task-management/internal/agent"
+	"autonomous-task-management/internal/model"
+)
+
+// UseCase defines the business logic interface for the timetrack domain.
+// Each user has at most one running timer; stopping it appends a "- ⏱" line to the task's
+// time log and updates its "- **Spent:**" total.
+type UseCase interface {
+	// Start starts a timer on a task, stopping the user's running timer first.
+	Start(ctx context.Context, sc model.Scope, input StartInput) (StartOutput, error)
+
+	// Stop stops the user's running timer and logs the session on its task.
+	Stop(ctx context.Context, sc model.Scope) (StopOutput, error)
+
+	// Active returns the user's running timer, if any.
+	Active(ctx context.Context, sc model.Scope) (Session, bool)
+
+	// RegisterAgentTools registers this domain's agent tools into the registry.
+	RegisterAgentTools(registry *agent.ToolRegistry)
+}
@@ -0,0 +1,32 @@
+package timetrack
+
+import "time"
+
+// Session is a running or finished work session on a task.
+type Session struct {
+	TaskID string
+	Title  string
+	UserID string
+	Start  time.Time
+	End    time.Time // Zero while the timer is running
+}
+
+// StartInput starts a timer on a task.
+type StartInput struct {
+	TaskID string
+}
+
+// StartOutput is the started timer and the session it replaced, if any.
+type StartOutput struct {
+	Session Session
+	Stopped *StopOutput // Previous timer, stopped and logged; nil if none was running
+}
+
+// StopOutput is a logged work session.
+type StopOutput struct {
+	Session      Session
+	Minutes      int  // Logged duration of this session
+	TotalMinutes int  // All time logged on the task
+	Estimated    int  // Estimated minutes of the task, 0 if unknown
+	Capped       bool // The session ran past the maximum length and was cut short
+}
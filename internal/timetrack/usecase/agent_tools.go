@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"autonomous-task-management/internal/agent"
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/timetrack"
+	pkgLog "autonomous-task-management/pkg/log"
+)
+
+// RegisterAgentTools registers the timetrack domain's agent tools into the registry.
+func (uc *implUseCase) RegisterAgentTools(registry *agent.ToolRegistry) {
+	registry.Register(&startTimerTool{uc: uc, l: uc.l})
+	registry.Register(&stopTimerTool{uc: uc, l: uc.l})
+}
+
+// agentScope returns the scope of the user the agent acts for, so agent and /track share a timer.
+func agentScope(ctx context.Context) model.Scope {
+	_, userID := history.ActorFrom(ctx)
+	return model.Scope{UserID: userID}
+}
+
+// startTimerTool starts tracking time on a task.
+type startTimerTool struct {
+	uc *implUseCase
+	l  pkgLog.Logger
+}
+
+type startTimerInput struct {
+	TaskID string `json:"task_id"`
+}
+
+type startTimerOutput struct {
+	TaskID  string `json:"task_id"`
+	Title   string `json:"title"`
+	Summary string `json:"summary"`
+}
+
+func (t *startTimerTool) Name() string { return "start_timer" }
+
+func (t *startTimerTool) Description() string {
+	return "Start tracking time spent on a task. Any timer already running for the user is stopped and logged first."
+}
+
+func (t *startTimerTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"task_id": map[string]interface{}{
+				"type":        "string",
+				"description": "Memos task ID (UID)",
+			},
+		},
+		"required": []string{"task_id"},
+	}
+}
+
+func (t *startTimerTool) Execute(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	inputBytes, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input: %w", err)
+	}
+	var params startTimerInput
+	if err := json.Unmarshal(inputBytes, &params); err != nil {
+		return nil, fmt.Errorf("failed to parse input: %w", err)
+	}
+
+	t.l.Infof(ctx, "start_timer: task_id=%s", params.TaskID)
+
+	out, err := t.uc.Start(ctx, agentScope(ctx), timetrack.StartInput{TaskID: params.TaskID})
+	if errors.Is(err, timetrack.ErrAlreadyRunning) {
+		return startTimerOutput{
+			TaskID:  out.Session.TaskID,
+			Title:   out.Session.Title,
+			Summary: fmt.Sprintf("⏱ Đang bấm giờ \"%s\" từ %s", out.Session.Title, out.Session.Start.Format("15:04")),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	summary := fmt.Sprintf("⏱ Bắt đầu bấm giờ \"%s\"", out.Session.Title)
+	if out.Stopped != nil {
+		summary += fmt.Sprintf(" (đã dừng \"%s\": %d phút)", out.Stopped.Session.Title, out.Stopped.Minutes)
+	}
+	return startTimerOutput{TaskID: out.Session.TaskID, Title: out.Session.Title, Summary: summary}, nil
+}
+
+var _ agent.Tool = (*startTimerTool)(nil)
+
+// stopTimerTool stops the running timer and logs the session.
+type stopTimerTool struct {
+	uc *implUseCase
+	l  pkgLog.Logger
+}
+
+type stopTimerOutput struct {
+	TaskID       string `json:"task_id"`
+	Title        string `json:"title"`
+	Minutes      int    `json:"minutes"`
+	TotalMinutes int    `json:"total_minutes"`
+	Estimated    int    `json:"estimated_minutes"`
+	Summary      string `json:"summary"`
+}
+
+func (t *stopTimerTool) Name() string { return "stop_timer" }
+
+func (t *stopTimerTool) Description() string {
+	return "Stop the user's running task timer and log the work session on the task. Returns the session length and the total time spent versus the estimate."
+}
+
+func (t *stopTimerTool) Parameters() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+func (t *stopTimerTool) Execute(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	t.l.Infof(ctx, "stop_timer")
+
+	out, err := t.uc.Stop(ctx, agentScope(ctx))
+	switch {
+	case errors.Is(err, timetrack.ErrNoActiveTimer):
+		return stopTimerOutput{Summary: "Không có đồng hồ nào đang chạy"}, nil
+	case errors.Is(err, timetrack.ErrSessionTooShort):
+		return stopTimerOutput{
+			TaskID:  out.Session.TaskID,
+			Title:   out.Session.Title,
+			Summary: "Phiên làm việc dưới 1 phút nên không được ghi lại",
+		}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	summary := fmt.Sprintf("⏹ \"%s\": %d phút, tổng %d phút", out.Session.Title, out.Minutes, out.TotalMinutes)
+	if out.Estimated > 0 {
+		summary += fmt.Sprintf(" / ước tính %d phút", out.Estimated)
+	}
+	return stopTimerOutput{
+		TaskID:       out.Session.TaskID,
+		Title:        out.Session.Title,
+		Minutes:      out.Minutes,
+		TotalMinutes: out.TotalMinutes,
+		Estimated:    out.Estimated,
+		Summary:      summary,
+	}, nil
+}
+
+var _ agent.Tool = (*stopTimerTool)(nil)
@@ -0,0 +1,10 @@
+package usecase
+
+import "time"
+
+const (
+	// minSession is the shortest session that is written to the time log.
+	minSession = time.Minute
+	// maxSession caps a forgotten timer so one missing /stop does not log days of work.
+	maxSession = 12 * time.Hour
+)
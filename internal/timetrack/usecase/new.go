@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/internal/timetrack"
+	"autonomous-task-management/pkg/jsonfile"
+	pkgLog "autonomous-task-management/pkg/log"
+)
+
+type implUseCase struct {
+	l         pkgLog.Logger
+	repo      repository.MemosRepository
+	loc       *time.Location
+	now       func() time.Time
+	storePath string // JSON file running timers are saved to; empty keeps them in memory only
+
+	mu     sync.Mutex
+	active map[string]timetrack.Session // Running timer by user ID
+}
+
+// New creates a new timetrack UseCase instance. Time log lines are written in timezone
+// (UTC if empty or invalid). Running timers are restored from storePath and saved there on
+// every start and stop; pass "" to keep them in memory only.
+func New(l pkgLog.Logger, repo repository.MemosRepository, timezone, storePath string) timetrack.UseCase {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	uc := &implUseCase{
+		l:         l,
+		repo:      repo,
+		loc:       loc,
+		now:       time.Now,
+		storePath: storePath,
+		active:    make(map[string]timetrack.Session),
+	}
+	if storePath != "" {
+		if err := jsonfile.Load(storePath, &uc.active); err != nil {
+			l.Errorf(context.Background(), "timetrack: failed to load running timers: %v", err)
+		}
+		if uc.active == nil { // The file held null
+			uc.active = make(map[string]timetrack.Session)
+		}
+	}
+	return uc
+}
+
+// saveLocked writes the running timers to storePath; the caller holds uc.mu.
+// A failed write is only logged: the timer keeps running until a restart.
+func (uc *implUseCase) saveLocked(ctx context.Context) {
+	if uc.storePath == "" {
+		return
+	}
+	if err := jsonfile.Save(uc.storePath, uc.active); err != nil {
+		uc.l.Warnf(ctx, "timetrack: failed to save running timers: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/timetrack"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// Start starts a timer on a task. A timer already running on another task is stopped and
+// logged first; starting the same task again is rejected with ErrAlreadyRunning.
+func (uc *implUseCase) Start(ctx context.Context, sc model.Scope, input timetrack.StartInput) (timetrack.StartOutput, error) {
+	taskID := strings.TrimSpace(input.TaskID)
+	if taskID == "" {
+		return timetrack.StartOutput{}, timetrack.ErrTaskRequired
+	}
+
+	t, err := uc.repo.GetTask(ctx, taskID)
+	if err != nil {
+		return timetrack.StartOutput{}, fmt.Errorf("failed to fetch task: %w", err)
+	}
+
+	session := timetrack.Session{
+		TaskID: t.ID,
+		Title:  taskmeta.ExtractTitle(t.Content),
+		UserID: sc.UserID,
+		Start:  uc.now().In(uc.loc),
+	}
+
+	uc.mu.Lock()
+	prev, running := uc.active[sc.UserID]
+	if running && taskmeta.SameID(prev.TaskID, session.TaskID) {
+		uc.mu.Unlock()
+		return timetrack.StartOutput{Session: prev}, timetrack.ErrAlreadyRunning
+	}
+	uc.active[sc.UserID] = session
+	uc.saveLocked(ctx)
+	uc.mu.Unlock()
+
+	uc.l.Infof(ctx, "timetrack.Start: user=%s task=%s", sc.UserID, session.TaskID)
+
+	out := timetrack.StartOutput{Session: session}
+	if running {
+		stopped, err := uc.logSession(ctx, prev)
+		switch {
+		case errors.Is(err, timetrack.ErrSessionTooShort):
+		case err != nil:
+			// The new timer is already running; losing the previous session is not worth failing it.
+			uc.l.Errorf(ctx, "timetrack.Start: failed to log previous session on %s: %v", prev.TaskID, err)
+		default:
+			out.Stopped = &stopped
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"autonomous-task-management/internal/history"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/internal/timetrack"
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// Stop stops the user's running timer and logs the session on its task. If the task cannot be
+// updated the timer keeps running so the stop can be retried.
+func (uc *implUseCase) Stop(ctx context.Context, sc model.Scope) (timetrack.StopOutput, error) {
+	uc.mu.Lock()
+	session, ok := uc.active[sc.UserID]
+	if ok {
+		delete(uc.active, sc.UserID)
+		uc.saveLocked(ctx)
+	}
+	uc.mu.Unlock()
+	if !ok {
+		return timetrack.StopOutput{}, timetrack.ErrNoActiveTimer
+	}
+
+	out, err := uc.logSession(ctx, session)
+	if err != nil && !errors.Is(err, timetrack.ErrSessionTooShort) {
+		uc.mu.Lock()
+		if _, restarted := uc.active[sc.UserID]; !restarted {
+			uc.active[sc.UserID] = session
+			uc.saveLocked(ctx)
+		}
+		uc.mu.Unlock()
+		return timetrack.StopOutput{}, err
+	}
+
+	uc.l.Infof(ctx, "timetrack.Stop: user=%s task=%s minutes=%d", sc.UserID, session.TaskID, out.Minutes)
+	return out, err
+}
+
+// Active returns the user's running timer, if any.
+func (uc *implUseCase) Active(ctx context.Context, sc model.Scope) (timetrack.Session, bool) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	session, ok := uc.active[sc.UserID]
+	return session, ok
+}
+
+// logSession ends a session now, appends it to the task's time log and recomputes the
+// "- **Spent:**" total from the whole log.
+func (uc *implUseCase) logSession(ctx context.Context, session timetrack.Session) (timetrack.StopOutput, error) {
+	out := timetrack.StopOutput{Session: session}
+
+	elapsed := uc.now().Sub(session.Start)
+	if elapsed > maxSession {
+		elapsed = maxSession
+		out.Capped = true
+	}
+	out.Session.End = session.Start.Add(elapsed)
+	if elapsed < minSession {
+		return out, timetrack.ErrSessionTooShort
+	}
+	out.Minutes = int(elapsed.Round(time.Minute) / time.Minute)
+
+	entry := taskmeta.TimeEntry{Start: session.Start.In(uc.loc), Minutes: out.Minutes}
+	ctx = history.WithAction(ctx, history.ActionTimeLog)
+	updated, err := repository.ModifyTask(ctx, uc.repo, session.TaskID, func(current model.Task) (string, error) {
+		content := taskmeta.AppendTimeEntry(current.Content, entry)
+		total := 0
+		for _, e := range taskmeta.ParseTimeLog(content) {
+			total += e.Minutes
+		}
+		return taskmeta.SetField(content, taskmeta.FieldSpent, fmt.Sprintf("%d min", total)), nil
+	})
+	if err != nil {
+		return timetrack.StopOutput{}, fmt.Errorf("failed to log session on %s: %w", session.TaskID, err)
+	}
+
+	meta := taskmeta.Parse(updated.Content)
+	out.TotalMinutes = meta.SpentMinutes
+	out.Estimated = meta.EstimatedMinutes
+	return out, nil
+}
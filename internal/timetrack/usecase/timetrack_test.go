@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/task/repository"
+	"autonomous-task-management/internal/task/repository/local"
+	"autonomous-task-management/internal/timetrack"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Mock implementations
+// ---------------------------------------------------------------------------
+
+type mockLogger struct{}
+
+func (m *mockLogger) Debug(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Debugf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Info(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Infof(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Warn(_ context.Context, _ ...any)              {}
+func (m *mockLogger) Warnf(_ context.Context, _ string, _ ...any)   {}
+func (m *mockLogger) Error(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Errorf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) DPanic(_ context.Context, _ ...any)            {}
+func (m *mockLogger) DPanicf(_ context.Context, _ string, _ ...any) {}
+func (m *mockLogger) Panic(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Panicf(_ context.Context, _ string, _ ...any)  {}
+func (m *mockLogger) Fatal(_ context.Context, _ ...any)             {}
+func (m *mockLogger) Fatalf(_ context.Context, _ string, _ ...any)  {}
+
+// failingRepo wraps a local Markdown repository; task writes fail while failWrite is set.
+type failingRepo struct {
+	repository.MemosRepository
+	failWrite bool
+}
+
+func (r *failingRepo) UpdateTask(ctx context.Context, id string, content string) error {
+	if r.failWrite {
+		return errors.New("memos unavailable")
+	}
+	return r.MemosRepository.UpdateTask(ctx, id, content)
+}
+
+func (r *failingRepo) UpdateTaskIfUnchanged(ctx context.Context, id, content, expectedUpdateTime string) (model.Task, error) {
+	if r.failWrite {
+		return model.Task{}, errors.New("memos unavailable")
+	}
+	return r.MemosRepository.UpdateTaskIfUnchanged(ctx, id, content, expectedUpdateTime)
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+// fakeClock is a settable time source.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// newTestRepo stores one task per content and returns their IDs.
+func newTestRepo(t *testing.T, contents ...string) (*failingRepo, []string) {
+	t.Helper()
+	repo, err := local.New(t.TempDir(), "", &mockLogger{})
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(contents))
+	for _, content := range contents {
+		task, err := repo.CreateTask(context.Background(), repository.CreateTaskOptions{Content: content})
+		require.NoError(t, err)
+		ids = append(ids, task.ID)
+	}
+	return &failingRepo{MemosRepository: repo}, ids
+}
+
+// contentOf returns the stored content of task id.
+func contentOf(t *testing.T, repo repository.MemosRepository, id string) string {
+	t.Helper()
+	task, err := repo.GetTask(context.Background(), id)
+	require.NoError(t, err)
+	return task.Content
+}
+
+func newTestUseCase(repo repository.MemosRepository) (*implUseCase, *fakeClock) {
+	loc := time.FixedZone("ICT", 7*3600)
+	clock := &fakeClock{t: time.Date(2026, 3, 10, 9, 0, 0, 0, loc)}
+	uc := New(&mockLogger{}, repo, "Asia/Ho_Chi_Minh", "").(*implUseCase)
+	uc.now = clock.now
+	return uc, clock
+}
+
+// ---------------------------------------------------------------------------
+// Tests
+// ---------------------------------------------------------------------------
+
+func TestStop_AppendsTimeLogAndTotal(t *testing.T) {
+	repo, ids := newTestRepo(t, "## A\n\n- **Estimated:** 60 min\n\n#project/alpha")
+	a := ids[0]
+	uc, clock := newTestUseCase(repo)
+	ctx := context.Background()
+	sc := model.Scope{UserID: "u1"}
+
+	_, err := uc.Start(ctx, sc, timetrack.StartInput{TaskID: a})
+	require.NoError(t, err)
+	clock.advance(50 * time.Minute)
+	out, err := uc.Stop(ctx, sc)
+	require.NoError(t, err)
+	assert.Equal(t, 50, out.Minutes)
+	assert.Equal(t, 50, out.TotalMinutes)
+	assert.Equal(t, 60, out.Estimated)
+
+	_, err = uc.Start(ctx, sc, timetrack.StartInput{TaskID: a})
+	require.NoError(t, err)
+	clock.advance(25 * time.Minute)
+	out, err = uc.Stop(ctx, sc)
+	require.NoError(t, err)
+	assert.Equal(t, 75, out.TotalMinutes)
+
+	assert.Equal(t, "## A\n\n- **Estimated:** 60 min\n- **Spent:** 75 min\n\n"+
+		"- ⏱ 2026-03-10 09:00 +07:00 · 50 min\n- ⏱ 2026-03-10 09:50 +07:00 · 25 min\n\n#project/alpha", contentOf(t, repo, a))
+
+	_, err = uc.Stop(ctx, sc)
+	assert.ErrorIs(t, err, timetrack.ErrNoActiveTimer)
+}
+
+func TestActive_SurvivesRestart(t *testing.T) {
+	repo, ids := newTestRepo(t, "## A")
+	a := ids[0]
+	path := filepath.Join(t.TempDir(), "timers.json")
+	ctx := context.Background()
+	sc := model.Scope{UserID: "u1"}
+
+	uc := New(&mockLogger{}, repo, "Asia/Ho_Chi_Minh", path)
+	started, err := uc.Start(ctx, sc, timetrack.StartInput{TaskID: a})
+	require.NoError(t, err)
+
+	restarted := New(&mockLogger{}, repo, "Asia/Ho_Chi_Minh", path)
+	active, ok := restarted.Active(ctx, sc)
+	require.True(t, ok)
+	assert.Equal(t, a, active.TaskID)
+	assert.True(t, started.Session.Start.Equal(active.Start))
+
+	_, err = restarted.Stop(ctx, sc)
+	assert.ErrorIs(t, err, timetrack.ErrSessionTooShort)
+	_, ok = New(&mockLogger{}, repo, "Asia/Ho_Chi_Minh", path).Active(ctx, sc)
+	assert.False(t, ok, "a stopped timer is removed from the file")
+}
+
+func TestStart_OneTimerPerUser(t *testing.T) {
+	repo, ids := newTestRepo(t, "## A", "## B")
+	a, b := ids[0], ids[1]
+	uc, clock := newTestUseCase(repo)
+	ctx := context.Background()
+
+	_, err := uc.Start(ctx, model.Scope{UserID: "u1"}, timetrack.StartInput{TaskID: a})
+	require.NoError(t, err)
+	_, err = uc.Start(ctx, model.Scope{UserID: "u2"}, timetrack.StartInput{TaskID: a})
+	require.NoError(t, err)
+
+	_, err = uc.Start(ctx, model.Scope{UserID: "u1"}, timetrack.StartInput{TaskID: a})
+	assert.ErrorIs(t, err, timetrack.ErrAlreadyRunning)
+
+	clock.advance(30 * time.Minute)
+	out, err := uc.Start(ctx, model.Scope{UserID: "u1"}, timetrack.StartInput{TaskID: b})
+	require.NoError(t, err)
+	require.NotNil(t, out.Stopped)
+	assert.Equal(t, "A", out.Stopped.Session.Title)
+	assert.Equal(t, 30, out.Stopped.Minutes)
+
+	active, ok := uc.Active(ctx, model.Scope{UserID: "u1"})
+	require.True(t, ok)
+	assert.Equal(t, b, active.TaskID)
+	_, ok = uc.Active(ctx, model.Scope{UserID: "u2"})
+	assert.True(t, ok, "other users keep their own timer")
+}
+
+func TestStop_ShortAndForgottenSessions(t *testing.T) {
+	repo, ids := newTestRepo(t, "## A")
+	a := ids[0]
+	uc, clock := newTestUseCase(repo)
+	ctx := context.Background()
+	sc := model.Scope{UserID: "u1"}
+
+	_, err := uc.Start(ctx, sc, timetrack.StartInput{TaskID: a})
+	require.NoError(t, err)
+	clock.advance(20 * time.Second)
+	_, err = uc.Stop(ctx, sc)
+	assert.ErrorIs(t, err, timetrack.ErrSessionTooShort)
+	assert.Equal(t, "## A", contentOf(t, repo, a))
+
+	_, err = uc.Start(ctx, sc, timetrack.StartInput{TaskID: a})
+	require.NoError(t, err)
+	clock.advance(30 * time.Hour)
+	out, err := uc.Stop(ctx, sc)
+	require.NoError(t, err)
+	assert.True(t, out.Capped)
+	assert.Equal(t, int(maxSession/time.Minute), out.Minutes)
+}
+
+func TestStop_KeepsTimerWhenTaskUpdateFails(t *testing.T) {
+	repo, ids := newTestRepo(t, "## A")
+	a := ids[0]
+	uc, clock := newTestUseCase(repo)
+	ctx := context.Background()
+	sc := model.Scope{UserID: "u1"}
+
+	_, err := uc.Start(ctx, sc, timetrack.StartInput{TaskID: a})
+	require.NoError(t, err)
+	clock.advance(10 * time.Minute)
+
+	repo.failWrite = true
+	_, err = uc.Stop(ctx, sc)
+	require.Error(t, err)
+	_, ok := uc.Active(ctx, sc)
+	assert.True(t, ok)
+
+	repo.failWrite = false
+	out, err := uc.Stop(ctx, sc)
+	require.NoError(t, err)
+	assert.Equal(t, 10, out.Minutes)
+}
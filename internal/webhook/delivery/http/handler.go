@@ -9,6 +9,8 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"autonomous-task-management/internal/automation"
+	"autonomous-task-management/internal/dependency"
+	"autonomous-task-management/internal/history"
 	"autonomous-task-management/internal/model"
 	"autonomous-task-management/internal/webhook"
 	pkgLog "autonomous-task-management/pkg/log"
@@ -18,13 +20,15 @@ import (
 type handler struct {
 	uc           webhook.UseCase
 	automationUC automation.UseCase
+	dependencyUC dependency.UseCase
 	l            pkgLog.Logger
 }
 
-func NewHandler(uc webhook.UseCase, automationUC automation.UseCase, l pkgLog.Logger) webhook.Handler {
+func NewHandler(uc webhook.UseCase, automationUC automation.UseCase, dependencyUC dependency.UseCase, l pkgLog.Logger) webhook.Handler {
 	return &handler{
 		uc:           uc,
 		automationUC: automationUC,
+		dependencyUC: dependencyUC,
 		l:            l,
 	}
 }
@@ -103,7 +107,8 @@ func (h *handler) processWebhookAsync(event model.WebhookEvent) {
 
 	h.l.Infof(ctx, "Processing webhook async: %s/%s from %s", event.EventType, event.Action, event.Repository)
 
-	sc := model.Scope{UserID: "system_webhook"}
+	sc := model.Scope{UserID: history.ActorWebhook}
+	ctx = history.WithActor(ctx, sc.UserID)
 	output, err := h.automationUC.ProcessWebhook(ctx, sc, automation.ProcessWebhookInput{
 		Event: event,
 	})
@@ -114,4 +119,14 @@ func (h *handler) processWebhookAsync(event model.WebhookEvent) {
 	}
 
 	h.l.Infof(ctx, "Webhook processed: %s", output.Message)
+
+	// Tell the configured chats about tasks unblocked by this event
+	if h.dependencyUC == nil {
+		return
+	}
+	for _, taskID := range output.TaskIDs {
+		if _, err := h.dependencyUC.OnTaskCompleted(ctx, sc, dependency.CompletedInput{TaskID: taskID}); err != nil {
+			h.l.Warnf(ctx, "Dependency notification failed for task %s: %v", taskID, err)
+		}
+	}
 }
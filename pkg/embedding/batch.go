@@ -0,0 +1,52 @@
+package embedding
+
+// Limits of one embeddings request. They are Voyage's, the tightest of the hosted providers;
+// OpenAI accepts more, and the local embedder has no limit.
+const (
+	MaxBatchTexts  = 128     // Texts per request
+	MaxBatchTokens = 120_000 // Total tokens per request (voyage-3)
+)
+
+// EstimateTokens returns a conservative token estimate for text. Tokenizers average about
+// 4 bytes per token for English and fewer for Vietnamese, so 3 bytes per token errs high.
+func EstimateTokens(text string) int {
+	return len(text)/3 + 1
+}
+
+// Batches splits texts into consecutive [start, end) ranges that each fit one embeddings
+// request: at most maxTexts texts (capped at MaxBatchTexts) and MaxBatchTokens tokens.
+// A single text over the token limit gets a batch of its own; the API truncates it.
+func Batches(texts []string, maxTexts int) [][2]int {
+	groups := make([][]string, len(texts))
+	for i := range texts {
+		groups[i] = texts[i : i+1]
+	}
+	return GroupBatches(groups, maxTexts)
+}
+
+// GroupBatches is Batches for groups of texts that must go into the same request, such as the
+// chunks of one task; the ranges index groups. A group over the limits gets a batch of its own.
+func GroupBatches(groups [][]string, maxTexts int) [][2]int {
+	if maxTexts <= 0 || maxTexts > MaxBatchTexts {
+		maxTexts = MaxBatchTexts
+	}
+
+	var batches [][2]int
+	start, texts, tokens := 0, 0, 0
+	for i, group := range groups {
+		n := 0
+		for _, text := range group {
+			n += EstimateTokens(text)
+		}
+		if i > start && (texts+len(group) > maxTexts || tokens+n > MaxBatchTokens) {
+			batches = append(batches, [2]int{start, i})
+			start, texts, tokens = i, 0, 0
+		}
+		texts += len(group)
+		tokens += n
+	}
+	if start < len(groups) {
+		batches = append(batches, [2]int{start, len(groups)})
+	}
+	return batches
+}
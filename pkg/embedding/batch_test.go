@@ -0,0 +1,37 @@
+package embedding_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"autonomous-task-management/pkg/embedding"
+)
+
+func TestBatches(t *testing.T) {
+	short := make([]string, 5)
+	if got := embedding.Batches(short, 2); !reflect.DeepEqual(got, [][2]int{{0, 2}, {2, 4}, {4, 5}}) {
+		t.Errorf("unexpected count batches: %v", got)
+	}
+
+	// Each text is about half the token budget, so at most two share a request
+	half := strings.Repeat("x", embedding.MaxBatchTokens*3/2-10)
+	long := []string{half, half, half, strings.Repeat("x", embedding.MaxBatchTokens*4)}
+	if got := embedding.Batches(long, 0); !reflect.DeepEqual(got, [][2]int{{0, 2}, {2, 3}, {3, 4}}) {
+		t.Errorf("unexpected token batches: %v", got)
+	}
+
+	if got := embedding.Batches(nil, 10); len(got) != 0 {
+		t.Errorf("expected no batches, got %v", got)
+	}
+}
+
+func TestGroupBatches(t *testing.T) {
+	groups := [][]string{{"a", "b"}, {"c"}, {"d", "e", "f"}, {"g"}}
+	if got := embedding.GroupBatches(groups, 3); !reflect.DeepEqual(got, [][2]int{{0, 2}, {2, 3}, {3, 4}}) {
+		t.Errorf("groups must not be split across batches: %v", got)
+	}
+	if got := embedding.GroupBatches(groups, 2); !reflect.DeepEqual(got, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}}) {
+		t.Errorf("an oversized group must get a batch of its own: %v", got)
+	}
+}
@@ -0,0 +1,22 @@
+package embedding
+
+import "time"
+
+const (
+	// DefaultOpenAIBaseURL is the OpenAI API endpoint used when no base URL is configured
+	DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+	// DefaultOpenAIModel is the default model of the OpenAI-compatible provider
+	DefaultOpenAIModel = "text-embedding-3-small"
+
+	// DefaultTimeout is the HTTP client timeout of the OpenAI-compatible provider
+	DefaultTimeout = 15 * time.Second
+
+	// LocalModel is the model name recorded for vectors of the local hashing embedder.
+	// Bump the version when the features or weighting change.
+	LocalModel = "local-hash-v2"
+
+	// FallbackCollectionSuffix names the collection used by the local fallback embedder, so its
+	// vectors never mix with those of the configured provider.
+	FallbackCollectionSuffix = "_local"
+)
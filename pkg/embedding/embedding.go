@@ -0,0 +1,58 @@
+package embedding
+
+import (
+	"errors"
+	"fmt"
+
+	"autonomous-task-management/config"
+	"autonomous-task-management/pkg/voyage"
+)
+
+// New creates the embedder selected by cfg.Provider. With cfg.FallbackLocal, a hosted provider
+// without an API key yields the local embedder instead of ErrAPIKeyRequired; callers can tell
+// by Model() returning LocalModel and must then store vectors in FallbackCollection.
+// Errors at embedding time never switch embedders.
+func New(cfg config.EmbeddingConfig) (Embedder, error) {
+	e, err := newProvider(cfg)
+	if errors.Is(err, ErrAPIKeyRequired) && cfg.FallbackLocal {
+		return NewLocal(cfg.Dimension)
+	}
+	return e, err
+}
+
+func newProvider(cfg config.EmbeddingConfig) (Embedder, error) {
+	if cfg.Dimension <= 0 {
+		return nil, ErrInvalidDimension
+	}
+
+	switch cfg.Provider {
+	case "", config.EmbeddingProviderVoyage:
+		client, err := voyage.New(cfg.APIKey)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrAPIKeyRequired, err)
+		}
+		if cfg.Model != "" {
+			client.WithModel(cfg.Model)
+		}
+		if cfg.BaseURL != "" {
+			client.WithBaseURL(cfg.BaseURL)
+		}
+		return client.WithDimension(cfg.Dimension), nil
+	case config.EmbeddingProviderOpenAI:
+		return NewOpenAI(OpenAIConfig{
+			APIKey:    cfg.APIKey,
+			BaseURL:   cfg.BaseURL,
+			Model:     cfg.Model,
+			Dimension: cfg.Dimension,
+		})
+	case config.EmbeddingProviderLocal:
+		return NewLocal(cfg.Dimension)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, cfg.Provider)
+	}
+}
+
+// FallbackCollection returns the collection the local fallback embedder writes to instead of name.
+func FallbackCollection(name string) string {
+	return name + FallbackCollectionSuffix
+}
@@ -0,0 +1,17 @@
+package embedding
+
+import "errors"
+
+var (
+	// ErrAPIKeyRequired indicates a hosted provider was selected without an API key
+	ErrAPIKeyRequired = errors.New("embedding: API key is required")
+
+	// ErrInvalidDimension indicates a missing or non-positive vector size
+	ErrInvalidDimension = errors.New("embedding: dimension must be positive")
+
+	// ErrDimensionMismatch indicates the provider returned vectors of another size than configured
+	ErrDimensionMismatch = errors.New("embedding: vector size does not match the configured dimension")
+
+	// ErrUnknownProvider indicates an unsupported embedding.provider
+	ErrUnknownProvider = errors.New("embedding: unknown provider")
+)
@@ -0,0 +1,16 @@
+package embedding
+
+import "context"
+
+// Embedder turns texts into vectors for semantic search.
+// Implementations are safe for concurrent use.
+type Embedder interface {
+	// Embed returns one vector of Dimension() values per text, in input order
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+
+	// Model returns the model name recorded with every stored vector
+	Model() string
+
+	// Dimension returns the vector size; it must match the Qdrant collection
+	Dimension() int
+}
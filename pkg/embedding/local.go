@@ -0,0 +1,102 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+
+	"autonomous-task-management/pkg/textnorm"
+)
+
+// localEmbedder is a deterministic feature-hashing embedder for offline development and tests.
+// Each word and each pair of adjacent words is hashed to a signed position of the vector and
+// weighted by 1+ln(term frequency); the vector is then L2-normalized, so cosine similarity
+// reflects shared vocabulary. Words are folded to unaccented lowercase first, so "tuần này"
+// in a query matches the unaccented "tuan nay" of enriched task text. There is no IDF term:
+// it would need corpus statistics, which would make a text's vector depend on what else was
+// indexed.
+type localEmbedder struct {
+	dimension int
+}
+
+var _ Embedder = (*localEmbedder)(nil)
+
+// NewLocal creates a local hashing embedder producing vectors of dimension values.
+func NewLocal(dimension int) (Embedder, error) {
+	if dimension <= 0 {
+		return nil, ErrInvalidDimension
+	}
+	return &localEmbedder{dimension: dimension}, nil
+}
+
+// Model returns LocalModel.
+func (e *localEmbedder) Model() string {
+	return LocalModel
+}
+
+// Dimension returns the vector size.
+func (e *localEmbedder) Dimension() int {
+	return e.dimension
+}
+
+// Embed hashes each text into a vector. It never calls out and only fails on a cancelled context.
+func (e *localEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("no texts provided")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embeddings[i] = e.embed(text)
+	}
+	return embeddings, nil
+}
+
+func (e *localEmbedder) embed(text string) []float32 {
+	words := strings.FieldsFunc(textnorm.Fold(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	counts := make(map[string]int, 2*len(words))
+	for i, word := range words {
+		counts[word]++
+		if i > 0 {
+			counts[words[i-1]+" "+word]++
+		}
+	}
+
+	vector := make([]float64, e.dimension)
+	for feature, n := range counts {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		sum := h.Sum64()
+
+		// The top bit picks the sign so colliding features tend to cancel out rather than add up
+		weight := 1 + math.Log(float64(n))
+		if sum>>63 == 1 {
+			weight = -weight
+		}
+		vector[sum%uint64(e.dimension)] += weight
+	}
+
+	var norm float64
+	for _, v := range vector {
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+
+	out := make([]float32, e.dimension)
+	if norm == 0 {
+		return out
+	}
+	for i, v := range vector {
+		out[i] = float32(v / norm)
+	}
+	return out
+}
@@ -0,0 +1,79 @@
+package embedding_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"autonomous-task-management/pkg/embedding"
+)
+
+func cosine(a, b []float32) float64 {
+	var dot float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	return dot
+}
+
+func TestLocalEmbedder(t *testing.T) {
+	e, err := embedding.NewLocal(256)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Model() != embedding.LocalModel || e.Dimension() != 256 {
+		t.Errorf("unexpected model %q / dimension %d", e.Model(), e.Dimension())
+	}
+
+	texts := []string{
+		"Fix the login bug in the auth service",
+		"Auth service: fix login bug",
+		"Buy groceries for the weekend",
+		"",
+	}
+	vectors, err := e.Embed(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(vectors) != len(texts) {
+		t.Fatalf("expected %d vectors, got %d", len(texts), len(vectors))
+	}
+	for i, v := range vectors[:3] {
+		if len(v) != 256 {
+			t.Fatalf("vector %d has %d values", i, len(v))
+		}
+		if norm := math.Sqrt(cosine(v, v)); math.Abs(norm-1) > 1e-5 {
+			t.Errorf("vector %d is not normalized: %f", i, norm)
+		}
+	}
+	if related, unrelated := cosine(vectors[0], vectors[1]), cosine(vectors[0], vectors[2]); related <= unrelated {
+		t.Errorf("expected related texts to be closer: %f <= %f", related, unrelated)
+	}
+	for _, x := range vectors[3] {
+		if x != 0 {
+			t.Fatalf("expected a zero vector for empty text")
+		}
+	}
+
+	again, _ := e.Embed(context.Background(), texts[:1])
+	for i := range again[0] {
+		if again[0][i] != vectors[0][i] {
+			t.Fatalf("embedding is not deterministic at %d", i)
+		}
+	}
+
+	if _, err := embedding.NewLocal(0); err != embedding.ErrInvalidDimension {
+		t.Errorf("expected ErrInvalidDimension, got %v", err)
+	}
+}
+
+func TestLocalEmbedder_FoldsDiacritics(t *testing.T) {
+	e, _ := embedding.NewLocal(256)
+	vectors, err := e.Embed(context.Background(), []string{"Deadline tuần này, Đà Nẵng", "deadline tuan nay, da nang"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sim := cosine(vectors[0], vectors[1]); math.Abs(sim-1) > 1e-5 {
+		t.Errorf("expected accented and unaccented text to embed alike, cosine %f", sim)
+	}
+}
@@ -0,0 +1,120 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// openAIEmbedder calls an OpenAI-compatible /embeddings endpoint: OpenAI itself, Azure-style
+// proxies, Ollama, LM Studio, vLLM and the like.
+type openAIEmbedder struct {
+	apiKey     string
+	baseURL    string
+	model      string
+	dimension  int
+	httpClient *http.Client
+}
+
+var _ Embedder = (*openAIEmbedder)(nil)
+
+// NewOpenAI creates an embedder for an OpenAI-compatible embeddings API.
+func NewOpenAI(cfg OpenAIConfig) (Embedder, error) {
+	if cfg.Dimension <= 0 {
+		return nil, ErrInvalidDimension
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultOpenAIBaseURL
+	}
+	if cfg.APIKey == "" && cfg.BaseURL == DefaultOpenAIBaseURL {
+		return nil, ErrAPIKeyRequired
+	}
+	if cfg.Model == "" {
+		cfg.Model = DefaultOpenAIModel
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	return &openAIEmbedder{
+		apiKey:     cfg.APIKey,
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		model:      cfg.Model,
+		dimension:  cfg.Dimension,
+		httpClient: cfg.HTTPClient,
+	}, nil
+}
+
+// Model returns the embedding model in use.
+func (e *openAIEmbedder) Model() string {
+	return e.model
+}
+
+// Dimension returns the configured vector size.
+func (e *openAIEmbedder) Dimension() int {
+	return e.dimension
+}
+
+// Embed generates embeddings for the given texts.
+func (e *openAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("no texts provided")
+	}
+
+	reqBody := openAIRequest{Input: texts, Model: e.model}
+	// text-embedding-3 models shorten their vectors on request; older models reject the field
+	if strings.HasPrefix(e.model, "text-embedding-3") {
+		reqBody.Dimensions = e.dimension
+	}
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/embeddings", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call embeddings API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp openAIError
+		if jsonErr := json.NewDecoder(resp.Body).Decode(&errResp); jsonErr == nil && errResp.Error.Message != "" {
+			return nil, fmt.Errorf("embeddings API error (%d): %s", resp.StatusCode, errResp.Error.Message)
+		}
+		return nil, fmt.Errorf("embeddings API error: %d", resp.StatusCode)
+	}
+
+	var embedResp openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embedResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(embedResp.Data) != len(texts) {
+		return nil, fmt.Errorf("embeddings API returned %d vectors for %d texts", len(embedResp.Data), len(texts))
+	}
+
+	// The API may return vectors out of order; index says which input each belongs to
+	embeddings := make([][]float32, len(texts))
+	for _, data := range embedResp.Data {
+		if data.Index < 0 || data.Index >= len(texts) {
+			return nil, fmt.Errorf("embeddings API returned index %d for %d texts", data.Index, len(texts))
+		}
+		if len(data.Embedding) != e.dimension {
+			return nil, fmt.Errorf("%w: %s returned %d, expected %d", ErrDimensionMismatch, e.model, len(data.Embedding), e.dimension)
+		}
+		embeddings[data.Index] = data.Embedding
+	}
+	return embeddings, nil
+}
@@ -0,0 +1,129 @@
+package embedding_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"autonomous-task-management/config"
+	"autonomous-task-management/pkg/embedding"
+)
+
+func TestOpenAIEmbedder(t *testing.T) {
+	var lastDimensions int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input      []string `json:"input"`
+			Model      string   `json:"model"`
+			Dimensions int      `json:"dimensions"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		lastDimensions = req.Dimensions
+
+		if r.URL.Path != "/v1/embeddings" || (req.Model == embedding.DefaultOpenAIModel && r.Header.Get("Authorization") != "Bearer test-key") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if req.Input[0] == "cause_400" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": {"message": "bad input", "type": "invalid_request_error"}}`))
+			return
+		}
+		size := 3
+		if req.Input[0] == "wrong_size" {
+			size = 2
+		}
+
+		// Answer in reverse order to check the client sorts by index
+		var resp struct {
+			Data []map[string]any `json:"data"`
+		}
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			vector := make([]float32, size)
+			vector[0] = float32(i)
+			resp.Data = append(resp.Data, map[string]any{"embedding": vector, "index": i})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer ts.Close()
+
+	e, err := embedding.NewOpenAI(embedding.OpenAIConfig{APIKey: "test-key", BaseURL: ts.URL + "/v1/", Dimension: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Model() != embedding.DefaultOpenAIModel || e.Dimension() != 3 {
+		t.Errorf("unexpected model %q / dimension %d", e.Model(), e.Dimension())
+	}
+
+	vectors, err := e.Embed(context.Background(), []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, v := range vectors {
+		if v[0] != float32(i) {
+			t.Errorf("vector %d is out of order: %v", i, v)
+		}
+	}
+	if lastDimensions != 3 {
+		t.Errorf("expected dimensions to be sent to %s, got %d", embedding.DefaultOpenAIModel, lastDimensions)
+	}
+
+	if _, err := e.Embed(context.Background(), []string{"cause_400"}); err == nil || err.Error() != "embeddings API error (400): bad input" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := e.Embed(context.Background(), []string{"wrong_size"}); !errors.Is(err, embedding.ErrDimensionMismatch) {
+		t.Errorf("expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := e.Embed(context.Background(), nil); err == nil {
+		t.Errorf("expected error for empty input")
+	}
+
+	// Self-hosted servers need no key, and older models do not take a dimensions field
+	local, err := embedding.NewOpenAI(embedding.OpenAIConfig{BaseURL: ts.URL + "/v1", Model: "nomic-embed-text", Dimension: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := local.Embed(context.Background(), []string{"a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lastDimensions != 0 {
+		t.Errorf("expected no dimensions for nomic-embed-text, got %d", lastDimensions)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.EmbeddingConfig
+		model   string
+		wantErr error
+	}{
+		{"voyage", config.EmbeddingConfig{Provider: config.EmbeddingProviderVoyage, APIKey: "k", Dimension: 512, Model: "voyage-3-lite"}, "voyage-3-lite", nil},
+		{"voyage without key", config.EmbeddingConfig{Provider: config.EmbeddingProviderVoyage, Dimension: 1024}, "", embedding.ErrAPIKeyRequired},
+		{"openai", config.EmbeddingConfig{Provider: config.EmbeddingProviderOpenAI, APIKey: "k", Dimension: 1536}, embedding.DefaultOpenAIModel, nil},
+		{"openai without key", config.EmbeddingConfig{Provider: config.EmbeddingProviderOpenAI, Dimension: 1536}, "", embedding.ErrAPIKeyRequired},
+		{"voyage without key, local fallback", config.EmbeddingConfig{Provider: config.EmbeddingProviderVoyage, Dimension: 1024, FallbackLocal: true}, embedding.LocalModel, nil},
+		{"openai with key ignores fallback", config.EmbeddingConfig{Provider: config.EmbeddingProviderOpenAI, APIKey: "k", Dimension: 1536, FallbackLocal: true}, embedding.DefaultOpenAIModel, nil},
+		{"local", config.EmbeddingConfig{Provider: config.EmbeddingProviderLocal, Dimension: 64}, embedding.LocalModel, nil},
+		{"no dimension", config.EmbeddingConfig{Provider: config.EmbeddingProviderLocal}, "", embedding.ErrInvalidDimension},
+		{"unknown", config.EmbeddingConfig{Provider: "cohere", Dimension: 64}, "", embedding.ErrUnknownProvider},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := embedding.New(tt.cfg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if e.Model() != tt.model || e.Dimension() != tt.cfg.Dimension {
+				t.Errorf("unexpected model %q / dimension %d", e.Model(), e.Dimension())
+			}
+		})
+	}
+}
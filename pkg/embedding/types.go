@@ -0,0 +1,36 @@
+package embedding
+
+import "net/http"
+
+// OpenAIConfig configures the OpenAI-compatible embeddings client.
+type OpenAIConfig struct {
+	APIKey     string // Required for the default base URL; self-hosted servers often need none
+	BaseURL    string // "" = DefaultOpenAIBaseURL
+	Model      string // "" = DefaultOpenAIModel
+	Dimension  int
+	HTTPClient *http.Client // nil = a client with DefaultTimeout
+}
+
+// openAIRequest is the request body of POST /embeddings.
+type openAIRequest struct {
+	Input      []string `json:"input"`
+	Model      string   `json:"model"`
+	Dimensions int      `json:"dimensions,omitempty"` // Only sent to models that can shorten vectors
+}
+
+// openAIResponse is the response body of POST /embeddings.
+type openAIResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+		Index     int       `json:"index"`
+	} `json:"data"`
+	Model string `json:"model"`
+}
+
+// openAIError is the error body of the OpenAI API.
+type openAIError struct {
+	Error struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	} `json:"error"`
+}
@@ -127,6 +127,18 @@ func (c *gcalendarImpl) CreateEvent(ctx context.Context, req CreateEventRequest)
 	}, nil
 }
 
+// DeleteEvent removes an event from Google Calendar.
+func (c *gcalendarImpl) DeleteEvent(ctx context.Context, calendarID, eventID string) error {
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+
+	if err := c.service.Events.Delete(calendarID, eventID).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("pkg: failed to delete calendar event: %w", err)
+	}
+	return nil
+}
+
 // ListEvents retrieves events from Google Calendar within a time range.
 func (c *gcalendarImpl) ListEvents(ctx context.Context, req ListEventsRequest) ([]Event, error) {
 	calendarID := req.CalendarID
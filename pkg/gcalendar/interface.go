@@ -7,6 +7,7 @@ import "context"
 type IGCalendar interface {
 	CreateEvent(ctx context.Context, req CreateEventRequest) (*Event, error)
 	ListEvents(ctx context.Context, req ListEventsRequest) ([]Event, error)
+	DeleteEvent(ctx context.Context, calendarID, eventID string) error
 }
 
 // New creates a new IGCalendar instance from raw Service Account JSON bytes.
@@ -0,0 +1,22 @@
+package idempotency
+
+import "time"
+
+// IStore remembers keys (and an optional result per key) for a limited window,
+// so replayed requests can be detected and answered without redoing side effects.
+type IStore[V any] interface {
+	// Claim records key with value if it is not already present.
+	// It returns true for the first claim within the window and false for replays.
+	Claim(key string, value V) bool
+	// Get returns the value stored for key.
+	Get(key string) (V, bool)
+	// Put stores value for key, replacing any previous value.
+	Put(key string, value V)
+	// Release forgets key so the operation can be retried.
+	Release(key string)
+}
+
+// New creates an in-memory store holding at most size keys, each for ttl.
+func New[V any](size int, ttl time.Duration) IStore[V] {
+	return newStore[V](size, ttl)
+}
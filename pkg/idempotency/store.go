@@ -0,0 +1,48 @@
+// Package idempotency provides a small in-memory store for deduplicating retried requests.
+package idempotency
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+type store[V any] struct {
+	mu  sync.Mutex // Makes Claim's check-and-add atomic
+	lru *expirable.LRU[string, V]
+}
+
+func newStore[V any](size int, ttl time.Duration) *store[V] {
+	return &store[V]{lru: expirable.NewLRU[string, V](size, nil, ttl)}
+}
+
+func (s *store[V]) Claim(key string, value V) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Get (unlike Contains) honours the TTL of entries not yet purged
+	if _, ok := s.lru.Get(key); ok {
+		return false
+	}
+	s.lru.Add(key, value)
+	return true
+}
+
+func (s *store[V]) Get(key string) (V, bool) {
+	return s.lru.Get(key)
+}
+
+func (s *store[V]) Put(key string, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lru.Add(key, value)
+}
+
+func (s *store[V]) Release(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lru.Remove(key)
+}
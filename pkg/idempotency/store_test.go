@@ -0,0 +1,49 @@
+package idempotency
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaim_OnlyFirstWins(t *testing.T) {
+	s := New[struct{}](10, time.Minute)
+
+	var wins int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if s.Claim("update:1", struct{}{}) {
+				atomic.AddInt32(&wins, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), wins)
+}
+
+func TestRelease_AllowsRetry(t *testing.T) {
+	s := New[int](10, time.Minute)
+
+	assert.True(t, s.Claim("k", 1))
+	s.Release("k")
+	assert.True(t, s.Claim("k", 2))
+
+	v, ok := s.Get("k")
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+}
+
+func TestClaim_ExpiresAfterTTL(t *testing.T) {
+	s := New[struct{}](10, 20*time.Millisecond)
+
+	assert.True(t, s.Claim("k", struct{}{}))
+	time.Sleep(40 * time.Millisecond)
+	assert.True(t, s.Claim("k", struct{}{}))
+}
@@ -0,0 +1,173 @@
+package indexer
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxChunks gioi han so chunk cua mot task; cac section sau do khong duoc embed.
+const MaxChunks = 32
+
+// chunkOverlapLines la so dong cuoi cua mot chunk lap lai o dau chunk ke tiep trong cung section,
+// de cau nam giua hai chunk van tim duoc.
+const chunkOverlapLines = 2
+
+var (
+	headingRegex   = regexp.MustCompile(`^#{1,6}\s+`)
+	checkboxRegex  = regexp.MustCompile(`^\s*[-*+]\s+\[[ xX]\]`)
+	minChunkBudget = MaxEnrichedBytes / 4
+)
+
+// Chunk la mot phan cua task duoc embed thanh mot vector rieng.
+type Chunk struct {
+	Index   int    // 0 la phan dau cua task; point cua no mang payload cua ca task
+	Section string // Heading cua section chua chunk, "" neu truoc heading dau tien
+	Content string // Markdown cua chunk, cho full-text search va highlight
+	Text    string // Enriched text de embed
+}
+
+// section la mot khoi dong lien tiep duoi cung heading: mot heading moi hoac mot checklist
+// bat dau/ket thuc deu mo section moi.
+type section struct {
+	heading string
+	lines   []string
+}
+
+// ChunkTaskContent chia task thanh cac chunk co enriched text <= MaxEnrichedBytes.
+// Task ngan la mot chunk duy nhat voi text cua EnrichTaskContent. Task dai (meeting notes...)
+// duoc cat theo heading va checklist; section van qua dai thi cat giua cac dong, lap lai
+// chunkOverlapLines dong. Moi chunk deu kem title, heading, deadline va tags cua task.
+func ChunkTaskContent(content string, tags []string, timezone string) []Chunk {
+	return ChunkTaskContentAt(content, tags, nowIn(timezone))
+}
+
+// ChunkTaskContentAt la ChunkTaskContent voi thoi diem hien tai cho truoc (timezone cua now).
+func ChunkTaskContentAt(content string, tags []string, now time.Time) []Chunk {
+	if whole := enrich(content, tags, now); len(whole) <= MaxEnrichedBytes {
+		return []Chunk{{Content: content, Text: whole}}
+	}
+
+	title, sections := splitSections(content)
+	suffix := taskContext(content, tags, now)
+
+	var chunks []Chunk
+	for _, sec := range sections {
+		for _, window := range splitWindows(sec, title, suffix) {
+			prefix := chunkPrefix(len(chunks), title, sec.heading)
+			parts := append(append(prefix, stripMarkdown(strings.Join(window, "\n"))), suffix...)
+			chunks = append(chunks, Chunk{
+				Index:   len(chunks),
+				Section: sec.heading,
+				Content: strings.Join(window, "\n"),
+				Text:    truncateUTF8(strings.Join(parts, " | "), MaxEnrichedBytes),
+			})
+			if len(chunks) == MaxChunks {
+				return chunks
+			}
+		}
+	}
+	return chunks
+}
+
+// chunkPrefix tra ve title va heading dat truoc noi dung chunk. Chunk 0 da chua title.
+func chunkPrefix(index int, title, heading string) []string {
+	var prefix []string
+	if index > 0 && title != "" {
+		prefix = append(prefix, title)
+	}
+	if heading != "" {
+		prefix = append(prefix, "Section: "+heading)
+	}
+	return prefix
+}
+
+// splitSections tach content thanh cac section. Heading o dong dau tien la title cua task va
+// thuoc section dau (heading "").
+func splitSections(content string) (string, []section) {
+	var (
+		title    string
+		sections []section
+		current  section
+		inList   bool
+		seenText bool
+	)
+	flush := func() {
+		if strings.TrimSpace(strings.Join(current.lines, "")) != "" {
+			sections = append(sections, current)
+		}
+		current = section{heading: current.heading}
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if headingRegex.MatchString(line) {
+			text := stripMarkdown(line)
+			inList = false
+			if seenText {
+				// Heading nam o Section cua chunk, khong lap lai trong noi dung
+				flush()
+				current.heading = text
+				continue
+			}
+			title = text
+		} else if isItem := checkboxRegex.MatchString(line); isItem != inList {
+			flush()
+			inList = isItem
+		}
+		current.lines = append(current.lines, line)
+		seenText = true
+	}
+	flush()
+	return title, sections
+}
+
+// splitWindows cat cac dong cua section thanh cac cua so vua ngan sach con lai sau title,
+// heading va suffix; cua so sau lap lai chunkOverlapLines dong cuoi cua cua so truoc.
+func splitWindows(sec section, title string, suffix []string) [][]string {
+	overhead := len(strings.Join(append(chunkPrefix(1, title, sec.heading), suffix...), " | ")) + len(" | ")
+	budget := max(MaxEnrichedBytes-overhead, minChunkBudget)
+
+	// Dong qua dai (mot doan van khong xuong dong) duoc cat thanh nhieu phan
+	var lines []string
+	for _, line := range sec.lines {
+		for len(line) > budget {
+			part := truncateUTF8(line, budget)
+			lines = append(lines, part)
+			line = line[len(part):]
+		}
+		lines = append(lines, line)
+	}
+
+	var (
+		windows [][]string
+		window  []string
+		size    int
+	)
+	for _, line := range lines {
+		n := len(stripMarkdown(line)) + 1
+		if len(window) > 0 && size+n > budget {
+			windows = append(windows, window)
+
+			// Overlap: chi giu khi van con cho cho dong moi va khong lap lai ca cua so
+			keep := min(chunkOverlapLines, len(window)-1)
+			window = append([]string(nil), window[len(window)-keep:]...)
+			size = 0
+			for _, l := range window {
+				size += len(stripMarkdown(l)) + 1
+			}
+			for len(window) > 0 && size+n > budget {
+				size -= len(stripMarkdown(window[0])) + 1
+				window = window[1:]
+			}
+		}
+		window = append(window, line)
+		size += n
+	}
+	if len(window) > 0 {
+		windows = append(windows, window)
+	}
+	return windows
+}
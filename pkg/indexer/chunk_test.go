@@ -0,0 +1,100 @@
+package indexer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func meetingNotes() string {
+	var b strings.Builder
+	b.WriteString("## Hop sprint planning\n\n- **Due:** 2026-03-15\n\n")
+	b.WriteString("### Thao luan\n\n")
+	for i := range 30 {
+		fmt.Fprintf(&b, "- Y kien %d: cần xem lại tiến độ của nhóm backend và frontend trước khi chốt phạm vi\n", i)
+	}
+	b.WriteString("\n### Viec can lam\n\n")
+	b.WriteString("- [ ] Cap nhat tai lieu API\n- [x] Gui bien ban hop\n")
+	b.WriteString("\n### Ghi chu cuoi\n\nNho gia han chung chi SSL cho staging truoc thu sau\n")
+	return b.String()
+}
+
+func TestChunkTaskContent_ShortTaskIsOneChunk(t *testing.T) {
+	content := "## Review PR #123\n\n- **Priority:** #priority/p1"
+	chunks := ChunkTaskContent(content, []string{"#pr/123"}, "Asia/Ho_Chi_Minh")
+
+	require.Len(t, chunks, 1)
+	assert.Equal(t, content, chunks[0].Content)
+	assert.Equal(t, EnrichTaskContent(content, []string{"#pr/123"}, "Asia/Ho_Chi_Minh"), chunks[0].Text)
+	assert.Empty(t, chunks[0].Section)
+}
+
+func TestChunkTaskContent_SplitsLongNotesBySection(t *testing.T) {
+	chunks := ChunkTaskContent(meetingNotes(), []string{"#project/smap"}, "Asia/Ho_Chi_Minh")
+	require.Greater(t, len(chunks), 3)
+
+	sections := map[string]bool{}
+	for i, c := range chunks {
+		assert.Equal(t, i, c.Index)
+		assert.LessOrEqual(t, len(c.Text), MaxEnrichedBytes)
+		assert.True(t, utf8.ValidString(c.Text), "chunk %d is not valid UTF-8", i)
+		assert.Contains(t, c.Text, "Tags: #project/smap", "chunk %d lacks the task context", i)
+		assert.Contains(t, c.Text, "Deadline: 15/03/2026", "chunk %d lacks the task context", i)
+		if i > 0 {
+			assert.Contains(t, c.Text, "Hop sprint planning", "chunk %d lacks the title", i)
+		}
+		sections[c.Section] = true
+	}
+	assert.True(t, sections["Thao luan"] && sections["Viec can lam"] && sections["Ghi chu cuoi"], "unexpected sections %v", sections)
+
+	// The detail at the very bottom has a chunk of its own
+	last := chunks[len(chunks)-1]
+	assert.Equal(t, "Ghi chu cuoi", last.Section)
+	assert.Contains(t, last.Content, "gia han chung chi SSL")
+
+	// The checklist is not merged into the discussion
+	for _, c := range chunks {
+		if strings.Contains(c.Content, "- [ ] Cap nhat tai lieu API") {
+			assert.Equal(t, "Viec can lam", c.Section)
+			assert.NotContains(t, c.Content, "Y kien")
+		}
+	}
+}
+
+func TestChunkTaskContent_OverlapsWithinASection(t *testing.T) {
+	chunks := ChunkTaskContent(meetingNotes(), nil, "Asia/Ho_Chi_Minh")
+
+	var discussion []Chunk
+	for _, c := range chunks {
+		if c.Section == "Thao luan" {
+			discussion = append(discussion, c)
+		}
+	}
+	require.Greater(t, len(discussion), 1)
+	for i := 1; i < len(discussion); i++ {
+		prev := strings.Split(discussion[i-1].Content, "\n")
+		next := strings.Split(discussion[i].Content, "\n")
+		assert.Equal(t, prev[len(prev)-chunkOverlapLines:], next[:chunkOverlapLines])
+	}
+}
+
+func TestChunkTaskContent_CapsChunks(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("## Nhat ky\n")
+	for i := range MaxChunks * 3 {
+		fmt.Fprintf(&b, "\n### Ngay %d\n\n%s\n", i, strings.Repeat("ghi chu ", 20))
+	}
+	assert.Len(t, ChunkTaskContent(b.String(), nil, "UTC"), MaxChunks)
+}
+
+func TestEnrichTaskContent_TruncatesWholeRunes(t *testing.T) {
+	content := strings.Repeat("Cập nhật tiến độ ", 200)
+	result := EnrichTaskContent(content, nil, "Asia/Ho_Chi_Minh")
+
+	assert.LessOrEqual(t, len(result), MaxEnrichedBytes)
+	assert.True(t, utf8.ValidString(result))
+}
@@ -13,19 +13,44 @@ import (
 	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
+// EnrichmentVersion identifies the output format of EnrichTaskContent. Bump it whenever that
+// output changes, so vectors built from the old text can be told apart and rebuilt.
+//
+// Version 2: long tasks are split into chunks (ChunkTaskContent); truncation keeps whole runes.
+// Version 3: the humanized due date is a calendar bucket without day counts (DueBucket).
+const EnrichmentVersion = 3
+
+// MaxEnrichedBytes gioi han do dai text embed cua mot task hoac mot chunk, de tranh embedding API limits.
+const MaxEnrichedBytes = 1200
+
 // EnrichTaskContent lam giau noi dung task voi temporal context truoc khi embed.
 // content: markdown content cua task (da co - **Due:** yyyy-mm-dd)
 // tags: danh sach tags cua task
 // timezone: timezone cua user de tinh humanized date
 func EnrichTaskContent(content string, tags []string, timezone string) string {
-	loc, err := time.LoadLocation(timezone)
-	if err != nil {
-		loc = time.UTC
+	return EnrichTaskContentAt(content, tags, nowIn(timezone))
+}
+
+// EnrichTaskContentAt la EnrichTaskContent voi thoi diem hien tai cho truoc (timezone cua now).
+func EnrichTaskContentAt(content string, tags []string, now time.Time) string {
+	return truncateUTF8(enrich(content, tags, now), MaxEnrichedBytes)
+}
+
+// TemporalBucket tra ve DueBucket cua due date trong content tai thoi diem now, "" neu khong co due date.
+// Text embed chi con dung khi bucket khong doi; bucket doi thi phai embed lai.
+func TemporalBucket(content string, now time.Time) string {
+	dueDate, ok := extractDueDate(content)
+	if !ok {
+		return ""
 	}
-	now := time.Now().In(loc)
+	return DueBucket(dueDate, now)
+}
 
+// enrich build enriched text day du (chua cat).
+func enrich(content string, tags []string, now time.Time) string {
 	var parts []string
 
 	// 1. Giu noi dung goc (stripped markdown)
@@ -34,25 +59,50 @@ func EnrichTaskContent(content string, tags []string, timezone string) string {
 		parts = append(parts, cleaned)
 	}
 
-	// 2. Them temporal context neu co due date trong content
+	// 2 + 3. Temporal context va tags
+	parts = append(parts, taskContext(content, tags, now)...)
+
+	return strings.Join(parts, " | ")
+}
+
+// taskContext tra ve cac phan "Deadline: ..." va "Tags: ..." gan vao moi text embed.
+func taskContext(content string, tags []string, now time.Time) []string {
+	var parts []string
+
+	// Them temporal context neu co due date trong content
 	if dueDate, ok := extractDueDate(content); ok {
-		humanized := humanizeDueDate(dueDate, now)
-		parts = append(parts, fmt.Sprintf("Deadline: %s (%s)", dueDate.Format("02/01/2006"), humanized))
+		deadline := "Deadline: " + dueDate.Format("02/01/2006")
+		if humanized := DueBucket(dueDate, now); humanized != "" {
+			deadline += fmt.Sprintf(" (%s)", humanized)
+		}
+		parts = append(parts, deadline)
 	}
 
-	// 3. Them tags de vector capture exact keyword matching
+	// Them tags de vector capture exact keyword matching
 	if len(tags) > 0 {
 		parts = append(parts, "Tags: "+strings.Join(tags, ", "))
 	}
+	return parts
+}
 
-	result := strings.Join(parts, " | ")
-
-	// Gioi han 1200 chars de tranh embedding API limits
-	if len(result) > 1200 {
-		result = result[:1200]
+// nowIn tra ve thoi diem hien tai theo timezone cua user (UTC neu timezone khong hop le).
+func nowIn(timezone string) time.Time {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
 	}
+	return time.Now().In(loc)
+}
 
-	return result
+// truncateUTF8 cat s con toi da n bytes ma khong cat giua mot rune (tieng Viet co dau nhieu byte).
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
 }
 
 // extractDueDate phan tich due date tu markdown content.
@@ -72,36 +122,48 @@ func extractDueDate(content string) (time.Time, bool) {
 	return t, true
 }
 
-// humanizeDueDate chuyen due date thanh string de hieu: "ngay mai", "tuan nay", "qua han 3 ngay".
+// DueBucket chuyen due date thanh cum tu de hieu: "ngay mai, tuan nay", "tuan sau", "qua han".
 // Day la key insight: vector capture "tuan nay" → query "deadline tuan nay" match.
-func humanizeDueDate(due, now time.Time) string {
-	// Tinh so ngay chenh lech (bo qua gio)
-	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, due.Location())
-	nowDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+//
+// Cum tu chi phu thuoc vao bucket lich (tuan bat dau tu thu hai, thang), khong co so ngay,
+// nen chi doi khi task sang bucket khac; "" neu due date o xa hon thang sau.
+// due va now duoc so sanh theo ngay lich cua moi ben, khong phu thuoc gio hay timezone.
+func DueBucket(due, now time.Time) string {
+	// Tinh so ngay chenh lech theo ngay lich (bo qua gio va timezone)
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	nowDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 	days := int(dueDay.Sub(nowDay).Hours() / 24)
 
+	// So ngay con lai den chu nhat tuan nay
+	daysUntilWeekEnd := int(time.Sunday-now.Weekday()+7) % 7
+
 	switch {
 	case days < -1:
-		return fmt.Sprintf("qua han %d ngay", -days)
+		return "qua han"
 	case days == -1:
 		return "qua han hom qua"
 	case days == 0:
 		return "hom nay"
-	case days == 1:
-		return "ngay mai, tuan nay"
-	case days <= 6:
-		// Kiem tra co trong tuan nay khong
-		daysUntilWeekEnd := int(time.Sunday-now.Weekday()+7) % 7
-		if days <= daysUntilWeekEnd {
-			return fmt.Sprintf("%d ngay nua, tuan nay", days)
+	case days <= daysUntilWeekEnd:
+		if days == 1 {
+			return "ngay mai, tuan nay"
+		}
+		return "tuan nay"
+	case days <= daysUntilWeekEnd+7:
+		if days == 1 {
+			return "ngay mai, tuan sau"
 		}
-		return fmt.Sprintf("%d ngay nua, tuan sau", days)
-	case days <= 13:
 		return "tuan sau"
-	case days <= 30:
-		return fmt.Sprintf("%d ngay nua, thang nay", days)
+	}
+
+	months := (dueDay.Year()-nowDay.Year())*12 + int(dueDay.Month()-nowDay.Month())
+	switch months {
+	case 0:
+		return "thang nay"
+	case 1:
+		return "thang sau"
 	default:
-		return fmt.Sprintf("%d ngay nua", days)
+		return ""
 	}
 }
 
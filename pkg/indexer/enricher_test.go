@@ -9,12 +9,22 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// fixedNow la thu tu 11/03/2026 luc 9h sang gio Viet Nam, de test khong phu thuoc ngay chay.
+func fixedNow(t *testing.T) time.Time {
+	loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	return time.Date(2026, time.March, 11, 9, 0, 0, 0, loc)
+}
+
 func TestEnrichTaskContent_WithDeadlineTomorrow(t *testing.T) {
-	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	now := fixedNow(t)
+	tomorrow := now.AddDate(0, 0, 1).Format("2006-01-02")
 	content := fmt.Sprintf("## Review PR #123\n\n- **Due:** %s\n- **Priority:** #priority/p1", tomorrow)
 	tags := []string{"#pr/123", "#priority/p1"}
 
-	result := EnrichTaskContent(content, tags, "Asia/Ho_Chi_Minh")
+	result := EnrichTaskContentAt(content, tags, now)
 
 	assert.Contains(t, result, "Review PR #123")
 	assert.Contains(t, result, "ngay mai")
@@ -22,39 +32,45 @@ func TestEnrichTaskContent_WithDeadlineTomorrow(t *testing.T) {
 }
 
 func TestEnrichTaskContent_WithDeadlineThisWeek(t *testing.T) {
-	// Tim ngay cuoi tuan (chu nhat)
-	now := time.Now()
-	daysUntilSunday := int(time.Sunday-now.Weekday()+7) % 7
-	if daysUntilSunday == 0 {
-		daysUntilSunday = 7 // neu hom nay la chu nhat, lay chu nhat tuan sau
-	}
-	thisWeekDay := now.AddDate(0, 0, daysUntilSunday-1) // lay thu bay tuan nay
-	deadline := thisWeekDay.Format("2006-01-02")
-	content := fmt.Sprintf("## Task tuan nay\n\n- **Due:** %s\n", deadline)
+	now := fixedNow(t)
+	saturday := now.AddDate(0, 0, 3).Format("2006-01-02")
+	content := fmt.Sprintf("## Task tuan nay\n\n- **Due:** %s\n", saturday)
 
-	result := EnrichTaskContent(content, nil, "Asia/Ho_Chi_Minh")
+	result := EnrichTaskContentAt(content, nil, now)
 
-	assert.Contains(t, result, "tuan")
+	assert.Contains(t, result, "Deadline: 14/03/2026 (tuan nay)")
 }
 
 func TestEnrichTaskContent_Overdue(t *testing.T) {
-	past := time.Now().AddDate(0, 0, -3).Format("2006-01-02")
+	now := fixedNow(t)
+	past := now.AddDate(0, 0, -3).Format("2006-01-02")
 	content := fmt.Sprintf("## Old Task\n\n- **Due:** %s\n", past)
 
-	result := EnrichTaskContent(content, nil, "Asia/Ho_Chi_Minh")
+	result := EnrichTaskContentAt(content, nil, now)
 
 	assert.Contains(t, result, "qua han")
 }
 
 func TestEnrichTaskContent_Today(t *testing.T) {
-	today := time.Now().Format("2006-01-02")
+	now := fixedNow(t)
+	today := now.Format("2006-01-02")
 	content := fmt.Sprintf("## Task hom nay\n\n- **Due:** %s\n", today)
 
-	result := EnrichTaskContent(content, nil, "Asia/Ho_Chi_Minh")
+	result := EnrichTaskContentAt(content, nil, now)
 
 	assert.Contains(t, result, "hom nay")
 }
 
+func TestEnrichTaskContent_FarDeadlineHasNoBucket(t *testing.T) {
+	now := fixedNow(t)
+	content := "## Gia han ten mien\n\n- **Due:** 2026-09-01\n"
+
+	result := EnrichTaskContentAt(content, nil, now)
+
+	assert.Contains(t, result, "Deadline: 01/09/2026")
+	assert.NotContains(t, result, "(")
+}
+
 func TestEnrichTaskContent_NoDeadline(t *testing.T) {
 	content := "## Task khong co deadline\n\nChi la mot task binh thuong."
 
@@ -101,25 +117,55 @@ func TestEnrichTaskContent_StripMarkdown(t *testing.T) {
 	assert.NotContains(t, result, "```")
 }
 
-func TestHumanizeDueDate(t *testing.T) {
-	now := time.Now()
+func TestDueBucket(t *testing.T) {
+	now := fixedNow(t) // thu tu
 
 	tests := []struct {
-		days     int
-		contains string
+		days int
+		want string
 	}{
 		{-5, "qua han"},
 		{-1, "qua han hom qua"},
 		{0, "hom nay"},
-		{1, "ngay mai"},
-		{10, "tuan sau"},
+		{1, "ngay mai, tuan nay"},
+		{4, "tuan nay"},   // chu nhat
+		{5, "tuan sau"},   // thu hai tuan sau
+		{10, "tuan sau"},  // thu bay tuan sau
+		{12, "thang nay"}, // thu hai 23/03
+		{21, "thang sau"}, // 01/04
+		{60, ""},          // 10/05
 	}
 
 	for _, tt := range tests {
 		due := now.AddDate(0, 0, tt.days)
-		result := humanizeDueDate(due, now)
-		assert.Contains(t, result, tt.contains, "days=%d", tt.days)
+		assert.Equal(t, tt.want, DueBucket(due, now), "days=%d", tt.days)
 	}
+
+	t.Run("tomorrow on sunday is next week", func(t *testing.T) {
+		sunday := now.AddDate(0, 0, 4)
+		assert.Equal(t, "ngay mai, tuan sau", DueBucket(sunday.AddDate(0, 0, 1), sunday))
+	})
+
+	t.Run("compares calendar dates, not durations", func(t *testing.T) {
+		// Due date parse tu markdown la nua dem UTC; 23h toi hom truoc van la "ngay mai"
+		due := time.Date(2026, time.March, 12, 0, 0, 0, 0, time.UTC)
+		late := time.Date(2026, time.March, 11, 23, 30, 0, 0, now.Location())
+		assert.Equal(t, "ngay mai, tuan nay", DueBucket(due, late))
+		assert.Equal(t, "hom nay", DueBucket(due, late.Add(time.Hour)))
+	})
+
+	t.Run("bucket only changes at bucket boundaries", func(t *testing.T) {
+		due := time.Date(2026, time.March, 27, 0, 0, 0, 0, time.UTC) // thu sau
+		assert.Equal(t, DueBucket(due, now), DueBucket(due, now.AddDate(0, 0, 2)))
+		assert.Equal(t, "tuan sau", DueBucket(due, now.AddDate(0, 0, 5)))
+		assert.Equal(t, "tuan nay", DueBucket(due, now.AddDate(0, 0, 12)))
+	})
+}
+
+func TestTemporalBucket(t *testing.T) {
+	now := fixedNow(t)
+	assert.Equal(t, "tuan nay", TemporalBucket("## Task\n\n- **Due:** 2026-03-14", now))
+	assert.Empty(t, TemporalBucket("## Task khong co deadline", now))
 }
 
 func TestExtractDueDate(t *testing.T) {
@@ -0,0 +1,69 @@
+package indexer_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"autonomous-task-management/pkg/embedding"
+	"autonomous-task-management/pkg/indexer"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func cosine(a, b []float32) float64 {
+	var dot float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+	}
+	return dot
+}
+
+// A task due next Friday is embedded on Wednesday. By Monday its text must say "tuan nay",
+// and the query "deadline tuần này" must rank the refreshed text above the stale one.
+func TestTemporalRefresh_ThisWeekQueryStillMatchesDaysLater(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	content := "## Nop bao cao quy\n\n- **Due:** 2026-03-20\n- **Priority:** p1"
+	tags := []string{"#project/finance"}
+	embeddedAt := time.Date(2026, time.March, 11, 9, 0, 0, 0, loc) // thu tu
+	monday := time.Date(2026, time.March, 16, 8, 0, 0, 0, loc)
+
+	stale := indexer.EnrichTaskContentAt(content, tags, embeddedAt)
+	assert.Contains(t, stale, "tuan sau")
+	assert.Equal(t, "tuan sau", indexer.TemporalBucket(content, embeddedAt))
+
+	// Same bucket for the rest of the week: nothing to re-embed
+	assert.Equal(t, "tuan sau", indexer.TemporalBucket(content, embeddedAt.AddDate(0, 0, 4)))
+
+	// Monday: the bucket moved, so the refresh job re-embeds the task
+	require.Equal(t, "tuan nay", indexer.TemporalBucket(content, monday))
+	fresh := indexer.EnrichTaskContentAt(content, tags, monday)
+	assert.Contains(t, fresh, "Deadline: 20/03/2026 (tuan nay)")
+	assert.NotContains(t, fresh, "tuan sau")
+
+	e, err := embedding.NewLocal(512)
+	require.NoError(t, err)
+	vectors, err := e.Embed(context.Background(), []string{"deadline tuần này", stale, fresh})
+	require.NoError(t, err)
+	query, staleVec, freshVec := vectors[0], vectors[1], vectors[2]
+	assert.Greater(t, cosine(query, freshVec), cosine(query, staleVec))
+
+	// Every chunk of a long task carries the refreshed deadline too
+	var long strings.Builder
+	long.WriteString(content + "\n\n### Ghi chu\n\n")
+	for i := range 40 {
+		fmt.Fprintf(&long, "- Ghi chu %d: doi chieu so lieu doanh thu voi bo phan ke toan truoc khi nop\n", i)
+	}
+	require.Greater(t, long.Len(), 2*indexer.MaxEnrichedBytes)
+	chunks := indexer.ChunkTaskContentAt(long.String(), tags, monday)
+	require.Greater(t, len(chunks), 1)
+	for i, c := range chunks {
+		assert.Contains(t, c.Text, "Deadline: 20/03/2026 (tuan nay)", "chunk %d", i)
+	}
+}
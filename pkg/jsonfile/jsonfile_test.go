@@ -0,0 +1,37 @@
+package jsonfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "subs.json")
+
+	// A missing file leaves the value as it was
+	got := map[string]int{"kept": 1}
+	require.NoError(t, Load(path, &got))
+	assert.Equal(t, map[string]int{"kept": 1}, got)
+
+	require.NoError(t, Save(path, map[string]int{"a": 1, "b": 2}))
+	got = nil
+	require.NoError(t, Load(path, &got))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, got)
+
+	// No temp files are left next to the state
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestLoad_CorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subs.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o644))
+
+	var got map[string]int
+	assert.Error(t, Load(path, &got))
+}
@@ -0,0 +1,142 @@
+package qdrant
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ListCollections returns the names of all collections.
+func (c *Client) ListCollections(ctx context.Context) ([]string, error) {
+	var result struct {
+		Result struct {
+			Collections []struct {
+				Name string `json:"name"`
+			} `json:"collections"`
+		} `json:"result"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/collections", c.baseURL), &result); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(result.Result.Collections))
+	for _, col := range result.Result.Collections {
+		names = append(names, col.Name)
+	}
+	return names, nil
+}
+
+// DeleteCollection drops a collection and all its points.
+func (c *Client) DeleteCollection(ctx context.Context, collectionName string) error {
+	url := fmt.Sprintf("%s/collections/%s", c.baseURL, collectionName)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to call qdrant API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("qdrant API error: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// ListAliases returns every alias with the collection it points to.
+func (c *Client) ListAliases(ctx context.Context) ([]Alias, error) {
+	var result struct {
+		Result struct {
+			Aliases []Alias `json:"aliases"`
+		} `json:"result"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/aliases", c.baseURL), &result); err != nil {
+		return nil, err
+	}
+	return result.Result.Aliases, nil
+}
+
+// ResolveAlias returns the collection an alias points to, or "" if there is no such alias.
+func (c *Client) ResolveAlias(ctx context.Context, alias string) (string, error) {
+	aliases, err := c.ListAliases(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, a := range aliases {
+		if a.AliasName == alias {
+			return a.CollectionName, nil
+		}
+	}
+	return "", nil
+}
+
+// UpdateAliases applies the actions in one atomic operation: readers of an alias see either
+// the old or the new collection, never neither.
+func (c *Client) UpdateAliases(ctx context.Context, actions []AliasAction) error {
+	url := fmt.Sprintf("%s/collections/aliases", c.baseURL)
+
+	body, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to call qdrant API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("qdrant API error: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SwitchAlias points alias at collectionName, creating the alias if needed.
+func (c *Client) SwitchAlias(ctx context.Context, alias, collectionName string) error {
+	current, err := c.ResolveAlias(ctx, alias)
+	if err != nil {
+		return err
+	}
+
+	var actions []AliasAction
+	if current != "" {
+		// Qdrant rejects deleting an alias that does not exist
+		actions = append(actions, AliasAction{DeleteAlias: &DeleteAliasOp{AliasName: alias}})
+	}
+	actions = append(actions, AliasAction{CreateAlias: &CreateAliasOp{CollectionName: collectionName, AliasName: alias}})
+	return c.UpdateAliases(ctx, actions)
+}
+
+func (c *Client) getJSON(ctx context.Context, url string, out interface{}) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to call qdrant API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("qdrant API error: %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 )
 
+// ErrNotFound is returned when the requested collection does not exist.
+var ErrNotFound = errors.New("qdrant: collection not found")
+
 // Client is the Qdrant HTTP API client.
 type Client struct {
 	baseURL    string
@@ -51,6 +55,39 @@ func (c *Client) CreateCollection(ctx context.Context, req CreateCollectionReque
 	return nil
 }
 
+// GetCollection describes a collection. It returns ErrNotFound if the collection does not exist.
+func (c *Client) GetCollection(ctx context.Context, collectionName string) (*CollectionInfo, error) {
+	url := fmt.Sprintf("%s/collections/%s", c.baseURL, collectionName)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call qdrant API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("qdrant API error: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Result CollectionInfo `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result.Result, nil
+}
+
 // UpsertPoints inserts or updates points (vectors) in a collection.
 func (c *Client) UpsertPoints(ctx context.Context, collectionName string, req UpsertPointsRequest) error {
 	url := fmt.Sprintf("%s/collections/%s/points", c.baseURL, collectionName)
@@ -112,8 +149,80 @@ func (c *Client) SearchPoints(ctx context.Context, collectionName string, req Se
 	return &result, nil
 }
 
+// SearchPointsBatch runs several semantic searches in one request.
+func (c *Client) SearchPointsBatch(ctx context.Context, collectionName string, req SearchBatchRequest) (*SearchBatchResponse, error) {
+	url := fmt.Sprintf("%s/collections/%s/points/search/batch", c.baseURL, collectionName)
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call qdrant API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("qdrant API error: %d", resp.StatusCode)
+	}
+
+	var result SearchBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result.Result) != len(req.Searches) {
+		return nil, fmt.Errorf("qdrant returned %d result lists for %d searches", len(result.Result), len(req.Searches))
+	}
+
+	return &result, nil
+}
+
+// GetPoints retrieves points by ID. Missing IDs are left out of the result.
+func (c *Client) GetPoints(ctx context.Context, collectionName string, req GetPointsRequest) ([]ScoredPoint, error) {
+	url := fmt.Sprintf("%s/collections/%s/points", c.baseURL, collectionName)
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call qdrant API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("qdrant API error: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Result []ScoredPoint `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Result, nil
+}
+
 // ScrollPoints fetches points matching a payload filter (no vector needed).
-// Used for full-text keyword search when a text index exists on the field.
+// Used for full-text keyword search when a text index exists on the field, and for
+// filter-only listings: pass Result.NextPageOffset as the next request's Offset until it is nil.
 func (c *Client) ScrollPoints(ctx context.Context, collectionName string, req ScrollRequest) (*ScrollResponse, error) {
 	url := fmt.Sprintf("%s/collections/%s/points/scroll", c.baseURL, collectionName)
 
@@ -146,6 +255,43 @@ func (c *Client) ScrollPoints(ctx context.Context, collectionName string, req Sc
 	return &result, nil
 }
 
+// CountPoints returns the number of points matching a payload filter.
+func (c *Client) CountPoints(ctx context.Context, collectionName string, req CountRequest) (int, error) {
+	url := fmt.Sprintf("%s/collections/%s/points/count", c.baseURL, collectionName)
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to call qdrant API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("qdrant API error: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Result struct {
+			Count int `json:"count"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Result.Count, nil
+}
+
 // CreatePayloadIndex creates an index on a payload field to enable fast filtering/text search.
 func (c *Client) CreatePayloadIndex(ctx context.Context, collectionName string, req CreatePayloadIndexRequest) error {
 	url := fmt.Sprintf("%s/collections/%s/index", c.baseURL, collectionName)
@@ -176,11 +322,16 @@ func (c *Client) CreatePayloadIndex(ctx context.Context, collectionName string,
 
 // DeletePoints deletes points by IDs.
 func (c *Client) DeletePoints(ctx context.Context, collectionName string, ids []string) error {
-	url := fmt.Sprintf("%s/collections/%s/points/delete", c.baseURL, collectionName)
+	return c.deletePoints(ctx, collectionName, DeletePointsRequest{Points: ids})
+}
 
-	req := DeletePointsRequest{
-		Points: ids,
-	}
+// DeletePointsByFilter deletes every point matching a payload filter.
+func (c *Client) DeletePointsByFilter(ctx context.Context, collectionName string, filter map[string]interface{}) error {
+	return c.deletePoints(ctx, collectionName, DeletePointsRequest{Filter: filter})
+}
+
+func (c *Client) deletePoints(ctx context.Context, collectionName string, req DeletePointsRequest) error {
+	url := fmt.Sprintf("%s/collections/%s/points/delete", c.baseURL, collectionName)
 
 	body, err := json.Marshal(req)
 	if err != nil {
@@ -40,6 +40,17 @@ func TestQdrantClient(t *testing.T) {
 			return
 		}
 
+		if r.Method == http.MethodPost && strings.HasSuffix(path, "/points/search/batch") {
+			var req qdrant.SearchBatchRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			results := make([][]qdrant.ScoredPoint, len(req.Searches))
+			for i, s := range req.Searches {
+				results[i] = []qdrant.ScoredPoint{{ID: "123", Score: float64(s.Limit) / 10}}
+			}
+			json.NewEncoder(w).Encode(qdrant.SearchBatchResponse{Result: results})
+			return
+		}
+
 		if r.Method == http.MethodPost && strings.Contains(path, "/points/search") {
 			var req qdrant.SearchRequest
 			json.NewDecoder(r.Body).Decode(&req)
@@ -70,6 +81,10 @@ func TestQdrantClient(t *testing.T) {
 				w.WriteHeader(http.StatusInternalServerError)
 				return
 			}
+			if len(req.Points) == 0 && req.Filter == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -148,6 +163,18 @@ func TestQdrantClient(t *testing.T) {
 		}
 	})
 
+	t.Run("SearchPointsBatch Success", func(t *testing.T) {
+		resp, err := client.SearchPointsBatch(context.Background(), "test_col", qdrant.SearchBatchRequest{
+			Searches: []qdrant.SearchRequest{{Limit: 3}, {Limit: 5}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resp.Result) != 2 || resp.Result[0][0].Score != 0.3 || resp.Result[1][0].Score != 0.5 {
+			t.Errorf("results should follow the request order: %v", resp.Result)
+		}
+	})
+
 	t.Run("SearchPoints Error", func(t *testing.T) {
 		_, err := client.SearchPoints(context.Background(), "test_col", qdrant.SearchRequest{
 			Limit: 999,
@@ -171,6 +198,15 @@ func TestQdrantClient(t *testing.T) {
 		}
 	})
 
+	t.Run("DeletePointsByFilter Success", func(t *testing.T) {
+		err := client.DeletePointsByFilter(context.Background(), "test_col", map[string]interface{}{
+			"must": []map[string]interface{}{{"key": "memo_id", "match": map[string]interface{}{"value": "memos/1"}}},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
 	t.Run("ScrollPoints Success", func(t *testing.T) {
 		resp, err := client.ScrollPoints(context.Background(), "test_col", qdrant.ScrollRequest{
 			Limit:       10,
@@ -0,0 +1,149 @@
+package qdrant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SchemaPointID is the ID of the point that records a collection's schema version.
+// It carries a zero vector, so it scores 0 against every query.
+const SchemaPointID = "00000000-0000-4000-8000-000000000001"
+
+// Payload keys of the schema point.
+const (
+	schemaVersionKey    = "schema_version"
+	schemaMigrationsKey = "schema_migrations"
+)
+
+// DefaultDistance is the distance metric used for new collections.
+const DefaultDistance = "Cosine"
+
+// ErrVectorSizeMismatch is returned when an existing collection was created for another
+// embedding size. It cannot be migrated in place; the collection has to be rebuilt.
+var ErrVectorSizeMismatch = errors.New("qdrant: collection vector size does not match the configured size")
+
+// Migration is one versioned change to a collection's schema. Up must be idempotent:
+// if recording the version fails, it runs again on the next start.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, c *Client, collectionName string) error
+}
+
+// Schema describes the collection Bootstrap ensures.
+type Schema struct {
+	Collection string
+	VectorSize int
+	Distance   string // Empty = DefaultDistance
+	Migrations []Migration
+}
+
+// BootstrapResult reports what Bootstrap changed.
+type BootstrapResult struct {
+	Created     bool     // The collection did not exist and was created
+	FromVersion int      // Schema version found
+	ToVersion   int      // Schema version after the applied migrations
+	Applied     []string // Names of the migrations applied, in order
+}
+
+// PayloadIndexes returns a migration step creating the given payload indexes.
+func PayloadIndexes(indexes ...CreatePayloadIndexRequest) func(ctx context.Context, c *Client, collectionName string) error {
+	return func(ctx context.Context, c *Client, collectionName string) error {
+		for _, idx := range indexes {
+			if err := c.CreatePayloadIndex(ctx, collectionName, idx); err != nil {
+				return fmt.Errorf("failed to index %s as %s: %w", idx.FieldName, idx.FieldSchema, err)
+			}
+		}
+		return nil
+	}
+}
+
+// Bootstrap creates the collection if it is missing, checks its vector size and applies the
+// migrations newer than the version recorded on the schema point, in version order. The
+// version is recorded after each migration, so a failure leaves the earlier ones applied.
+func Bootstrap(ctx context.Context, c *Client, schema Schema) (BootstrapResult, error) {
+	if schema.Distance == "" {
+		schema.Distance = DefaultDistance
+	}
+	var result BootstrapResult
+
+	info, err := c.GetCollection(ctx, schema.Collection)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		if err := c.CreateCollection(ctx, CreateCollectionRequest{
+			Name:    schema.Collection,
+			Vectors: VectorConfig{Size: schema.VectorSize, Distance: schema.Distance},
+		}); err != nil {
+			return result, fmt.Errorf("failed to create collection %s: %w", schema.Collection, err)
+		}
+		result.Created = true
+	case err != nil:
+		return result, fmt.Errorf("failed to get collection %s: %w", schema.Collection, err)
+	case info.Config.Params.Vectors.Size != schema.VectorSize:
+		return result, fmt.Errorf("%w: %s has %d, expected %d", ErrVectorSizeMismatch,
+			schema.Collection, info.Config.Params.Vectors.Size, schema.VectorSize)
+	}
+
+	version, history, err := readSchemaPoint(ctx, c, schema.Collection)
+	if err != nil {
+		return result, err
+	}
+	result.FromVersion = version
+	result.ToVersion = version
+
+	migrations := append([]Migration(nil), schema.Migrations...)
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	for _, m := range migrations {
+		if m.Version <= version {
+			continue
+		}
+		if err := m.Up(ctx, c, schema.Collection); err != nil {
+			return result, fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+		}
+		history = append(history, map[string]interface{}{
+			"version":    m.Version,
+			"name":       m.Name,
+			"applied_at": time.Now().UTC().Format(time.RFC3339),
+		})
+		if err := writeSchemaPoint(ctx, c, schema, m.Version, history); err != nil {
+			return result, fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+		}
+		version = m.Version
+		result.ToVersion = version
+		result.Applied = append(result.Applied, m.Name)
+	}
+
+	return result, nil
+}
+
+// readSchemaPoint returns the recorded schema version and migration history; 0 if none.
+func readSchemaPoint(ctx context.Context, c *Client, collectionName string) (int, []interface{}, error) {
+	points, err := c.GetPoints(ctx, collectionName, GetPointsRequest{IDs: []string{SchemaPointID}, WithPayload: true})
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if len(points) == 0 {
+		return 0, nil, nil
+	}
+
+	payload := points[0].Payload
+	version, _ := payload[schemaVersionKey].(float64) // JSON numbers decode as float64
+	history, _ := payload[schemaMigrationsKey].([]interface{})
+	return int(version), history, nil
+}
+
+// writeSchemaPoint records the schema version and migration history.
+func writeSchemaPoint(ctx context.Context, c *Client, schema Schema, version int, history []interface{}) error {
+	return c.UpsertPoints(ctx, schema.Collection, UpsertPointsRequest{Points: []Point{{
+		ID:     SchemaPointID,
+		Vector: make([]float32, schema.VectorSize),
+		Payload: map[string]interface{}{
+			schemaVersionKey:    version,
+			schemaMigrationsKey: history,
+		},
+	}}})
+}
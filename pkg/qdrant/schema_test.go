@@ -0,0 +1,190 @@
+package qdrant_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"autonomous-task-management/pkg/qdrant"
+)
+
+// fakeQdrant keeps one collection in memory: its vector size, indexes and points.
+type fakeQdrant struct {
+	mu         sync.Mutex
+	exists     bool
+	vectorSize int
+	indexes    map[string]string
+	points     map[string]qdrant.Point
+	failIndex  string // Field whose index creation fails
+}
+
+func newFakeQdrant() *fakeQdrant {
+	return &fakeQdrant{indexes: make(map[string]string), points: make(map[string]qdrant.Point)}
+}
+
+func (f *fakeQdrant) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/collections/tasks":
+		if !f.exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		info := qdrant.CollectionInfo{Status: "green", PayloadSchema: map[string]qdrant.PayloadFieldInfo{}}
+		info.Config.Params.Vectors = qdrant.VectorConfig{Size: f.vectorSize, Distance: "Cosine"}
+		json.NewEncoder(w).Encode(map[string]interface{}{"result": info})
+
+	case r.Method == http.MethodPut && r.URL.Path == "/collections/tasks":
+		var req qdrant.CreateCollectionRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		f.exists, f.vectorSize = true, req.Vectors.Size
+
+	case r.Method == http.MethodPut && r.URL.Path == "/collections/tasks/index":
+		var req qdrant.CreatePayloadIndexRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.FieldName == f.failIndex {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		f.indexes[req.FieldName] = req.FieldSchema
+
+	case r.Method == http.MethodPut && r.URL.Path == "/collections/tasks/points":
+		var req qdrant.UpsertPointsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		for _, p := range req.Points {
+			if len(p.Vector) != f.vectorSize {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			f.points[p.ID.(string)] = p
+		}
+
+	case r.Method == http.MethodPost && r.URL.Path == "/collections/tasks/points":
+		var req qdrant.GetPointsRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		result := []qdrant.ScoredPoint{}
+		for _, id := range req.IDs {
+			if p, ok := f.points[id]; ok {
+				result = append(result, qdrant.ScoredPoint{ID: id, Payload: p.Payload})
+			}
+		}
+		// Round-trip through JSON so payload numbers come back as float64, as from Qdrant
+		body, _ := json.Marshal(map[string]interface{}{"result": result})
+		w.Write(body)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+var testMigrations = []qdrant.Migration{
+	{Version: 2, Name: "dates", Up: qdrant.PayloadIndexes(
+		qdrant.CreatePayloadIndexRequest{FieldName: "create_time", FieldSchema: "datetime"},
+	)},
+	{Version: 1, Name: "text", Up: qdrant.PayloadIndexes(
+		qdrant.CreatePayloadIndexRequest{FieldName: "content", FieldSchema: "text"},
+		qdrant.CreatePayloadIndexRequest{FieldName: "tags", FieldSchema: "keyword"},
+	)},
+}
+
+func TestBootstrap_CreatesCollectionAndAppliesMigrationsOnce(t *testing.T) {
+	fake := newFakeQdrant()
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+	client := qdrant.NewClient(ts.URL)
+	schema := qdrant.Schema{Collection: "tasks", VectorSize: 4, Migrations: testMigrations}
+
+	result, err := qdrant.Bootstrap(context.Background(), client, schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Created || result.FromVersion != 0 || result.ToVersion != 2 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if strings.Join(result.Applied, ",") != "text,dates" {
+		t.Errorf("migrations applied out of order: %v", result.Applied)
+	}
+	if fake.vectorSize != 4 {
+		t.Errorf("collection created with vector size %d", fake.vectorSize)
+	}
+	if fake.indexes["content"] != "text" || fake.indexes["tags"] != "keyword" || fake.indexes["create_time"] != "datetime" {
+		t.Errorf("unexpected indexes: %v", fake.indexes)
+	}
+
+	// A second start finds the recorded version and does nothing
+	fake.indexes = make(map[string]string)
+	result, err = qdrant.Bootstrap(context.Background(), client, schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Created || result.FromVersion != 2 || len(result.Applied) != 0 || len(fake.indexes) != 0 {
+		t.Errorf("expected no changes, got %+v, indexes %v", result, fake.indexes)
+	}
+
+	// A new migration is picked up on the next start
+	schema.Migrations = append(schema.Migrations, qdrant.Migration{Version: 3, Name: "memo id", Up: qdrant.PayloadIndexes(
+		qdrant.CreatePayloadIndexRequest{FieldName: "memo_id", FieldSchema: "keyword"},
+	)})
+	result, err = qdrant.Bootstrap(context.Background(), client, schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.FromVersion != 2 || result.ToVersion != 3 || fake.indexes["memo_id"] != "keyword" {
+		t.Errorf("unexpected result: %+v, indexes %v", result, fake.indexes)
+	}
+	history, _ := fake.points[qdrant.SchemaPointID].Payload["schema_migrations"].([]interface{})
+	if len(history) != 3 {
+		t.Errorf("expected 3 recorded migrations, got %v", history)
+	}
+}
+
+func TestBootstrap_FailedMigrationKeepsEarlierVersions(t *testing.T) {
+	fake := newFakeQdrant()
+	fake.failIndex = "create_time"
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+	client := qdrant.NewClient(ts.URL)
+	schema := qdrant.Schema{Collection: "tasks", VectorSize: 4, Migrations: testMigrations}
+
+	result, err := qdrant.Bootstrap(context.Background(), client, schema)
+	if err == nil || !strings.Contains(err.Error(), "migration 2 (dates)") {
+		t.Fatalf("expected migration 2 to fail, got %v", err)
+	}
+	if result.ToVersion != 1 {
+		t.Errorf("expected version 1 to be recorded, got %d", result.ToVersion)
+	}
+
+	// The failed migration is retried on the next start
+	fake.failIndex = ""
+	result, err = qdrant.Bootstrap(context.Background(), client, schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.FromVersion != 1 || strings.Join(result.Applied, ",") != "dates" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestBootstrap_VectorSizeMismatch(t *testing.T) {
+	fake := newFakeQdrant()
+	fake.exists, fake.vectorSize = true, 768
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	_, err := qdrant.Bootstrap(context.Background(), qdrant.NewClient(ts.URL), qdrant.Schema{
+		Collection: "tasks", VectorSize: 1024, Migrations: testMigrations,
+	})
+	if !errors.Is(err, qdrant.ErrVectorSizeMismatch) {
+		t.Fatalf("expected ErrVectorSizeMismatch, got %v", err)
+	}
+	if len(fake.indexes) != 0 {
+		t.Errorf("no migration should run on a mismatched collection, got %v", fake.indexes)
+	}
+}
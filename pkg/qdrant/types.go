@@ -38,22 +38,35 @@ type SearchResponse struct {
 	Result []ScoredPoint `json:"result"`
 }
 
+// SearchBatchRequest runs several searches in one call.
+type SearchBatchRequest struct {
+	Searches []SearchRequest `json:"searches"`
+}
+
+// SearchBatchResponse holds the results of each search, in request order.
+type SearchBatchResponse struct {
+	Result [][]ScoredPoint `json:"result"`
+}
+
 // ScoredPoint is a search result with similarity score.
 type ScoredPoint struct {
 	ID      string                 `json:"id"`
 	Score   float64                `json:"score"`
 	Payload map[string]interface{} `json:"payload"`
+	Vector  []float32              `json:"vector,omitempty"` // Only when requested with with_vector
 }
 
-// DeletePointsRequest is the request to delete points.
+// DeletePointsRequest is the request to delete points, either by IDs or by payload filter.
 type DeletePointsRequest struct {
-	Points []string `json:"points"`
+	Points []string               `json:"points,omitempty"`
+	Filter map[string]interface{} `json:"filter,omitempty"`
 }
 
 // ScrollRequest fetches points by payload filter (used for keyword/text search).
 type ScrollRequest struct {
 	Filter      map[string]interface{} `json:"filter,omitempty"`
 	Limit       int                    `json:"limit"`
+	Offset      interface{}            `json:"offset,omitempty"` // NextPageOffset of the previous page
 	WithPayload bool                   `json:"with_payload"`
 	WithVector  bool                   `json:"with_vector"`
 }
@@ -70,8 +83,66 @@ type ScrollResult struct {
 	NextPageOffset interface{}   `json:"next_page_offset"`
 }
 
+// CountRequest counts the points matching a payload filter.
+type CountRequest struct {
+	Filter map[string]interface{} `json:"filter,omitempty"`
+	Exact  bool                   `json:"exact"`
+}
+
 // CreatePayloadIndexRequest creates an index on a payload field.
 type CreatePayloadIndexRequest struct {
 	FieldName   string `json:"field_name"`
 	FieldSchema string `json:"field_schema"` // "text", "keyword", "integer", etc.
 }
+
+// CollectionInfo describes an existing collection.
+type CollectionInfo struct {
+	Status        string                      `json:"status"`
+	PointsCount   int64                       `json:"points_count"`
+	Config        CollectionConfig            `json:"config"`
+	PayloadSchema map[string]PayloadFieldInfo `json:"payload_schema"` // Indexed payload fields by name
+}
+
+// CollectionConfig is the configuration part of CollectionInfo.
+type CollectionConfig struct {
+	Params CollectionParams `json:"params"`
+}
+
+// CollectionParams holds the vector settings of a collection.
+type CollectionParams struct {
+	Vectors VectorConfig `json:"vectors"`
+}
+
+// PayloadFieldInfo describes an indexed payload field.
+type PayloadFieldInfo struct {
+	DataType string `json:"data_type"` // "text", "keyword", "datetime", etc.
+}
+
+// GetPointsRequest retrieves points by ID.
+type GetPointsRequest struct {
+	IDs         []string `json:"ids"`
+	WithPayload bool     `json:"with_payload"`
+}
+
+// Alias is a second name for a collection.
+type Alias struct {
+	AliasName      string `json:"alias_name"`
+	CollectionName string `json:"collection_name"`
+}
+
+// AliasAction is one step of an atomic alias update; set exactly one field.
+type AliasAction struct {
+	CreateAlias *CreateAliasOp `json:"create_alias,omitempty"`
+	DeleteAlias *DeleteAliasOp `json:"delete_alias,omitempty"`
+}
+
+// CreateAliasOp creates an alias for a collection.
+type CreateAliasOp struct {
+	CollectionName string `json:"collection_name"`
+	AliasName      string `json:"alias_name"`
+}
+
+// DeleteAliasOp deletes an alias.
+type DeleteAliasOp struct {
+	AliasName string `json:"alias_name"`
+}
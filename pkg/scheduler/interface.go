@@ -0,0 +1,27 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	pkgLog "autonomous-task-management/pkg/log"
+)
+
+// Job is a unit of background work. Errors are logged, never fatal.
+type Job func(ctx context.Context) error
+
+// IScheduler runs named jobs periodically in the background.
+// Implementations are safe for concurrent use.
+type IScheduler interface {
+	// Every registers job to run every interval once the scheduler is started.
+	Every(name string, interval time.Duration, job Job)
+	// Start launches all registered jobs; it returns immediately.
+	Start(ctx context.Context)
+	// Stop cancels all jobs and waits for in-flight runs to finish.
+	Stop()
+}
+
+// New creates a new IScheduler instance.
+func New(l pkgLog.Logger) IScheduler {
+	return newScheduler(l)
+}
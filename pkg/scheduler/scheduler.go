@@ -0,0 +1,114 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pkgLog "autonomous-task-management/pkg/log"
+)
+
+// entry is a registered job and its interval.
+type entry struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+// schedulerImpl runs each job on its own ticker goroutine.
+type schedulerImpl struct {
+	l pkgLog.Logger
+
+	mu      sync.Mutex
+	entries []entry
+	runCtx  context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	started bool
+}
+
+var _ IScheduler = (*schedulerImpl)(nil)
+
+func newScheduler(l pkgLog.Logger) *schedulerImpl {
+	return &schedulerImpl{l: l}
+}
+
+// Every registers a job. Jobs registered after Start are launched immediately.
+func (s *schedulerImpl) Every(name string, interval time.Duration, job Job) {
+	if interval <= 0 || job == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := entry{name: name, interval: interval, job: job}
+	s.entries = append(s.entries, e)
+	if s.started {
+		s.launch(s.runCtx, e)
+	}
+}
+
+// Start launches every registered job with a context derived from ctx.
+func (s *schedulerImpl) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.runCtx, s.cancel = context.WithCancel(ctx)
+	s.started = true
+	for _, e := range s.entries {
+		s.launch(s.runCtx, e)
+	}
+}
+
+// Stop cancels all jobs and waits for running invocations to return.
+func (s *schedulerImpl) Stop() {
+	s.mu.Lock()
+	if !s.started {
+		s.mu.Unlock()
+		return
+	}
+	s.cancel()
+	s.started = false
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// launch runs e on a ticker until ctx is cancelled.
+// Caller must hold s.mu.
+func (s *schedulerImpl) launch(ctx context.Context, e entry) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+
+		s.l.Infof(ctx, "scheduler: job %q started (every %s)", e.name, e.interval)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.run(ctx, e)
+			}
+		}
+	}()
+}
+
+// run executes one invocation, recovering from panics so one bad job cannot kill the process.
+func (s *schedulerImpl) run(ctx context.Context, e entry) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.l.Errorf(ctx, "scheduler: job %q panicked: %v", e.name, r)
+		}
+	}()
+
+	if err := e.job(ctx); err != nil {
+		s.l.Errorf(ctx, "scheduler: job %q failed: %v", e.name, err)
+	}
+}
@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(context.Context, ...any)           {}
+func (noopLogger) Debugf(context.Context, string, ...any)  {}
+func (noopLogger) Info(context.Context, ...any)            {}
+func (noopLogger) Infof(context.Context, string, ...any)   {}
+func (noopLogger) Warn(context.Context, ...any)            {}
+func (noopLogger) Warnf(context.Context, string, ...any)   {}
+func (noopLogger) Error(context.Context, ...any)           {}
+func (noopLogger) Errorf(context.Context, string, ...any)  {}
+func (noopLogger) DPanic(context.Context, ...any)          {}
+func (noopLogger) DPanicf(context.Context, string, ...any) {}
+func (noopLogger) Panic(context.Context, ...any)           {}
+func (noopLogger) Panicf(context.Context, string, ...any)  {}
+func (noopLogger) Fatal(context.Context, ...any)           {}
+func (noopLogger) Fatalf(context.Context, string, ...any)  {}
+
+func TestScheduler_RunsJobsUntilStopped(t *testing.T) {
+	s := New(noopLogger{})
+
+	var calls atomic.Int32
+	s.Every("count", 10*time.Millisecond, func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+
+	s.Start(context.Background())
+	time.Sleep(55 * time.Millisecond)
+	s.Stop()
+
+	got := calls.Load()
+	assert.GreaterOrEqual(t, got, int32(2))
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, got, calls.Load(), "no runs after Stop")
+}
+
+func TestScheduler_SurvivesErrorsAndPanics(t *testing.T) {
+	s := New(noopLogger{})
+
+	var calls atomic.Int32
+	s.Every("flaky", 10*time.Millisecond, func(ctx context.Context) error {
+		n := calls.Add(1)
+		if n == 1 {
+			panic("boom")
+		}
+		return errors.New("still failing")
+	})
+
+	s.Start(context.Background())
+	time.Sleep(45 * time.Millisecond)
+	s.Stop()
+
+	assert.GreaterOrEqual(t, calls.Load(), int32(2))
+}
+
+func TestScheduler_JobRegisteredAfterStart(t *testing.T) {
+	s := New(noopLogger{})
+	s.Start(context.Background())
+	defer s.Stop()
+
+	done := make(chan struct{}, 1)
+	s.Every("late", 5*time.Millisecond, func(ctx context.Context) error {
+		select {
+		case done <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("late job never ran")
+	}
+}
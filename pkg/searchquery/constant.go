@@ -0,0 +1,122 @@
+package searchquery
+
+import "autonomous-task-management/pkg/taskmeta"
+
+// dueKind is a relative due date range.
+type dueKind int
+
+const (
+	dueToday dueKind = iota
+	dueTomorrow
+	dueThisWeek
+	dueNextWeek
+	dueThisMonth
+	dueNextMonth
+	dueOverdue
+)
+
+// Phrases are matched on folded words (lower case, no diacritics); longer phrases are tried first.
+var (
+	duePhrases = map[string]dueKind{
+		"hom nay":    dueToday,
+		"today":      dueToday,
+		"ngay mai":   dueTomorrow,
+		"tomorrow":   dueTomorrow,
+		"tuan nay":   dueThisWeek,
+		"this week":  dueThisWeek,
+		"tuan sau":   dueNextWeek,
+		"tuan toi":   dueNextWeek,
+		"next week":  dueNextWeek,
+		"thang nay":  dueThisMonth,
+		"this month": dueThisMonth,
+		"thang sau":  dueNextMonth,
+		"thang toi":  dueNextMonth,
+		"next month": dueNextMonth,
+		"qua han":    dueOverdue,
+		"tre han":    dueOverdue,
+		"overdue":    dueOverdue,
+	}
+
+	// dueBounds introduce a deadline: "truoc thu 6", "by friday", "before 2026-03-20".
+	// The bool tells whether the day itself is included.
+	dueBounds = map[string]bool{
+		"truoc":  false,
+		"before": false,
+		"by":     true,
+		"den":    true,
+		"until":  true,
+	}
+
+	weekdayPhrases = map[string]int{ // Days after Sunday, like time.Weekday
+		"chu nhat": 0, "sunday": 0,
+		"thu hai": 1, "thu 2": 1, "monday": 1,
+		"thu ba": 2, "thu 3": 2, "tuesday": 2,
+		"thu tu": 3, "thu 4": 3, "wednesday": 3,
+		"thu nam": 4, "thu 5": 4, "thursday": 4,
+		"thu sau": 5, "thu 6": 5, "friday": 5,
+		"thu bay": 6, "thu 7": 6, "saturday": 6,
+	}
+
+	priorityPhrases = map[string][]string{
+		"khan cap":      {"p0"},
+		"urgent":        {"p0"},
+		"uu tien cao":   {"p0", "p1"},
+		"high priority": {"p0", "p1"},
+		"uu tien thap":  {"p3"},
+		"low priority":  {"p3"},
+	}
+
+	// statusOpen stands for "not done" in statusPhrases.
+	statusPhrases = map[string]string{
+		"chua xong":       statusOpen,
+		"chua hoan thanh": statusOpen,
+		"open":            statusOpen,
+		"pending":         statusOpen,
+		"unfinished":      statusOpen,
+		"chua lam":        taskmeta.StatusTodo,
+		"dang lam":        taskmeta.StatusInProgress,
+		"dang thuc hien":  taskmeta.StatusInProgress,
+		"in progress":     taskmeta.StatusInProgress,
+		"da xong":         taskmeta.StatusDone,
+		"da hoan thanh":   taskmeta.StatusDone,
+		"done":            taskmeta.StatusDone,
+		"completed":       taskmeta.StatusDone,
+		"finished":        taskmeta.StatusDone,
+	}
+
+	// stopPhrases carry no meaning for retrieval: "tim cac task ve deploy" searches "deploy".
+	stopPhrases = []string{
+		"tim kiem", "tim", "search", "find", "show", "list", "liet ke",
+		"tat ca", "all", "cac", "nhung", "task", "tasks", "cong viec", "viec",
+		"cua toi", "cho toi", "cua", "my", "nao", "co", "ve", "about", "for", "lien quan", "related to",
+	}
+
+	// dueWords and priorityWords only name the filter next to them: "deadline tuan nay", "priority p1".
+	dueWords      = []string{"deadline", "han chot", "het han", "han", "due"}
+	priorityWords = []string{"priority", "uu tien"}
+)
+
+const statusOpen = "open"
+
+// synonyms are groups of words with the same meaning in Vietnamese and English. A word of
+// the query found in a group expands to the rest of the group.
+var synonyms = [][]string{
+	{"họp", "meeting"},
+	{"báo cáo", "report"},
+	{"triển khai", "deploy"},
+	{"lỗi", "bug"},
+	{"sửa lỗi", "bugfix"},
+	{"hóa đơn", "invoice"},
+	{"thanh toán", "payment"},
+	{"tài liệu", "document", "docs"},
+	{"kiểm thử", "test"},
+	{"phỏng vấn", "interview"},
+	{"khách hàng", "customer", "client"},
+	{"hợp đồng", "contract"},
+	{"thiết kế", "design"},
+	{"ngân sách", "budget"},
+	{"kế hoạch", "plan"},
+	{"đánh giá", "review"},
+	{"dự án", "project"},
+	{"phát hành", "release"},
+}
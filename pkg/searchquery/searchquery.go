@@ -0,0 +1,312 @@
+package searchquery
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"autonomous-task-management/pkg/taskmeta"
+	"autonomous-task-management/pkg/textnorm"
+)
+
+var (
+	priorityRegex = regexp.MustCompile(`^p[0-3]$`)
+
+	// Date layouts accepted after a due bound; layouts without a year mean this year.
+	dateLayouts = []string{taskmeta.DateLayout, "2/1/2006", "2/1"}
+
+	sortedDuePhrases      = longestFirst(keys(duePhrases))
+	sortedDueBounds       = keys(dueBounds)
+	sortedWeekdayPhrases  = longestFirst(keys(weekdayPhrases))
+	sortedPriorityPhrases = longestFirst(keys(priorityPhrases))
+	sortedStatusPhrases   = longestFirst(keys(statusPhrases))
+	sortedStopPhrases     = longestFirst(stopPhrases)
+	sortedDueWords        = longestFirst(dueWords)
+	sortedPriorityWords   = longestFirst(priorityWords)
+)
+
+// Parse takes the structured parts out of a search query: due date phrases ("tuần sau",
+// "before Friday", "quá hạn"), priorities ("p1", "khẩn cấp"), statuses ("chưa xong", "done")
+// and hashtags. What is left, minus filler words, is the text to retrieve with. now anchors
+// relative dates; its location is the user's timezone. Weeks start on Monday.
+func Parse(raw string, now time.Time) Query {
+	q := Query{Raw: raw}
+	toks := tokenize(raw)
+
+	for i := range toks {
+		t := &toks[i]
+		lower := strings.ToLower(t.text)
+		switch {
+		case strings.HasPrefix(lower, taskmeta.TagPrefixPriority):
+			q.Priorities = appendUnique(q.Priorities, strings.TrimPrefix(lower, taskmeta.TagPrefixPriority))
+		case strings.HasPrefix(lower, taskmeta.TagPrefixProject):
+			q.Projects = appendUnique(q.Projects, strings.TrimPrefix(lower, taskmeta.TagPrefixProject))
+		case strings.HasPrefix(lower, taskmeta.TagPrefixStatus):
+			q.Statuses = appendUnique(q.Statuses, strings.TrimPrefix(lower, taskmeta.TagPrefixStatus))
+		case strings.HasPrefix(t.text, "#") && len(t.text) > 1:
+			q.Tags = appendUnique(q.Tags, t.text)
+		case priorityRegex.MatchString(t.folded):
+			q.Priorities = appendUnique(q.Priorities, t.folded)
+		default:
+			continue
+		}
+		t.used = true
+	}
+
+	parseDue(&q, toks, now)
+
+	consume(toks, sortedPriorityPhrases, func(phrase string, _, _ int) bool {
+		for _, p := range priorityPhrases[phrase] {
+			q.Priorities = appendUnique(q.Priorities, p)
+		}
+		return true
+	})
+	consume(toks, sortedStatusPhrases, func(phrase string, _, _ int) bool {
+		if status := statusPhrases[phrase]; status == statusOpen {
+			q.ExcludeStatuses = appendUnique(q.ExcludeStatuses, taskmeta.StatusDone)
+		} else {
+			q.Statuses = appendUnique(q.Statuses, status)
+		}
+		return true
+	})
+
+	always := func(string, int, int) bool { return true }
+	if q.DuePhrase != "" {
+		consume(toks, sortedDueWords, always)
+	}
+	if len(q.Priorities) > 0 {
+		consume(toks, sortedPriorityWords, always)
+	}
+	consume(toks, sortedStopPhrases, always)
+
+	var words []string
+	for _, t := range toks {
+		if !t.used {
+			words = append(words, t.text)
+		}
+	}
+	q.Text = strings.Join(words, " ")
+	q.Expansions = expand(toks)
+	return q
+}
+
+// parseDue reads the first due date phrase of the query into q.
+func parseDue(q *Query, toks []token, now time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// "truoc thu 6", "by 2026-03-20": the bound is followed by a weekday or a date
+	consume(toks, sortedDueBounds, func(bound string, start, end int) bool {
+		if q.DuePhrase != "" {
+			return false
+		}
+		rest := toks[end:]
+		day, n, ok := weekdayOrDate(rest, today)
+		if !ok {
+			return false
+		}
+		q.DueTo = day
+		if dueBounds[bound] {
+			q.DueTo = day.AddDate(0, 0, 1)
+		}
+		q.DuePhrase = phrase(toks[start : end+n])
+		for i := range n {
+			rest[i].used = true
+		}
+		return true
+	})
+
+	consume(toks, sortedDuePhrases, func(p string, start, end int) bool {
+		if q.DuePhrase != "" {
+			return false
+		}
+		monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		firstOfMonth := today.AddDate(0, 0, 1-today.Day())
+		switch duePhrases[p] {
+		case dueToday:
+			q.DueFrom, q.DueTo = today, today.AddDate(0, 0, 1)
+		case dueTomorrow:
+			q.DueFrom, q.DueTo = today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)
+		case dueThisWeek:
+			q.DueFrom, q.DueTo = monday, monday.AddDate(0, 0, 7)
+		case dueNextWeek:
+			q.DueFrom, q.DueTo = monday.AddDate(0, 0, 7), monday.AddDate(0, 0, 14)
+		case dueThisMonth:
+			q.DueFrom, q.DueTo = firstOfMonth, firstOfMonth.AddDate(0, 1, 0)
+		case dueNextMonth:
+			q.DueFrom, q.DueTo = firstOfMonth.AddDate(0, 1, 0), firstOfMonth.AddDate(0, 2, 0)
+		case dueOverdue:
+			q.DueTo = today
+			q.ExcludeStatuses = appendUnique(q.ExcludeStatuses, taskmeta.StatusDone)
+		}
+		q.DuePhrase = phrase(toks[start:end])
+		return true
+	})
+}
+
+// weekdayOrDate reads the weekday or date at the start of toks. A weekday is its next
+// occurrence after today, as in datemath. It returns the day and the number of words read.
+func weekdayOrDate(toks []token, today time.Time) (time.Time, int, bool) {
+	for _, p := range sortedWeekdayPhrases {
+		if n := matchAt(toks, 0, p); n > 0 {
+			days := (weekdayPhrases[p] - int(today.Weekday()) + 7) % 7
+			if days == 0 {
+				days = 7
+			}
+			return today.AddDate(0, 0, days), n, true
+		}
+	}
+	if len(toks) == 0 || toks[0].used {
+		return time.Time{}, 0, false
+	}
+	for _, layout := range dateLayouts {
+		day, err := time.ParseInLocation(layout, toks[0].folded, today.Location())
+		if err != nil {
+			continue
+		}
+		if !strings.Contains(layout, "2006") {
+			day = day.AddDate(today.Year(), 0, 0)
+		}
+		return day, 1, true
+	}
+	return time.Time{}, 0, false
+}
+
+// expand returns the synonyms of the unused words of toks that the query does not contain yet.
+func expand(toks []token) []string {
+	present := map[string]bool{}
+	for _, t := range toks {
+		present[t.folded] = true
+	}
+
+	var members []string
+	group := map[string][]string{}
+	for _, g := range synonyms {
+		for _, m := range g {
+			members = append(members, m)
+			group[m] = g
+		}
+	}
+
+	var out []string
+	seen := make([]bool, len(toks))
+	for i := range toks {
+		for _, m := range longestFirst(members) {
+			n := matchSynonym(toks[i:], seen[i:], m)
+			if n == 0 {
+				continue
+			}
+			for k := range n {
+				seen[i+k] = true
+			}
+			for _, other := range group[m] {
+				if other != m && !present[textnorm.Fold(other)] {
+					out = appendUnique(out, other)
+				}
+			}
+			break
+		}
+	}
+	return out
+}
+
+// matchSynonym matches a synonym at the start of toks. Accented words must match exactly, so
+// "lời" is not "lỗi"; words typed without accents match any accents.
+func matchSynonym(toks []token, seen []bool, member string) int {
+	words := strings.Fields(member)
+	if len(words) > len(toks) {
+		return 0
+	}
+	for k, w := range words {
+		t := toks[k]
+		if t.used || seen[k] {
+			return 0
+		}
+		lower := strings.ToLower(t.text)
+		if lower != w && (lower != t.folded || t.folded != textnorm.Fold(w)) {
+			return 0
+		}
+	}
+	return len(words)
+}
+
+// consume marks the unused words matching each phrase as used when fn accepts the match
+// of toks[start:end].
+func consume(toks []token, phrases []string, fn func(phrase string, start, end int) bool) {
+	for i := range toks {
+		for _, p := range phrases {
+			n := matchAt(toks, i, p)
+			if n == 0 || !fn(p, i, i+n) {
+				continue
+			}
+			for k := i; k < i+n; k++ {
+				toks[k].used = true
+			}
+			break
+		}
+	}
+}
+
+// matchAt returns the number of words of phrase found unused at toks[i:], 0 if it does not match.
+func matchAt(toks []token, i int, phrase string) int {
+	words := strings.Fields(phrase)
+	if i+len(words) > len(toks) {
+		return 0
+	}
+	for k, w := range words {
+		if toks[i+k].used || toks[i+k].folded != w {
+			return 0
+		}
+	}
+	return len(words)
+}
+
+// tokenize splits the query into words, without the punctuation around them.
+func tokenize(raw string) []token {
+	var toks []token
+	for _, f := range strings.Fields(raw) {
+		f = strings.TrimFunc(f, func(r rune) bool {
+			return unicode.IsPunct(r) && r != '#' && r != '/'
+		})
+		if f != "" {
+			toks = append(toks, token{text: f, folded: textnorm.Fold(f)})
+		}
+	}
+	return toks
+}
+
+func phrase(toks []token) string {
+	words := make([]string, 0, len(toks))
+	for _, t := range toks {
+		words = append(words, t.text)
+	}
+	return strings.Join(words, " ")
+}
+
+func keys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// longestFirst sorts phrases by word count, longest first, so "tuan sau" wins over "sau".
+func longestFirst(phrases []string) []string {
+	out := append([]string(nil), phrases...)
+	sort.SliceStable(out, func(i, j int) bool {
+		return len(strings.Fields(out[i])) > len(strings.Fields(out[j]))
+	})
+	return out
+}
+
+func appendUnique(values []string, v string) []string {
+	for _, existing := range values {
+		if existing == v {
+			return values
+		}
+	}
+	return append(values, v)
+}
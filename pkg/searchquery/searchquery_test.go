@@ -0,0 +1,97 @@
+package searchquery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// wednesday is 11/03/2026, 9h in Vietnam.
+var wednesday = time.Date(2026, time.March, 11, 9, 0, 0, 0, time.FixedZone("ICT", 7*3600))
+
+func day(d int) time.Time {
+	return time.Date(2026, time.March, d, 0, 0, 0, 0, wednesday.Location())
+}
+
+func TestParse_DueRanges(t *testing.T) {
+	tests := []struct {
+		query    string
+		from, to time.Time
+		phrase   string
+		text     string
+	}{
+		{"deploy hôm nay", day(11), day(12), "hôm nay", "deploy"},
+		{"ngày mai họp gì", day(12), day(13), "ngày mai", "họp gì"},
+		{"deadline tuần này", day(9), day(16), "tuần này", ""},
+		{"tìm task p1 hạn tuần sau về deploy", day(16), day(23), "tuần sau", "deploy"},
+		{"tasks due next week", day(16), day(23), "next week", ""},
+		{"bao cao thang sau", day(1).AddDate(0, 1, 0), day(1).AddDate(0, 2, 0), "thang sau", "bao cao"},
+		{"report before Friday", time.Time{}, day(13), "before Friday", "report"},
+		{"report by friday", time.Time{}, day(14), "by friday", "report"},
+		{"nộp thuế trước thứ 4", time.Time{}, day(18), "trước thứ 4", "nộp thuế"},
+		{"invoice by 2026-03-20", time.Time{}, day(21), "by 2026-03-20", "invoice"},
+		{"hợp đồng trước 25/03", time.Time{}, day(25), "trước 25/03", "hợp đồng"},
+	}
+	for _, tt := range tests {
+		q := Parse(tt.query, wednesday)
+		assert.Equal(t, tt.from, q.DueFrom, tt.query)
+		assert.Equal(t, tt.to, q.DueTo, tt.query)
+		assert.Equal(t, tt.phrase, q.DuePhrase, tt.query)
+		assert.Equal(t, tt.text, q.Text, tt.query)
+	}
+}
+
+func TestParse_Overdue(t *testing.T) {
+	q := Parse("việc quá hạn của dự án #project/SMAP", wednesday)
+
+	assert.True(t, q.DueFrom.IsZero())
+	assert.Equal(t, day(11), q.DueTo)
+	assert.Equal(t, []string{"done"}, q.ExcludeStatuses)
+	assert.Equal(t, []string{"smap"}, q.Projects)
+	assert.Equal(t, "dự án", q.Text)
+}
+
+func TestParse_PrioritiesStatusesAndTags(t *testing.T) {
+	q := Parse("Urgent tasks chưa xong #pr/123 #priority/P1 p2 migrate DB", wednesday)
+
+	assert.Equal(t, []string{"p1", "p2", "p0"}, q.Priorities)
+	assert.Equal(t, []string{"#pr/123"}, q.Tags)
+	assert.Equal(t, []string{"done"}, q.ExcludeStatuses)
+	assert.Equal(t, "migrate DB", q.Text)
+	assert.True(t, q.HasFilters())
+
+	q = Parse("ưu tiên cao đang làm", wednesday)
+	assert.Equal(t, []string{"p0", "p1"}, q.Priorities)
+	assert.Equal(t, []string{"in_progress"}, q.Statuses)
+	assert.Empty(t, q.Text)
+
+	q = Parse("task đã xong #status/blocked", wednesday)
+	assert.Equal(t, []string{"blocked", "done"}, q.Statuses)
+}
+
+func TestParse_PlainQueryIsUntouched(t *testing.T) {
+	q := Parse("Fix login bug in auth service", wednesday)
+
+	assert.False(t, q.HasFilters())
+	assert.Empty(t, q.DuePhrase)
+	assert.Equal(t, "Fix login bug in auth service", q.Text)
+
+	// Filter words only count next to what they name
+	q = Parse("gia hạn chứng chỉ SSL", wednesday)
+	assert.False(t, q.HasFilters())
+	assert.Equal(t, "gia hạn chứng chỉ SSL", q.Text)
+}
+
+func TestParse_Expansions(t *testing.T) {
+	assert.Equal(t, []string{"meeting", "report"}, Parse("họp báo cáo quý", wednesday).Expansions)
+	assert.Equal(t, []string{"triển khai"}, Parse("deploy staging", wednesday).Expansions)
+	assert.Equal(t, []string{"bugfix"}, Parse("sua loi", wednesday).Expansions)
+
+	// The longer phrase wins, and a word already in the query is not added again
+	assert.Equal(t, []string{"contract"}, Parse("hợp đồng", wednesday).Expansions)
+	assert.Empty(t, Parse("meeting họp", wednesday).Expansions)
+
+	// Accents are significant when typed: "lời" is not "lỗi"
+	assert.Empty(t, Parse("lời nhắn", wednesday).Expansions)
+}
@@ -0,0 +1,32 @@
+package searchquery
+
+import "time"
+
+// Query is a search query split into structured filters and the free text left for retrieval.
+type Query struct {
+	Raw        string   // The query as typed
+	Text       string   // Free text left after the filters were taken out; "" if nothing is left
+	Expansions []string // Words of the other language (vi <-> en) with the meaning of words of Text
+
+	Priorities      []string  // "p0".."p3"
+	Projects        []string  // Values of #project/* tags, lower case
+	Tags            []string  // Other hashtags, as written
+	Statuses        []string  // Only these statuses
+	ExcludeStatuses []string  // None of these statuses
+	DueFrom         time.Time // Due on or after; zero = open
+	DueTo           time.Time // Due before; zero = open
+	DuePhrase       string    // Words the due range was read from, e.g. "tuần sau"
+}
+
+// HasFilters reports whether any structured filter was found in the query.
+func (q Query) HasFilters() bool {
+	return len(q.Priorities) > 0 || len(q.Projects) > 0 || len(q.Tags) > 0 ||
+		len(q.Statuses) > 0 || len(q.ExcludeStatuses) > 0 || !q.DueFrom.IsZero() || !q.DueTo.IsZero()
+}
+
+// token is one word of the query. folded is lower case without diacritics, for matching.
+type token struct {
+	text   string
+	folded string
+	used   bool
+}
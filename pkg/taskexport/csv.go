@@ -0,0 +1,56 @@
+package taskexport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"time"
+
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// csvHeader names the columns; title, description, due, priority, tags and done are the ones
+// the CSV importer reads back.
+var csvHeader = []string{"id", "title", "description", "due", "priority", "tags", "done", "progress", "url", "created", "updated"}
+
+func encodeCSV(tasks []Task) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+
+	for _, t := range tasks {
+		due := ""
+		if !t.Due.IsZero() {
+			due = t.Due.Format(taskmeta.DateLayout)
+		}
+		record := []string{
+			t.ID,
+			t.Title,
+			t.Description,
+			due,
+			t.Priority,
+			strings.Join(t.Tags, " "),
+			strconv.FormatBool(t.Done),
+			strconv.FormatFloat(t.Progress, 'f', 0, 64),
+			t.URL,
+			formatTimestamp(t.Created),
+			formatTimestamp(t.Updated),
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,148 @@
+package taskexport
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icsProdID     = "-//autonomous-task-management//export//EN"
+	icsUIDDomain  = "autonomous-task-management"
+	icsDateLayout = "20060102"
+	icsTimeLayout = "20060102T150405Z"
+	// icsLineLimit is the RFC 5545 line length in octets; longer lines are folded.
+	icsLineLimit = 75
+)
+
+// icsPriority maps p0..p3 onto the RFC 5545 scale, where 1 is the highest.
+var icsPriority = map[string]int{"p0": 1, "p1": 3, "p2": 5, "p3": 7}
+
+// encodeICS writes tasks with a due date as VTODOs, or as all-day VEVENTs when opt.ICSEvents is set.
+func encodeICS(tasks []Task, opt Options) []byte {
+	now := opt.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	stamp := now.UTC().Format(icsTimeLayout)
+
+	var buf bytes.Buffer
+	line := func(name, value string) { writeICSLine(&buf, name+":"+value) }
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", icsProdID)
+	line("CALSCALE", "GREGORIAN")
+	if opt.CalendarName != "" {
+		line("X-WR-CALNAME", escapeICSText(opt.CalendarName))
+	}
+
+	for _, t := range tasks {
+		if t.Due.IsZero() {
+			continue
+		}
+		due := t.Due.Format(icsDateLayout)
+
+		component := "VTODO"
+		if opt.ICSEvents {
+			component = "VEVENT"
+		}
+		line("BEGIN", component)
+		line("UID", icsUID(t.ID))
+		line("DTSTAMP", stamp)
+		line("SUMMARY", escapeICSText(t.Title))
+		if desc := icsDescription(t); desc != "" {
+			line("DESCRIPTION", escapeICSText(desc))
+		}
+		if opt.ICSEvents {
+			line("DTSTART;VALUE=DATE", due)
+			line("DTEND;VALUE=DATE", t.Due.AddDate(0, 0, 1).Format(icsDateLayout))
+			line("TRANSP", "TRANSPARENT")
+		} else {
+			line("DUE;VALUE=DATE", due)
+			if t.Done {
+				line("STATUS", "COMPLETED")
+				line("PERCENT-COMPLETE", "100")
+			} else {
+				line("STATUS", "NEEDS-ACTION")
+				line("PERCENT-COMPLETE", fmt.Sprintf("%.0f", t.Progress))
+			}
+		}
+		if p, ok := icsPriority[t.Priority]; ok {
+			line("PRIORITY", fmt.Sprint(p))
+		}
+		if len(t.Tags) > 0 {
+			categories := make([]string, 0, len(t.Tags))
+			for _, tag := range t.Tags {
+				categories = append(categories, escapeICSText(strings.TrimPrefix(tag, "#")))
+			}
+			line("CATEGORIES", strings.Join(categories, ","))
+		}
+		if t.URL != "" {
+			line("URL", t.URL)
+		}
+		if !t.Created.IsZero() {
+			line("CREATED", t.Created.UTC().Format(icsTimeLayout))
+		}
+		if !t.Updated.IsZero() {
+			line("LAST-MODIFIED", t.Updated.UTC().Format(icsTimeLayout))
+		}
+		line("END", component)
+	}
+
+	line("END", "VCALENDAR")
+	return buf.Bytes()
+}
+
+// icsDescription is the task description followed by its checklist.
+func icsDescription(t Task) string {
+	parts := []string{}
+	if d := strings.TrimSpace(t.Description); d != "" {
+		parts = append(parts, d)
+	}
+	if len(t.Checklist) > 0 {
+		lines := make([]string, 0, len(t.Checklist))
+		for _, c := range t.Checklist {
+			lines = append(lines, checkbox(c.Done)+" "+c.Text)
+		}
+		parts = append(parts, strings.Join(lines, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// icsUID turns a task ID such as "memos/abc" into a globally unique UID.
+func icsUID(id string) string {
+	return strings.ReplaceAll(id, "/", "-") + "@" + icsUIDDomain
+}
+
+// escapeICSText escapes a TEXT value as required by RFC 5545 section 3.3.11.
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeICSLine writes a content line terminated by CRLF, folding it every icsLineLimit octets
+// without splitting a UTF-8 sequence.
+func writeICSLine(buf *bytes.Buffer, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = icsLineLimit - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
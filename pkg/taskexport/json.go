@@ -0,0 +1,25 @@
+package taskexport
+
+import (
+	"encoding/json"
+
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// jsonTask writes the due date as a plain calendar day rather than a timestamp.
+type jsonTask struct {
+	Task
+	Due string `json:"due,omitempty"`
+}
+
+func encodeJSON(tasks []Task) ([]byte, error) {
+	out := make([]jsonTask, 0, len(tasks))
+	for _, t := range tasks {
+		jt := jsonTask{Task: t}
+		if !t.Due.IsZero() {
+			jt.Due = t.Due.Format(taskmeta.DateLayout)
+		}
+		out = append(out, jt)
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
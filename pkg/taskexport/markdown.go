@@ -0,0 +1,57 @@
+package taskexport
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"autonomous-task-management/pkg/taskmeta"
+)
+
+// encodeMarkdown writes one checklist line per task in the inline syntax of the Markdown importer
+// ("due:2026-03-15 p1 #tag"), with checklist items and the description indented below it.
+func encodeMarkdown(tasks []Task) []byte {
+	var buf bytes.Buffer
+	for _, t := range tasks {
+		buf.WriteString("- ")
+		buf.WriteString(checkbox(t.Done))
+		buf.WriteString(" ")
+		buf.WriteString(oneLine(t.Title))
+		if !t.Due.IsZero() {
+			fmt.Fprintf(&buf, " due:%s", t.Due.Format(taskmeta.DateLayout))
+		}
+		if t.Priority != "" {
+			buf.WriteString(" " + t.Priority)
+		}
+		for _, tag := range t.Tags {
+			// Priority and status are carried by the line itself
+			if strings.HasPrefix(tag, taskmeta.TagPrefixPriority) || strings.HasPrefix(tag, taskmeta.TagPrefixStatus) {
+				continue
+			}
+			buf.WriteString(" " + tag)
+		}
+		buf.WriteString("\n")
+
+		for _, c := range t.Checklist {
+			fmt.Fprintf(&buf, "  - %s %s\n", checkbox(c.Done), oneLine(c.Text))
+		}
+		for _, line := range strings.Split(t.Description, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				buf.WriteString("  " + line + "\n")
+			}
+		}
+	}
+	return buf.Bytes()
+}
+
+func checkbox(done bool) string {
+	if done {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+// oneLine collapses whitespace, including newlines, into single spaces.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,60 @@
+// Package taskexport writes tasks as Markdown, CSV, JSON or iCalendar files.
+package taskexport
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Encode renders tasks in the given format.
+func Encode(format Format, tasks []Task, opt Options) ([]byte, error) {
+	switch format {
+	case FormatMarkdown:
+		return encodeMarkdown(tasks), nil
+	case FormatCSV:
+		return encodeCSV(tasks)
+	case FormatJSON:
+		return encodeJSON(tasks)
+	case FormatICS:
+		return encodeICS(tasks, opt), nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+// ParseFormat accepts a format name or a file extension ("md", ".ics", "ical", ...).
+func ParseFormat(name string) (Format, bool) {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(name)), ".") {
+	case "markdown", "md":
+		return FormatMarkdown, true
+	case "csv":
+		return FormatCSV, true
+	case "json":
+		return FormatJSON, true
+	case "ics", "ical", "icalendar":
+		return FormatICS, true
+	}
+	return "", false
+}
+
+// Extension is the file extension, without the dot, used for files in format.
+func (f Format) Extension() string {
+	if f == FormatMarkdown {
+		return "md"
+	}
+	return string(f)
+}
+
+// ContentType is the MIME type of files in format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSON:
+		return "application/json"
+	case FormatICS:
+		return "text/calendar; charset=utf-8"
+	}
+	return "application/octet-stream"
+}
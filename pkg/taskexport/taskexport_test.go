@@ -0,0 +1,129 @@
+package taskexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"autonomous-task-management/pkg/taskimport"
+)
+
+var sampleTasks = []Task{
+	{
+		ID:          "memos/abc",
+		Title:       "Finish report",
+		Description: "Numbers for Q1, see sheet",
+		Due:         time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		Priority:    "p1",
+		Tags:        []string{"#priority/p1", "#project/smap"},
+		Checklist:   []ChecklistItem{{Text: "collect data", Done: true}, {Text: "write summary"}},
+		Progress:    50,
+		URL:         "http://memos/m/abc",
+		Created:     time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:    "memos/def",
+		Title: "Call Ahamove",
+		Tags:  []string{"#status/done"},
+		Done:  true,
+	},
+}
+
+func TestEncodeMarkdown_RoundTripsThroughImporter(t *testing.T) {
+	data, err := Encode(FormatMarkdown, sampleTasks, Options{})
+	require.NoError(t, err)
+
+	items, err := taskimport.Parse(taskimport.FormatMarkdown, "", data, nil)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "Finish report", items[0].Title)
+	assert.Equal(t, "p1", items[0].Priority)
+	assert.Equal(t, sampleTasks[0].Due, items[0].Due)
+	assert.Equal(t, []string{"#project/smap"}, items[0].Tags)
+	assert.Equal(t, "Numbers for Q1, see sheet", items[0].Description)
+	assert.Len(t, items[0].Checklist, 2)
+	assert.True(t, items[1].Done)
+}
+
+func TestEncodeCSV_RoundTripsThroughImporter(t *testing.T) {
+	data, err := Encode(FormatCSV, sampleTasks, Options{})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "id,title,description,due,priority,tags,done"))
+
+	items, err := taskimport.Parse(taskimport.FormatCSV, "", data, nil)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Numbers for Q1, see sheet", items[0].Description)
+	assert.Equal(t, "p1", items[0].Priority)
+	assert.True(t, items[1].Done)
+}
+
+func TestEncodeJSON_DueAsCalendarDay(t *testing.T) {
+	data, err := Encode(FormatJSON, sampleTasks, Options{})
+	require.NoError(t, err)
+
+	var out []map[string]any
+	require.NoError(t, json.Unmarshal(data, &out))
+	require.Len(t, out, 2)
+	assert.Equal(t, "2026-03-15", out[0]["due"])
+	assert.NotContains(t, out[1], "due")
+	assert.Equal(t, true, out[1]["done"])
+}
+
+func TestEncodeICS_TodosOnlyForTasksWithDueDate(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	data, err := Encode(FormatICS, sampleTasks, Options{Now: now, CalendarName: "Tasks"})
+	require.NoError(t, err)
+	ics := string(data)
+
+	assert.Equal(t, 1, strings.Count(ics, "BEGIN:VTODO"))
+	assert.Contains(t, ics, "UID:memos-abc@autonomous-task-management\r\n")
+	assert.Contains(t, ics, "DTSTAMP:20260302T100000Z\r\n")
+	assert.Contains(t, ics, "DUE;VALUE=DATE:20260315\r\n")
+	assert.Contains(t, ics, "PRIORITY:3\r\n")
+	assert.Contains(t, ics, "STATUS:NEEDS-ACTION\r\n")
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	assert.Contains(t, unfolded, `DESCRIPTION:Numbers for Q1\, see sheet\n\n[x] collect data\n[ ] write summary`)
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), icsLineLimit, line)
+	}
+
+	events, err := Encode(FormatICS, sampleTasks, Options{Now: now, ICSEvents: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(events), "DTSTART;VALUE=DATE:20260315\r\nDTEND;VALUE=DATE:20260316\r\n")
+	assert.NotContains(t, string(events), "VTODO")
+}
+
+func TestWriteICSLine_FoldsWithoutSplittingRunes(t *testing.T) {
+	long := "SUMMARY:" + strings.Repeat("Kiểm tra ", 20)
+	var buf bytes.Buffer
+	writeICSLine(&buf, long)
+
+	folded := strings.TrimSuffix(buf.String(), "\r\n")
+	assert.Equal(t, long, strings.ReplaceAll(folded, "\r\n ", ""))
+	for _, line := range strings.Split(folded, "\r\n") {
+		assert.True(t, utf8.ValidString(line), line)
+		assert.LessOrEqual(t, len(line), icsLineLimit)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"md": FormatMarkdown, ".ICS": FormatICS, "ical": FormatICS, "json": FormatJSON} {
+		got, ok := ParseFormat(in)
+		assert.True(t, ok, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, ok := ParseFormat("xlsx")
+	assert.False(t, ok)
+
+	_, err := Encode("xlsx", nil, Options{})
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
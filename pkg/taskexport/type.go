@@ -0,0 +1,51 @@
+package taskexport
+
+import (
+	"errors"
+	"time"
+)
+
+// Format is the kind of file produced by an export.
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown" // Checklist lines that the Markdown importer reads back
+	FormatCSV      Format = "csv"      // One row per task, with the columns the CSV importer reads
+	FormatJSON     Format = "json"     // Array of Task objects
+	FormatICS      Format = "ics"      // iCalendar; only tasks with a due date are written
+)
+
+var ErrUnknownFormat = errors.New("taskexport: unknown format")
+
+// Task is one exported task, already extracted from the memo markdown.
+type Task struct {
+	ID          string          `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	Due         time.Time       `json:"-"` // Calendar day; zero when the task has no due date
+	Priority    string          `json:"priority,omitempty"`
+	Tags        []string        `json:"tags,omitempty"`
+	Checklist   []ChecklistItem `json:"checklist,omitempty"`
+	Done        bool            `json:"done"`
+	Progress    float64         `json:"progress"` // Checklist completion, 0-100
+	Archived    bool            `json:"archived,omitempty"`
+	URL         string          `json:"url,omitempty"`
+	Created     time.Time       `json:"created"`
+	Updated     time.Time       `json:"updated"`
+}
+
+// ChecklistItem is a checkbox of an exported task.
+type ChecklistItem struct {
+	Text string `json:"text"`
+	Done bool   `json:"done"`
+}
+
+// Options tune the generated file.
+type Options struct {
+	// ICSEvents writes all-day VEVENTs instead of VTODOs, for calendar apps that ignore to-dos.
+	ICSEvents bool
+	// CalendarName is the X-WR-CALNAME of an iCalendar export.
+	CalendarName string
+	// Now is the DTSTAMP of iCalendar components; zero uses the current time.
+	Now time.Time
+}
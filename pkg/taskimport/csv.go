@@ -0,0 +1,92 @@
+package taskimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvColumns maps accepted header names onto the columns parseCSV understands.
+var csvColumns = map[string]string{
+	"title":       "title",
+	"name":        "title",
+	"task":        "title",
+	"content":     "title",
+	"description": "description",
+	"notes":       "description",
+	"due":         "due",
+	"due_date":    "due",
+	"deadline":    "due",
+	"priority":    "priority",
+	"tags":        "tags",
+	"labels":      "tags",
+	"done":        "done",
+	"completed":   "done",
+}
+
+// parseCSV reads a CSV file whose first row names the columns. Only the title column is required.
+func parseCSV(data []byte, loc *time.Location) ([]Item, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing CSV header: %v", ErrInvalidFile, err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		if col, ok := csvColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			if _, seen := columns[col]; !seen {
+				columns[col] = i
+			}
+		}
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("%w: CSV needs a title column", ErrInvalidFile)
+	}
+
+	var items []Item
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: CSV line %d: %v", ErrInvalidFile, line, err)
+		}
+
+		cell := func(col string) string {
+			i, ok := columns[col]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		title := cell("title")
+		if title == "" {
+			continue
+		}
+		item := Item{
+			Title:       title,
+			Description: cell("description"),
+			Priority:    normalizePriority(cell("priority")),
+		}
+		item.Due, item.DueHasTime, _ = parseDate(cell("due"), loc)
+		item.Done, _ = strconv.ParseBool(cell("done"))
+		for _, label := range splitTags(cell("tags")) {
+			if p := normalizePriority(label); p != "" && strings.HasPrefix(strings.ToLower(label), "#priority/") {
+				item.Priority = p
+				continue
+			}
+			item.Tags = appendTag(item.Tags, normalizeTag(label, "tag"))
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
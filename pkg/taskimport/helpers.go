@@ -0,0 +1,109 @@
+package taskimport
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"autonomous-task-management/pkg/textnorm"
+)
+
+// dateLayouts are tried in order; the bool reports whether the layout carries a time of day.
+var dateLayouts = []struct {
+	layout  string
+	hasTime bool
+}{
+	{time.RFC3339, true},
+	{"2006-01-02T15:04:05", true},
+	{"2006-01-02 15:04", true},
+	{"2006-01-02", false},
+	{"02/01/2006 15:04", true},
+	{"02/01/2006", false},
+}
+
+// parseDate reads the date formats found in exports. Values without a zone are read in loc.
+func parseDate(value string, loc *time.Location) (time.Time, bool, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false, false
+	}
+	for _, l := range dateLayouts {
+		if t, err := time.ParseInLocation(l.layout, value, loc); err == nil {
+			return t, l.hasTime, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// normalizePriority maps "p1", "P1", "1", "high", "urgent", ... onto "p0".."p3".
+func normalizePriority(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "#"), "priority/")
+	switch value {
+	case "p0", "0", "urgent", "critical":
+		return "p0"
+	case "p1", "1", "high":
+		return "p1"
+	case "p2", "2", "medium", "normal":
+		return "p2"
+	case "p3", "3", "low":
+		return "p3"
+	}
+	return ""
+}
+
+// normalizeTag turns a label into a tag the rest of the service can match: "#category/value"
+// with only ASCII letters, digits, '_' and '/'. Labels without a category get defaultCategory.
+func normalizeTag(label, defaultCategory string) string {
+	label = strings.TrimPrefix(strings.TrimSpace(label), "#")
+	if label == "" {
+		return ""
+	}
+	parts := strings.Split(label, "/")
+	for i, p := range parts {
+		parts[i] = slug(p)
+	}
+	if len(parts) == 1 {
+		if parts[0] == "" {
+			return ""
+		}
+		return "#" + defaultCategory + "/" + parts[0]
+	}
+	return "#" + strings.Join(parts, "/")
+}
+
+// slug lowercases s, strips diacritics and replaces everything else with '_'.
+func slug(s string) string {
+	var sb strings.Builder
+	lastUnderscore := true
+	for _, r := range textnorm.Fold(strings.TrimSpace(s)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			lastUnderscore = false
+		} else if !lastUnderscore {
+			sb.WriteByte('_')
+			lastUnderscore = true
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "_")
+}
+
+// splitTags splits a CSV tag cell on spaces, commas and semicolons.
+func splitTags(cell string) []string {
+	return strings.FieldsFunc(cell, func(r rune) bool {
+		return r == ',' || r == ';' || unicode.IsSpace(r)
+	})
+}
+
+// appendTag adds tag if it is non-empty and not present yet.
+func appendTag(tags []string, tag string) []string {
+	if tag == "" {
+		return tags
+	}
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
@@ -0,0 +1,106 @@
+package taskimport
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// checkboxRegex matches "- [ ] text" / "* [x] text" with its indentation.
+	checkboxRegex = regexp.MustCompile(`^(\s*)[-*+]\s+\[([ xX])\]\s+(.+)$`)
+	// headingRegex matches a section heading, used as the project of the tasks below it.
+	headingRegex = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+	// inlineTagRegex matches hashtags written in a task line, including non-ASCII labels.
+	inlineTagRegex = regexp.MustCompile(`(?:^|\s)#([\p{L}\p{N}_/-]+)`)
+	// inlineDueRegex matches "due:2026-03-15", "due: 15/03/2026" or the Obsidian Tasks "📅 2026-03-15".
+	inlineDueRegex = regexp.MustCompile(`(?i)(?:due:|📅)\s*(\d{4}-\d{2}-\d{2}|\d{2}/\d{2}/\d{4})`)
+	// inlinePriorityRegex matches a standalone "p1" or "!p1".
+	inlinePriorityRegex = regexp.MustCompile(`(?i)(?:^|\s)!?(p[0-3])(?:\s|$)`)
+)
+
+// parseMarkdown reads checklist lines. An indented checkbox becomes a checklist item of the task
+// above it, an indented plain line becomes part of its description.
+func parseMarkdown(data []byte, loc *time.Location) []Item {
+	var (
+		items   []Item
+		current *Item
+		indent  int
+		project string
+	)
+
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if m := headingRegex.FindStringSubmatch(strings.TrimSpace(line)); m != nil && !strings.HasPrefix(line, " ") {
+			project = normalizeTag(m[1], "project")
+			current = nil
+			continue
+		}
+
+		m := checkboxRegex.FindStringSubmatch(line)
+		if m == nil {
+			// Indented notes under a task extend its description
+			if current != nil && leadingSpaces(line) > indent {
+				current.Description = strings.TrimSpace(current.Description + "\n" + strings.TrimSpace(line))
+			}
+			continue
+		}
+
+		lineIndent, done, text := leadingSpaces(m[1]), m[2] != " ", m[3]
+		if current != nil && lineIndent > indent {
+			current.Checklist = append(current.Checklist, ChecklistItem{Text: strings.TrimSpace(text), Done: done})
+			continue
+		}
+
+		item := parseInline(text, loc)
+		item.Done = done
+		item.Tags = appendTag(item.Tags, project)
+		items = append(items, item)
+		current, indent = &items[len(items)-1], lineIndent
+	}
+	return items
+}
+
+// parseInline pulls the due date, priority and tags written inline out of a task line.
+func parseInline(text string, loc *time.Location) Item {
+	var item Item
+
+	if m := inlineDueRegex.FindStringSubmatch(text); m != nil {
+		item.Due, item.DueHasTime, _ = parseDate(m[1], loc)
+		text = strings.Replace(text, m[0], " ", 1)
+	}
+	if m := inlinePriorityRegex.FindStringSubmatch(text); m != nil {
+		item.Priority = strings.ToLower(m[1])
+		text = strings.Replace(text, m[0], " ", 1)
+	}
+	for _, m := range inlineTagRegex.FindAllStringSubmatch(text, -1) {
+		if p := normalizePriority(m[1]); strings.HasPrefix(strings.ToLower(m[1]), "priority/") && p != "" {
+			item.Priority = p
+		} else {
+			item.Tags = appendTag(item.Tags, normalizeTag(m[1], "tag"))
+		}
+		text = strings.Replace(text, "#"+m[1], "", 1)
+	}
+
+	item.Title = strings.Join(strings.Fields(text), " ")
+	return item
+}
+
+// leadingSpaces counts indentation, a tab counting as four spaces.
+func leadingSpaces(s string) int {
+	n := 0
+	for _, r := range s {
+		switch r {
+		case ' ':
+			n++
+		case '\t':
+			n += 4
+		default:
+			return n
+		}
+	}
+	return n
+}
@@ -0,0 +1,67 @@
+// Package taskimport reads tasks from files exported by other tools (Markdown checklists, CSV,
+// Todoist and Trello JSON) into a common Item shape.
+package taskimport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Parse reads every task in data. An empty format is detected with Detect. Dates written
+// without a zone are read in loc, or UTC if loc is nil.
+func Parse(format Format, filename string, data []byte, loc *time.Location) ([]Item, error) {
+	if format == "" {
+		format = Detect(filename, data)
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	switch format {
+	case FormatMarkdown:
+		return parseMarkdown(data, loc), nil
+	case FormatCSV:
+		return parseCSV(data, loc)
+	case FormatTodoist:
+		return parseTodoist(data, loc)
+	case FormatTrello:
+		return parseTrello(data, loc)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+// Detect guesses the format from the file extension and, for JSON, the shape of the document.
+func Detect(filename string, data []byte) Format {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV
+	case ".md", ".markdown", ".txt":
+		return FormatMarkdown
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		if strings.Contains(firstLine(string(trimmed)), ",") && !strings.Contains(string(trimmed), "- [") {
+			return FormatCSV
+		}
+		return FormatMarkdown
+	}
+
+	// Trello board exports carry "cards"; anything else JSON is treated as Todoist
+	var probe struct {
+		Cards json.RawMessage `json:"cards"`
+	}
+	if trimmed[0] == '{' && json.Unmarshal(trimmed, &probe) == nil && probe.Cards != nil {
+		return FormatTrello
+	}
+	return FormatTodoist
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,137 @@
+package taskimport
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMarkdown_ChecklistWithInlineMetadata(t *testing.T) {
+	data := []byte(`# Dự án SMAP
+
+- [ ] Finish report p1 due:2026-03-15 #type/research
+  - [x] collect data
+  - [ ] write summary
+  notes for the report
+- [x] Call Ahamove #khách_hàng
+
+Random paragraph that is not a task
+* [ ] Prepare slides 📅 2026-03-20 #priority/p0
+`)
+	items, err := Parse(FormatMarkdown, "", data, nil)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	report := items[0]
+	assert.Equal(t, "Finish report", report.Title)
+	assert.Equal(t, "p1", report.Priority)
+	assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), report.Due)
+	assert.False(t, report.DueHasTime)
+	assert.Equal(t, []string{"#type/research", "#project/du_an_smap"}, report.Tags)
+	assert.Equal(t, []ChecklistItem{{Text: "collect data", Done: true}, {Text: "write summary"}}, report.Checklist)
+	assert.Equal(t, "notes for the report", report.Description)
+
+	assert.True(t, items[1].Done)
+	assert.Equal(t, []string{"#tag/khach_hang", "#project/du_an_smap"}, items[1].Tags)
+
+	assert.Equal(t, "Prepare slides", items[2].Title)
+	assert.Equal(t, "p0", items[2].Priority)
+}
+
+func TestParseCSV_HeaderAliasesAndTags(t *testing.T) {
+	data := []byte("Title,Due,Priority,Tags,Notes\n" +
+		"Review PR,2026-03-01 14:00,high,\"#pr/12, backend\",check tests\n" +
+		",2026-03-02,p1,,\n" +
+		"Write docs,02/03/2026,,docs;#priority/p3,\n")
+
+	items, err := Parse("", "tasks.csv", data, nil)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "Review PR", items[0].Title)
+	assert.Equal(t, "p1", items[0].Priority)
+	assert.True(t, items[0].DueHasTime)
+	assert.Equal(t, 14, items[0].Due.Hour())
+	assert.Equal(t, []string{"#pr/12", "#tag/backend"}, items[0].Tags)
+	assert.Equal(t, "check tests", items[0].Description)
+
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), items[1].Due)
+	assert.Equal(t, "p3", items[1].Priority)
+	assert.Equal(t, []string{"#tag/docs"}, items[1].Tags)
+
+	_, err = Parse(FormatCSV, "", []byte("due,priority\n2026-01-01,p1\n"), nil)
+	assert.ErrorIs(t, err, ErrInvalidFile)
+}
+
+func TestParseCSV_ZonelessTimesUseLocation(t *testing.T) {
+	loc := time.FixedZone("ICT", 7*60*60)
+	data := []byte("title,due\n" +
+		"Standup,2026-03-01 14:00\n" +
+		"Deploy,2026-03-01T09:00:00Z\n" +
+		"Retro,2026-03-02\n")
+
+	items, err := Parse(FormatCSV, "", data, loc)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	assert.True(t, items[0].Due.Equal(time.Date(2026, 3, 1, 14, 0, 0, 0, loc)))
+	assert.True(t, items[1].Due.Equal(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)), "an explicit zone wins")
+	assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, loc), items[2].Due)
+	assert.False(t, items[2].DueHasTime)
+}
+
+func TestParseTodoist_SyncExportWithSubtasks(t *testing.T) {
+	data := []byte(`{
+		"projects": [{"id": "p1", "name": "Work"}],
+		"items": [
+			{"id": "1", "project_id": "p1", "content": "Ship release", "priority": 4, "labels": ["backend"], "due": {"date": "2026-04-01"}},
+			{"id": 2, "parent_id": "1", "content": "Tag version", "checked": true},
+			{"id": "3", "content": "Buy milk", "priority": 1, "due": {"date": "2026-04-02T09:30:00", "datetime": "2026-04-02T09:30:00Z"}}
+		]
+	}`)
+
+	assert.Equal(t, FormatTodoist, Detect("export.json", data))
+	items, err := Parse("", "export.json", data, nil)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "p0", items[0].Priority)
+	assert.Equal(t, []string{"#project/work", "#label/backend"}, items[0].Tags)
+	assert.Equal(t, []ChecklistItem{{Text: "Tag version", Done: true}}, items[0].Checklist)
+
+	assert.Equal(t, "", items[1].Priority, "Todoist's default priority maps to ours")
+	assert.True(t, items[1].DueHasTime)
+}
+
+func TestParseTrello_SkipsArchivedCards(t *testing.T) {
+	data := []byte(`{
+		"name": "Sprint 12",
+		"lists": [{"id": "l1", "name": "To Do"}, {"id": "l2", "name": "Old", "closed": true}],
+		"cards": [
+			{"id": "c1", "name": "Login page", "desc": "OAuth", "due": "2026-05-01T10:00:00.000Z", "idList": "l1",
+			 "labels": [{"name": "High"}, {"name": "", "color": "green"}]},
+			{"id": "c2", "name": "Archived", "closed": true, "idList": "l1"},
+			{"id": "c3", "name": "On closed list", "idList": "l2"}
+		],
+		"checklists": [{"idCard": "c1", "checkItems": [{"name": "design", "state": "complete"}]}]
+	}`)
+
+	assert.Equal(t, FormatTrello, Detect("board.json", data))
+	items, err := Parse("", "board.json", data, nil)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	card := items[0]
+	assert.Equal(t, "Login page", card.Title)
+	assert.Equal(t, "p1", card.Priority)
+	assert.Equal(t, []string{"#project/sprint_12", "#list/to_do", "#label/green"}, card.Tags)
+	assert.Equal(t, []ChecklistItem{{Text: "design", Done: true}}, card.Checklist)
+	assert.Equal(t, time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC), card.Due)
+}
+
+func TestParse_UnknownFormat(t *testing.T) {
+	_, err := Parse("xlsx", "", nil, nil)
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -0,0 +1,117 @@
+package taskimport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// todoistID accepts both the numeric IDs of older exports and the string IDs of newer ones.
+type todoistID string
+
+func (id *todoistID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*id = todoistID(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*id = todoistID(n)
+	return nil
+}
+
+// todoistTask covers both the REST API task and the Sync API item shapes.
+type todoistTask struct {
+	ID          todoistID `json:"id"`
+	ParentID    todoistID `json:"parent_id"`
+	ProjectID   todoistID `json:"project_id"`
+	Content     string    `json:"content"`
+	Description string    `json:"description"`
+	Priority    int       `json:"priority"` // 4 is the most urgent
+	Labels      []string  `json:"labels"`
+	Checked     bool      `json:"checked"`
+	IsCompleted bool      `json:"is_completed"`
+	Due         *struct {
+		Date     string `json:"date"`
+		Datetime string `json:"datetime"`
+	} `json:"due"`
+}
+
+type todoistProject struct {
+	ID   todoistID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// todoistPriorities maps Todoist's API priority (4 = urgent) onto ours; 1 is Todoist's default.
+var todoistPriorities = map[int]string{4: "p0", 3: "p1", 2: "p2"}
+
+// parseTodoist reads a JSON array of REST API tasks, or a Sync API document with "items"
+// (or "tasks") and "projects". Sub-tasks become checklist items of their parent.
+func parseTodoist(data []byte, loc *time.Location) ([]Item, error) {
+	var doc struct {
+		Items    []todoistTask    `json:"items"`
+		Tasks    []todoistTask    `json:"tasks"`
+		Projects []todoistProject `json:"projects"`
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &doc.Tasks); err != nil {
+			return nil, fmt.Errorf("%w: Todoist JSON: %v", ErrInvalidFile, err)
+		}
+	} else if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: Todoist JSON: %v", ErrInvalidFile, err)
+	}
+
+	projects := make(map[string]string, len(doc.Projects))
+	for _, p := range doc.Projects {
+		projects[string(p.ID)] = p.Name
+	}
+
+	tasks := append(doc.Items, doc.Tasks...)
+	parents := make(map[string]int) // Todoist task ID -> index in items
+	var items []Item
+	for _, t := range tasks {
+		if t.Content == "" {
+			continue
+		}
+		done := t.Checked || t.IsCompleted
+
+		if i, ok := parents[string(t.ParentID)]; ok && t.ParentID != "" {
+			items[i].Checklist = append(items[i].Checklist, ChecklistItem{Text: t.Content, Done: done})
+			continue
+		}
+
+		item := Item{
+			Title:       t.Content,
+			Description: t.Description,
+			Priority:    todoistPriorities[t.Priority],
+			Done:        done,
+		}
+		if t.Due != nil {
+			if t.Due.Datetime != "" {
+				item.Due, item.DueHasTime, _ = parseDate(t.Due.Datetime, loc)
+			} else {
+				item.Due, item.DueHasTime, _ = parseDate(t.Due.Date, loc)
+			}
+		}
+		if name, ok := projects[string(t.ProjectID)]; ok {
+			item.Tags = appendTag(item.Tags, normalizeTag(name, "project"))
+		}
+		for _, label := range t.Labels {
+			item.Tags = appendTag(item.Tags, normalizeTag(label, "label"))
+		}
+
+		items = append(items, item)
+		if t.ID != "" {
+			parents[string(t.ID)] = len(items) - 1
+		}
+	}
+	return items, nil
+}
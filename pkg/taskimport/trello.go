@@ -0,0 +1,91 @@
+package taskimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type trelloBoard struct {
+	Name  string `json:"name"`
+	Lists []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Closed bool   `json:"closed"`
+	} `json:"lists"`
+	Cards []struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Desc        string `json:"desc"`
+		Due         string `json:"due"`
+		DueComplete bool   `json:"dueComplete"`
+		Closed      bool   `json:"closed"`
+		IDList      string `json:"idList"`
+		Labels      []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"labels"`
+	} `json:"cards"`
+	Checklists []struct {
+		IDCard     string `json:"idCard"`
+		CheckItems []struct {
+			Name  string `json:"name"`
+			State string `json:"state"` // "complete" or "incomplete"
+		} `json:"checkItems"`
+	} `json:"checklists"`
+}
+
+// parseTrello reads a Trello board export. Archived cards and cards on archived lists are skipped;
+// the board becomes the project tag and each list a "#list/..." tag.
+func parseTrello(data []byte, loc *time.Location) ([]Item, error) {
+	var board trelloBoard
+	if err := json.Unmarshal(data, &board); err != nil {
+		return nil, fmt.Errorf("%w: Trello JSON: %v", ErrInvalidFile, err)
+	}
+
+	lists := make(map[string]string, len(board.Lists))
+	closedLists := make(map[string]bool)
+	for _, l := range board.Lists {
+		lists[l.ID] = l.Name
+		closedLists[l.ID] = l.Closed
+	}
+	checklists := make(map[string][]ChecklistItem)
+	for _, cl := range board.Checklists {
+		for _, ci := range cl.CheckItems {
+			checklists[cl.IDCard] = append(checklists[cl.IDCard], ChecklistItem{Text: ci.Name, Done: ci.State == "complete"})
+		}
+	}
+	project := normalizeTag(board.Name, "project")
+
+	var items []Item
+	for _, c := range board.Cards {
+		if c.Closed || closedLists[c.IDList] || c.Name == "" {
+			continue
+		}
+
+		item := Item{
+			Title:       c.Name,
+			Description: c.Desc,
+			Done:        c.DueComplete,
+			Checklist:   checklists[c.ID],
+		}
+		item.Due, item.DueHasTime, _ = parseDate(c.Due, loc)
+		item.Tags = appendTag(item.Tags, project)
+		if name, ok := lists[c.IDList]; ok {
+			item.Tags = appendTag(item.Tags, normalizeTag(name, "list"))
+		}
+		for _, label := range c.Labels {
+			name := label.Name
+			if name == "" {
+				name = label.Color
+			}
+			if p := normalizePriority(name); p != "" {
+				item.Priority = p
+				continue
+			}
+			item.Tags = appendTag(item.Tags, normalizeTag(name, "label"))
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
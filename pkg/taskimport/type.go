@@ -0,0 +1,39 @@
+package taskimport
+
+import (
+	"errors"
+	"time"
+)
+
+// Format is the kind of file being imported.
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown" // Checklist lines ("- [ ] task"), nested items become its checklist
+	FormatCSV      Format = "csv"      // Header row with title, due, priority, tags (description, done optional)
+	FormatTodoist  Format = "todoist"  // JSON from the Todoist REST or Sync API
+	FormatTrello   Format = "trello"   // JSON board export from Trello
+)
+
+var (
+	ErrUnknownFormat = errors.New("taskimport: unknown format")
+	ErrInvalidFile   = errors.New("taskimport: invalid file")
+)
+
+// Item is one task read from an import file, independent of the source format.
+type Item struct {
+	Title       string
+	Description string
+	Due         time.Time // Zero when the source has no due date; zone-less values are in the Parse location
+	DueHasTime  bool      // False for date-only values, which callers place at the end of the day
+	Priority    string    // "p0".."p3", or "" when the source has none
+	Tags        []string  // Normalized "#category/value" tags
+	Checklist   []ChecklistItem
+	Done        bool
+}
+
+// ChecklistItem is a sub-item of an imported task.
+type ChecklistItem struct {
+	Text string
+	Done bool
+}
@@ -0,0 +1,35 @@
+package taskmeta
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// checkboxRegex matches "- [ ] item" and "- [x] item" lines and captures the state.
+	checkboxRegex = regexp.MustCompile(`(?m)^\s*- \[([ xX])\] .+$`)
+	// fencedCodeRegex and inlineCodeRegex match code, whose checkboxes are examples, not items.
+	fencedCodeRegex = regexp.MustCompile("(?s)```.*?```")
+	inlineCodeRegex = regexp.MustCompile("`[^`]+`")
+)
+
+// ChecklistDone reports whether content has at least one checkbox and all of them are ticked.
+// Checkboxes inside code blocks are ignored.
+func ChecklistDone(content string) bool {
+	content = inlineCodeRegex.ReplaceAllString(fencedCodeRegex.ReplaceAllString(content, ""), "")
+	matches := checkboxRegex.FindAllStringSubmatch(content, -1)
+	if len(matches) == 0 {
+		return false
+	}
+	for _, m := range matches {
+		if strings.ToLower(m[1]) != "x" {
+			return false
+		}
+	}
+	return true
+}
+
+// IsDone reports whether a task is complete: every checkbox ticked or tagged #status/done.
+func IsDone(content string) bool {
+	return Parse(content).Done()
+}
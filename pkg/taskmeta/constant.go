@@ -0,0 +1,34 @@
+package taskmeta
+
+// Tag prefixes used by the task markdown convention (see buildMarkdownContent).
+const (
+	TagPrefixPriority = "#priority/"
+	TagPrefixProject  = "#project/"
+	TagPrefixStatus   = "#status/"
+)
+
+// Well-known status values carried by #status/* tags.
+const (
+	StatusDone       = "done"
+	StatusInProgress = "in_progress"
+	StatusTodo       = "todo"
+)
+
+// DateLayout is the layout of the "- **Due:**" metadata line.
+const DateLayout = "2006-01-02"
+
+// TimeLogLayout is the layout of the session start in a "- ⏱" time log line, of a "- **Scheduled:**"
+// block and of the "- **Done:**" time.
+const TimeLogLayout = "2006-01-02 15:04 -07:00"
+
+// Labels of the "- **Label:** value" metadata lines.
+const (
+	FieldDue       = "Due"
+	FieldPriority  = "Priority"
+	FieldEstimated = "Estimated"
+	FieldSpent     = "Spent"
+	FieldScheduled = "Scheduled"
+	FieldBlockedBy = "Blocked by"
+	FieldParent    = "Parent"
+	FieldDone      = "Done" // When the task was completed, see StampDone
+)
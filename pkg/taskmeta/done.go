@@ -0,0 +1,31 @@
+package taskmeta
+
+import "time"
+
+// StampDone keeps the "- **Done:**" line in step with the task's status: a task that became
+// done without one gets now, and a task that is no longer done loses it. Content that is
+// already consistent is returned unchanged, so the original completion time is kept.
+func StampDone(content string, now time.Time) string {
+	_, stamped := GetField(content, FieldDone)
+	done := Parse(content).Done()
+	switch {
+	case done && !stamped:
+		return SetField(content, FieldDone, now.Format(TimeLogLayout))
+	case !done && stamped:
+		return SetField(content, FieldDone, "")
+	}
+	return content
+}
+
+// DoneAt returns the completion time recorded by StampDone.
+func DoneAt(content string) (time.Time, bool) {
+	value, ok := GetField(content, FieldDone)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(TimeLogLayout, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
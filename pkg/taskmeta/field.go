@@ -0,0 +1,109 @@
+package taskmeta
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// fieldLineRegex matches any "- **Label:** value" metadata line.
+var fieldLineRegex = regexp.MustCompile(`^\s*-\s+\*\*[^*]+:\*\*`)
+
+// fieldRegex returns the regex matching the metadata line for label, capturing its value.
+func fieldRegex(label string) *regexp.Regexp {
+	return regexp.MustCompile(`(?im)^\s*-\s+\*\*` + regexp.QuoteMeta(label) + `:\*\*[ \t]*(.*)$`)
+}
+
+// GetField returns the value of the "- **label:** value" line, if present.
+func GetField(content, label string) (string, bool) {
+	m := fieldRegex(label).FindStringSubmatch(content)
+	if len(m) < 2 {
+		return "", false
+	}
+	return strings.TrimSpace(m[1]), true
+}
+
+// SetField writes "- **label:** value" into content. An existing line is replaced in place;
+// otherwise the line is added after the last metadata line (or before a trailing tag line).
+// An empty value removes the line.
+func SetField(content, label, value string) string {
+	line := fmt.Sprintf("- **%s:** %s", label, value)
+	lines := strings.Split(content, "\n")
+
+	re := fieldRegex(label)
+	for i, l := range lines {
+		if !re.MatchString(l) {
+			continue
+		}
+		if value == "" {
+			return strings.Join(append(lines[:i], lines[i+1:]...), "\n")
+		}
+		lines[i] = line
+		return strings.Join(lines, "\n")
+	}
+
+	if value == "" {
+		return content
+	}
+
+	insertAt := -1
+	for i, l := range lines {
+		if fieldLineRegex.MatchString(l) {
+			insertAt = i + 1
+		}
+	}
+	if insertAt < 0 {
+		end := len(lines)
+		for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+			end--
+		}
+		// Keep the tag line Memos appends at the bottom.
+		if end < 2 || !isTagLine(lines[end-1]) {
+			return strings.TrimRight(content, "\n") + "\n\n" + line
+		}
+		insertAt = end - 1
+		line += "\n"
+	}
+
+	out := make([]string, 0, len(lines)+1)
+	out = append(out, lines[:insertAt]...)
+	out = append(out, line)
+	out = append(out, lines[insertAt:]...)
+	return strings.Join(out, "\n")
+}
+
+// ParseIDList splits a comma-separated list of task IDs.
+func ParseIDList(value string) []string {
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// NormalizeID strips whitespace and the "memos/" resource prefix from a task reference
+// so "memos/12" and "12" compare equal.
+func NormalizeID(id string) string {
+	return strings.TrimPrefix(strings.TrimSpace(id), "memos/")
+}
+
+// SameID reports whether two task references point at the same memo.
+func SameID(a, b string) bool {
+	return NormalizeID(a) == NormalizeID(b)
+}
+
+// isTagLine reports whether line consists only of hashtags.
+func isTagLine(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+	for _, f := range fields {
+		if !tagRegex.MatchString(f) || tagRegex.FindString(f) != f {
+			return false
+		}
+	}
+	return true
+}
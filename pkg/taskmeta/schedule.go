@@ -0,0 +1,55 @@
+package taskmeta
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// scheduledRegex matches the value of a "- **Scheduled:**" line: "2026-03-10 14:00 +07:00 · 90 min · event abc123".
+var scheduledRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2} [+-]\d{2}:\d{2})\s+·\s+(\d+)\s*min(?:\s+·\s+event\s+(\S+))?$`)
+
+// Block is a calendar block booked to work on a task.
+type Block struct {
+	Start   time.Time
+	Minutes int
+	EventID string // Calendar event holding the block, empty if unknown
+}
+
+// End returns when the block finishes.
+func (b Block) End() time.Time {
+	return b.Start.Add(time.Duration(b.Minutes) * time.Minute)
+}
+
+// FormatBlock renders a block as the value of the "- **Scheduled:**" line.
+func FormatBlock(b Block) string {
+	value := fmt.Sprintf("%s · %d min", b.Start.Format(TimeLogLayout), b.Minutes)
+	if b.EventID != "" {
+		value += " · event " + b.EventID
+	}
+	return value
+}
+
+// ParseBlock parses the value of a "- **Scheduled:**" line.
+func ParseBlock(value string) (Block, bool) {
+	m := scheduledRegex.FindStringSubmatch(value)
+	if len(m) != 4 {
+		return Block{}, false
+	}
+	start, err := time.Parse(TimeLogLayout, m[1])
+	if err != nil {
+		return Block{}, false
+	}
+	minutes, _ := strconv.Atoi(m[2])
+	return Block{Start: start, Minutes: minutes, EventID: m[3]}, true
+}
+
+// ScheduledBlock returns the block recorded on the task, if any.
+func ScheduledBlock(content string) (Block, bool) {
+	value, ok := GetField(content, FieldScheduled)
+	if !ok {
+		return Block{}, false
+	}
+	return ParseBlock(value)
+}
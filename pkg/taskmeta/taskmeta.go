@@ -0,0 +1,148 @@
+// Package taskmeta parses the metadata that task memos carry in their markdown body
+// (due date, priority, tags, estimate, time spent) so that every domain reads it the same way.
+package taskmeta
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// tagRegex matches hashtags like #repo/myproject, #pr/123, #priority/p1.
+	tagRegex = regexp.MustCompile(`#[a-zA-Z0-9_/]+`)
+	// dueRegex matches "- **Due:** 2026-03-15" or "Due: 2026-03-15".
+	dueRegex = regexp.MustCompile(`(?i)due[:\s*]+(\d{4}-\d{2}-\d{2})`)
+	// estimateRegex matches "- **Estimated:** 90 min".
+	estimateRegex = regexp.MustCompile(`(?i)estimated[:\s*]+(\d+)\s*min`)
+	// spentRegex matches "- **Spent:** 120 min".
+	spentRegex = regexp.MustCompile(`(?im)^\s*-\s+\*\*spent:\*\*\s*(\d+)\s*min`)
+	// headingRegex matches a markdown heading line.
+	headingRegex = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+)
+
+// Parse extracts task metadata from markdown content.
+func Parse(content string) Meta {
+	meta := Meta{
+		Title: ExtractTitle(content),
+		Tags:  ExtractTags(content),
+	}
+
+	if due, ok := ExtractDueDate(content); ok {
+		meta.DueDate = due
+		meta.HasDue = true
+	}
+
+	if m := estimateRegex.FindStringSubmatch(content); len(m) == 2 {
+		meta.EstimatedMinutes, _ = strconv.Atoi(m[1])
+	}
+	if m := spentRegex.FindStringSubmatch(content); len(m) == 2 {
+		meta.SpentMinutes, _ = strconv.Atoi(m[1])
+	}
+
+	if v, ok := GetField(content, FieldBlockedBy); ok {
+		meta.BlockedBy = ParseIDList(v)
+	}
+	if v, ok := GetField(content, FieldParent); ok {
+		meta.Parent = v
+	}
+	meta.ChecklistDone = ChecklistDone(content)
+
+	for _, tag := range meta.Tags {
+		switch {
+		case strings.HasPrefix(tag, TagPrefixPriority) && meta.Priority == "":
+			meta.Priority = strings.TrimPrefix(tag, TagPrefixPriority)
+		case strings.HasPrefix(tag, TagPrefixProject):
+			meta.Projects = append(meta.Projects, strings.TrimPrefix(tag, TagPrefixProject))
+		case strings.HasPrefix(tag, TagPrefixStatus):
+			meta.Status = strings.TrimPrefix(tag, TagPrefixStatus)
+		}
+	}
+
+	return meta
+}
+
+// Done reports whether the task is complete: its checklist is fully ticked or it carries
+// #status/done. Every domain that needs to tell open from finished tasks uses this rule.
+func (m Meta) Done() bool {
+	return m.ChecklistDone || m.Status == StatusDone
+}
+
+// ExtractTags extracts hashtags from markdown content, deduplicated in order of appearance.
+func ExtractTags(content string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+
+	for _, line := range strings.Split(content, "\n") {
+		for _, tag := range tagRegex.FindAllString(strings.TrimSpace(line), -1) {
+			if !seen[tag] {
+				tags = append(tags, tag)
+				seen[tag] = true
+			}
+		}
+	}
+	return tags
+}
+
+// ExtractDueDate finds the first "Due: yyyy-mm-dd" marker in content.
+// The returned time is midnight UTC of that date; callers convert it to the user's timezone.
+func ExtractDueDate(content string) (time.Time, bool) {
+	matches := dueRegex.FindStringSubmatch(content)
+	if len(matches) < 2 {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(DateLayout, matches[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// ExtractTitle returns the first markdown heading, or the first non-empty line.
+func ExtractTitle(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if m := headingRegex.FindStringSubmatch(line); len(m) == 2 {
+			return strings.TrimSpace(m[1])
+		}
+		line = strings.ReplaceAll(line, "**", "")
+		return strings.TrimSpace(line)
+	}
+	return ""
+}
+
+// HasTag reports whether tags contains tag (case-insensitive).
+func HasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// DueIn returns the due date as a calendar day in loc, so comparisons with
+// "today" in the user's timezone are not skewed by the UTC parse.
+func (m Meta) DueIn(loc *time.Location) time.Time {
+	if !m.HasDue {
+		return time.Time{}
+	}
+	return time.Date(m.DueDate.Year(), m.DueDate.Month(), m.DueDate.Day(), 0, 0, 0, 0, loc)
+}
+
+// ParseTime parses a Memos RFC3339 timestamp, returning the zero time on failure.
+func ParseTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
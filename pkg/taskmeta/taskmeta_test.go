@@ -0,0 +1,160 @@
+package taskmeta
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_FullTask(t *testing.T) {
+	content := "## Finish SMAP report\n\nWrite summary\n\n- **Due:** 2026-03-15\n- **Priority:** #priority/p1\n- **Estimated:** 90 min\n\n#priority/p1 #project/smap #status/in_progress"
+
+	meta := Parse(content)
+
+	assert.Equal(t, "Finish SMAP report", meta.Title)
+	assert.True(t, meta.HasDue)
+	assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), meta.DueDate)
+	assert.Equal(t, "p1", meta.Priority)
+	assert.Equal(t, []string{"smap"}, meta.Projects)
+	assert.Equal(t, StatusInProgress, meta.Status)
+	assert.Equal(t, 90, meta.EstimatedMinutes)
+	assert.Equal(t, []string{"#priority/p1", "#project/smap", "#status/in_progress"}, meta.Tags)
+}
+
+func TestParse_NoMetadata(t *testing.T) {
+	meta := Parse("Just a note")
+
+	assert.Equal(t, "Just a note", meta.Title)
+	assert.False(t, meta.HasDue)
+	assert.True(t, meta.DueIn(time.UTC).IsZero())
+	assert.Empty(t, meta.Priority)
+	assert.Empty(t, meta.Tags)
+}
+
+func TestDueIn_KeepsCalendarDay(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
+	meta := Parse("- **Due:** 2026-03-15")
+
+	due := meta.DueIn(loc)
+
+	assert.Equal(t, 15, due.Day())
+	assert.Equal(t, loc, due.Location())
+}
+
+func TestHasTag_CaseInsensitive(t *testing.T) {
+	assert.True(t, HasTag([]string{"#Status/Done"}, "#status/done"))
+	assert.False(t, HasTag(nil, "#status/done"))
+}
+
+func TestParseTime(t *testing.T) {
+	assert.True(t, ParseTime("").IsZero())
+	assert.True(t, ParseTime("not a time").IsZero())
+	assert.Equal(t, 2026, ParseTime("2026-01-02T03:04:05Z").Year())
+}
+
+func TestNormalizeID(t *testing.T) {
+	assert.Equal(t, "12", NormalizeID(" memos/12 "))
+	assert.Equal(t, "12", NormalizeID("12"))
+	assert.True(t, SameID("memos/12", "12 "))
+	assert.False(t, SameID("memos/12", "13"))
+}
+
+func TestParse_Relations(t *testing.T) {
+	content := "## Deploy\n\n- **Blocked by:** memos/1, memos/2\n- **Parent:** memos/9"
+
+	meta := Parse(content)
+
+	assert.Equal(t, []string{"memos/1", "memos/2"}, meta.BlockedBy)
+	assert.Equal(t, "memos/9", meta.Parent)
+}
+
+func TestSetField(t *testing.T) {
+	content := "## Deploy\n\n- **Due:** 2026-03-15\n\n#project/smap"
+
+	added := SetField(content, FieldParent, "memos/9")
+	assert.Equal(t, "## Deploy\n\n- **Due:** 2026-03-15\n- **Parent:** memos/9\n\n#project/smap", added)
+
+	replaced := SetField(added, FieldParent, "memos/10")
+	v, ok := GetField(replaced, FieldParent)
+	assert.True(t, ok)
+	assert.Equal(t, "memos/10", v)
+
+	assert.Equal(t, content, SetField(added, FieldParent, ""))
+
+	plain := SetField("Note\n\n#tag", FieldParent, "memos/9")
+	assert.Equal(t, "Note\n\n- **Parent:** memos/9\n\n#tag", plain)
+	assert.Equal(t, "Note\n\n- **Parent:** memos/9", SetField("Note", FieldParent, "memos/9"))
+}
+
+func TestTimeLog(t *testing.T) {
+	loc := time.FixedZone("ICT", 7*3600)
+	first := TimeEntry{Start: time.Date(2026, 3, 10, 9, 0, 0, 0, loc), Minutes: 90}
+	second := TimeEntry{Start: time.Date(2026, 3, 11, 14, 30, 0, 0, loc), Minutes: 25}
+
+	content := AppendTimeEntry("## Deploy\n\n- **Estimated:** 60 min\n\n#project/smap", first)
+	assert.Equal(t, "## Deploy\n\n- **Estimated:** 60 min\n\n- ⏱ 2026-03-10 09:00 +07:00 · 90 min\n\n#project/smap", content)
+
+	content = AppendTimeEntry(content, second)
+	content = SetField(content, FieldSpent, "115 min")
+
+	entries := ParseTimeLog(content)
+	if assert.Len(t, entries, 2) {
+		assert.True(t, entries[0].Start.Equal(first.Start))
+		assert.Equal(t, 90, entries[0].Minutes)
+		assert.True(t, entries[1].Start.Equal(second.Start))
+	}
+
+	meta := Parse(content)
+	assert.Equal(t, 60, meta.EstimatedMinutes)
+	assert.Equal(t, 115, meta.SpentMinutes)
+}
+
+func TestScheduledBlock(t *testing.T) {
+	loc := time.FixedZone("ICT", 7*3600)
+	block := Block{Start: time.Date(2026, 3, 10, 14, 0, 0, 0, loc), Minutes: 90, EventID: "evt1"}
+
+	content := SetField("## Deploy\n\n- **Due:** 2026-03-15", FieldScheduled, FormatBlock(block))
+	assert.Contains(t, content, "- **Scheduled:** 2026-03-10 14:00 +07:00 · 90 min · event evt1")
+
+	got, ok := ScheduledBlock(content)
+	assert.True(t, ok)
+	assert.True(t, got.Start.Equal(block.Start))
+	assert.Equal(t, 90, got.Minutes)
+	assert.Equal(t, "evt1", got.EventID)
+	assert.True(t, got.End().Equal(block.Start.Add(90*time.Minute)))
+
+	_, ok = ParseBlock("tomorrow afternoon")
+	assert.False(t, ok)
+	noEvent, ok := ParseBlock("2026-03-10 14:00 +07:00 · 30 min")
+	assert.True(t, ok)
+	assert.Empty(t, noEvent.EventID)
+}
+
+func TestIsDone(t *testing.T) {
+	assert.True(t, IsDone("## Pack\n\n- [x] Passport\n- [X] Charger"))
+	assert.True(t, IsDone("## Ship\n\n#status/done"))
+	assert.False(t, IsDone("## Pack\n\n- [x] Passport\n- [ ] Charger"))
+	assert.False(t, IsDone("## Note without checkboxes"))
+	assert.False(t, IsDone("## Docs\n\n```\n- [x] example\n```"), "checkboxes in code are examples")
+}
+
+func TestStampDone(t *testing.T) {
+	loc := time.FixedZone("ICT", 7*3600)
+	first := time.Date(2026, 3, 10, 14, 0, 0, 0, loc)
+
+	done := StampDone("## Pack\n\n- **Due:** 2026-03-15\n\n- [x] Passport", first)
+	assert.Equal(t, "## Pack\n\n- **Due:** 2026-03-15\n- **Done:** 2026-03-10 14:00 +07:00\n\n- [x] Passport", done)
+	at, ok := DoneAt(done)
+	assert.True(t, ok)
+	assert.True(t, at.Equal(first))
+
+	assert.Equal(t, done, StampDone(done, first.Add(time.Hour)), "later edits keep the completion time")
+
+	reopened := strings.Replace(done, "- [x]", "- [ ]", 1)
+	assert.Equal(t, "## Pack\n\n- **Due:** 2026-03-15\n\n- [ ] Passport", StampDone(reopened, first))
+
+	_, ok = DoneAt("## Pack")
+	assert.False(t, ok)
+}
@@ -0,0 +1,72 @@
+package taskmeta
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLogRegex matches a work session line: "- ⏱ 2026-03-10 09:00 +07:00 · 90 min".
+var timeLogRegex = regexp.MustCompile(`^\s*-\s+⏱\s+(\d{4}-\d{2}-\d{2} \d{2}:\d{2} [+-]\d{2}:\d{2})\s+·\s+(\d+)\s*min`)
+
+// TimeEntry is one logged work session.
+type TimeEntry struct {
+	Start   time.Time
+	Minutes int
+}
+
+// FormatTimeEntry renders a work session as a time log line.
+func FormatTimeEntry(e TimeEntry) string {
+	return fmt.Sprintf("- ⏱ %s · %d min", e.Start.Format(TimeLogLayout), e.Minutes)
+}
+
+// ParseTimeLog returns the work sessions logged in content, in order of appearance.
+func ParseTimeLog(content string) []TimeEntry {
+	var entries []TimeEntry
+	for _, line := range strings.Split(content, "\n") {
+		m := timeLogRegex.FindStringSubmatch(line)
+		if len(m) != 3 {
+			continue
+		}
+		start, err := time.Parse(TimeLogLayout, m[1])
+		if err != nil {
+			continue
+		}
+		minutes, _ := strconv.Atoi(m[2])
+		entries = append(entries, TimeEntry{Start: start, Minutes: minutes})
+	}
+	return entries
+}
+
+// AppendTimeEntry adds a work session after the last time log line, or at the end of content
+// (before a trailing tag line) when nothing has been logged yet.
+func AppendTimeEntry(content string, e TimeEntry) string {
+	line := FormatTimeEntry(e)
+	lines := strings.Split(content, "\n")
+
+	insertAt := -1
+	for i, l := range lines {
+		if timeLogRegex.MatchString(l) {
+			insertAt = i + 1
+		}
+	}
+	if insertAt < 0 {
+		end := len(lines)
+		for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+			end--
+		}
+		if end < 2 || !isTagLine(lines[end-1]) {
+			return strings.TrimRight(content, "\n") + "\n\n" + line
+		}
+		insertAt = end - 1
+		line += "\n"
+	}
+
+	out := make([]string, 0, len(lines)+1)
+	out = append(out, lines[:insertAt]...)
+	out = append(out, line)
+	out = append(out, lines[insertAt:]...)
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,19 @@
+package taskmeta
+
+import "time"
+
+// Meta is the structured metadata extracted from a task's markdown content.
+type Meta struct {
+	Title            string    // First heading (or first non-empty line)
+	DueDate          time.Time // Zero when the task has no due date
+	HasDue           bool      // true if a "Due:" line was found
+	Priority         string    // "p0".."p3", empty if unknown
+	Tags             []string  // All hashtags in order of appearance, deduplicated
+	Projects         []string  // Values of #project/* tags (e.g. "smap")
+	Status           string    // Value of the #status/* tag, empty if none
+	EstimatedMinutes int       // From "- **Estimated:** N min", 0 if absent
+	SpentMinutes     int       // From "- **Spent:** N min", the total of the time log
+	BlockedBy        []string  // Task IDs from "- **Blocked by:** id1, id2"
+	Parent           string    // Task ID from "- **Parent:** id", empty for top-level tasks
+	ChecklistDone    bool      // true if the task has checkboxes and all are ticked
+}
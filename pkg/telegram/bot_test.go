@@ -2,6 +2,7 @@ package telegram
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -48,6 +49,39 @@ func TestBot(t *testing.T) {
 			return
 		}
 
+		if strings.HasSuffix(path, "/sendDocument") {
+			file, header, err := r.FormFile("document")
+			if err != nil || r.FormValue("chat_id") != "12345" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			defer file.Close()
+			content, _ := io.ReadAll(file)
+			if header.Filename != "tasks.csv" || string(content) != "title\nA\n" || r.FormValue("caption") != "3 tasks" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"ok": false, "description": "unexpected upload"}`))
+				return
+			}
+			w.Write([]byte(`{"ok": true}`))
+			return
+		}
+
+		if strings.HasSuffix(path, "/getFile") {
+			var req map[string]string
+			json.NewDecoder(r.Body).Decode(&req)
+			if req["file_id"] == "missing" {
+				w.Write([]byte(`{"ok": false, "description": "file not found"}`))
+				return
+			}
+			w.Write([]byte(`{"ok": true, "result": {"file_id": "f1", "file_size": 11, "file_path": "documents/tasks.md"}}`))
+			return
+		}
+
+		if strings.HasSuffix(path, "/file/documents/tasks.md") {
+			w.Write([]byte("- [ ] hello"))
+			return
+		}
+
 		w.WriteHeader(http.StatusNotFound)
 	}))
 	defer ts.Close()
@@ -105,6 +139,36 @@ func TestBot(t *testing.T) {
 		}
 	})
 
+	t.Run("SendDocument Success", func(t *testing.T) {
+		if err := bot.SendDocument(12345, "tasks.csv", []byte("title\nA\n"), "3 tasks"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("SendDocument API Failed", func(t *testing.T) {
+		err := bot.SendDocument(12345, "other.csv", nil, "")
+		if err == nil || !strings.Contains(err.Error(), "unexpected upload") {
+			t.Fatalf("expected api failure error, got: %v", err)
+		}
+	})
+
+	t.Run("DownloadFile Success", func(t *testing.T) {
+		data, err := bot.DownloadFile("f1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != "- [ ] hello" {
+			t.Fatalf("unexpected content: %q", data)
+		}
+	})
+
+	t.Run("DownloadFile API Failed", func(t *testing.T) {
+		_, err := bot.DownloadFile("missing")
+		if err == nil || !strings.Contains(err.Error(), "file not found") {
+			t.Fatalf("expected api failure error, got: %v", err)
+		}
+	})
+
 	t.Run("Invalid API URL logic", func(t *testing.T) {
 		badBot := NewBot("test").(*botImpl)
 		badBot.SetAPIURL("http://invalid-url.local:1234")
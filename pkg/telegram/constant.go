@@ -2,4 +2,7 @@ package telegram
 
 const (
 	DefaultParseMode = "HTML"
+
+	// MaxDownloadSize is the largest file the Bot API lets bots download.
+	MaxDownloadSize = 20 << 20
 )
@@ -8,6 +8,8 @@ type IBot interface {
 	SendMessageHTML(chatID int64, text string) error
 	SendMessagePlain(chatID int64, text string) error
 	SendMessageWithMode(chatID int64, text string, parseMode string) error
+	SendDocument(chatID int64, filename string, data []byte, caption string) error
+	DownloadFile(fileID string) ([]byte, error)
 }
 
 // New creates a new IBot instance.
@@ -0,0 +1,29 @@
+package telegram
+
+import "strings"
+
+// Helpers for user text (task titles, project names) embedded in legacy "Markdown" messages.
+// Telegram rejects a message whose markup does not parse, so one stray '_' in a title loses it.
+
+// markdownEscaper escapes the characters legacy Markdown treats as markup.
+var markdownEscaper = strings.NewReplacer("_", `\_`, "*", `\*`, "`", "\\`", "[", `\[`)
+
+// linkTextReplacer keeps brackets from ending link text early.
+var linkTextReplacer = strings.NewReplacer("[", "(", "]", ")")
+
+// EscapeMarkdown makes text safe outside any entity.
+func EscapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// MarkdownBold renders text in bold. Escapes do not work inside an entity, so a '*' in the
+// text is replaced by a look-alike instead.
+func MarkdownBold(s string) string {
+	return "*" + strings.ReplaceAll(s, "*", "∗") + "*"
+}
+
+// MarkdownLink renders text as a link to url. Escapes do not work inside a link, so brackets
+// in the text become parentheses.
+func MarkdownLink(text, url string) string {
+	return "[" + linkTextReplacer.Replace(text) + "](" + url + ")"
+}
@@ -0,0 +1,18 @@
+package telegram
+
+import "testing"
+
+func TestMarkdownHelpers(t *testing.T) {
+	tests := []struct {
+		name, got, want string
+	}{
+		{"escape", EscapeMarkdown("fix *prod* db_migrate [x] `y`"), "fix \\*prod\\* db\\_migrate \\[x] \\`y\\`"},
+		{"bold", MarkdownBold("a*b_c"), "*a∗b_c*"},
+		{"link", MarkdownLink("Review [draft]", "https://memos/1"), "[Review (draft)](https://memos/1)"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -14,6 +16,7 @@ import (
 type botImpl struct {
 	token      string
 	apiURL     string
+	fileURL    string
 	httpClient *http.Client
 }
 
@@ -24,6 +27,7 @@ func NewBot(token string) IBot {
 	return &botImpl{
 		token:      token,
 		apiURL:     fmt.Sprintf("https://api.telegram.org/bot%s", token),
+		fileURL:    fmt.Sprintf("https://api.telegram.org/file/bot%s", token),
 		httpClient: &http.Client{},
 	}
 }
@@ -31,6 +35,7 @@ func NewBot(token string) IBot {
 // SetAPIURL overrides the default Telegram API URL for testing purposes.
 func (b *botImpl) SetAPIURL(url string) {
 	b.apiURL = url
+	b.fileURL = url + "/file"
 }
 
 // SetWebhook registers the webhook URL with Telegram.
@@ -170,3 +175,77 @@ func (b *botImpl) SendMessagePlain(chatID int64, text string) error {
 
 	return nil
 }
+
+// SendDocument uploads data as a file named filename, with an optional plain-text caption.
+func (b *botImpl) SendDocument(chatID int64, filename string, data []byte, caption string) error {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	_ = form.WriteField("chat_id", strconv.FormatInt(chatID, 10))
+	if caption != "" {
+		_ = form.WriteField("caption", caption)
+	}
+	part, err := form.CreateFormFile("document", filename)
+	if err != nil {
+		return fmt.Errorf("pkg: failed to build document upload: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return fmt.Errorf("pkg: failed to build document upload: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return fmt.Errorf("pkg: failed to build document upload: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/sendDocument", b.apiURL)
+	resp, err := b.httpClient.Post(url, form.FormDataContentType(), &body)
+	if err != nil {
+		return fmt.Errorf("pkg: failed to send document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("pkg: telegram sendDocument API error %d: %s", resp.StatusCode, string(raw))
+	}
+	return nil
+}
+
+// DownloadFile resolves a file ID with getFile and downloads its content.
+// Files larger than MaxDownloadSize are rejected, as the Bot API does.
+func (b *botImpl) DownloadFile(fileID string) ([]byte, error) {
+	url := fmt.Sprintf("%s/getFile", b.apiURL)
+	body, _ := json.Marshal(map[string]string{"file_id": fileID})
+	resp, err := b.httpClient.Post(url, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("pkg: failed to get file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var fileResp getFileResponse
+	if err := json.NewDecoder(resp.Body).Decode(&fileResp); err != nil {
+		return nil, fmt.Errorf("pkg: failed to decode getFile response: %w", err)
+	}
+	if !fileResp.OK || fileResp.Result.FilePath == "" {
+		return nil, fmt.Errorf("pkg: telegram getFile failed: %s", fileResp.Description)
+	}
+	if fileResp.Result.FileSize > MaxDownloadSize {
+		return nil, fmt.Errorf("pkg: file too large: %d bytes", fileResp.Result.FileSize)
+	}
+
+	download, err := b.httpClient.Get(fmt.Sprintf("%s/%s", b.fileURL, fileResp.Result.FilePath))
+	if err != nil {
+		return nil, fmt.Errorf("pkg: failed to download file: %w", err)
+	}
+	defer download.Body.Close()
+
+	if download.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("pkg: telegram file download error %d", download.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(download.Body, MaxDownloadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("pkg: failed to read file: %w", err)
+	}
+	if len(data) > MaxDownloadSize {
+		return nil, fmt.Errorf("pkg: file too large")
+	}
+	return data, nil
+}
@@ -8,12 +8,14 @@ type Update struct {
 
 // Message represents a Telegram message.
 type Message struct {
-	MessageID int64  `json:"message_id"`
-	From      *User  `json:"from,omitempty"`
-	Chat      *Chat  `json:"chat"`
-	Date      int64  `json:"date"`
-	Text      string `json:"text,omitempty"`
-	Voice     *Voice `json:"voice,omitempty"`
+	MessageID int64     `json:"message_id"`
+	From      *User     `json:"from,omitempty"`
+	Chat      *Chat     `json:"chat"`
+	Date      int64     `json:"date"`
+	Text      string    `json:"text,omitempty"`
+	Caption   string    `json:"caption,omitempty"`
+	Voice     *Voice    `json:"voice,omitempty"`
+	Document  *Document `json:"document,omitempty"`
 }
 
 // User represents a Telegram user.
@@ -37,6 +39,21 @@ type Voice struct {
 	MimeType string `json:"mime_type,omitempty"`
 }
 
+// Document represents a file sent as a Telegram document.
+type Document struct {
+	FileID   string `json:"file_id"`
+	FileName string `json:"file_name,omitempty"`
+	MimeType string `json:"mime_type,omitempty"`
+	FileSize int64  `json:"file_size,omitempty"`
+}
+
+// File is the result of the getFile API; FilePath is used to download the content.
+type File struct {
+	FileID   string `json:"file_id"`
+	FileSize int64  `json:"file_size,omitempty"`
+	FilePath string `json:"file_path,omitempty"`
+}
+
 // SendMessageRequest is the payload for Telegram sendMessage API.
 type SendMessageRequest struct {
 	ChatID    int64  `json:"chat_id"`
@@ -49,3 +66,9 @@ type APIResponse struct {
 	OK          bool   `json:"ok"`
 	Description string `json:"description,omitempty"`
 }
+
+// getFileResponse is the getFile API response.
+type getFileResponse struct {
+	APIResponse
+	Result File `json:"result"`
+}
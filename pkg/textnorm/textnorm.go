@@ -0,0 +1,24 @@
+// Package textnorm normalizes Vietnamese and English text for matching.
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Fold lowercases s and strips diacritics ('đ' becomes 'd'), so "Tuần sau" and "tuan sau" match.
+func Fold(s string) string {
+	var sb strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r == 'đ':
+			r = 'd'
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,13 @@
+package textnorm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	assert.Equal(t, "tuan sau", Fold("Tuần sau"))
+	assert.Equal(t, "dang lam", Fold("Đang làm"))
+	assert.Equal(t, "#p1 deploy", Fold("#P1 Deploy"))
+}
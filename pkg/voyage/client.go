@@ -12,6 +12,9 @@ import (
 const (
 	DefaultBaseURL = "https://api.voyageai.com/v1"
 	DefaultModel   = "voyage-3" // Latest model with 1024 dimensions
+
+	// DefaultDimension is the vector size of DefaultModel.
+	DefaultDimension = 1024
 )
 
 // Client is the Voyage AI embedding API client.
@@ -19,6 +22,7 @@ type Client struct {
 	apiKey     string
 	baseURL    string
 	model      string
+	dimension  int
 	httpClient *http.Client
 }
 
@@ -32,6 +36,7 @@ func New(apiKey string) (*Client, error) {
 		apiKey:     apiKey,
 		baseURL:    DefaultBaseURL,
 		model:      DefaultModel,
+		dimension:  DefaultDimension,
 		httpClient: &http.Client{Timeout: 15 * time.Second},
 	}, nil
 }
@@ -42,6 +47,22 @@ func (c *Client) WithModel(model string) *Client {
 	return c
 }
 
+// Model returns the embedding model in use.
+func (c *Client) Model() string {
+	return c.model
+}
+
+// WithDimension sets the vector size of the model, for models other than DefaultModel.
+func (c *Client) WithDimension(dimension int) *Client {
+	c.dimension = dimension
+	return c
+}
+
+// Dimension returns the vector size of the model.
+func (c *Client) Dimension() int {
+	return c.dimension
+}
+
 // WithBaseURL overrides the default Voyage API base URL.
 func (c *Client) WithBaseURL(baseURL string) *Client {
 	c.baseURL = baseURL
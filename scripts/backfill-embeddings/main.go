@@ -2,25 +2,34 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"os"
 
 	"autonomous-task-management/config"
+	"autonomous-task-management/internal/model"
 	"autonomous-task-management/internal/task/repository"
 	memosRepo "autonomous-task-management/internal/task/repository/memos"
 	qdrantRepo "autonomous-task-management/internal/task/repository/qdrant"
+	"autonomous-task-management/pkg/embedding"
 	"autonomous-task-management/pkg/log"
 	pkgQdrant "autonomous-task-management/pkg/qdrant"
-	"autonomous-task-management/pkg/voyage"
 )
 
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run scripts/backfill-embeddings/main.go <path/to/config.yaml>")
-		fmt.Println("Example: go run scripts/backfill-embeddings/main.go config/config.yaml")
+	checkpointPath := flag.String("checkpoint", "backfill-checkpoint.json", "file recording indexed tasks; a rerun resumes from it")
+	batchSize := flag.Int("batch", embedding.MaxBatchTexts, "texts per embedding request")
+	concurrency := flag.Int("concurrency", 4, "embedding requests in flight")
+	rps := flag.Float64("rps", 2, "embedding requests per second (0 = unlimited)")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		fmt.Println("Usage: go run scripts/backfill-embeddings/main.go [flags] <path/to/config.yaml>")
+		fmt.Println("Example: go run scripts/backfill-embeddings/main.go -rps 1 config/config.yaml")
+		flag.PrintDefaults()
 		os.Exit(1)
 	}
-	configPath := os.Args[1]
+	configPath := flag.Arg(0)
 
 	// Load config
 	os.Setenv("CONFIG_PATH", configPath)
@@ -43,35 +52,56 @@ func main() {
 	memosClient := memosRepo.NewClient(cfg.Memos.URL, cfg.Memos.AccessToken)
 	memosRepository := memosRepo.New(memosClient, cfg.Memos.URL, logger)
 
-	qdrantClient := pkgQdrant.NewClient(cfg.Qdrant.URL)
-	embeddingClient, err := voyage.New(cfg.Voyage.APIKey)
+	// Never rebuild the index with the local fallback: a missing key must stop the run
+	embeddingCfg := cfg.Embedding
+	embeddingCfg.FallbackLocal = false
+	embeddingClient, err := embedding.New(embeddingCfg)
 	if err != nil {
-		logger.Fatalf(ctx, "Failed to initialize Voyage API: %v", err)
+		logger.Fatalf(ctx, "Failed to initialize %s embeddings: %v", cfg.Embedding.Provider, err)
+	}
+	qdrantClient := pkgQdrant.NewClient(cfg.Qdrant.URL)
+	if err := qdrantRepo.Bootstrap(ctx, qdrantClient, cfg.Qdrant.CollectionName, embeddingClient.Dimension(), logger); err != nil {
+		logger.Fatalf(ctx, "Failed to prepare Qdrant collection: %v", err)
 	}
-	vectorRepo := qdrantRepo.New(qdrantClient, embeddingClient, cfg.Qdrant.CollectionName, logger)
+	vectorRepo := qdrantRepo.New(qdrantClient, embeddingClient, cfg.Qdrant.CollectionName, cfg.LLM.Timezone, logger)
 
 	logger.Info(ctx, "Starting backfill process...")
 
-	// Fetch all tasks from Memos
-	tasks, err := memosRepository.ListTasks(ctx, repository.ListTasksOptions{
-		Limit: 1000, // Adjust as needed
-	})
-	if err != nil {
-		logger.Fatalf(ctx, "Failed to list tasks: %v", err)
+	// Fetch all tasks from Memos, following page tokens
+	var tasks []model.Task
+	for task, err := range memosRepository.IterateTasks(ctx, repository.ListTasksOptions{Limit: 200}) {
+		if err != nil {
+			logger.Fatalf(ctx, "Failed to list tasks: %v", err)
+		}
+		tasks = append(tasks, task)
 	}
 
 	logger.Infof(ctx, "Found %d tasks to backfill to Qdrant", len(tasks))
 
-	successCount := 0
-	// Embed each task
-	for i, task := range tasks {
-		if err := vectorRepo.EmbedTask(ctx, task); err != nil {
-			logger.Errorf(ctx, "Failed to embed task %s: %v", task.ID, err)
-			continue
-		}
-		logger.Infof(ctx, "Embedded task %d/%d: %s", i+1, len(tasks), task.ID)
-		successCount++
+	checkpoint, err := repository.NewFileCheckpoint(*checkpointPath)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to load checkpoint: %v", err)
+	}
+	if n := checkpoint.Len(); n > 0 {
+		logger.Infof(ctx, "Resuming from %s: %d tasks already indexed", *checkpointPath, n)
+	}
+
+	result, err := vectorRepo.IndexTasks(ctx, tasks, repository.IndexOptions{
+		BatchSize:         *batchSize,
+		Concurrency:       *concurrency,
+		RequestsPerSecond: *rps,
+		Checkpoint:        checkpoint,
+		Progress: func(p repository.IndexResult) {
+			logger.Infof(ctx, "Progress: %d/%d indexed, %d failed", p.Indexed, p.Total-p.Skipped, len(p.Failed))
+		},
+	})
+	if err != nil {
+		logger.Errorf(ctx, "Backfill stopped: %v", err)
+	}
+	for _, id := range result.Failed {
+		logger.Errorf(ctx, "Failed to embed task %s", id)
 	}
 
-	logger.Infof(ctx, "Backfill complete! %d/%d tasks successfully embedded.", successCount, len(tasks))
+	logger.Infof(ctx, "Backfill complete! %d tasks embedded, %d already indexed, %d failed (rerun to retry).",
+		result.Indexed, result.Skipped, len(result.Failed))
 }
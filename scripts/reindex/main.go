@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"autonomous-task-management/config"
+	"autonomous-task-management/internal/model"
+	"autonomous-task-management/internal/sync"
+	syncUC "autonomous-task-management/internal/sync/usecase"
+	"autonomous-task-management/internal/task/repository"
+	memosRepo "autonomous-task-management/internal/task/repository/memos"
+	qdrantRepo "autonomous-task-management/internal/task/repository/qdrant"
+	"autonomous-task-management/pkg/embedding"
+	"autonomous-task-management/pkg/log"
+	pkgQdrant "autonomous-task-management/pkg/qdrant"
+)
+
+// Rebuilds the task collection with the configured embedding provider, model and enrichment,
+// without downtime: a new collection sized for the embedder is filled next to the live one,
+// then the qdrant.collection_name alias is switched to it. The previous collection is kept;
+// -rollback switches back to it.
+func main() {
+	rollback := flag.Bool("rollback", false, "point the alias back at the previous collection and exit")
+	keep := flag.Int("keep", 1, "previous collections to keep for rollback")
+	batchSize := flag.Int("batch", embedding.MaxBatchTexts, "texts per embedding request")
+	concurrency := flag.Int("concurrency", 4, "embedding requests in flight")
+	rps := flag.Float64("rps", 2, "embedding requests per second (0 = unlimited)")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		fmt.Println("Usage: go run scripts/reindex/main.go [flags] <path/to/config.yaml>")
+		fmt.Println("Example: go run scripts/reindex/main.go config/config.yaml")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	os.Setenv("CONFIG_PATH", flag.Arg(0))
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger := log.Init(log.ZapConfig{
+		Level:        "info",
+		Mode:         "development",
+		ColorEnabled: true,
+	})
+	ctx := context.Background()
+
+	qdrantClient := pkgQdrant.NewClient(cfg.Qdrant.URL)
+	alias := cfg.Qdrant.CollectionName
+
+	if *rollback {
+		from, to, err := qdrantRepo.Rollback(ctx, qdrantClient, alias, logger)
+		if err != nil {
+			logger.Fatalf(ctx, "Rollback failed: %v", err)
+		}
+		logger.Infof(ctx, "Rolled back %s from %s to %s", alias, from, to)
+		return
+	}
+
+	// Never rebuild the index with the local fallback: a missing key must stop the run
+	embeddingCfg := cfg.Embedding
+	embeddingCfg.FallbackLocal = false
+	embeddingClient, err := embedding.New(embeddingCfg)
+	if err != nil {
+		logger.Fatalf(ctx, "Failed to initialize %s embeddings: %v", cfg.Embedding.Provider, err)
+	}
+	memosClient := memosRepo.NewClient(cfg.Memos.URL, cfg.Memos.AccessToken)
+	memosRepository := memosRepo.New(memosClient, cfg.Memos.URL, logger)
+
+	var tasks []model.Task
+	for task, err := range memosRepository.IterateTasks(ctx, repository.ListTasksOptions{Limit: 200}) {
+		if err != nil {
+			logger.Fatalf(ctx, "Failed to list tasks: %v", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	result, err := qdrantRepo.Reindex(ctx, qdrantClient, embeddingClient, tasks, qdrantRepo.ReindexOptions{
+		Alias:    alias,
+		Keep:     *keep,
+		Timezone: cfg.LLM.Timezone,
+		Index: repository.IndexOptions{
+			BatchSize:         *batchSize,
+			Concurrency:       *concurrency,
+			RequestsPerSecond: *rps,
+			Progress: func(p repository.IndexResult) {
+				logger.Infof(ctx, "Progress: %d/%d indexed, %d failed", p.Indexed, p.Total, len(p.Failed))
+			},
+		},
+	}, logger)
+	if err != nil {
+		logger.Fatalf(ctx, "Reindex failed, %s still points to %q: %v", alias, result.Previous, err)
+	}
+	logger.Infof(ctx, "Switched %s from %q to %s (%d tasks); pruned %v",
+		alias, result.Previous, result.Current, result.Index.Indexed, result.Pruned)
+
+	// Pick up edits that reached the old collection while the new one was being built
+	vectorRepo := qdrantRepo.New(qdrantClient, embeddingClient, alias, cfg.LLM.Timezone, logger)
+	report, err := syncUC.New(memosRepository, vectorRepo, logger, cfg.Reconcile.MaxOrphanRatio).Reconcile(ctx, sync.ReconcileInput{})
+	if err != nil {
+		logger.Fatalf(ctx, "Reconciliation after the switch failed, rerun it with POST /api/admin/reconcile: %v", err)
+	}
+	logger.Infof(ctx, "Reindex complete! Reconciliation repaired %d drifted tasks.", report.Drift())
+}